@@ -119,3 +119,27 @@ func (aB *abstractBalance) debitUsage(usage *decimal.Big,
 	}
 	return
 }
+
+// debitCost implements the balanceOperator interface
+// the abstract units can pay for a monetary cost only when converted with an UnitFactor
+func (aB *abstractBalance) debitCost(cost *decimal.Big,
+	cgrEv *utils.CGREvent) (ec *utils.EventCharges, err error) {
+	evNm := utils.MapStorage{
+		utils.MetaOpts: cgrEv.Opts,
+		utils.MetaReq:  cgrEv.Event,
+	}
+	var uF *utils.UnitFactor
+	if uF, err = unitFactor(aB.blnCfg.UnitFactors, aB.fltrS, cgrEv.Tenant, evNm); err != nil {
+		return
+	}
+	if uF == nil { // no conversion between the cost and the abstract units
+		return nil, utils.ErrNotImplemented
+	}
+	// same direct debit of units as for the concrete balances
+	var dbted *utils.Decimal
+	if dbted, _, err = (&concreteBalance{blnCfg: aB.blnCfg, fltrS: aB.fltrS}).debitUnits(
+		&utils.Decimal{Big: cost}, cgrEv.Tenant, evNm); err != nil {
+		return
+	}
+	return &utils.EventCharges{Cost: dbted}, nil
+}
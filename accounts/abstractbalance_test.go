@@ -1183,3 +1183,38 @@ func TestAMCostWithUnitFactor(t *testing.T) {
 		t.Errorf("Unexpected units in concrete balance: %s", aB.cncrtBlncs[0].blnCfg.Units)
 	}
 }
+
+func TestABDebitCost(t *testing.T) {
+	aB := &abstractBalance{
+		blnCfg: &utils.Balance{
+			ID:    "AB1",
+			Type:  utils.MetaAbstract,
+			Units: utils.NewDecimal(int64(60*time.Second), 0), // 1 min
+		},
+		fltrS: new(engine.FilterS),
+	}
+	if _, err := aB.debitCost(decimal.New(1, 0),
+		new(utils.CGREvent)); err != utils.ErrNotImplemented {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotImplemented, err)
+	} else if aB.blnCfg.Units.Cmp(decimal.New(int64(60*time.Second), 0)) != 0 {
+		t.Errorf("balance remaining: %s", aB.blnCfg.Units)
+	}
+	// loyalty points paying the cost, 10 points for each unit of cost
+	aB.blnCfg.Units = utils.NewDecimal(25, 0)
+	aB.blnCfg.UnitFactors = []*utils.UnitFactor{{Factor: utils.NewDecimal(10, 0)}}
+	if ec, err := aB.debitCost(decimal.New(2, 0), new(utils.CGREvent)); err != nil {
+		t.Error(err)
+	} else if ec.Cost.Cmp(decimal.New(2, 0)) != 0 {
+		t.Errorf("debited cost: %s", ec.Cost)
+	} else if aB.blnCfg.Units.Cmp(decimal.New(5, 0)) != 0 {
+		t.Errorf("balance remaining: %s", aB.blnCfg.Units)
+	}
+	// partial debit, never under the limit
+	if ec, err := aB.debitCost(decimal.New(2, 0), new(utils.CGREvent)); err != nil {
+		t.Error(err)
+	} else if ec.Cost.Cmp(decimal.New(5, 1)) != 0 {
+		t.Errorf("debited cost: %s", ec.Cost)
+	} else if aB.blnCfg.Units.Cmp(decimal.New(0, 0)) != 0 {
+		t.Errorf("balance remaining: %s", aB.blnCfg.Units)
+	}
+}
@@ -215,6 +215,7 @@ func (aS *AccountS) accountsDebitUsage(acnts []*utils.AccountProfileWithWeight,
 			}
 			return
 		}
+		ecDbt.Accounting = chargedAccounting(acnt.AccountProfile, acntBkps[i])
 		if store && acnt.AccountProfile.BalancesAltered(acntBkps[i]) {
 			if err = aS.dm.SetAccountProfile(acnt.AccountProfile, false); err != nil {
 				restoreAccounts(aS.dm, acnts, acntBkps)
@@ -227,8 +228,99 @@ func (aS *AccountS) accountsDebitUsage(acnts []*utils.AccountProfileWithWeight,
 	return
 }
 
-func (aS *AccountS) accountDebitCost(acnt *utils.AccountProfile,
+// accountDebitCost will debit the cost out of an Account
+func (aS *AccountS) accountDebitCost(acnt *utils.AccountProfile, cost *decimal.Big,
 	cgrEv *utils.CGREvent) (ec *utils.EventCharges, err error) {
+	// Find balances matching event
+	blcsWithWeight := make(utils.BalancesWithWeight, 0, len(acnt.Balances))
+	for _, blnCfg := range acnt.Balances {
+		var weight float64
+		if weight, err = engine.WeightFromDynamics(blnCfg.Weights,
+			aS.fltrS, cgrEv.Tenant, cgrEv.AsDataProvider()); err != nil {
+			return
+		}
+		blcsWithWeight = append(blcsWithWeight, &utils.BalanceWithWeight{Balance: blnCfg, Weight: weight})
+	}
+	blcsWithWeight.Sort()
+	var blncOpers []balanceOperator
+	if blncOpers, err = newBalanceOperators(blcsWithWeight.Balances(), aS.fltrS, aS.connMgr,
+		aS.cfg.AccountSCfg().AttributeSConns, aS.cfg.AccountSCfg().RateSConns); err != nil {
+		return
+	}
+
+	for i, blncOper := range blncOpers {
+		if i == 0 {
+			ec = utils.NewEventCharges()
+		}
+		if cost.Cmp(decimal.New(0, 0)) <= 0 {
+			return // no more debit
+		}
+		var ecDbt *utils.EventCharges
+		if ecDbt, err = blncOper.debitCost(new(decimal.Big).Copy(cost), cgrEv); err != nil {
+			if err == utils.ErrFilterNotPassingNoCaps ||
+				err == utils.ErrNotImplemented {
+				err = nil
+				continue
+			}
+			return
+		}
+		cost = utils.SubstractBig(cost, ecDbt.Cost.Big)
+		ec.Merge(ecDbt)
+	}
+	return
+}
+
+// accountsDebitCost will debit a cost out of multiple accounts
+func (aS *AccountS) accountsDebitCost(acnts []*utils.AccountProfileWithWeight,
+	cgrEv *utils.CGREvent, store bool) (ec *utils.EventCharges, err error) {
+	var costEv float64
+	if costEv, err = cgrEv.FieldAsFloat64(utils.Cost); err != nil {
+		if err != utils.ErrNotFound {
+			return
+		}
+		// not found, try at opts level
+		if costEv, err = cgrEv.OptAsFloat64(utils.MetaCost); err != nil {
+			if err != utils.ErrNotFound {
+				return
+			}
+			return nil, utils.NewErrMandatoryIeMissing(utils.Cost)
+		}
+	}
+	cost := utils.NewDecimalFromFloat64(costEv).Big
+	ec = &utils.EventCharges{Cost: utils.NewDecimal(0, 0)}
+	acntBkps := make([]utils.AccountBalancesBackup, len(acnts))
+	for i, acnt := range acnts {
+		if cost.Cmp(decimal.New(0, 0)) <= 0 {
+			return // no more debits
+		}
+		acntBkps[i] = acnt.AccountProfile.AccountBalancesBackup()
+		var ecDbt *utils.EventCharges
+		if ecDbt, err = aS.accountDebitCost(acnt.AccountProfile,
+			new(decimal.Big).Copy(cost), cgrEv); err != nil {
+			if store {
+				restoreAccounts(aS.dm, acnts, acntBkps)
+			} else { // the previous accounts were restored already
+				acnt.AccountProfile.RestoreFromBackup(acntBkps[i])
+			}
+			return
+		}
+		if ecDbt != nil {
+			ecDbt.Accounting = chargedAccounting(acnt.AccountProfile, acntBkps[i])
+		}
+		if acnt.AccountProfile.BalancesAltered(acntBkps[i]) {
+			if !store { // query only, do not alter the cached account
+				acnt.AccountProfile.RestoreFromBackup(acntBkps[i])
+			} else if err = aS.dm.SetAccountProfile(acnt.AccountProfile, false); err != nil {
+				restoreAccounts(aS.dm, acnts, acntBkps)
+				return
+			}
+		}
+		if ecDbt == nil || ecDbt.Cost == nil { // no balance could pay
+			continue
+		}
+		cost = utils.SubstractBig(cost, ecDbt.Cost.Big)
+		ec.Merge(ecDbt)
+	}
 	return
 }
 
@@ -289,11 +381,23 @@ func (aS *AccountS) V1DebitUsage(args *utils.ArgsAccountsForEvent, eEc *utils.Ex
 		}
 	}()
 
+	acntBkps := make([]utils.AccountBalancesBackup, len(acnts))
+	for i, acnt := range acnts {
+		acntBkps[i] = acnt.AccountProfile.AccountBalancesBackup()
+	}
 	var procEC *utils.EventCharges
 	if procEC, err = aS.accountsDebitUsage(acnts, args.CGREvent, true); err != nil {
 		return
 	}
 
+	if engine.MapEvent(args.CGREvent.Opts).GetBoolOrDefault(utils.OptsAccountsStoreCharges,
+		aS.cfg.AccountSCfg().StoreCharges) {
+		if err = aS.storeCharges(args.CGREvent.Tenant, procEC, acnts); err != nil {
+			restoreAccounts(aS.dm, acnts, acntBkps)
+			return
+		}
+	}
+
 	var rcvEec *utils.ExtEventCharges
 	if rcvEec, err = procEC.AsExtEventCharges(); err != nil {
 		return
@@ -302,3 +406,353 @@ func (aS *AccountS) V1DebitUsage(args *utils.ArgsAccountsForEvent, eEc *utils.Ex
 	*eEc = *rcvEec
 	return
 }
+
+// V1MaxCost returns the maximum cost which can be covered for the event, based on matching Accounts
+func (aS *AccountS) V1MaxCost(args *utils.ArgsAccountsForEvent, eEc *utils.ExtEventCharges) (err error) {
+	var acnts utils.AccountProfilesWithWeight
+	if acnts, err = aS.matchingAccountsForEvent(args.CGREvent.Tenant,
+		args.CGREvent, args.AccountIDs, true); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	defer func() {
+		for _, lkID := range acnts.LockIDs() {
+			guardian.Guardian.UnguardIDs(lkID)
+		}
+	}()
+	var procEC *utils.EventCharges
+	if procEC, err = aS.accountsDebitCost(acnts, args.CGREvent, false); err != nil {
+		return
+	}
+	var rcvEec *utils.ExtEventCharges
+	if rcvEec, err = procEC.AsExtEventCharges(); err != nil {
+		return
+	}
+	*eEc = *rcvEec
+	return
+}
+
+// V1DebitCost performs debit of the cost for the provided event
+func (aS *AccountS) V1DebitCost(args *utils.ArgsAccountsForEvent, eEc *utils.ExtEventCharges) (err error) {
+	var acnts utils.AccountProfilesWithWeight
+	if acnts, err = aS.matchingAccountsForEvent(args.CGREvent.Tenant,
+		args.CGREvent, args.AccountIDs, true); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	defer func() {
+		for _, lkID := range acnts.LockIDs() {
+			guardian.Guardian.UnguardIDs(lkID)
+		}
+	}()
+
+	acntBkps := make([]utils.AccountBalancesBackup, len(acnts))
+	for i, acnt := range acnts {
+		acntBkps[i] = acnt.AccountProfile.AccountBalancesBackup()
+	}
+	var procEC *utils.EventCharges
+	if procEC, err = aS.accountsDebitCost(acnts, args.CGREvent, true); err != nil {
+		return
+	}
+
+	if engine.MapEvent(args.CGREvent.Opts).GetBoolOrDefault(utils.OptsAccountsStoreCharges,
+		aS.cfg.AccountSCfg().StoreCharges) {
+		if err = aS.storeCharges(args.CGREvent.Tenant, procEC, acnts); err != nil {
+			restoreAccounts(aS.dm, acnts, acntBkps)
+			return
+		}
+	}
+
+	var rcvEec *utils.ExtEventCharges
+	if rcvEec, err = procEC.AsExtEventCharges(); err != nil {
+		return
+	}
+
+	*eEc = *rcvEec
+	return
+}
+
+// storeCharges stores in DataDB the units debited with the EventCharges so they can be refunded
+// the ID of the EventCharges is set only once stored since only then it can be refunded
+func (aS *AccountS) storeCharges(tnt string, ec *utils.EventCharges,
+	acnts utils.AccountProfilesWithWeight) (err error) {
+	if len(ec.Accounting) == 0 { // nothing to refund
+		return
+	}
+	aC := &utils.AccountCharges{
+		Tenant:     utils.FirstNonEmpty(tnt, aS.cfg.GeneralCfg().DefaultTenant),
+		ID:         utils.UUIDSha1Prefix(),
+		Accounting: ec.Accounting,
+		Balances:   make(map[string]*utils.Balance),
+	}
+	for _, acnt := range acnts {
+		for _, cA := range ec.Accounting {
+			if cA.AccountID != acnt.ID {
+				continue
+			}
+			if blnc, has := acnt.AccountProfile.Balances[cA.BalanceID]; has {
+				blncCfg := blnc.Clone() // keep the config to recreate the Balance on refund
+				blncCfg.Units = utils.NewDecimal(0, 0)
+				aC.Balances[utils.ConcatenatedKey(acnt.ID, cA.BalanceID)] = blncCfg
+			}
+		}
+	}
+	if ttl := aS.cfg.AccountSCfg().ChargesTTL; ttl != 0 {
+		aC.ExpiryTime = utils.TimePointer(time.Now().Add(ttl))
+	}
+	if err = aS.dm.SetAccountCharges(aC); err != nil {
+		return
+	}
+	ec.ID = aC.ID
+	return
+}
+
+// refundCharges puts back on the Balances the units recorded in AccountCharges, marking them as refunded afterwards
+// the Accounts are locked so the refund is atomic, the Balances expired or removed in the meantime are recreated
+// out of the config stored on debit
+func (aS *AccountS) refundCharges(aC *utils.AccountCharges) (err error) {
+	var acntIDs []string
+	chrgs := make(map[string][]*utils.ChargedAccounting)
+	for _, cA := range aC.Accounting {
+		if _, has := chrgs[cA.AccountID]; !has {
+			acntIDs = append(acntIDs, cA.AccountID)
+		}
+		chrgs[cA.AccountID] = append(chrgs[cA.AccountID], cA)
+	}
+	acnts := make(utils.AccountProfilesWithWeight, 0, len(acntIDs))
+	defer func() {
+		for _, lkID := range acnts.LockIDs() {
+			guardian.Guardian.UnguardIDs(lkID)
+		}
+	}()
+	for _, acntID := range acntIDs {
+		refID := guardian.Guardian.GuardIDs("",
+			aS.cfg.GeneralCfg().LockingTimeout,
+			utils.ConcatenatedKey(utils.CacheAccountProfiles, acntID))
+		var acnt *utils.AccountProfile
+		if acnt, err = aS.dm.GetAccountProfile(aC.Tenant, acntID,
+			true, true, utils.NonTransactional); err != nil {
+			guardian.Guardian.UnguardIDs(refID)
+			if err == utils.ErrNotFound {
+				err = fmt.Errorf("account <%s> not found", utils.ConcatenatedKey(aC.Tenant, acntID))
+			}
+			return
+		}
+		acnts = append(acnts, &utils.AccountProfileWithWeight{AccountProfile: acnt, LockID: refID})
+	}
+	acntBkps := make([]utils.AccountBalancesBackup, len(acnts))
+	crtdBlncs := make([][]string, len(acnts)) // Balances recreated by the refund, removed on rollback
+	rollback := func() {
+		for i, acnt := range acnts {
+			for _, blncID := range crtdBlncs[i] {
+				delete(acnt.Balances, blncID)
+			}
+			acnt.AccountProfile.RestoreFromBackup(acntBkps[i])
+			if err := aS.dm.SetAccountProfile(acnt.AccountProfile, false); err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> error <%s> restoring account <%s>",
+					utils.AccountS, err, acnt.AccountProfile.TenantID()))
+			}
+		}
+	}
+	for i, acnt := range acnts {
+		acntBkps[i] = acnt.AccountProfile.AccountBalancesBackup()
+		for _, cA := range chrgs[acnt.ID] {
+			blnc, has := acnt.Balances[cA.BalanceID]
+			if !has { // balance expired or removed in the meantime
+				blncCfg, canRecreate := aC.Balances[utils.ConcatenatedKey(acnt.ID, cA.BalanceID)]
+				if !canRecreate {
+					rollback()
+					return fmt.Errorf("no config stored to recreate balance <%s> of account <%s>",
+						cA.BalanceID, acnt.AccountProfile.TenantID())
+				}
+				blnc = blncCfg.Clone()
+				blnc.Units = utils.NewDecimal(0, 0)
+				if acnt.Balances == nil {
+					acnt.Balances = make(map[string]*utils.Balance)
+				}
+				acnt.Balances[cA.BalanceID] = blnc
+				crtdBlncs[i] = append(crtdBlncs[i], cA.BalanceID)
+			}
+			blnc.Units.Big = utils.SumBig(blnc.Units.Big, cA.Units.Big)
+		}
+		if !acnt.AccountProfile.BalancesAltered(acntBkps[i]) {
+			continue
+		}
+		if err = aS.dm.SetAccountProfile(acnt.AccountProfile, false); err != nil {
+			rollback()
+			return
+		}
+	}
+	aC.Refunded = true // keep the charges until expired so the refund is not repeated
+	if err = aS.dm.SetAccountCharges(aC); err != nil {
+		aC.Refunded = false
+		rollback()
+	}
+	return
+}
+
+// V1RefundCharges will put back on the Balances the units debited with the EventCharges
+// the charges are identified by ChargesID or by the EventCharges returned on debit
+// only the charges stored on debit and not expired are refunded, repeated refunds having no effect
+func (aS *AccountS) V1RefundCharges(args *utils.ArgsRefundCharges, rply *string) (err error) {
+	chrgsID := args.ChargesID
+	if chrgsID == utils.EmptyString && args.EventCharges != nil {
+		chrgsID = args.EventCharges.ID
+	}
+	if chrgsID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ChargesID)
+	}
+	tnt := utils.FirstNonEmpty(args.Tenant, aS.cfg.GeneralCfg().DefaultTenant)
+	refID := guardian.Guardian.GuardIDs("",
+		aS.cfg.GeneralCfg().LockingTimeout,
+		utils.ConcatenatedKey(utils.CacheAccountCharges, tnt, chrgsID))
+	defer guardian.Guardian.UnguardIDs(refID)
+	var aC *utils.AccountCharges
+	if aC, err = aS.dm.GetAccountCharges(tnt, chrgsID); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	if args.EventCharges != nil {
+		if err = checkRefundedCharges(aC, args.EventCharges); err != nil {
+			return
+		}
+	}
+	if !aC.Refunded {
+		if err = aS.refundCharges(aC); err != nil {
+			return utils.NewErrServerError(err)
+		}
+	}
+	*rply = utils.OK
+	return
+}
+
+// checkRefundedCharges makes sure the EventCharges received for refund are the ones stored on debit
+func checkRefundedCharges(aC *utils.AccountCharges, eEc *utils.ExtEventCharges) (err error) {
+	var stored *utils.ExtEventCharges
+	if stored, err = (&utils.EventCharges{Accounting: aC.Accounting}).AsExtEventCharges(); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	if len(eEc.Accounting) != len(stored.Accounting) {
+		return fmt.Errorf("EventCharges do not match the charges stored with ID <%s>", aC.ID)
+	}
+	for i, cA := range stored.Accounting {
+		if eEc.Accounting[i] == nil ||
+			eEc.Accounting[i].AccountID != cA.AccountID ||
+			eEc.Accounting[i].BalanceID != cA.BalanceID ||
+			eEc.Accounting[i].Units == nil || cA.Units == nil ||
+			*eEc.Accounting[i].Units != *cA.Units {
+			return fmt.Errorf("EventCharges do not match the charges stored with ID <%s>", aC.ID)
+		}
+	}
+	return
+}
+
+// actSetBalance alters the Units of a Balance, creating it if missing
+func (aS *AccountS) actSetBalance(tnt string, args *utils.ArgsActSetBalance) (err error) {
+	switch args.Type {
+	case utils.MetaAddBalance, utils.MetaSetBalance, utils.MetaDebitBalance:
+		if args.Units == nil {
+			return utils.NewErrMandatoryIeMissing(utils.Units)
+		}
+	case utils.MetaResetBalance:
+	default:
+		return fmt.Errorf("unsupported balance action: <%s>", args.Type)
+	}
+	var acnt *utils.AccountProfile
+	if acnt, err = aS.dm.GetAccountProfile(tnt, args.AccountID,
+		true, true, utils.NonTransactional); err != nil {
+		return
+	}
+	blnc, has := acnt.Balances[args.BalanceID]
+	if !has {
+		if args.Type != utils.MetaAddBalance &&
+			args.Type != utils.MetaSetBalance {
+			return utils.ErrPrefixNotFound(args.BalanceID)
+		}
+		if blnc, err = newBalanceFromOpts(args.BalanceID, args.Opts); err != nil {
+			return
+		}
+	}
+	bkpUnts := blnc.Units.Clone() // the account is cached so we restore it on errors
+	switch args.Type {
+	case utils.MetaAddBalance:
+		blnc.Units = &utils.Decimal{Big: utils.SumBig(blnc.Units.Big, args.Units.Big)}
+	case utils.MetaSetBalance:
+		blnc.Units = args.Units.Clone()
+	case utils.MetaDebitBalance: // same debit as for the events, considering the UnitFactor and the balance limit
+		var dbted *utils.Decimal
+		if dbted, _, err = debitBalanceUnits(blnc, args.Units.Clone(), aS.fltrS, tnt,
+			utils.MapStorage{utils.MetaOpts: args.Opts}); err != nil {
+			blnc.Units = bkpUnts
+			return
+		}
+		if dbted.Compare(args.Units) < 0 { // the balance cannot cover all the units
+			blnc.Units = bkpUnts
+			return utils.ErrInsufficientCredit
+		}
+	case utils.MetaResetBalance:
+		blnc.Units = utils.NewDecimal(0, 0)
+	}
+	if !has {
+		if acnt.Balances == nil {
+			acnt.Balances = make(map[string]*utils.Balance)
+		}
+		acnt.Balances[args.BalanceID] = blnc
+	}
+	if err = aS.dm.SetAccountProfile(acnt, false); err != nil {
+		if has {
+			blnc.Units = bkpUnts
+		} else {
+			delete(acnt.Balances, args.BalanceID)
+		}
+	}
+	return
+}
+
+// V1ActionSetBalance alters the Units of a Balance, used by ActionS
+func (aS *AccountS) V1ActionSetBalance(args *utils.ArgsActSetBalance, rply *string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.AccountID, utils.BalanceID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := utils.FirstNonEmpty(args.Tenant, aS.cfg.GeneralCfg().DefaultTenant)
+	if _, err = guardian.Guardian.Guard(func() (_ interface{}, gErr error) {
+		gErr = aS.actSetBalance(tnt, args)
+		return
+	}, aS.cfg.GeneralCfg().LockingTimeout,
+		utils.ConcatenatedKey(utils.CacheAccountProfiles, args.AccountID)); err != nil {
+		return
+	}
+	*rply = utils.OK
+	return
+}
+
+// V1ActionRemoveBalance removes Balances out of an Account, used by ActionS
+func (aS *AccountS) V1ActionRemoveBalance(args *utils.ArgsActRemoveBalance, rply *string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.AccountID, utils.BalanceIDs}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := utils.FirstNonEmpty(args.Tenant, aS.cfg.GeneralCfg().DefaultTenant)
+	if _, err = guardian.Guardian.Guard(func() (_ interface{}, gErr error) {
+		var acnt *utils.AccountProfile
+		if acnt, gErr = aS.dm.GetAccountProfile(tnt, args.AccountID,
+			true, true, utils.NonTransactional); gErr != nil {
+			return
+		}
+		for _, blncID := range args.BalanceIDs {
+			delete(acnt.Balances, blncID)
+		}
+		gErr = aS.dm.SetAccountProfile(acnt, false)
+		return
+	}, aS.cfg.GeneralCfg().LockingTimeout,
+		utils.ConcatenatedKey(utils.CacheAccountProfiles, args.AccountID)); err != nil {
+		return
+	}
+	*rply = utils.OK
+	return
+}
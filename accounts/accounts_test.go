@@ -0,0 +1,422 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package accounts
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/ericlagergren/decimal"
+)
+
+func TestV1DebitCost(t *testing.T) {
+	engine.Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	fltrS := engine.NewFilterS(cfg, nil, dm)
+	accnts := NewAccountS(cfg, fltrS, nil, dm)
+	acntPrf := &utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "TestV1DebitCost",
+		Balances: map[string]*utils.Balance{
+			"AB1": {
+				ID:    "AB1",
+				Type:  utils.MetaAbstract,
+				Units: utils.NewDecimal(int64(time.Minute), 0),
+				Weights: utils.DynamicWeights{
+					{
+						Weight: 30,
+					},
+				},
+			},
+			"CB1": {
+				ID:    "CB1",
+				Type:  utils.MetaConcrete,
+				Units: utils.NewDecimal(5, 0),
+				Weights: utils.DynamicWeights{
+					{
+						Weight: 20,
+					},
+				},
+			},
+			"CB2": {
+				ID:    "CB2",
+				Type:  utils.MetaConcrete,
+				Units: utils.NewDecimal(200, 0), // cents
+				UnitFactors: []*utils.UnitFactor{
+					{
+						Factor: utils.NewDecimal(100, 0),
+					},
+				},
+				Weights: utils.DynamicWeights{
+					{
+						Weight: 10,
+					},
+				},
+			},
+		},
+	}
+	if err := dm.SetAccountProfile(acntPrf, false); err != nil {
+		t.Fatal(err)
+	}
+	args := &utils.ArgsAccountsForEvent{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestV1DebitCost",
+			Event: map[string]interface{}{
+				utils.Cost: 6.5,
+			},
+		},
+		AccountIDs: []string{"TestV1DebitCost"},
+	}
+	var eEc utils.ExtEventCharges
+	if err := accnts.V1MaxCost(args, &eEc); err != nil {
+		t.Fatal(err)
+	} else if eEc.Cost == nil || *eEc.Cost != 6.5 {
+		t.Errorf("unexpected cost: %+v", eEc.Cost)
+	}
+	// MaxCost should not modify the account
+	if rcv, err := dm.GetAccountProfile("cgrates.org", "TestV1DebitCost",
+		true, true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if rcv.Balances["CB1"].Units.Cmp(decimal.New(5, 0)) != 0 ||
+		rcv.Balances["CB2"].Units.Cmp(decimal.New(200, 0)) != 0 {
+		t.Errorf("unexpected balances: %s", utils.ToJSON(rcv.Balances))
+	}
+
+	args.CGREvent.Event[utils.Cost] = 10.0 // more than available
+	if err := accnts.V1DebitCost(args, &eEc); err != nil {
+		t.Fatal(err)
+	} else if eEc.Cost == nil || *eEc.Cost != 7.0 {
+		t.Errorf("unexpected cost: %+v", eEc.Cost)
+	}
+	if rcv, err := dm.GetAccountProfile("cgrates.org", "TestV1DebitCost",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if rcv.Balances["CB1"].Units.Cmp(decimal.New(0, 0)) != 0 ||
+		rcv.Balances["CB2"].Units.Cmp(decimal.New(0, 0)) != 0 ||
+		rcv.Balances["AB1"].Units.Cmp(decimal.New(int64(time.Minute), 0)) != 0 {
+		t.Errorf("unexpected balances: %s", utils.ToJSON(rcv.Balances))
+	}
+
+	delete(args.CGREvent.Event, utils.Cost)
+	if err := accnts.V1DebitCost(args, &eEc); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.Cost).Error() {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestV1RefundCharges(t *testing.T) {
+	engine.Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	cfg.AccountSCfg().StoreCharges = true
+	fltrS := engine.NewFilterS(cfg, nil, dm)
+	accnts := NewAccountS(cfg, fltrS, nil, dm)
+	acntPrf := &utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "TestV1RefundCharges",
+		Balances: map[string]*utils.Balance{
+			"CB1": {
+				ID:    "CB1",
+				Type:  utils.MetaConcrete,
+				Units: utils.NewDecimal(5, 0),
+				Weights: utils.DynamicWeights{
+					{
+						Weight: 20,
+					},
+				},
+			},
+			"CB2": {
+				ID:    "CB2",
+				Type:  utils.MetaConcrete,
+				Units: utils.NewDecimal(200, 0), // cents
+				UnitFactors: []*utils.UnitFactor{
+					{
+						Factor: utils.NewDecimal(100, 0),
+					},
+				},
+				Weights: utils.DynamicWeights{
+					{
+						Weight: 10,
+					},
+				},
+			},
+		},
+	}
+	if err := dm.SetAccountProfile(acntPrf, false); err != nil {
+		t.Fatal(err)
+	}
+	args := &utils.ArgsAccountsForEvent{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestV1RefundCharges",
+			Event: map[string]interface{}{
+				utils.Cost: 6.5,
+			},
+		},
+		AccountIDs: []string{"TestV1RefundCharges"},
+	}
+	var eEc utils.ExtEventCharges
+	if err := accnts.V1DebitCost(args, &eEc); err != nil {
+		t.Fatal(err)
+	}
+	expAcntg := []*utils.ExtChargedAccounting{
+		{
+			AccountID: "TestV1RefundCharges",
+			BalanceID: "CB1",
+			Units:     utils.Float64Pointer(5),
+		},
+		{
+			AccountID: "TestV1RefundCharges",
+			BalanceID: "CB2",
+			Units:     utils.Float64Pointer(150),
+		},
+	}
+	if eEc.ID == utils.EmptyString {
+		t.Error("missing charges ID")
+	} else if !reflect.DeepEqual(expAcntg, eEc.Accounting) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(expAcntg), utils.ToJSON(eEc.Accounting))
+	}
+	var rply string
+	if err := accnts.V1RefundCharges(&utils.ArgsRefundCharges{
+		ChargesID: eEc.ID}, &rply); err != nil {
+		t.Error(err)
+	} else if rply != utils.OK {
+		t.Errorf("unexpected reply: %s", rply)
+	}
+	// repeating the refund does not credit twice
+	if err := accnts.V1RefundCharges(&utils.ArgsRefundCharges{
+		ChargesID: eEc.ID}, &rply); err != nil {
+		t.Error(err)
+	}
+	if aC, err := dm.GetAccountCharges("cgrates.org", eEc.ID); err != nil {
+		t.Error(err)
+	} else if !aC.Refunded {
+		t.Errorf("expecting the charges marked as refunded: %s", utils.ToJSON(aC))
+	}
+	if rcv, err := dm.GetAccountProfile("cgrates.org", "TestV1RefundCharges",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if rcv.Balances["CB1"].Units.Cmp(decimal.New(5, 0)) != 0 ||
+		rcv.Balances["CB2"].Units.Cmp(decimal.New(200, 0)) != 0 {
+		t.Errorf("unexpected balances: %s", utils.ToJSON(rcv.Balances))
+	}
+
+	// balance removed in the meantime, refunded by EventCharges
+	if err := accnts.V1DebitCost(args, &eEc); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := dm.GetAccountProfile("cgrates.org", "TestV1RefundCharges",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else {
+		delete(rcv.Balances, "CB1")
+		if err := dm.SetAccountProfile(rcv, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wrongEc := eEc
+	wrongEc.Accounting = []*utils.ExtChargedAccounting{{
+		AccountID: "TestV1RefundCharges",
+		BalanceID: "CB2",
+		Units:     utils.Float64Pointer(1000),
+	}}
+	if err := accnts.V1RefundCharges(&utils.ArgsRefundCharges{
+		EventCharges: &wrongEc}, &rply); err == nil {
+		t.Error("expecting the EventCharges not matching the stored charges")
+	}
+	if err := accnts.V1RefundCharges(&utils.ArgsRefundCharges{
+		EventCharges: &eEc}, &rply); err != nil {
+		t.Error(err)
+	}
+	// the missing balance is recreated out of the stored config
+	if rcv, err := dm.GetAccountProfile("cgrates.org", "TestV1RefundCharges",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if len(rcv.Balances) != 2 ||
+		rcv.Balances["CB1"].Type != utils.MetaConcrete ||
+		!reflect.DeepEqual(rcv.Balances["CB1"].Weights, acntPrf.Balances["CB1"].Weights) ||
+		rcv.Balances["CB1"].Units.Cmp(decimal.New(5, 0)) != 0 ||
+		rcv.Balances["CB2"].Units.Cmp(decimal.New(200, 0)) != 0 {
+		t.Errorf("unexpected balances: %s", utils.ToJSON(rcv.Balances))
+	}
+
+	// without the config stored the missing balance is not recreated
+	if err := dm.SetAccountCharges(&utils.AccountCharges{
+		Tenant: "cgrates.org",
+		ID:     "NO_CONFIG",
+		Accounting: []*utils.ChargedAccounting{{
+			AccountID: "TestV1RefundCharges",
+			BalanceID: "CB3",
+			Units:     utils.NewDecimal(5, 0),
+		}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := accnts.V1RefundCharges(&utils.ArgsRefundCharges{
+		ChargesID: "NO_CONFIG"}, &rply); err == nil {
+		t.Error("expecting the refund refused")
+	}
+	if rcv, err := dm.GetAccountProfile("cgrates.org", "TestV1RefundCharges",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if _, has := rcv.Balances["CB3"]; has {
+		t.Errorf("unexpected balances: %s", utils.ToJSON(rcv.Balances))
+	}
+
+	// charges not stored when disabled in APIOpts
+	args.CGREvent.Opts = map[string]interface{}{utils.OptsAccountsStoreCharges: false}
+	if err := accnts.V1DebitCost(args, &eEc); err != nil {
+		t.Fatal(err)
+	}
+	if eEc.ID != utils.EmptyString {
+		t.Errorf("unexpected charges ID: %s", eEc.ID)
+	}
+
+	// expired charges cannot be refunded
+	if err := dm.SetAccountCharges(&utils.AccountCharges{
+		Tenant: "cgrates.org",
+		ID:     "EXPIRED",
+		Accounting: []*utils.ChargedAccounting{{
+			AccountID: "TestV1RefundCharges",
+			BalanceID: "CB1",
+			Units:     utils.NewDecimal(5, 0),
+		}},
+		ExpiryTime: utils.TimePointer(time.Now().Add(-time.Second)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := accnts.V1RefundCharges(&utils.ArgsRefundCharges{
+		ChargesID: "EXPIRED"}, &rply); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+
+	if err := accnts.V1RefundCharges(&utils.ArgsRefundCharges{
+		ChargesID: "UNKNOWN"}, &rply); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if err := accnts.V1RefundCharges(new(utils.ArgsRefundCharges), &rply); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.ChargesID).Error() {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestV1ActionSetBalance(t *testing.T) {
+	engine.Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	fltrS := engine.NewFilterS(cfg, nil, dm)
+	accnts := NewAccountS(cfg, fltrS, nil, dm)
+	acntPrf := &utils.AccountProfile{
+		Tenant: "cgrates.org",
+		ID:     "TestV1ActionSetBalance",
+		Balances: map[string]*utils.Balance{
+			"CB1": {
+				ID:    "CB1",
+				Type:  utils.MetaConcrete,
+				Units: utils.NewDecimal(5, 0),
+			},
+		},
+	}
+	if err := dm.SetAccountProfile(acntPrf, false); err != nil {
+		t.Fatal(err)
+	}
+	var rply string
+	args := &utils.ArgsActSetBalance{
+		Tenant:    "cgrates.org",
+		AccountID: "TestV1ActionSetBalance",
+		BalanceID: "CB1",
+		Type:      utils.MetaAddBalance,
+		Units:     utils.NewDecimal(10, 0),
+	}
+	if err := accnts.V1ActionSetBalance(args, &rply); err != nil {
+		t.Fatal(err)
+	}
+	args.Type = utils.MetaDebitBalance
+	args.Units = utils.NewDecimal(3, 0)
+	if err := accnts.V1ActionSetBalance(args, &rply); err != nil {
+		t.Fatal(err)
+	}
+	// the balance limit is not passed, the balance being left untouched
+	args.Units = utils.NewDecimal(13, 0)
+	if err := accnts.V1ActionSetBalance(args, &rply); err != utils.ErrInsufficientCredit {
+		t.Errorf("Expected %+v, received %+v", utils.ErrInsufficientCredit, err)
+	}
+	args.Units = utils.NewDecimal(3, 0)
+	args.BalanceID = "CB2"
+	if err := accnts.V1ActionSetBalance(args, &rply); err == nil ||
+		err.Error() != utils.ErrPrefixNotFound("CB2").Error() {
+		t.Errorf("Expected %+v, received %+v", utils.ErrPrefixNotFound("CB2"), err)
+	}
+	args.Type = utils.MetaSetBalance
+	args.Opts = map[string]interface{}{
+		utils.MetaBalanceType:    utils.MetaAbstract,
+		utils.MetaBalanceWeights: ";10",
+	}
+	if err := accnts.V1ActionSetBalance(args, &rply); err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]*utils.Balance{
+		"CB1": {
+			ID:    "CB1",
+			Type:  utils.MetaConcrete,
+			Units: utils.NewDecimal(12, 0),
+		},
+		"CB2": {
+			ID:    "CB2",
+			Type:  utils.MetaAbstract,
+			Units: utils.NewDecimal(3, 0),
+			Weights: utils.DynamicWeights{
+				{
+					Weight: 10,
+				},
+			},
+		},
+	}
+	if rcv, err := dm.GetAccountProfile("cgrates.org", "TestV1ActionSetBalance",
+		true, true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if utils.ToJSON(rcv.Balances) != utils.ToJSON(exp) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv.Balances))
+	}
+
+	remArgs := &utils.ArgsActRemoveBalance{
+		Tenant:     "cgrates.org",
+		AccountID:  "TestV1ActionSetBalance",
+		BalanceIDs: []string{"CB1"},
+	}
+	if err := accnts.V1ActionRemoveBalance(remArgs, &rply); err != nil {
+		t.Fatal(err)
+	}
+	delete(exp, "CB1")
+	if rcv, err := dm.GetAccountProfile("cgrates.org", "TestV1ActionSetBalance",
+		true, true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if utils.ToJSON(rcv.Balances) != utils.ToJSON(exp) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv.Balances))
+	}
+}
@@ -70,10 +70,16 @@ func (cB *concreteBalance) debitUnits(dUnts *utils.Decimal, tnt string,
 	} else if !pass {
 		return nil, nil, utils.ErrFilterNotPassingNoCaps
 	}
+	return debitBalanceUnits(cB.blnCfg, dUnts, cB.fltrS, tnt, ev)
+}
 
+// debitBalanceUnits debits the units out of the Balance, converted with the UnitFactor
+// the Balance is never debited under its limit
+func debitBalanceUnits(blnCfg *utils.Balance, dUnts *utils.Decimal, fltrS *engine.FilterS,
+	tnt string, ev utils.DataProvider) (dbted *utils.Decimal, uF *utils.UnitFactor, err error) {
 	// unitFactor
 	var hasUF bool
-	if uF, err = unitFactor(cB.blnCfg.UnitFactors, cB.fltrS, tnt, ev); err != nil {
+	if uF, err = unitFactor(blnCfg.UnitFactors, fltrS, tnt, ev); err != nil {
 		return
 	}
 	if uF != nil && uF.Factor.Cmp(decimal.New(1, 0)) != 0 {
@@ -84,21 +90,21 @@ func (cB *concreteBalance) debitUnits(dUnts *utils.Decimal, tnt string,
 	// balanceLimit
 	var hasLmt bool
 	var blncLmt *utils.Decimal
-	if blncLmt, err = balanceLimit(cB.blnCfg.Opts); err != nil {
+	if blncLmt, err = balanceLimit(blnCfg.Opts); err != nil {
 		return
 	}
 	if blncLmt != nil && blncLmt.Big.Cmp(decimal.New(0, 0)) != 0 {
-		cB.blnCfg.Units.Big = utils.SubstractBig(cB.blnCfg.Units.Big, blncLmt.Big)
+		blnCfg.Units.Big = utils.SubstractBig(blnCfg.Units.Big, blncLmt.Big)
 		hasLmt = true
 	}
 
-	if cB.blnCfg.Units.Compare(dUnts) <= 0 && blncLmt != nil { // balance smaller than debit and limited
-		dbted = &utils.Decimal{cB.blnCfg.Units.Big}
-		cB.blnCfg.Units.Big = blncLmt.Big
+	if blnCfg.Units.Compare(dUnts) <= 0 && blncLmt != nil { // balance smaller than debit and limited
+		dbted = &utils.Decimal{blnCfg.Units.Big}
+		blnCfg.Units.Big = blncLmt.Big
 	} else {
-		cB.blnCfg.Units.Big = utils.SubstractBig(cB.blnCfg.Units.Big, dUnts.Big)
+		blnCfg.Units.Big = utils.SubstractBig(blnCfg.Units.Big, dUnts.Big)
 		if hasLmt { // put back the limit
-			cB.blnCfg.Units.Big = utils.SumBig(cB.blnCfg.Units.Big, blncLmt.Big)
+			blnCfg.Units.Big = utils.SumBig(blnCfg.Units.Big, blncLmt.Big)
 		}
 		dbted = dUnts
 	}
@@ -140,3 +146,18 @@ func (cB *concreteBalance) debitUsage(usage *decimal.Big,
 		costIcrm)
 
 }
+
+// debitCost implements the balanceOperator interface
+func (cB *concreteBalance) debitCost(cost *decimal.Big,
+	cgrEv *utils.CGREvent) (ec *utils.EventCharges, err error) {
+	evNm := utils.MapStorage{
+		utils.MetaOpts: cgrEv.Opts,
+		utils.MetaReq:  cgrEv.Event,
+	}
+	var dbted *utils.Decimal
+	if dbted, _, err = cB.debitUnits(&utils.Decimal{Big: cost},
+		cgrEv.Tenant, evNm); err != nil {
+		return
+	}
+	return &utils.EventCharges{Cost: dbted}, nil
+}
@@ -818,3 +818,38 @@ func TestCBSDebitUsageCoverProcessAttributes2(t *testing.T) { // coverage purpos
 		t.Error(err)
 	}
 }
+
+func TestCBDebitCost(t *testing.T) {
+	cb := &concreteBalance{
+		blnCfg: &utils.Balance{
+			ID:   "TestCBDebitCost",
+			Type: utils.MetaConcrete,
+			UnitFactors: []*utils.UnitFactor{
+				{
+					Factor: utils.NewDecimal(100, 0), // EuroCents
+				},
+			},
+			Units: utils.NewDecimal(500, 0), // 500 EURcents
+		},
+		fltrS: new(engine.FilterS),
+	}
+	cgrEv := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "TestCBDebitCost",
+	}
+	if ec, err := cb.debitCost(decimal.New(3, 0), cgrEv); err != nil {
+		t.Error(err)
+	} else if ec.Cost.Cmp(decimal.New(3, 0)) != 0 {
+		t.Errorf("debited: %s", ec.Cost)
+	} else if cb.blnCfg.Units.Cmp(decimal.New(200, 0)) != 0 {
+		t.Errorf("balance remaining: %s", cb.blnCfg.Units)
+	}
+	// not enough units, debit only what is available
+	if ec, err := cb.debitCost(decimal.New(3, 0), cgrEv); err != nil {
+		t.Error(err)
+	} else if ec.Cost.Cmp(decimal.New(2, 0)) != 0 {
+		t.Errorf("debited: %s", ec.Cost)
+	} else if cb.blnCfg.Units.Cmp(decimal.New(0, 0)) != 0 {
+		t.Errorf("balance remaining: %s", cb.blnCfg.Units)
+	}
+}
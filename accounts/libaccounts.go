@@ -21,6 +21,8 @@ package accounts
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/cgrates/cgrates/config"
 
@@ -76,6 +78,7 @@ func newBalanceOperator(blncCfg *utils.Balance, cncrtBlncs []*concreteBalance,
 // balanceOperator is the implementation of a balance type
 type balanceOperator interface {
 	debitUsage(usage *decimal.Big, cgrEv *utils.CGREvent) (ec *utils.EventCharges, err error)
+	debitCost(cost *decimal.Big, cgrEv *utils.CGREvent) (ec *utils.EventCharges, err error)
 }
 
 // roundUsageWithIncrements rounds the usage based on increments
@@ -333,3 +336,55 @@ func restoreAccounts(dm *engine.DataManager,
 		}
 	}
 }
+
+// chargedAccounting returns the units debited out of each Balance, compared with the backup
+func chargedAccounting(acnt *utils.AccountProfile,
+	bkp utils.AccountBalancesBackup) (cAs []*utils.ChargedAccounting) {
+	blncIDs := make([]string, 0, len(acnt.Balances))
+	for blncID := range acnt.Balances {
+		blncIDs = append(blncIDs, blncID)
+	}
+	sort.Strings(blncIDs)
+	for _, blncID := range blncIDs {
+		bkpUnts, has := bkp[blncID]
+		if !has {
+			continue
+		}
+		dbted := utils.SubstractBig(bkpUnts, acnt.Balances[blncID].Units.Big)
+		if dbted.Cmp(decimal.New(0, 0)) == 0 {
+			continue
+		}
+		cAs = append(cAs, &utils.ChargedAccounting{
+			AccountID: acnt.ID,
+			BalanceID: blncID,
+			Units:     &utils.Decimal{Big: dbted},
+		})
+	}
+	return
+}
+
+// newBalanceFromOpts builds a new Balance out of the options received from ActionS
+func newBalanceFromOpts(blncID string, opts map[string]interface{}) (blnc *utils.Balance, err error) {
+	blnc = &utils.Balance{
+		ID:    blncID,
+		Type:  utils.MetaConcrete,
+		Units: utils.NewDecimal(0, 0),
+	}
+	if blncType, has := opts[utils.MetaBalanceType]; has {
+		blnc.Type = utils.IfaceAsString(blncType)
+	}
+	if blnc.Type != utils.MetaConcrete &&
+		blnc.Type != utils.MetaAbstract {
+		return nil, fmt.Errorf("unsupported balance type: <%s>", blnc.Type)
+	}
+	if fltrIDs, has := opts[utils.MetaBalanceFilterIDs]; has {
+		blnc.FilterIDs = strings.Split(utils.IfaceAsString(fltrIDs), utils.InfieldSep)
+	}
+	if weights, has := opts[utils.MetaBalanceWeights]; has {
+		if blnc.Weights, err = utils.NewDynamicWeightsFromString(
+			utils.IfaceAsString(weights), utils.InfieldSep, utils.ANDSep); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
@@ -524,7 +524,7 @@ func TestLogActionExecute(t *testing.T) {
 	}
 
 	logAction := actLog{}
-	if err := logAction.execute(nil, evNM); err != nil {
+	if err := logAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 
@@ -613,7 +613,7 @@ func TestCDRLogActionExecute(t *testing.T) {
 		},
 		utils.MetaOpts: map[string]interface{}{},
 	}
-	if err := cdrLogAction.execute(nil, evNM); err != nil {
+	if err := cdrLogAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -724,7 +724,7 @@ func TestCDRLogActionWithOpts(t *testing.T) {
 			"EventFieldOpt": "eventValue",
 		},
 	}
-	if err := cdrLogAction.execute(nil, evNM); err != nil {
+	if err := cdrLogAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -776,7 +776,7 @@ func TestExportAction(t *testing.T) {
 			"EventFieldOpt": "eventValue",
 		},
 	}
-	if err := exportAction.execute(nil, evNM); err != nil {
+	if err := exportAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -834,7 +834,7 @@ func TestExportActionWithEeIDs(t *testing.T) {
 			"EventFieldOpt": "eventValue",
 		},
 	}
-	if err := exportAction.execute(nil, evNM); err != nil {
+	if err := exportAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -881,7 +881,7 @@ func TestExportActionResetThresholdStaticTenantID(t *testing.T) {
 	evNM := utils.MapStorage{
 		utils.MetaOpts: map[string]interface{}{},
 	}
-	if err := exportAction.execute(nil, evNM); err != nil {
+	if err := exportAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -928,7 +928,7 @@ func TestExportActionResetThresholdStaticID(t *testing.T) {
 	evNM := utils.MapStorage{
 		utils.MetaOpts: map[string]interface{}{},
 	}
-	if err := exportAction.execute(nil, evNM); err != nil {
+	if err := exportAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -983,7 +983,7 @@ func TestExportActionResetThresholdVariableID(t *testing.T) {
 		},
 		utils.MetaOpts: map[string]interface{}{},
 	}
-	if err := exportAction.execute(nil, evNM); err != nil {
+	if err := exportAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -1030,7 +1030,7 @@ func TestExportActionResetStatStaticTenantID(t *testing.T) {
 	evNM := utils.MapStorage{
 		utils.MetaOpts: map[string]interface{}{},
 	}
-	if err := exportAction.execute(nil, evNM); err != nil {
+	if err := exportAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -1077,7 +1077,7 @@ func TestExportActionResetStatStaticID(t *testing.T) {
 	evNM := utils.MapStorage{
 		utils.MetaOpts: map[string]interface{}{},
 	}
-	if err := exportAction.execute(nil, evNM); err != nil {
+	if err := exportAction.execute(nil, evNM, utils.EmptyString); err != nil {
 		t.Error(err)
 	}
 }
@@ -1132,7 +1132,121 @@ func TestExportActionResetStatVariableID(t *testing.T) {
 		},
 		utils.MetaOpts: map[string]interface{}{},
 	}
-	if err := exportAction.execute(nil, evNM); err != nil {
+	if err := exportAction.execute(nil, evNM, utils.EmptyString); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestActionTargetBalance(t *testing.T) {
+	for _, act := range []string{utils.MetaAddBalance, utils.MetaSetBalance,
+		utils.MetaRemBalance, utils.MetaDebitBalance, utils.MetaResetBalance} {
+		if rcv := actionTarget(act); rcv != utils.MetaAccounts {
+			t.Errorf("Expected %+v, received %+v", utils.MetaAccounts, rcv)
+		}
+	}
+	if rcv := actionTarget(utils.MetaLog); rcv != utils.MetaNone {
+		t.Errorf("Expected %+v, received %+v", utils.MetaNone, rcv)
+	}
+}
+
+func TestActSetBalanceExecute(t *testing.T) {
+	// Clear cache because connManager sets the internal connection in cache
+	engine.Cache.Clear([]string{utils.CacheRPCConnections})
+	sMock := &testMockCDRsConn{
+		calls: map[string]func(arg interface{}, rply interface{}) error{
+			utils.AccountSv1ActionSetBalance: func(arg interface{}, rply interface{}) error {
+				argConv, can := arg.(*utils.ArgsActSetBalance)
+				if !can {
+					return fmt.Errorf("Wrong argument type: %T", arg)
+				}
+				exp := &utils.ArgsActSetBalance{
+					Tenant:    "cgrates.org",
+					AccountID: "1001",
+					BalanceID: "MONETARY",
+					Type:      utils.MetaAddBalance,
+					Units:     utils.NewDecimal(10, 0),
+				}
+				if !reflect.DeepEqual(exp, argConv) {
+					return fmt.Errorf("Expected %+v, received %+v", utils.ToJSON(exp), utils.ToJSON(argConv))
+				}
+				return nil
+			},
+		},
+	}
+	internalChann := make(chan rpcclient.ClientConnector, 1)
+	internalChann <- sMock
+	cfg := config.NewDefaultCGRConfig()
+	apA := &engine.APAction{
+		ID:    "ACT_ADD_BALANCE",
+		Type:  utils.MetaAddBalance,
+		Path:  "MONETARY",
+		Value: config.NewRSRParsersMustCompile("~*req.Units", utils.InfieldSep),
+	}
+	evNM := utils.MapStorage{
+		utils.MetaReq: map[string]interface{}{
+			"Units": "10",
+		},
+	}
+	act, err := newActioner(cfg, nil, nil, nil, apA, "cgrates.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expErr := "no connection with AccountS"
+	if err := act.execute(nil, evNM, "1001"); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+	cfg.ActionSCfg().AccountSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts)}
+	connMgr := engine.NewConnManager(config.CgrConfig(), map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts): internalChann,
+	})
+	if act, err = newActioner(cfg, nil, nil, connMgr, apA, "cgrates.org"); err != nil {
+		t.Fatal(err)
+	}
+	if err := act.execute(nil, evNM, "1001"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestActRemBalanceExecute(t *testing.T) {
+	// Clear cache because connManager sets the internal connection in cache
+	engine.Cache.Clear([]string{utils.CacheRPCConnections})
+	sMock := &testMockCDRsConn{
+		calls: map[string]func(arg interface{}, rply interface{}) error{
+			utils.AccountSv1ActionRemoveBalance: func(arg interface{}, rply interface{}) error {
+				argConv, can := arg.(*utils.ArgsActRemoveBalance)
+				if !can {
+					return fmt.Errorf("Wrong argument type: %T", arg)
+				}
+				exp := &utils.ArgsActRemoveBalance{
+					Tenant:     "cgrates.org",
+					AccountID:  "1001",
+					BalanceIDs: []string{"MONETARY", "VOICE"},
+				}
+				if !reflect.DeepEqual(exp, argConv) {
+					return fmt.Errorf("Expected %+v, received %+v", utils.ToJSON(exp), utils.ToJSON(argConv))
+				}
+				return nil
+			},
+		},
+	}
+	internalChann := make(chan rpcclient.ClientConnector, 1)
+	internalChann <- sMock
+	cfg := config.NewDefaultCGRConfig()
+	cfg.ActionSCfg().AccountSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts)}
+	connMgr := engine.NewConnManager(config.CgrConfig(), map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts): internalChann,
+	})
+	act := &actRemBalance{
+		tnt:     "cgrates.org",
+		config:  cfg,
+		connMgr: connMgr,
+		aCfg: &engine.APAction{
+			ID:   "ACT_REM_BALANCE",
+			Type: utils.MetaRemBalance,
+			Path: "MONETARY;VOICE",
+		},
+	}
+	if err := act.execute(nil, utils.MapStorage{}, "1001"); err != nil {
 		t.Error(err)
 	}
 }
@@ -35,6 +35,9 @@ import (
 // actionTarget returns the target attached to an action
 func actionTarget(act string) (trgt string) {
 	switch act {
+	case utils.MetaAddBalance, utils.MetaSetBalance, utils.MetaRemBalance,
+		utils.MetaDebitBalance, utils.MetaResetBalance:
+		trgt = utils.MetaAccounts
 	default:
 		trgt = utils.MetaNone
 	}
@@ -68,7 +71,7 @@ func (s *scheduledActs) Execute() (err error) {
 	var partExec bool
 	for _, act := range s.acts {
 		//ctx, cancel := context.WithTimeout(s.ctx, act.cfg().TTL)
-		if err := act.execute(s.ctx, s.data); err != nil {
+		if err := act.execute(s.ctx, s.data, s.trgID); err != nil {
 			utils.Logger.Warning(fmt.Sprintf("executing action: <%s>, error: <%s>", act.id(), err))
 			partExec = true
 		}
@@ -113,6 +116,11 @@ func newActioner(cfg *config.CGRConfig, fltrS *engine.FilterS, dm *engine.DataMa
 		return &actResetStat{config: cfg, connMgr: connMgr, aCfg: aCfg, tnt: tnt}, nil
 	case utils.MetaResetThreshold:
 		return &actResetThreshold{config: cfg, connMgr: connMgr, aCfg: aCfg, tnt: tnt}, nil
+	case utils.MetaAddBalance, utils.MetaSetBalance,
+		utils.MetaDebitBalance, utils.MetaResetBalance:
+		return &actSetBalance{config: cfg, connMgr: connMgr, aCfg: aCfg, tnt: tnt}, nil
+	case utils.MetaRemBalance:
+		return &actRemBalance{config: cfg, connMgr: connMgr, aCfg: aCfg, tnt: tnt}, nil
 	default:
 		return nil, fmt.Errorf("unsupported action type: <%s>", aCfg.Type)
 
@@ -123,7 +131,7 @@ func newActioner(cfg *config.CGRConfig, fltrS *engine.FilterS, dm *engine.DataMa
 type actioner interface {
 	id() string
 	cfg() *engine.APAction
-	execute(ctx context.Context, data utils.MapStorage) (err error)
+	execute(ctx context.Context, data utils.MapStorage, trgID string) (err error)
 }
 
 // actLogger will log data to CGRateS logger
@@ -140,7 +148,7 @@ func (aL *actLog) cfg() *engine.APAction {
 }
 
 // execute implements actioner interface
-func (aL *actLog) execute(ctx context.Context, data utils.MapStorage) (err error) {
+func (aL *actLog) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
 	var body []byte
 	if body, err = json.Marshal(data); err != nil {
 		return
@@ -166,7 +174,7 @@ func (aL *actCDRLog) cfg() *engine.APAction {
 }
 
 // execute implements actioner interface
-func (aL *actCDRLog) execute(ctx context.Context, data utils.MapStorage) (err error) {
+func (aL *actCDRLog) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
 	if len(aL.config.ActionSCfg().CDRsConns) == 0 {
 		//eroare predefinita
 		return fmt.Errorf("no connection with CDR Server")
@@ -229,7 +237,7 @@ func (aL *actHTTPPost) cfg() *engine.APAction {
 }
 
 // execute implements actioner interface
-func (aL *actHTTPPost) execute(ctx context.Context, data utils.MapStorage) (err error) {
+func (aL *actHTTPPost) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
 	var body []byte
 	if body, err = json.Marshal(data); err != nil {
 		return
@@ -271,7 +279,7 @@ func (aL *actExport) cfg() *engine.APAction {
 }
 
 // execute implements actioner interface
-func (aL *actExport) execute(ctx context.Context, data utils.MapStorage) (err error) {
+func (aL *actExport) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
 	var exporterIDs []string
 	if expIDs, has := aL.cfg().Opts[utils.MetaExporterIDs]; has { // if templateID is not present we use default template
 		exporterIDs = strings.Split(utils.IfaceAsString(expIDs), utils.InfieldSep)
@@ -308,7 +316,7 @@ func (aL *actResetStat) cfg() *engine.APAction {
 }
 
 // execute implements actioner interface
-func (aL *actResetStat) execute(ctx context.Context, data utils.MapStorage) (err error) {
+func (aL *actResetStat) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
 	var tenID string
 	if tenID, err = aL.cfg().Value.ParseDataProvider(data); err != nil {
 		return
@@ -341,7 +349,7 @@ func (aL *actResetThreshold) cfg() *engine.APAction {
 }
 
 // execute implements actioner interface
-func (aL *actResetThreshold) execute(ctx context.Context, data utils.MapStorage) (err error) {
+func (aL *actResetThreshold) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
 	var tenID string
 	if tenID, err = aL.cfg().Value.ParseDataProvider(data); err != nil {
 		return
@@ -357,3 +365,75 @@ func (aL *actResetThreshold) execute(ctx context.Context, data utils.MapStorage)
 	return aL.connMgr.Call(aL.config.ActionSCfg().ThresholdSConns, nil,
 		utils.ThresholdSv1ResetThreshold, args, &rply)
 }
+
+type actSetBalance struct {
+	tnt     string
+	config  *config.CGRConfig
+	connMgr *engine.ConnManager
+	aCfg    *engine.APAction
+}
+
+func (aL *actSetBalance) id() string {
+	return aL.aCfg.ID
+}
+
+func (aL *actSetBalance) cfg() *engine.APAction {
+	return aL.aCfg
+}
+
+// execute implements actioner interface
+func (aL *actSetBalance) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
+	if len(aL.config.ActionSCfg().AccountSConns) == 0 {
+		return fmt.Errorf("no connection with AccountS")
+	}
+	args := &utils.ArgsActSetBalance{
+		Tenant:    aL.tnt,
+		AccountID: trgID,
+		BalanceID: aL.cfg().Path,
+		Type:      aL.cfg().Type,
+		Opts:      aL.cfg().Opts,
+	}
+	if aL.cfg().Type != utils.MetaResetBalance {
+		var val string
+		if val, err = aL.cfg().Value.ParseDataProvider(data); err != nil {
+			return
+		}
+		if args.Units, err = utils.NewDecimalFromString(val); err != nil {
+			return
+		}
+	}
+	var rply string
+	return aL.connMgr.Call(aL.config.ActionSCfg().AccountSConns, nil,
+		utils.AccountSv1ActionSetBalance, args, &rply)
+}
+
+type actRemBalance struct {
+	tnt     string
+	config  *config.CGRConfig
+	connMgr *engine.ConnManager
+	aCfg    *engine.APAction
+}
+
+func (aL *actRemBalance) id() string {
+	return aL.aCfg.ID
+}
+
+func (aL *actRemBalance) cfg() *engine.APAction {
+	return aL.aCfg
+}
+
+// execute implements actioner interface
+func (aL *actRemBalance) execute(ctx context.Context, data utils.MapStorage, trgID string) (err error) {
+	if len(aL.config.ActionSCfg().AccountSConns) == 0 {
+		return fmt.Errorf("no connection with AccountS")
+	}
+	args := &utils.ArgsActRemoveBalance{
+		Tenant:     aL.tnt,
+		AccountID:  trgID,
+		BalanceIDs: strings.Split(aL.cfg().Path, utils.InfieldSep),
+		Opts:       aL.cfg().Opts,
+	}
+	var rply string
+	return aL.connMgr.Call(aL.config.ActionSCfg().AccountSConns, nil,
+		utils.AccountSv1ActionRemoveBalance, args, &rply)
+}
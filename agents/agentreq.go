@@ -58,6 +58,7 @@ func NewAgentRequest(req utils.DataProvider,
 		Vars:       vars,
 		CGRRequest: utils.NewOrderedNavigableMap(),
 		diamreq:    utils.NewOrderedNavigableMap(), // special case when CGRateS is building the request
+		radDAReq:   utils.NewOrderedNavigableMap(), // special case when CGRateS is building the request
 		CGRReply:   cgrRply,
 		Reply:      rply,
 		Timezone:   timezone,
@@ -92,6 +93,7 @@ type AgentRequest struct {
 	Header     utils.DataProvider
 	Trailer    utils.DataProvider
 	diamreq    *utils.OrderedNavigableMap // used in case of building requests (ie. DisconnectSession)
+	radDAReq   *utils.OrderedNavigableMap // used in case of building RADIUS Disconnect/CoA requests
 	tmp        utils.NavigableMap2        // used in case you want to store temporary items and access them later
 	Opts       *utils.OrderedNavigableMap
 	Cfg        utils.DataProvider
@@ -122,6 +124,8 @@ func (ar *AgentRequest) FieldAsInterface(fldPath []string) (val interface{}, err
 		val, err = ar.CGRReply.FieldAsInterface(fldPath[1:])
 	case utils.MetaDiamreq:
 		val, err = ar.diamreq.FieldAsInterface(fldPath[1:])
+	case utils.MetaRadDAReq:
+		val, err = ar.radDAReq.FieldAsInterface(fldPath[1:])
 	case utils.MetaRep:
 		val, err = ar.Reply.FieldAsInterface(fldPath[1:])
 	case utils.MetaHdr:
@@ -163,6 +167,8 @@ func (ar *AgentRequest) Field(fldPath utils.PathItems) (val utils.NMInterface, e
 		val, err = ar.CGRReply.Field(fldPath[1:])
 	case utils.MetaDiamreq:
 		val, err = ar.diamreq.Field(fldPath[1:])
+	case utils.MetaRadDAReq:
+		val, err = ar.radDAReq.Field(fldPath[1:])
 	case utils.MetaRep:
 		val, err = ar.Reply.Field(fldPath[1:])
 	case utils.MetaTmp:
@@ -276,6 +282,11 @@ func (ar *AgentRequest) Set(fullPath *utils.FullPath, nm utils.NMInterface) (add
 			PathItems: fullPath.PathItems[1:],
 			Path:      fullPath.Path[9:],
 		}, nm)
+	case utils.MetaRadDAReq:
+		return ar.radDAReq.Set(&utils.FullPath{
+			PathItems: fullPath.PathItems[1:],
+			Path:      fullPath.Path[10:],
+		}, nm)
 	case utils.MetaTmp:
 		return ar.tmp.Set(fullPath.PathItems[1:], nm)
 	case utils.MetaOpts:
@@ -304,6 +315,8 @@ func (ar *AgentRequest) RemoveAll(prefix string) error {
 		ar.Reply.RemoveAll()
 	case utils.MetaDiamreq:
 		ar.diamreq.RemoveAll()
+	case utils.MetaRadDAReq:
+		ar.radDAReq.RemoveAll()
 	case utils.MetaTmp:
 		ar.tmp = utils.NavigableMap2{}
 	case utils.MetaUCH:
@@ -338,6 +351,11 @@ func (ar *AgentRequest) Remove(fullPath *utils.FullPath) error {
 			PathItems: fullPath.PathItems[1:].Clone(),
 			Path:      fullPath.Path[9:],
 		})
+	case utils.MetaRadDAReq:
+		return ar.radDAReq.Remove(&utils.FullPath{
+			PathItems: fullPath.PathItems[1:].Clone(),
+			Path:      fullPath.Path[10:],
+		})
 	case utils.MetaTmp:
 		return ar.tmp.Remove(fullPath.PathItems[1:])
 	case utils.MetaOpts:
@@ -188,3 +188,40 @@ func radauthReq(flags utils.FlagsWithParams, req *radigo.Packet, aReq *AgentRequ
 
 	return true, nil
 }
+
+// radPacketData is cached when the packet is needed later (ie. Disconnect-Request)
+type radPacketData struct {
+	pkt  *radigo.Packet
+	vars utils.NavigableMap2
+}
+
+// radClientID returns the id used to identify the client secret and dictionary out of its address
+func radClientID(addr net.Addr) (clientID string) {
+	if addr == nil {
+		return radigo.MetaDefault
+	}
+	var err error
+	if clientID, _, err = net.SplitHostPort(addr.String()); err != nil {
+		return addr.String()
+	}
+	return
+}
+
+// radDACodeName returns the name of the Dynamic Authorization packet code
+func radDACodeName(code radigo.PacketCode) string {
+	switch code {
+	case DisconnectRequest:
+		return "Disconnect-Request"
+	case DisconnectACK:
+		return "Disconnect-ACK"
+	case DisconnectNAK:
+		return "Disconnect-NAK"
+	case CoARequest:
+		return "CoA-Request"
+	case CoAACK:
+		return "CoA-ACK"
+	case CoANAK:
+		return "CoA-NAK"
+	}
+	return code.String()
+}
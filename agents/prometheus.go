@@ -0,0 +1,198 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/ltcache"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	capsAllocatedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(utils.MetricsNamespace, "caps", "allocated"),
+		"Number of requests currently holding a caps slot", nil, nil)
+	capsLimitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(utils.MetricsNamespace, "caps", "limit"),
+		"Maximum number of concurrent requests, 0 meaning unlimited", nil, nil)
+	capsQueuedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(utils.MetricsNamespace, "caps", "queued"),
+		"Number of requests waiting for a caps slot with the *queue strategy", nil, nil)
+	activeSessionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(utils.MetricsNamespace, "sessions", "active"),
+		"Number of active sessions", nil, nil)
+	passiveSessionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(utils.MetricsNamespace, "sessions", "passive"),
+		"Number of passive sessions", nil, nil)
+	cacheItemsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(utils.MetricsNamespace, "cache", "items"),
+		"Number of items stored in cache, per partition", []string{utils.MetricsLabelPart}, nil)
+	cacheGroupsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(utils.MetricsNamespace, "cache", "groups"),
+		"Number of groups stored in cache, per partition", []string{utils.MetricsLabelPart}, nil)
+	statQueueMetricDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(utils.MetricsNamespace, "stats", "queue_metric"),
+		"Value of the StatQueue metric",
+		[]string{utils.MetricsLabelTenant, utils.MetricsLabelQueue, utils.MetricsLabelMetric}, nil)
+)
+
+// NewPrometheusAgent constructs a PrometheusAgent
+func NewPrometheusAgent(cfg *config.CGRConfig, caps *engine.Caps,
+	connMgr *engine.ConnManager) (pa *PrometheusAgent) {
+	pa = &PrometheusAgent{
+		cfg:     cfg,
+		caps:    caps,
+		connMgr: connMgr,
+		reg:     prometheus.NewRegistry(),
+	}
+	pa.reg.MustRegister(pa,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	pa.handler = promhttp.HandlerFor(pa.reg,
+		promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError})
+	return
+}
+
+// PrometheusAgent exposes the engine metrics in the Prometheus format.
+// Besides the metrics recorded by the subsystems and registered on its
+// own registry it queries the configured services on each scrape
+type PrometheusAgent struct {
+	cfg     *config.CGRConfig
+	caps    *engine.Caps
+	connMgr *engine.ConnManager
+	reg     *prometheus.Registry
+	handler http.Handler
+}
+
+// RegisterCollectors adds the metrics recorded by the subsystems to the exposed ones
+func (pa *PrometheusAgent) RegisterCollectors(cs ...prometheus.Collector) (err error) {
+	for i, c := range cs {
+		if err = pa.reg.Register(c); err != nil {
+			pa.UnregisterCollectors(cs[:i]...)
+			return
+		}
+	}
+	return
+}
+
+// UnregisterCollectors removes the metrics recorded by the subsystems from the exposed ones
+func (pa *PrometheusAgent) UnregisterCollectors(cs ...prometheus.Collector) {
+	for _, c := range cs {
+		pa.reg.Unregister(c)
+	}
+}
+
+// ServeHTTP implements http.Handler interface
+func (pa *PrometheusAgent) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	pa.handler.ServeHTTP(w, req)
+}
+
+// Describe implements prometheus.Collector interface
+func (pa *PrometheusAgent) Describe(ch chan<- *prometheus.Desc) {
+	ch <- capsAllocatedDesc
+	ch <- capsLimitDesc
+	ch <- capsQueuedDesc
+	ch <- activeSessionsDesc
+	ch <- passiveSessionsDesc
+	ch <- cacheItemsDesc
+	ch <- cacheGroupsDesc
+	ch <- statQueueMetricDesc
+}
+
+// Collect implements prometheus.Collector interface
+func (pa *PrometheusAgent) Collect(ch chan<- prometheus.Metric) {
+	if pa.caps != nil {
+		ch <- prometheus.MustNewConstMetric(capsAllocatedDesc, prometheus.GaugeValue, float64(pa.caps.Allocated()))
+		ch <- prometheus.MustNewConstMetric(capsLimitDesc, prometheus.GaugeValue, float64(pa.caps.Limit()))
+		ch <- prometheus.MustNewConstMetric(capsQueuedDesc, prometheus.GaugeValue, float64(pa.caps.Queued()))
+	}
+	pCfg := pa.cfg.PrometheusAgentCfg()
+	if len(pCfg.SessionSConns) != 0 {
+		pa.collectSessions(ch, pCfg.SessionSConns)
+	}
+	if len(pCfg.CachesConns) != 0 {
+		pa.collectCaches(ch, pCfg.CachesConns, pCfg.CacheIDs)
+	}
+	if len(pCfg.StatSConns) != 0 {
+		pa.collectStatQueues(ch, pCfg.StatSConns, pCfg.StatQueueIDs)
+	}
+}
+
+func (pa *PrometheusAgent) collectSessions(ch chan<- prometheus.Metric, connIDs []string) {
+	for _, s := range []struct {
+		method string
+		desc   *prometheus.Desc
+	}{
+		{utils.SessionSv1GetActiveSessionsCount, activeSessionsDesc},
+		{utils.SessionSv1GetPassiveSessionsCount, passiveSessionsDesc},
+	} {
+		var count int
+		if err := pa.connMgr.Call(connIDs, nil, s.method,
+			new(utils.SessionFilter), &count); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s querying %s",
+					utils.PrometheusAgent, err.Error(), s.method))
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(s.desc, prometheus.GaugeValue, float64(count))
+	}
+}
+
+func (pa *PrometheusAgent) collectCaches(ch chan<- prometheus.Metric, connIDs, cacheIDs []string) {
+	var cacheStats map[string]*ltcache.CacheStats
+	if err := pa.connMgr.Call(connIDs, nil, utils.CacheSv1GetCacheStats,
+		&utils.AttrCacheIDsWithOpts{CacheIDs: cacheIDs}, &cacheStats); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s querying %s",
+				utils.PrometheusAgent, err.Error(), utils.CacheSv1GetCacheStats))
+		return
+	}
+	for cacheID, cs := range cacheStats {
+		ch <- prometheus.MustNewConstMetric(cacheItemsDesc, prometheus.GaugeValue, float64(cs.Items), cacheID)
+		ch <- prometheus.MustNewConstMetric(cacheGroupsDesc, prometheus.GaugeValue, float64(cs.Groups), cacheID)
+	}
+}
+
+func (pa *PrometheusAgent) collectStatQueues(ch chan<- prometheus.Metric, connIDs, sqIDs []string) {
+	for _, sqID := range sqIDs {
+		tntID := utils.NewTenantID(sqID)
+		if tntID.Tenant == utils.EmptyString {
+			tntID.Tenant = pa.cfg.GeneralCfg().DefaultTenant
+		}
+		var metrics map[string]float64
+		if err := pa.connMgr.Call(connIDs, nil, utils.StatSv1GetQueueFloatMetrics,
+			&utils.TenantIDWithOpts{TenantID: tntID}, &metrics); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s querying metrics for StatQueue: %s",
+					utils.PrometheusAgent, err.Error(), tntID.TenantID()))
+			continue
+		}
+		for metricID, val := range metrics {
+			ch <- prometheus.MustNewConstMetric(statQueueMetricDesc, prometheus.GaugeValue, val,
+				tntID.Tenant, tntID.ID, metricID)
+		}
+	}
+}
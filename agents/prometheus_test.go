@@ -0,0 +1,109 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func TestPrometheusAgentServeHTTP(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.PrometheusAgentCfg().Enabled = true
+	cfg.PrometheusAgentCfg().CacheIDs = []string{utils.CacheAttributeProfiles}
+	cfg.PrometheusAgentCfg().SessionSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)}
+	cfg.PrometheusAgentCfg().StatSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)}
+	cfg.PrometheusAgentCfg().StatQueueIDs = []string{"SQ_1"}
+
+	engine.Cache = engine.NewCacheS(cfg, nil, nil)
+	engine.Cache.SetWithoutReplicate(utils.CacheAttributeProfiles, "cgrates.org:ATTR_1", nil, nil, true, utils.EmptyString)
+	engine.Cache.Get(utils.CacheAttributeProfiles, "cgrates.org:ATTR_1")
+	engine.Cache.Get(utils.CacheAttributeProfiles, "cgrates.org:ATTR_2")
+
+	cacheChan := make(chan rpcclient.ClientConnector, 1)
+	cacheChan <- engine.Cache
+	sessChan := make(chan rpcclient.ClientConnector, 1)
+	sessChan <- &testMockSessionConn{calls: map[string]func(arg interface{}, rply interface{}) error{
+		utils.SessionSv1GetActiveSessionsCount: func(arg interface{}, rply interface{}) error {
+			*rply.(*int) = 3
+			return nil
+		},
+		utils.SessionSv1GetPassiveSessionsCount: func(arg interface{}, rply interface{}) error {
+			*rply.(*int) = 1
+			return nil
+		},
+	}}
+	statChan := make(chan rpcclient.ClientConnector, 1)
+	statChan <- &testMockSessionConn{calls: map[string]func(arg interface{}, rply interface{}) error{
+		utils.StatSv1GetQueueFloatMetrics: func(arg interface{}, rply interface{}) error {
+			if tntID := arg.(*utils.TenantIDWithOpts).TenantID; tntID.TenantID() != "cgrates.org:SQ_1" {
+				t.Errorf("Unexpected StatQueue: %s", tntID.TenantID())
+			}
+			*rply.(*map[string]float64) = map[string]float64{utils.MetaACD: 62.5}
+			return nil
+		},
+	}}
+	connMgr := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches):   cacheChan,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS): sessChan,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats):    statChan,
+	})
+	caps := engine.NewCaps(10, utils.MetaBusy)
+	caps.Allocate()
+	pa := NewPrometheusAgent(cfg, caps, connMgr)
+	if err := pa.RegisterCollectors(engine.MetricsCollectors()...); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	pa.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Unexpected status code: %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, exp := range []string{
+		"cgrates_caps_allocated 1",
+		"cgrates_caps_limit 10",
+		"cgrates_caps_queued 0",
+		"cgrates_sessions_active 3",
+		"cgrates_sessions_passive 1",
+		`cgrates_cache_items{partition="*attribute_profiles"} 1`,
+		`cgrates_cache_hits_total{partition="*attribute_profiles"} 1`,
+		`cgrates_cache_misses_total{partition="*attribute_profiles"} 1`,
+		`cgrates_stats_queue_metric{metric="*acd",queue="SQ_1",tenant="cgrates.org"} 62.5`,
+	} {
+		if !strings.Contains(body, exp) {
+			t.Errorf("Expected %q in the metrics, received:\n%s", exp, body)
+		}
+	}
+
+	pa.UnregisterCollectors(engine.MetricsCollectors()...)
+	rec = httptest.NewRecorder()
+	pa.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if body = rec.Body.String(); strings.Contains(body, "cgrates_cache_hits_total") {
+		t.Errorf("Expected the cache metrics unregistered, received:\n%s", body)
+	}
+}
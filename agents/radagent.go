@@ -19,13 +19,21 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"bytes"
+	"crypto/md5"
+	"encoding/binary"
 	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
 
+	"github.com/cenkalti/rpc2"
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/radigo"
+	"github.com/cgrates/rpcclient"
 )
 
 const (
@@ -38,6 +46,17 @@ const (
 	MSCHAPResponseAVP  = "MS-CHAP-Response"
 	MicrosoftVendor    = "Microsoft"
 	MSCHAP2SuccessAVP  = "MS-CHAP2-Success"
+	ErrorCauseAVPNr    = 101 // Error-Cause attribute number defined in RFC 5176
+
+	// packet codes defined in RFC 5176, not known by radigo
+	DisconnectRequest radigo.PacketCode = 40
+	DisconnectACK     radigo.PacketCode = 41
+	DisconnectNAK     radigo.PacketCode = 42
+	CoARequest        radigo.PacketCode = 43
+	CoAACK            radigo.PacketCode = 44
+	CoANAK            radigo.PacketCode = 45
+
+	radDAPortDefault = "3799" // default Dynamic Authorization port on the NAS
 )
 
 func NewRadiusAgent(cgrCfg *config.CGRConfig, filterS *engine.FilterS,
@@ -51,15 +70,15 @@ func NewRadiusAgent(cgrCfg *config.CGRConfig, filterS *engine.FilterS,
 			return
 		}
 	}
-	dicts := radigo.NewDictionaries(dts)
-	ra = &RadiusAgent{cgrCfg: cgrCfg, filterS: filterS, connMgr: connMgr}
-	secrets := radigo.NewSecrets(cgrCfg.RadiusAgentCfg().ClientSecrets)
+	ra = &RadiusAgent{cgrCfg: cgrCfg, filterS: filterS, connMgr: connMgr,
+		dicts:   radigo.NewDictionaries(dts),
+		secrets: radigo.NewSecrets(cgrCfg.RadiusAgentCfg().ClientSecrets)}
 	ra.rsAuth = radigo.NewServer(cgrCfg.RadiusAgentCfg().ListenNet,
-		cgrCfg.RadiusAgentCfg().ListenAuth, secrets, dicts,
+		cgrCfg.RadiusAgentCfg().ListenAuth, ra.secrets, ra.dicts,
 		map[radigo.PacketCode]func(*radigo.Packet) (*radigo.Packet, error){
 			radigo.AccessRequest: ra.handleAuth}, nil)
 	ra.rsAcct = radigo.NewServer(cgrCfg.RadiusAgentCfg().ListenNet,
-		cgrCfg.RadiusAgentCfg().ListenAcct, secrets, dicts,
+		cgrCfg.RadiusAgentCfg().ListenAcct, ra.secrets, ra.dicts,
 		map[radigo.PacketCode]func(*radigo.Packet) (*radigo.Packet, error){
 			radigo.AccountingRequest: ra.handleAcct}, nil)
 	return
@@ -71,6 +90,9 @@ type RadiusAgent struct {
 	filterS *engine.FilterS
 	rsAuth  *radigo.Server
 	rsAcct  *radigo.Server
+	dicts   *radigo.Dictionaries
+	secrets *radigo.Secrets
+	daReqID uint32 // Identifier of the last Disconnect/CoA request sent
 }
 
 // handleAuth handles RADIUS Authorization request
@@ -197,6 +219,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			fmt.Sprintf("<%s> LOG, processorID: %s, radius message: %s",
 				utils.RadiusAgent, reqProcessor.ID, agReq.Request.String()))
 	}
+	ra.cacheRequest(req, agReq.Vars, cgrEv, reqType)
 	switch reqType {
 	default:
 		return false, fmt.Errorf("unknown request type: <%s>", reqType)
@@ -222,7 +245,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.ParamValue(utils.MetaRoutesMaxCost),
 		)
 		rply := new(sessions.V1AuthorizeReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1AuthorizeEvent,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1AuthorizeEvent,
 			authArgs, rply)
 		rply.SetMaxUsageNeeded(authArgs.GetMaxUsage)
 		if err = agReq.setCGRReply(rply, err); err != nil {
@@ -240,7 +263,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.Has(utils.MetaAccounts),
 			cgrEv, reqProcessor.Flags.Has(utils.MetaFD))
 		rply := new(sessions.V1InitSessionReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1InitiateSession,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1InitiateSession,
 			initArgs, rply)
 		rply.SetMaxUsageNeeded(initArgs.InitSession)
 		if err = agReq.setCGRReply(rply, err); err != nil {
@@ -253,7 +276,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.Has(utils.MetaAccounts),
 			cgrEv, reqProcessor.Flags.Has(utils.MetaFD))
 		rply := new(sessions.V1UpdateSessionReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1UpdateSession,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1UpdateSession,
 			updateArgs, rply)
 		rply.SetMaxUsageNeeded(updateArgs.UpdateSession)
 		if err = agReq.setCGRReply(rply, err); err != nil {
@@ -269,7 +292,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.ParamsSlice(utils.MetaStats, utils.MetaIDs),
 			cgrEv, reqProcessor.Flags.Has(utils.MetaFD))
 		var rply string
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1TerminateSession,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1TerminateSession,
 			terminateArgs, &rply)
 		if err = agReq.setCGRReply(nil, err); err != nil {
 			return
@@ -291,7 +314,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.ParamValue(utils.MetaRoutesMaxCost),
 		)
 		rply := new(sessions.V1ProcessMessageReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessMessage, evArgs, rply)
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1ProcessMessage, evArgs, rply)
 		if utils.ErrHasPrefix(err, utils.RalsErrorPrfx) {
 			cgrEv.Event[utils.Usage] = 0 // avoid further debits
 		} else if evArgs.Debit {
@@ -308,7 +331,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			Paginator: cgrArgs,
 		}
 		rply := new(sessions.V1ProcessEventReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessEvent,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1ProcessEvent,
 			evArgs, rply)
 		if utils.ErrHasPrefix(err, utils.RalsErrorPrfx) {
 			cgrEv.Event[utils.Usage] = 0 // avoid further debits
@@ -329,7 +352,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 	// separate request so we can capture the Terminate/Event also here
 	if reqProcessor.Flags.GetBool(utils.MetaCDRs) {
 		var rplyCDRs string
-		if err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessCDR,
+		if err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1ProcessCDR,
 			cgrEv, &rplyCDRs); err != nil {
 			agReq.CGRReply.Set(utils.PathItems{{Field: utils.Error}}, utils.NewNMData(err.Error()))
 		}
@@ -369,3 +392,258 @@ func (ra *RadiusAgent) ListenAndServe(stopChan <-chan struct{}) (err error) {
 	err = <-errListen
 	return
 }
+
+// cacheRequest keeps the request data needed to build the Disconnect/CoA requests for the session
+func (ra *RadiusAgent) cacheRequest(req *radigo.Packet, vars utils.NavigableMap2,
+	cgrEv *utils.CGREvent, reqType string) {
+	if ra.cgrCfg.RadiusAgentCfg().DMRTemplate == utils.EmptyString &&
+		ra.cgrCfg.RadiusAgentCfg().CoATemplate == utils.EmptyString {
+		return
+	}
+	originID, err := cgrEv.FieldAsString(utils.OriginID)
+	if err != nil {
+		return
+	}
+	if reqType == utils.MetaTerminate {
+		engine.Cache.Remove(utils.CacheRadiusPackets, originID, true, utils.NonTransactional)
+		return
+	}
+	if errCh := engine.Cache.Set(utils.CacheRadiusPackets, originID, &radPacketData{req, vars},
+		nil, true, utils.NonTransactional); errCh != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed caching packet with OriginID: <%s>, err: %s",
+				utils.RadiusAgent, originID, errCh.Error()))
+	}
+}
+
+// Call implements rpcclient.ClientConnector interface
+func (ra *RadiusAgent) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.RPCCall(ra, serviceMethod, args, reply)
+}
+
+// V1DisconnectSession is part of the sessions.BiRPClient
+// sends a Disconnect-Request to the NAS which originated the session
+func (ra *RadiusAgent) V1DisconnectSession(args utils.AttrDisconnectSession, reply *string) (err error) {
+	ssID, has := args.EventStart[utils.OriginID]
+	if !has {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> cannot disconnect session, missing OriginID in event: %s",
+				utils.RadiusAgent, utils.ToJSON(args.EventStart)))
+		return utils.ErrMandatoryIeMissing
+	}
+	if ra.cgrCfg.RadiusAgentCfg().DMRTemplate == utils.EmptyString {
+		return utils.ErrNotImplemented
+	}
+	if err = ra.sendDARequest(DisconnectRequest, utils.IfaceAsString(ssID),
+		ra.cgrCfg.RadiusAgentCfg().DMRTemplate); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1GetActiveSessionIDs is part of the sessions.BiRPClient
+func (ra *RadiusAgent) V1GetActiveSessionIDs(ignParam string,
+	sessionIDs *[]*sessions.SessionID) error {
+	return utils.ErrNotImplemented
+}
+
+// V1ReAuthorize sends a CoA-Request to the NAS which originated the session
+func (ra *RadiusAgent) V1ReAuthorize(originID string, reply *string) (err error) {
+	if originID == utils.EmptyString {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> cannot send CoA, missing session ID",
+				utils.RadiusAgent))
+		return utils.ErrMandatoryIeMissing
+	}
+	if ra.cgrCfg.RadiusAgentCfg().CoATemplate == utils.EmptyString {
+		return utils.ErrNotImplemented
+	}
+	if err = ra.sendDARequest(CoARequest, originID,
+		ra.cgrCfg.RadiusAgentCfg().CoATemplate); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1DisconnectPeer is used to implement the sessions.BiRPClient interface
+func (*RadiusAgent) V1DisconnectPeer(args *utils.DPRArgs, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
+// V1WarnDisconnect is used to implement the sessions.BiRPClient interface
+func (*RadiusAgent) V1WarnDisconnect(args map[string]interface{}, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
+// sendDARequest builds the Dynamic Authorization request out of the template and the cached packet
+// and sends it to the NAS, returning error if the NAS did not acknowledge it
+func (ra *RadiusAgent) sendDARequest(code radigo.PacketCode, originID, tplID string) (err error) {
+	pktData, has := engine.Cache.Get(utils.CacheRadiusPackets, originID)
+	if !has {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot retrieve packet from cache with OriginID: <%s>",
+				utils.RadiusAgent, originID))
+		return utils.ErrMandatoryIeMissing
+	}
+	rpd := pktData.(*radPacketData)
+	aReq := NewAgentRequest(
+		newRADataProvider(rpd.pkt),
+		rpd.vars, nil, nil, nil, nil,
+		ra.cgrCfg.GeneralCfg().DefaultTenant,
+		ra.cgrCfg.GeneralCfg().DefaultTimezone, ra.filterS, nil, nil)
+	if err = aReq.SetFields(ra.cgrCfg.TemplatesCfg()[tplID]); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot send %s with OriginID: <%s>, err: %s",
+				utils.RadiusAgent, radDACodeName(code), originID, err.Error()))
+		return utils.ErrServerError
+	}
+	clientID := radClientID(rpd.pkt.RemoteAddr())
+	secret := ra.secrets.GetSecret(clientID)
+	pkt := radigo.NewPacket(code, uint8(atomic.AddUint32(&ra.daReqID, 1)),
+		ra.dicts.GetInstance(clientID), radigo.NewCoder(), secret)
+	if err = radReplyAppendAttributes(pkt, aReq.radDAReq); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot send %s with OriginID: <%s>, err: %s",
+				utils.RadiusAgent, radDACodeName(code), originID, err.Error()))
+		return utils.ErrServerError
+	}
+	var rpl *radigo.Packet
+	if rpl, err = sendRadDAPacket(pkt, secret,
+		ra.daAddress(clientID), ra.cgrCfg.GeneralCfg().ReplyTimeout); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed sending %s with OriginID: <%s>, err: %s",
+				utils.RadiusAgent, radDACodeName(code), originID, err.Error()))
+		return
+	}
+	if rpl.Code != code+1 { // ACK codes follow the request ones
+		errCause := "unknown"
+		for _, avp := range rpl.AVPs {
+			if avp.Number == ErrorCauseAVPNr && len(avp.RawValue) == 4 {
+				errCause = utils.IfaceAsString(binary.BigEndian.Uint32(avp.RawValue))
+				break
+			}
+		}
+		return fmt.Errorf("%s received with Error-Cause: <%s>", radDACodeName(rpl.Code), errCause)
+	}
+	return
+}
+
+// daAddress returns the address of the Dynamic Authorization server for the client
+func (ra *RadiusAgent) daAddress(clientID string) string {
+	if addr, has := ra.cgrCfg.RadiusAgentCfg().ClientDaAddresses[clientID]; has {
+		return addr
+	}
+	if addr, has := ra.cgrCfg.RadiusAgentCfg().ClientDaAddresses[utils.MetaDefault]; has {
+		return addr
+	}
+	return net.JoinHostPort(clientID, radDAPortDefault)
+}
+
+// sendRadDAPacket sends the Dynamic Authorization request over UDP and waits for the authenticated reply
+func sendRadDAPacket(pkt *radigo.Packet, secret, addr string,
+	timeout time.Duration) (rpl *radigo.Packet, err error) {
+	buf := make([]byte, radigo.MaxPacketLen)
+	var n int
+	if n, err = pkt.Encode(buf); err != nil {
+		return
+	}
+	// radigo does not know the Dynamic Authorization codes so the Request Authenticator is computed here (RFC 5176 2.3)
+	reqAuth := radDAAuthenticator(buf[:n], [16]byte{}, secret)
+	copy(buf[4:20], reqAuth[:])
+	var conn net.Conn
+	if conn, err = net.DialTimeout(utils.UDP, addr, timeout); err != nil {
+		return
+	}
+	defer conn.Close()
+	if timeout != 0 {
+		conn.SetDeadline(time.Now().Add(timeout))
+	}
+	if _, err = conn.Write(buf[:n]); err != nil {
+		return
+	}
+	rplBuf := make([]byte, radigo.MaxPacketLen)
+	if n, err = conn.Read(rplBuf); err != nil {
+		return
+	}
+	if n < 20 || int(binary.BigEndian.Uint16(rplBuf[2:4])) > n {
+		return nil, fmt.Errorf("invalid reply length: %d", n)
+	}
+	rplBuf = rplBuf[:binary.BigEndian.Uint16(rplBuf[2:4])]
+	if rplAuth := radDAAuthenticator(rplBuf, reqAuth, secret); !bytes.Equal(rplAuth[:], rplBuf[4:20]) {
+		return nil, fmt.Errorf("invalid reply authenticator")
+	}
+	rpl = radigo.NewPacket(0, 0, nil, nil, secret)
+	if err = rpl.Decode(rplBuf); err != nil {
+		return nil, err
+	}
+	if rpl.Identifier != pkt.Identifier {
+		return nil, fmt.Errorf("unexpected reply identifier: %d", rpl.Identifier)
+	}
+	return
+}
+
+// radDAAuthenticator computes the authenticator of a Dynamic Authorization packet,
+// reqAuth should be empty for requests and the request authenticator for replies
+func radDAAuthenticator(raw []byte, reqAuth [16]byte, secret string) (acator [16]byte) {
+	hash := md5.New()
+	hash.Write(raw[:4])
+	hash.Write(reqAuth[:])
+	hash.Write(raw[20:])
+	hash.Write([]byte(secret))
+	copy(acator[:], hash.Sum(nil))
+	return
+}
+
+// CallBiRPC is part of utils.BiRPCServer interface to help internal connections do calls over rpcclient.ClientConnector interface
+func (ra *RadiusAgent) CallBiRPC(clnt rpcclient.ClientConnector, serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.BiRPCCall(ra, clnt, serviceMethod, args, reply)
+}
+
+// BiRPCv1DisconnectSession is internal method to disconnect session on the NAS
+func (ra *RadiusAgent) BiRPCv1DisconnectSession(clnt rpcclient.ClientConnector, args utils.AttrDisconnectSession, reply *string) error {
+	return ra.V1DisconnectSession(args, reply)
+}
+
+// BiRPCv1GetActiveSessionIDs is internal method to get all active sessions on the NAS
+func (ra *RadiusAgent) BiRPCv1GetActiveSessionIDs(clnt rpcclient.ClientConnector, ignParam string,
+	sessionIDs *[]*sessions.SessionID) error {
+	return ra.V1GetActiveSessionIDs(ignParam, sessionIDs)
+}
+
+// BiRPCv1ReAuthorize is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1ReAuthorize(clnt rpcclient.ClientConnector, originID string, reply *string) (err error) {
+	return ra.V1ReAuthorize(originID, reply)
+}
+
+// BiRPCv1DisconnectPeer is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1DisconnectPeer(clnt rpcclient.ClientConnector, args *utils.DPRArgs, reply *string) (err error) {
+	return ra.V1DisconnectPeer(args, reply)
+}
+
+// BiRPCv1WarnDisconnect is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1WarnDisconnect(clnt rpcclient.ClientConnector, args map[string]interface{}, reply *string) (err error) {
+	return ra.V1WarnDisconnect(args, reply)
+}
+
+// Handlers is used to implement the rpcclient.BiRPCConector interface
+func (ra *RadiusAgent) Handlers() map[string]interface{} {
+	return map[string]interface{}{
+		utils.SessionSv1DisconnectSession: func(clnt *rpc2.Client, args utils.AttrDisconnectSession, rply *string) error {
+			return ra.BiRPCv1DisconnectSession(clnt, args, rply)
+		},
+		utils.SessionSv1GetActiveSessionIDs: func(clnt *rpc2.Client, args string, rply *[]*sessions.SessionID) error {
+			return ra.BiRPCv1GetActiveSessionIDs(clnt, args, rply)
+		},
+		utils.SessionSv1ReAuthorize: func(clnt *rpc2.Client, args string, rply *string) (err error) {
+			return ra.BiRPCv1ReAuthorize(clnt, args, rply)
+		},
+		utils.SessionSv1DisconnectPeer: func(clnt *rpc2.Client, args *utils.DPRArgs, rply *string) (err error) {
+			return ra.BiRPCv1DisconnectPeer(clnt, args, rply)
+		},
+		utils.SessionSv1WarnDisconnect: func(clnt *rpc2.Client, args map[string]interface{}, rply *string) (err error) {
+			return ra.BiRPCv1WarnDisconnect(clnt, args, rply)
+		},
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/radigo"
+)
+
+// fakeRadDAServer answers the Dynamic Authorization requests as a NAS would,
+// sending back NAK with Error-Cause 503 (Session Context Not Found) for the CoA requests
+func fakeRadDAServer(t *testing.T, conn net.PacketConn, secret string, rcvReqs chan *radigo.Packet) {
+	buf := make([]byte, radigo.MaxPacketLen)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		req := buf[:n]
+		reqAuth := radDAAuthenticator(req, [16]byte{}, secret)
+		if !bytes.Equal(reqAuth[:], req[4:20]) {
+			t.Errorf("invalid request authenticator")
+			continue
+		}
+		pkt := radigo.NewPacket(0, 0, dictRad, coder, secret)
+		if err = pkt.Decode(req); err != nil {
+			t.Error(err)
+			continue
+		}
+		rpl := []byte{byte(pkt.Code) + 1, pkt.Identifier, 0, 20}
+		rpl = append(rpl, make([]byte, 16)...)
+		if pkt.Code == CoARequest {
+			rpl[0] = byte(CoANAK)
+			rpl = append(rpl, ErrorCauseAVPNr, 6, 0, 0, 0x01, 0xf7)
+		}
+		binary.BigEndian.PutUint16(rpl[2:4], uint16(len(rpl)))
+		rplAuth := radDAAuthenticator(rpl, pkt.Authenticator, secret)
+		copy(rpl[4:20], rplAuth[:])
+		if _, err = conn.WriteTo(rpl, addr); err != nil {
+			t.Error(err)
+		}
+		rcvReqs <- pkt
+	}
+}
+
+func TestRadiusAgentDARequests(t *testing.T) {
+	conn, err := net.ListenPacket(utils.UDP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	rcvReqs := make(chan *radigo.Packet, 1)
+	go fakeRadDAServer(t, conn, "CGRateS.org", rcvReqs)
+
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RadiusAgentCfg().ClientDaAddresses = map[string]string{utils.MetaDefault: conn.LocalAddr().String()}
+	cfg.RadiusAgentCfg().DMRTemplate = "*dmr"
+	cfg.RadiusAgentCfg().CoATemplate = "*coa"
+	tpl := []*config.FCTemplate{
+		{Tag: "User-Name", Path: "*radDAReq.User-Name", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.User-Name", utils.InfieldSep)},
+		{Tag: "Acct-Session-Id", Path: "*radDAReq.Acct-Session-Id", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Acct-Session-Id", utils.InfieldSep)},
+	}
+	for _, fc := range tpl {
+		fc.ComputePath()
+	}
+	cfg.TemplatesCfg()["*dmr"] = tpl
+	cfg.TemplatesCfg()["*coa"] = tpl
+	engine.Cache = engine.NewCacheS(cfg, nil, nil)
+	ra := &RadiusAgent{
+		cgrCfg:  cfg,
+		filterS: engine.NewFilterS(cfg, nil, nil),
+		dicts:   radigo.NewDictionaries(map[string]*radigo.Dictionary{utils.MetaDefault: dictRad}),
+		secrets: radigo.NewSecrets(map[string]string{utils.MetaDefault: "CGRateS.org"}),
+	}
+
+	var reply string
+	args := utils.AttrDisconnectSession{EventStart: map[string]interface{}{utils.OriginID: "sess1"}}
+	if err = ra.V1DisconnectSession(args, &reply); err != utils.ErrMandatoryIeMissing {
+		t.Errorf("Expected %+v, received %+v", utils.ErrMandatoryIeMissing, err)
+	}
+
+	req := radigo.NewPacket(radigo.AccountingRequest, 1, dictRad, coder, "CGRateS.org")
+	req.AddAVPWithName("User-Name", "1001", utils.EmptyString)
+	req.AddAVPWithName("Acct-Session-Id", "sess1", utils.EmptyString)
+	req.SetAVPValues()
+	ra.cacheRequest(req, utils.NavigableMap2{}, &utils.CGREvent{
+		Event: map[string]interface{}{utils.OriginID: "sess1"}}, utils.MetaInitiate)
+
+	if err = ra.V1DisconnectSession(args, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Unexpected reply: %s", reply)
+	}
+	select {
+	case pkt := <-rcvReqs:
+		if pkt.Code != DisconnectRequest {
+			t.Errorf("Expected %s, received %s", radDACodeName(DisconnectRequest), radDACodeName(pkt.Code))
+		}
+		pkt.SetAVPValues()
+		if avps := pkt.AttributesWithName("User-Name", utils.EmptyString); len(avps) != 1 ||
+			avps[0].StringValue != "1001" {
+			t.Errorf("Unexpected User-Name in request: %s", utils.ToJSON(avps))
+		}
+		if avps := pkt.AttributesWithName("Acct-Session-Id", utils.EmptyString); len(avps) != 1 ||
+			avps[0].StringValue != "sess1" {
+			t.Errorf("Unexpected Acct-Session-Id in request: %s", utils.ToJSON(avps))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for the Disconnect-Request")
+	}
+
+	expErr := "CoA-NAK received with Error-Cause: <503>"
+	if err = ra.V1ReAuthorize("sess1", &reply); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %s, received %v", expErr, err)
+	}
+	<-rcvReqs
+
+	ra.cacheRequest(req, utils.NavigableMap2{}, &utils.CGREvent{
+		Event: map[string]interface{}{utils.OriginID: "sess1"}}, utils.MetaTerminate)
+	if _, has := engine.Cache.Get(utils.CacheRadiusPackets, "sess1"); has {
+		t.Error("Expected the packet to be removed from cache on terminate")
+	}
+}
+
+func TestRadiusAgentDAAddress(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	ra := &RadiusAgent{cgrCfg: cfg}
+	if rcv := ra.daAddress("10.0.0.1"); rcv != "10.0.0.1:3799" {
+		t.Errorf("Unexpected address: %s", rcv)
+	}
+	cfg.RadiusAgentCfg().ClientDaAddresses = map[string]string{
+		"10.0.0.1":        "10.0.0.2:1700",
+		utils.MetaDefault: "10.0.0.3:1700",
+	}
+	if rcv := ra.daAddress("10.0.0.1"); rcv != "10.0.0.2:1700" {
+		t.Errorf("Unexpected address: %s", rcv)
+	}
+	if rcv := ra.daAddress("10.0.0.5"); rcv != "10.0.0.3:1700" {
+		t.Errorf("Unexpected address: %s", rcv)
+	}
+}
@@ -141,13 +141,26 @@ type QueryArgs struct {
 // V1StringQuery returns a list of API that match the query
 func (aS *AnalyzerService) V1StringQuery(args *QueryArgs, reply *[]map[string]interface{}) error {
 	s := bleve.NewSearchRequest(bleve.NewQueryStringQuery(args.HeaderFilters))
+	rply, _, err := aS.search(s, nil, args.ContentFilters)
+	if err != nil {
+		return err
+	}
+	*reply = rply
+	return nil
+}
+
+// search executes the search request and returns the fields of the matching API calls
+// the calls are checked also against the optional hdrPass function and the content filters
+// the number of hits returned by bleve, before these checks, is returned as well
+func (aS *AnalyzerService) search(s *bleve.SearchRequest,
+	hdrPass func(map[string]interface{}) bool, contentFltrs []string) ([]map[string]interface{}, int, error) {
 	s.Fields = []string{utils.Meta} // return all fields
 	searchResults, err := aS.db.Search(s)
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
 	rply := make([]map[string]interface{}, 0, searchResults.Hits.Len())
-	lenContentFltrs := len(args.ContentFilters)
+	lenContentFltrs := len(contentFltrs)
 	for _, obj := range searchResults.Hits {
 		// make sure that the result is corectly marshaled
 		rep := json.RawMessage(utils.IfaceAsString(obj.Fields[utils.Reply]))
@@ -161,20 +174,22 @@ func (aS *AnalyzerService) V1StringQuery(args *QueryArgs, reply *[]map[string]in
 		if val, has := obj.Fields[utils.ReplyError]; !has || len(utils.IfaceAsString(val)) == 0 {
 			obj.Fields[utils.ReplyError] = nil
 		}
+		if hdrPass != nil && !hdrPass(obj.Fields) {
+			continue
+		}
 		if lenContentFltrs != 0 {
 			dp, err := getDPFromSearchresult(req, rep, obj.Fields)
 			if err != nil {
-				return err
+				return nil, 0, err
 			}
 			if pass, err := aS.filterS.Pass(aS.cfg.GeneralCfg().DefaultTenant,
-				args.ContentFilters, dp); err != nil {
-				return err
+				contentFltrs, dp); err != nil {
+				return nil, 0, err
 			} else if !pass {
 				continue
 			}
 		}
 		rply = append(rply, obj.Fields)
 	}
-	*reply = rply
-	return nil
+	return rply, searchResults.Hits.Len(), nil
 }
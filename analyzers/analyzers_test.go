@@ -19,8 +19,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package analyzers
 
 import (
+	"bytes"
 	"encoding/json"
+	"io/ioutil"
 	"os"
+	"path"
 	"reflect"
 	"runtime"
 	"strconv"
@@ -401,3 +404,183 @@ func TestAnalyzersV1Search(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestAnalyzersV1Query(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.AnalyzerSCfg().DBPath = "/tmp/analyzers"
+	if err := os.RemoveAll(cfg.AnalyzerSCfg().DBPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(cfg.AnalyzerSCfg().DBPath, 0700); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cfg.AnalyzerSCfg().DBPath)
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	anz, err := NewAnalyzerService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	anz.SetFilterS(engine.NewFilterS(cfg, nil, dm))
+	t1 := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	if err = anz.logTrafic(1, utils.CoreSv1Ping, &utils.CGREvent{}, utils.Pong, nil,
+		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012",
+		t1, t1.Add(2*time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if err = anz.logTrafic(2, utils.CoreSv1Status, &utils.CGREvent{}, nil, utils.ErrNotFound,
+		utils.MetaJSON, "10.0.0.1:5565", "127.0.0.1:2012",
+		t1.Add(time.Minute), t1.Add(time.Minute+20*time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if err = anz.logTrafic(3, utils.CoreSv1Ping, &utils.CGREvent{}, utils.Pong, nil,
+		utils.MetaJSON, "127.0.0.1:5566", "127.0.0.1:2012",
+		t1.Add(2*time.Minute), t1.Add(2*time.Minute+2*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	idsOf := func(calls []map[string]interface{}) (ids []float64) {
+		for _, call := range calls {
+			ids = append(ids, call["RequestID"].(float64))
+		}
+		return
+	}
+	var reply []map[string]interface{}
+	if err = anz.V1Query(&V1QueryArgs{}, &reply); err != nil {
+		t.Fatal(err)
+	} else if ids := idsOf(reply); !reflect.DeepEqual([]float64{1, 2, 3}, ids) {
+		t.Errorf("Unexpected calls: %v", ids)
+	}
+	if err = anz.V1Query(&V1QueryArgs{
+		Methods:     []string{utils.CoreSv1Ping},
+		MinDuration: utils.DurationPointer(time.Second),
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if ids := idsOf(reply); !reflect.DeepEqual([]float64{3}, ids) {
+		t.Errorf("Unexpected calls: %v", ids)
+	}
+	if err = anz.V1Query(&V1QueryArgs{
+		StartTime: utils.TimePointer(t1.Add(time.Minute)),
+		EndTime:   utils.TimePointer(t1.Add(2 * time.Minute)),
+		HasError:  utils.BoolPointer(true),
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if ids := idsOf(reply); !reflect.DeepEqual([]float64{2}, ids) {
+		t.Errorf("Unexpected calls: %v", ids)
+	}
+	if err = anz.V1Query(&V1QueryArgs{
+		RequestSource: "127.0.0.1",
+		OrderBy:       []string{"-" + utils.RequestStartTime},
+		Offset:        1,
+		Limit:         1,
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if ids := idsOf(reply); !reflect.DeepEqual([]float64{1}, ids) {
+		t.Errorf("Unexpected calls: %v", ids)
+	}
+	if err = anz.V1Query(&V1QueryArgs{
+		HasError: utils.BoolPointer(false),
+		Offset:   1,
+		Limit:    5,
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if ids := idsOf(reply); !reflect.DeepEqual([]float64{3}, ids) {
+		t.Errorf("Unexpected calls: %v", ids)
+	}
+	if err = anz.V1Query(&V1QueryArgs{Limit: 2}, &reply); err != nil {
+		t.Fatal(err)
+	} else if ids := idsOf(reply); !reflect.DeepEqual([]float64{1, 2}, ids) {
+		t.Errorf("Unexpected calls: %v", ids)
+	}
+	if err = anz.V1Query(&V1QueryArgs{
+		RequestSource: "127.0.0.1",
+		Offset:        2,
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if len(reply) != 0 {
+		t.Errorf("Unexpected calls: %v", idsOf(reply))
+	}
+
+	var metrics map[string]*MethodMetrics
+	if err = anz.V1QueryMetrics(&V1QueryMetricsArgs{
+		LatencyBuckets: []time.Duration{10 * time.Millisecond, time.Second},
+	}, &metrics); err != nil {
+		t.Fatal(err)
+	}
+	expMetrics := map[string]*MethodMetrics{
+		utils.CoreSv1Ping: {
+			Count:       2,
+			MinDuration: 2 * time.Millisecond,
+			MaxDuration: 2 * time.Second,
+			AvgDuration: time.Second + time.Millisecond,
+			Histogram: []*LatencyBucket{
+				{UpperBound: 10 * time.Millisecond, Count: 1},
+				{UpperBound: time.Second},
+				{UpperBound: -1, Count: 1},
+			},
+		},
+		utils.CoreSv1Status: {
+			Count:       1,
+			Errors:      1,
+			MinDuration: 20 * time.Millisecond,
+			MaxDuration: 20 * time.Millisecond,
+			AvgDuration: 20 * time.Millisecond,
+			Histogram: []*LatencyBucket{
+				{UpperBound: 10 * time.Millisecond},
+				{UpperBound: time.Second, Count: 1},
+				{UpperBound: -1},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expMetrics, metrics) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expMetrics), utils.ToJSON(metrics))
+	}
+
+	cfg.AnalyzerSCfg().ExportPath = cfg.AnalyzerSCfg().DBPath
+	expPath := path.Join(cfg.AnalyzerSCfg().DBPath, "calls.txt")
+	var rply string
+	if err = anz.V1Export(&V1ExportArgs{
+		V1QueryArgs: V1QueryArgs{Methods: []string{utils.CoreSv1Ping}},
+		Format:      utils.MetaCGRTester,
+		ExportPath:  "calls.txt",
+	}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	expExport := `{"id":1,"method":"CoreSv1.Ping","params":[{"Tenant":"","ID":"","Time":null,"Event":null,"Opts":null}]}` + "\n\n" +
+		`{"id":3,"method":"CoreSv1.Ping","params":[{"Tenant":"","ID":"","Time":null,"Event":null,"Opts":null}]}`
+	if rcv, err := ioutil.ReadFile(expPath); err != nil {
+		t.Fatal(err)
+	} else if string(rcv) != expExport {
+		t.Errorf("Expected %s, received %s", expExport, rcv)
+	}
+	if err = anz.V1Export(&V1ExportArgs{
+		V1QueryArgs: V1QueryArgs{HasError: utils.BoolPointer(true)},
+		Format:      utils.MetaJSONLines,
+		ExportPath:  expPath,
+	}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := ioutil.ReadFile(expPath); err != nil {
+		t.Fatal(err)
+	} else if lines := bytes.Split(bytes.TrimSpace(rcv), []byte("\n")); len(lines) != 1 {
+		t.Errorf("Unexpected export: %s", rcv)
+	} else {
+		var call map[string]interface{}
+		if err := json.Unmarshal(lines[0], &call); err != nil {
+			t.Fatal(err)
+		} else if call[utils.RequestMethod] != utils.CoreSv1Status ||
+			call[utils.ReplyError] != utils.ErrNotFound.Error() {
+			t.Errorf("Unexpected call: %s", lines[0])
+		}
+	}
+	if err = anz.V1Export(&V1ExportArgs{Format: "*xml", ExportPath: expPath}, &rply); err == nil {
+		t.Error("Expected error for the unsupported format")
+	}
+	for _, outPath := range []string{"../calls.txt", "/tmp/calls.txt", "."} {
+		if err = anz.V1Export(&V1ExportArgs{ExportPath: outPath}, &rply); err == nil {
+			t.Errorf("Expected error for the ExportPath %q", outPath)
+		}
+	}
+	if err = anz.db.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,335 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package analyzers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/blevesearch/bleve"
+	"github.com/blevesearch/bleve/search/query"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// defaultLatencyBuckets are the upper bounds of the latency histogram used if none are requested
+var defaultLatencyBuckets = []time.Duration{time.Millisecond, 5 * time.Millisecond,
+	10 * time.Millisecond, 50 * time.Millisecond, 100 * time.Millisecond,
+	500 * time.Millisecond, time.Second, 5 * time.Second}
+
+// V1QueryArgs the typed filters used to query the API calls
+type V1QueryArgs struct {
+	Methods       []string       // match any of the API methods
+	StartTime     *time.Time     // the calls started at or after this time
+	EndTime       *time.Time     // the calls started before this time
+	MinDuration   *time.Duration // the calls lasting at least this duration
+	MaxDuration   *time.Duration // the calls lasting less than this duration
+	HasError      *bool          // the calls replied with or without error
+	RequestSource string         // the address of the requester, matched as prefix
+	// an extra query string(https://blevesearch.com/docs/Query-String-Query/) matched together with the filters above
+	HeaderFilters string
+	// a list of filters that we use to filter the call similar to how we filter the events
+	ContentFilters []string
+
+	OrderBy []string // the fields to sort on, prefixed with "-" for descending order, defaults to RequestStartTime
+	Offset  int      // the number of matching calls to skip
+	Limit   int      // the maximum number of calls returned, 0 for all
+}
+
+// bleveQuery returns the query to be sent to bleve out of the indexed filters
+func (args *V1QueryArgs) bleveQuery() query.Query {
+	var conjuncts []query.Query
+	if args.HeaderFilters != utils.EmptyString {
+		conjuncts = append(conjuncts, bleve.NewQueryStringQuery(args.HeaderFilters))
+	}
+	if len(args.Methods) != 0 {
+		mthds := make([]query.Query, len(args.Methods))
+		for i, mthd := range args.Methods {
+			q := bleve.NewMatchPhraseQuery(mthd)
+			q.SetField(utils.RequestMethod)
+			mthds[i] = q
+		}
+		conjuncts = append(conjuncts, bleve.NewDisjunctionQuery(mthds...))
+	}
+	if args.StartTime != nil || args.EndTime != nil {
+		var start, end time.Time
+		if args.StartTime != nil {
+			start = *args.StartTime
+		}
+		if args.EndTime != nil {
+			end = *args.EndTime
+		}
+		q := bleve.NewDateRangeQuery(start, end) // start inclusive, end exclusive
+		q.SetField(utils.RequestStartTime)
+		conjuncts = append(conjuncts, q)
+	}
+	if args.MinDuration != nil || args.MaxDuration != nil {
+		var min, max *float64
+		if args.MinDuration != nil {
+			min = utils.Float64Pointer(float64(*args.MinDuration))
+		}
+		if args.MaxDuration != nil {
+			max = utils.Float64Pointer(float64(*args.MaxDuration))
+		}
+		q := bleve.NewNumericRangeQuery(min, max) // min inclusive, max exclusive
+		q.SetField(utils.RequestDuration)
+		conjuncts = append(conjuncts, q)
+	}
+	var mustNot []query.Query
+	if args.HasError != nil {
+		q := bleve.NewWildcardQuery("*") // any term, the calls without error have no ReplyError indexed
+		q.SetField(utils.ReplyError)
+		if *args.HasError {
+			conjuncts = append(conjuncts, q)
+		} else {
+			mustNot = append(mustNot, q)
+		}
+	}
+	if len(conjuncts) == 0 {
+		conjuncts = append(conjuncts, bleve.NewMatchAllQuery())
+	}
+	if len(mustNot) != 0 {
+		q := bleve.NewBooleanQuery()
+		q.AddMust(conjuncts...)
+		q.AddMustNot(mustNot...)
+		return q
+	}
+	return bleve.NewConjunctionQuery(conjuncts...)
+}
+
+// passHeader checks the filters which are not part of the bleve query
+func (args *V1QueryArgs) passHeader(fields map[string]interface{}) bool {
+	return strings.HasPrefix(utils.IfaceAsString(fields[utils.RequestSource]), args.RequestSource)
+}
+
+// queryPageSize is the maximum number of calls requested at once from bleve
+const queryPageSize = 1000
+
+// query returns the API calls matching the arguments, sorted and paginated
+// the pagination is done by bleve unless the calls need to be filtered after the search
+// in which case the calls are read in pages until Limit is reached
+func (aS *AnalyzerService) query(args *V1QueryArgs) (rply []map[string]interface{}, err error) {
+	sortBy := make([]string, 0, len(args.OrderBy)+1)
+	sortBy = append(sortBy, args.OrderBy...)
+	if len(sortBy) == 0 {
+		sortBy = append(sortBy, utils.RequestStartTime)
+	}
+	sortBy = append(sortBy, "_id") // keep the order stable between pages
+	var hdrPass func(map[string]interface{}) bool
+	if args.RequestSource != utils.EmptyString {
+		hdrPass = args.passHeader
+	}
+	from, skip := args.Offset, 0
+	if hdrPass != nil || len(args.ContentFilters) != 0 {
+		from, skip = 0, args.Offset
+	}
+	qry := args.bleveQuery()
+	rply = make([]map[string]interface{}, 0)
+	for {
+		size := queryPageSize
+		if args.Limit > 0 && args.Limit-len(rply)+skip < size {
+			size = args.Limit - len(rply) + skip
+		}
+		s := bleve.NewSearchRequestOptions(qry, size, from, false)
+		s.SortBy(sortBy)
+		var calls []map[string]interface{}
+		var hits int
+		if calls, hits, err = aS.search(s, hdrPass, args.ContentFilters); err != nil {
+			return nil, err
+		}
+		from += hits
+		if skip != 0 {
+			if skip >= len(calls) {
+				skip -= len(calls)
+				calls = nil
+			} else {
+				calls, skip = calls[skip:], 0
+			}
+		}
+		if args.Limit > 0 && len(rply)+len(calls) > args.Limit {
+			calls = calls[:args.Limit-len(rply)]
+		}
+		rply = append(rply, calls...)
+		if hits < size ||
+			(args.Limit > 0 && len(rply) == args.Limit) {
+			return
+		}
+	}
+}
+
+// V1Query returns the API calls matching the typed filters
+func (aS *AnalyzerService) V1Query(args *V1QueryArgs, reply *[]map[string]interface{}) error {
+	rply, err := aS.query(args)
+	if err != nil {
+		return err
+	}
+	*reply = rply
+	return nil
+}
+
+// LatencyBucket is one bucket of the latency histogram
+type LatencyBucket struct {
+	UpperBound time.Duration // -1 for the bucket of the calls slower than all the bounds
+	Count      int
+}
+
+// MethodMetrics are the aggregated metrics of the calls to one API method
+type MethodMetrics struct {
+	Count       int
+	Errors      int
+	MinDuration time.Duration
+	MaxDuration time.Duration
+	AvgDuration time.Duration
+	Histogram   []*LatencyBucket
+}
+
+// V1QueryMetricsArgs the filters of the calls to aggregate
+type V1QueryMetricsArgs struct {
+	V1QueryArgs
+	LatencyBuckets []time.Duration // the upper bounds of the histogram buckets, in ascending order
+}
+
+// V1QueryMetrics returns the counts and latency histograms per API method of the matching calls
+func (aS *AnalyzerService) V1QueryMetrics(args *V1QueryMetricsArgs, reply *map[string]*MethodMetrics) error {
+	qryArgs := args.V1QueryArgs
+	qryArgs.Offset, qryArgs.Limit = 0, 0 // the metrics are computed over all the matching calls
+	calls, err := aS.query(&qryArgs)
+	if err != nil {
+		return err
+	}
+	bkts := args.LatencyBuckets
+	if len(bkts) == 0 {
+		bkts = defaultLatencyBuckets
+	}
+	totalDur := make(map[string]time.Duration)
+	rply := make(map[string]*MethodMetrics)
+	for _, call := range calls {
+		mthd := utils.IfaceAsString(call[utils.RequestMethod])
+		dur, err := utils.IfaceAsDuration(call[utils.RequestDuration])
+		if err != nil {
+			return err
+		}
+		mm, has := rply[mthd]
+		if !has {
+			mm = &MethodMetrics{
+				MinDuration: dur,
+				Histogram:   make([]*LatencyBucket, len(bkts)+1),
+			}
+			for i, bkt := range bkts {
+				mm.Histogram[i] = &LatencyBucket{UpperBound: bkt}
+			}
+			mm.Histogram[len(bkts)] = &LatencyBucket{UpperBound: -1}
+			rply[mthd] = mm
+		}
+		mm.Count++
+		if call[utils.ReplyError] != nil {
+			mm.Errors++
+		}
+		if dur < mm.MinDuration {
+			mm.MinDuration = dur
+		}
+		if dur > mm.MaxDuration {
+			mm.MaxDuration = dur
+		}
+		totalDur[mthd] += dur
+		bktIdx := len(bkts)
+		for i, bkt := range bkts {
+			if dur <= bkt {
+				bktIdx = i
+				break
+			}
+		}
+		mm.Histogram[bktIdx].Count++
+	}
+	for mthd, mm := range rply {
+		mm.AvgDuration = totalDur[mthd] / time.Duration(mm.Count)
+	}
+	*reply = rply
+	return nil
+}
+
+// V1ExportArgs the filters of the calls to export and the export destination
+type V1ExportArgs struct {
+	V1QueryArgs
+	Format     string // *json_lines or *cgr_tester
+	ExportPath string // path of the file the calls are written to, relative to the configured export_path
+}
+
+// V1Export writes the matching calls into a file
+// *json_lines writes one call per line while *cgr_tester writes the calls as
+// JSON-RPC requests which can be replayed with cgr-tester -file_path
+func (aS *AnalyzerService) V1Export(args *V1ExportArgs, reply *string) (err error) {
+	if args.ExportPath == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing("ExportPath")
+	}
+	expDir := filepath.Clean(aS.cfg.AnalyzerSCfg().ExportPath)
+	expPath := args.ExportPath
+	if !filepath.IsAbs(expPath) {
+		expPath = filepath.Join(expDir, expPath)
+	}
+	if rel, err := filepath.Rel(expDir, filepath.Clean(expPath)); err != nil ||
+		rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("ExportPath <%s> outside of <%s>", args.ExportPath, expDir)
+	}
+	var sep []byte
+	var asRecord func(map[string]interface{}) interface{}
+	switch args.Format {
+	case utils.EmptyString, utils.MetaJSONLines:
+		sep = []byte("\n")
+		asRecord = func(call map[string]interface{}) interface{} { return call }
+	case utils.MetaCGRTester:
+		sep = []byte("\n\n") // default -req_separator of cgr-tester
+		asRecord = func(call map[string]interface{}) interface{} {
+			return map[string]interface{}{
+				"id":     call["RequestID"],
+				"method": call[utils.RequestMethod],
+				"params": []interface{}{call[utils.RequestParams]},
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported format: <%s>", args.Format)
+	}
+	var calls []map[string]interface{}
+	if err = aS.V1Query(&args.V1QueryArgs, &calls); err != nil {
+		return
+	}
+	var buf bytes.Buffer
+	for i, call := range calls {
+		if i != 0 {
+			buf.Write(sep)
+		}
+		var rec []byte
+		if rec, err = json.Marshal(asRecord(call)); err != nil {
+			return
+		}
+		buf.Write(rec)
+	}
+	if args.Format != utils.MetaCGRTester && len(calls) != 0 {
+		buf.Write(sep)
+	}
+	if err = ioutil.WriteFile(expPath, buf.Bytes(), 0644); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
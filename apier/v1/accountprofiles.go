@@ -175,3 +175,33 @@ func (aSv1 *AccountSv1) DebitUsage(args *utils.ArgsAccountsForEvent,
 	eEc *utils.ExtEventCharges) (err error) {
 	return aSv1.aS.V1DebitUsage(args, eEc)
 }
+
+// MaxCost returns the maximum cost for the event, based on matching Account
+func (aSv1 *AccountSv1) MaxCost(args *utils.ArgsAccountsForEvent,
+	eEc *utils.ExtEventCharges) (err error) {
+	return aSv1.aS.V1MaxCost(args, eEc)
+}
+
+// DebitCost performs debit of the cost for the provided event
+func (aSv1 *AccountSv1) DebitCost(args *utils.ArgsAccountsForEvent,
+	eEc *utils.ExtEventCharges) (err error) {
+	return aSv1.aS.V1DebitCost(args, eEc)
+}
+
+// RefundCharges puts back the units debited with the EventCharges having the provided ID
+func (aSv1 *AccountSv1) RefundCharges(args *utils.ArgsRefundCharges,
+	reply *string) (err error) {
+	return aSv1.aS.V1RefundCharges(args, reply)
+}
+
+// ActionSetBalance alters the Units of a Balance, used by ActionS
+func (aSv1 *AccountSv1) ActionSetBalance(args *utils.ArgsActSetBalance,
+	reply *string) (err error) {
+	return aSv1.aS.V1ActionSetBalance(args, reply)
+}
+
+// ActionRemoveBalance removes Balances out of an Account, used by ActionS
+func (aSv1 *AccountSv1) ActionRemoveBalance(args *utils.ArgsActRemoveBalance,
+	reply *string) (err error) {
+	return aSv1.aS.V1ActionRemoveBalance(args, reply)
+}
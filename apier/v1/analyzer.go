@@ -49,3 +49,18 @@ func (aSv1 *AnalyzerSv1) Ping(ign *utils.CGREvent, reply *string) error {
 func (aSv1 *AnalyzerSv1) StringQuery(search *analyzers.QueryArgs, reply *[]map[string]interface{}) error {
 	return aSv1.aS.V1StringQuery(search, reply)
 }
+
+// Query returns a list of API that match the typed filters
+func (aSv1 *AnalyzerSv1) Query(args *analyzers.V1QueryArgs, reply *[]map[string]interface{}) error {
+	return aSv1.aS.V1Query(args, reply)
+}
+
+// QueryMetrics returns the counts and latency histograms per method of the API that match the filters
+func (aSv1 *AnalyzerSv1) QueryMetrics(args *analyzers.V1QueryMetricsArgs, reply *map[string]*analyzers.MethodMetrics) error {
+	return aSv1.aS.V1QueryMetrics(args, reply)
+}
+
+// Export writes the API that match the filters into a file
+func (aSv1 *AnalyzerSv1) Export(args *analyzers.V1ExportArgs, reply *string) error {
+	return aSv1.aS.V1Export(args, reply)
+}
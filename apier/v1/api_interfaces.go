@@ -43,6 +43,7 @@ type StatSv1Interface interface {
 	GetStatQueuesForEvent(args *engine.StatsArgsProcessEvent, reply *[]string) (err error)
 	GetQueueStringMetrics(args *utils.TenantIDWithOpts, reply *map[string]string) (err error)
 	GetQueueFloatMetrics(args *utils.TenantIDWithOpts, reply *map[string]float64) (err error)
+	GetQueueMetricsHistory(args *utils.TenantIDWithOpts, reply *map[string][]*engine.StatMetricPoint) (err error)
 	Ping(ign *utils.CGREvent, reply *string) error
 }
 
@@ -50,6 +51,8 @@ type ResourceSv1Interface interface {
 	GetResourcesForEvent(args *utils.ArgRSv1ResourceUsage, reply *engine.Resources) error
 	AuthorizeResources(args *utils.ArgRSv1ResourceUsage, reply *string) error
 	AllocateResources(args *utils.ArgRSv1ResourceUsage, reply *string) error
+	AuthorizeResourcesWithLimits(args *utils.ArgRSv1ResourceUsage, reply *engine.ResourceAllocationReply) error
+	AllocateResourcesWithLimits(args *utils.ArgRSv1ResourceUsage, reply *engine.ResourceAllocationReply) error
 	ReleaseResources(args *utils.ArgRSv1ResourceUsage, reply *string) error
 	GetResource(args *utils.TenantIDWithOpts, reply *engine.Resource) error
 	GetResourceWithConfig(args *utils.TenantIDWithOpts, reply *engine.ResourceWithConfig) error
@@ -59,6 +62,9 @@ type ResourceSv1Interface interface {
 type RouteSv1Interface interface {
 	GetRoutes(args *engine.ArgsGetRoutes, reply *engine.SortedRoutes) error
 	GetRouteProfilesForEvent(args *utils.CGREvent, reply *[]*engine.RouteProfile) error
+	ProcessOutcome(args *utils.CGREvent, reply *string) error
+	GetRouteBreaker(args *utils.TenantIDWithOpts, reply *engine.RouteBreaker) error
+	GetRouteBreakerIDs(args *utils.TenantWithOpts, reply *[]string) error
 	Ping(ign *utils.CGREvent, reply *string) error
 }
 
@@ -99,6 +105,8 @@ type SessionSv1Interface interface {
 
 	STIRAuthenticate(args *sessions.V1STIRAuthenticateArgs, reply *string) error
 	STIRIdentity(args *sessions.V1STIRIdentityArgs, reply *string) error
+	LinkSessions(args *sessions.V1LinkSessionsArgs, reply *string) error
+	RebillSession(args *sessions.V1RebillSessionArgs, reply *string) error
 }
 
 type ResponderInterface interface {
@@ -153,6 +161,13 @@ type CDRsV1Interface interface {
 	GetCDRsCount(args *utils.RPCCDRsFilterWithOpts, reply *int64) error
 	GetCDRs(args *utils.RPCCDRsFilterWithOpts, reply *[]*engine.CDR) error
 	Ping(ign *utils.CGREvent, reply *string) error
+	GenerateInvoice(args *engine.ArgsGenerateInvoice, reply *engine.Invoice) error
+	IssueInvoice(args *utils.TenantIDWithOpts, reply *engine.Invoice) error
+	CreateCreditNote(args *engine.ArgsCreditNote, reply *engine.Invoice) error
+	GetInvoice(args *utils.TenantIDWithOpts, reply *engine.Invoice) error
+	GetInvoiceIDs(args *utils.TenantWithOpts, reply *[]string) error
+	RemoveInvoice(args *utils.TenantIDWithOpts, reply *string) error
+	ExportInvoice(args *engine.ArgsExportInvoice, reply *string) error
 }
 
 type ServiceManagerV1Interface interface {
@@ -184,6 +199,8 @@ type CoreSv1Interface interface {
 type RateSv1Interface interface {
 	Ping(ign *utils.CGREvent, reply *string) error
 	CostForEvent(args *utils.ArgsCostForEvent, rpCost *engine.RateProfileCost) error
+	CostForEvents(args *utils.ArgsCostForEvents, rpCosts *[]*engine.RateProfileCostForEvent) error
+	RateProfilesCostForEvent(args *utils.ArgsCostForEvent, rpCosts *[]*engine.RateProfileCostForProfile) error
 }
 
 type RateProfileSv1Interface interface {
@@ -275,6 +292,10 @@ type ReplicatorSv1Interface interface {
 	SetAccountProfile(args *utils.AccountProfileWithOpts, reply *string) error
 	RemoveAccountProfile(args *utils.TenantIDWithOpts, reply *string) error
 
+	GetRouteBreaker(tntID *utils.TenantIDWithOpts, reply *engine.RouteBreaker) error
+	SetRouteBreaker(args *engine.RouteBreakerWithOpts, reply *string) error
+	RemoveRouteBreaker(args *utils.TenantIDWithOpts, reply *string) error
+
 	GetActionProfile(tntID *utils.TenantIDWithOpts, reply *engine.ActionProfile) error
 	SetActionProfile(args *engine.ActionProfileWithOpts, reply *string) error
 	RemoveActionProfile(args *utils.TenantIDWithOpts, reply *string) error
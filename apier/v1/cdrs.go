@@ -126,3 +126,38 @@ func (cdrSv1 *CDRsV1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
 	return nil
 }
+
+// GenerateInvoice builds a draft invoice out of the rated CDRs within the billing period
+func (cdrSv1 *CDRsV1) GenerateInvoice(args *engine.ArgsGenerateInvoice, reply *engine.Invoice) error {
+	return cdrSv1.CDRs.V1GenerateInvoice(args, reply)
+}
+
+// IssueInvoice issues a draft invoice, making it immutable
+func (cdrSv1 *CDRsV1) IssueInvoice(args *utils.TenantIDWithOpts, reply *engine.Invoice) error {
+	return cdrSv1.CDRs.V1IssueInvoice(args, reply)
+}
+
+// CreateCreditNote corrects an issued invoice by crediting its lines
+func (cdrSv1 *CDRsV1) CreateCreditNote(args *engine.ArgsCreditNote, reply *engine.Invoice) error {
+	return cdrSv1.CDRs.V1CreateCreditNote(args, reply)
+}
+
+// GetInvoice returns an invoice or credit note
+func (cdrSv1 *CDRsV1) GetInvoice(args *utils.TenantIDWithOpts, reply *engine.Invoice) error {
+	return cdrSv1.CDRs.V1GetInvoice(args, reply)
+}
+
+// GetInvoiceIDs returns the IDs of the invoices and credit notes of the tenant
+func (cdrSv1 *CDRsV1) GetInvoiceIDs(args *utils.TenantWithOpts, reply *[]string) error {
+	return cdrSv1.CDRs.V1GetInvoiceIDs(args, reply)
+}
+
+// RemoveInvoice removes a draft invoice
+func (cdrSv1 *CDRsV1) RemoveInvoice(args *utils.TenantIDWithOpts, reply *string) error {
+	return cdrSv1.CDRs.V1RemoveInvoice(args, reply)
+}
+
+// ExportInvoice renders an invoice through the EEs exporters
+func (cdrSv1 *CDRsV1) ExportInvoice(args *engine.ArgsExportInvoice, reply *string) error {
+	return cdrSv1.CDRs.V1ExportInvoice(args, reply)
+}
@@ -285,6 +285,11 @@ func (dSts *DispatcherStatSv1) GetQueueFloatMetrics(args *utils.TenantIDWithOpts
 	return dSts.dS.StatSv1GetQueueFloatMetrics(args, reply)
 }
 
+func (dSts *DispatcherStatSv1) GetQueueMetricsHistory(args *utils.TenantIDWithOpts,
+	reply *map[string][]*engine.StatMetricPoint) error {
+	return dSts.dS.StatSv1GetQueueMetricsHistory(args, reply)
+}
+
 func (dSts *DispatcherStatSv1) GetQueueIDs(args *utils.TenantWithOpts,
 	reply *[]string) error {
 	return dSts.dS.StatSv1GetQueueIDs(args, reply)
@@ -333,6 +338,16 @@ func (dRs *DispatcherResourceSv1) AllocateResources(args *utils.ArgRSv1ResourceU
 	return dRs.dRs.ResourceSv1AllocateResources(*args, reply)
 }
 
+func (dRs *DispatcherResourceSv1) AuthorizeResourcesWithLimits(args *utils.ArgRSv1ResourceUsage,
+	reply *engine.ResourceAllocationReply) error {
+	return dRs.dRs.ResourceSv1AuthorizeResourcesWithLimits(*args, reply)
+}
+
+func (dRs *DispatcherResourceSv1) AllocateResourcesWithLimits(args *utils.ArgRSv1ResourceUsage,
+	reply *engine.ResourceAllocationReply) error {
+	return dRs.dRs.ResourceSv1AllocateResourcesWithLimits(*args, reply)
+}
+
 func (dRs *DispatcherResourceSv1) ReleaseResources(args *utils.ArgRSv1ResourceUsage,
 	reply *string) error {
 	return dRs.dRs.ResourceSv1ReleaseResources(*args, reply)
@@ -364,6 +379,21 @@ func (dRoute *DispatcherRouteSv1) GetRouteProfilesForEvent(args *utils.CGREvent,
 	return dRoute.dRoute.RouteSv1GetRouteProfilesForEvent(args, reply)
 }
 
+// ProcessOutcome implements RouteSv1ProcessOutcome
+func (dRoute *DispatcherRouteSv1) ProcessOutcome(args *utils.CGREvent, reply *string) error {
+	return dRoute.dRoute.RouteSv1ProcessOutcome(args, reply)
+}
+
+// GetRouteBreaker implements RouteSv1GetRouteBreaker
+func (dRoute *DispatcherRouteSv1) GetRouteBreaker(args *utils.TenantIDWithOpts, reply *engine.RouteBreaker) error {
+	return dRoute.dRoute.RouteSv1GetRouteBreaker(args, reply)
+}
+
+// GetRouteBreakerIDs implements RouteSv1GetRouteBreakerIDs
+func (dRoute *DispatcherRouteSv1) GetRouteBreakerIDs(args *utils.TenantWithOpts, reply *[]string) error {
+	return dRoute.dRoute.RouteSv1GetRouteBreakerIDs(args, reply)
+}
+
 func NewDispatcherAttributeSv1(dps *dispatchers.DispatcherService) *DispatcherAttributeSv1 {
 	return &DispatcherAttributeSv1{dA: dps}
 }
@@ -543,6 +573,14 @@ func (dS *DispatcherSessionSv1) STIRIdentity(args *sessions.V1STIRIdentityArgs,
 	return dS.dS.SessionSv1STIRIdentity(args, reply)
 }
 
+func (dS *DispatcherSessionSv1) LinkSessions(args *sessions.V1LinkSessionsArgs, reply *string) error {
+	return dS.dS.SessionSv1LinkSessions(args, reply)
+}
+
+func (dS *DispatcherSessionSv1) RebillSession(args *sessions.V1RebillSessionArgs, reply *string) error {
+	return dS.dS.SessionSv1RebillSession(args, reply)
+}
+
 func NewDispatcherResponder(dps *dispatchers.DispatcherService) *DispatcherResponder {
 	return &DispatcherResponder{dS: dps}
 }
@@ -799,6 +837,34 @@ func (dS *DispatcherSCDRsV1) ProcessCDR(args *engine.CDRWithOpts, reply *string)
 	return dS.dS.CDRsV1ProcessCDR(args, reply)
 }
 
+func (dS *DispatcherSCDRsV1) GenerateInvoice(args *engine.ArgsGenerateInvoice, reply *engine.Invoice) error {
+	return dS.dS.CDRsV1GenerateInvoice(args, reply)
+}
+
+func (dS *DispatcherSCDRsV1) IssueInvoice(args *utils.TenantIDWithOpts, reply *engine.Invoice) error {
+	return dS.dS.CDRsV1IssueInvoice(args, reply)
+}
+
+func (dS *DispatcherSCDRsV1) CreateCreditNote(args *engine.ArgsCreditNote, reply *engine.Invoice) error {
+	return dS.dS.CDRsV1CreateCreditNote(args, reply)
+}
+
+func (dS *DispatcherSCDRsV1) GetInvoice(args *utils.TenantIDWithOpts, reply *engine.Invoice) error {
+	return dS.dS.CDRsV1GetInvoice(args, reply)
+}
+
+func (dS *DispatcherSCDRsV1) GetInvoiceIDs(args *utils.TenantWithOpts, reply *[]string) error {
+	return dS.dS.CDRsV1GetInvoiceIDs(args, reply)
+}
+
+func (dS *DispatcherSCDRsV1) RemoveInvoice(args *utils.TenantIDWithOpts, reply *string) error {
+	return dS.dS.CDRsV1RemoveInvoice(args, reply)
+}
+
+func (dS *DispatcherSCDRsV1) ExportInvoice(args *engine.ArgsExportInvoice, reply *string) error {
+	return dS.dS.CDRsV1ExportInvoice(args, reply)
+}
+
 func NewDispatcherSServiceManagerV1(dps *dispatchers.DispatcherService) *DispatcherSServiceManagerV1 {
 	return &DispatcherSServiceManagerV1{dS: dps}
 }
@@ -1305,6 +1371,21 @@ func (dS *DispatcherReplicatorSv1) RemoveAccountProfile(args *utils.TenantIDWith
 	return dS.dS.ReplicatorSv1RemoveAccountProfile(args, reply)
 }
 
+// GetRouteBreaker .
+func (dS *DispatcherReplicatorSv1) GetRouteBreaker(tntID *utils.TenantIDWithOpts, reply *engine.RouteBreaker) error {
+	return dS.dS.ReplicatorSv1GetRouteBreaker(tntID, reply)
+}
+
+// SetRouteBreaker .
+func (dS *DispatcherReplicatorSv1) SetRouteBreaker(args *engine.RouteBreakerWithOpts, reply *string) error {
+	return dS.dS.ReplicatorSv1SetRouteBreaker(args, reply)
+}
+
+// RemoveRouteBreaker .
+func (dS *DispatcherReplicatorSv1) RemoveRouteBreaker(args *utils.TenantIDWithOpts, reply *string) error {
+	return dS.dS.ReplicatorSv1RemoveRouteBreaker(args, reply)
+}
+
 // GetActionProfile .
 func (dS *DispatcherReplicatorSv1) GetActionProfile(tntID *utils.TenantIDWithOpts, reply *engine.ActionProfile) error {
 	return dS.dS.ReplicatorSv1GetActionProfile(tntID, reply)
@@ -1338,6 +1419,14 @@ func (dR *DispatcherRateSv1) CostForEvent(args *utils.ArgsCostForEvent, rpCost *
 	return dR.dR.RateSv1CostForEvent(args, rpCost)
 }
 
+func (dR *DispatcherRateSv1) CostForEvents(args *utils.ArgsCostForEvents, rpCosts *[]*engine.RateProfileCostForEvent) error {
+	return dR.dR.RateSv1CostForEvents(args, rpCosts)
+}
+
+func (dR *DispatcherRateSv1) RateProfilesCostForEvent(args *utils.ArgsCostForEvent, rpCosts *[]*engine.RateProfileCostForProfile) error {
+	return dR.dR.RateSv1RateProfilesCostForEvent(args, rpCosts)
+}
+
 func NewDispatcherActionSv1(dps *dispatchers.DispatcherService) *DispatcherActionSv1 {
 	return &DispatcherActionSv1{dR: dps}
 }
@@ -1366,6 +1455,31 @@ func (dR *DispatcherAccountSv1) Ping(args *utils.CGREvent, reply *string) error
 	return dR.dR.AccountSv1Ping(args, reply)
 }
 
+// MaxCost implements AccountSv1MaxCost
+func (dR *DispatcherAccountSv1) MaxCost(args *utils.ArgsAccountsForEvent, eEc *utils.ExtEventCharges) error {
+	return dR.dR.AccountSv1MaxCost(args, eEc)
+}
+
+// DebitCost implements AccountSv1DebitCost
+func (dR *DispatcherAccountSv1) DebitCost(args *utils.ArgsAccountsForEvent, eEc *utils.ExtEventCharges) error {
+	return dR.dR.AccountSv1DebitCost(args, eEc)
+}
+
+// RefundCharges implements AccountSv1RefundCharges
+func (dR *DispatcherAccountSv1) RefundCharges(args *utils.ArgsRefundCharges, reply *string) error {
+	return dR.dR.AccountSv1RefundCharges(args, reply)
+}
+
+// ActionSetBalance implements AccountSv1ActionSetBalance
+func (dR *DispatcherAccountSv1) ActionSetBalance(args *utils.ArgsActSetBalance, reply *string) error {
+	return dR.dR.AccountSv1ActionSetBalance(args, reply)
+}
+
+// ActionRemoveBalance implements AccountSv1ActionRemoveBalance
+func (dR *DispatcherAccountSv1) ActionRemoveBalance(args *utils.ArgsActRemoveBalance, reply *string) error {
+	return dR.dR.AccountSv1ActionRemoveBalance(args, reply)
+}
+
 func (rS *DispatcherSv1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
 	return nil
@@ -41,3 +41,9 @@ func (eeSv1 *EeSv1) ProcessEvent(args *utils.CGREventWithEeIDs,
 	reply *map[string]map[string]interface{}) error {
 	return eeSv1.eeS.V1ProcessEvent(args, reply)
 }
+
+// ProcessEvents exports a batch of events together, one export per exporter
+func (eeSv1 *EeSv1) ProcessEvents(args *utils.CGREventsWithEeIDs,
+	reply *map[string]map[string]interface{}) error {
+	return eeSv1.eeS.V1ProcessEvents(args, reply)
+}
@@ -19,8 +19,6 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package v1
 
 import (
-	"strings"
-
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
@@ -100,19 +98,7 @@ func (apierSv1 *APIerSv1) composeArgsReload(tnt, cacheID, itemID string, filterI
 			return
 		}
 		for _, flt := range fltr.Rules {
-			if !engine.FilterIndexTypes.Has(flt.Type) {
-				continue
-			}
-			isDyn := strings.HasPrefix(flt.Element, utils.DynamicDataPrefix)
-			for _, fldVal := range flt.Values {
-				if isDyn {
-					if !strings.HasPrefix(fldVal, utils.DynamicDataPrefix) {
-						indxIDs = append(indxIDs, utils.ConcatenatedKey(flt.Type, flt.Element[1:], fldVal))
-					}
-				} else if strings.HasPrefix(fldVal, utils.DynamicDataPrefix) {
-					indxIDs = append(indxIDs, utils.ConcatenatedKey(flt.Type, fldVal[1:], flt.Element))
-				}
-			}
+			indxIDs = append(indxIDs, flt.IndexKeys()...)
 		}
 	}
 	if cacheID != utils.CacheAttributeProfiles &&
@@ -39,12 +39,12 @@ func (ldrSv1 *LoaderSv1) Call(serviceMethod string,
 }
 
 func (ldrSv1 *LoaderSv1) Load(args *loaders.ArgsProcessFolder,
-	rply *string) error {
+	rply *interface{}) error {
 	return ldrSv1.ldrS.V1Load(args, rply)
 }
 
 func (ldrSv1 *LoaderSv1) Remove(args *loaders.ArgsProcessFolder,
-	rply *string) error {
+	rply *interface{}) error {
 	return ldrSv1.ldrS.V1Remove(args, rply)
 }
 
@@ -222,6 +222,14 @@ func (rSv1 *RateSv1) CostForEvent(args *utils.ArgsCostForEvent, rpCost *engine.R
 	return rSv1.rS.V1CostForEvent(args, rpCost)
 }
 
+func (rSv1 *RateSv1) CostForEvents(args *utils.ArgsCostForEvents, rpCosts *[]*engine.RateProfileCostForEvent) (err error) {
+	return rSv1.rS.V1CostForEvents(args, rpCosts)
+}
+
+func (rSv1 *RateSv1) RateProfilesCostForEvent(args *utils.ArgsCostForEvent, rpCosts *[]*engine.RateProfileCostForProfile) (err error) {
+	return rSv1.rS.V1RateProfilesCostForEvent(args, rpCosts)
+}
+
 func (rSv1 *RateSv1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
 	return nil
@@ -304,6 +304,15 @@ func (rplSv1 *ReplicatorSv1) GetAccountProfile(tntID *utils.TenantIDWithOpts, re
 	return nil
 }
 
+func (rplSv1 *ReplicatorSv1) GetRouteBreaker(tntID *utils.TenantIDWithOpts, reply *engine.RouteBreaker) error {
+	if rcv, err := rplSv1.dm.DataDB().GetRouteBreakerDrv(tntID.Tenant, tntID.ID); err != nil {
+		return err
+	} else {
+		*reply = *rcv.Clone()
+	}
+	return nil
+}
+
 //GetResourceProfile
 func (rplSv1 *ReplicatorSv1) GetItemLoadIDs(itemID *utils.StringWithOpts, reply *map[string]int64) error {
 	if rcv, err := rplSv1.dm.DataDB().GetItemLoadIDsDrv(itemID.Arg); err != nil {
@@ -545,6 +554,17 @@ func (rplSv1 *ReplicatorSv1) SetAccountProfile(acp *utils.AccountProfileWithOpts
 	return nil
 }
 
+// SetRouteBreaker stores the breaker and updates the cached one since RouteS reads it from cache
+func (rplSv1 *ReplicatorSv1) SetRouteBreaker(rb *engine.RouteBreakerWithOpts, reply *string) error {
+	if err := rplSv1.dm.DataDB().SetRouteBreakerDrv(rb.RouteBreaker); err != nil {
+		return err
+	}
+	engine.Cache.SetWithoutReplicate(utils.CacheRouteBreakers, rb.TenantID(), rb.RouteBreaker, nil,
+		true, utils.NonTransactional)
+	*reply = utils.OK
+	return nil
+}
+
 // RemoveThreshold
 func (rplSv1 *ReplicatorSv1) RemoveThreshold(args *utils.TenantIDWithOpts, reply *string) error {
 	if err := rplSv1.dm.DataDB().RemoveThresholdDrv(args.Tenant, args.ID); err != nil {
@@ -756,6 +776,16 @@ func (rplSv1 *ReplicatorSv1) RemoveAccountProfile(args *utils.TenantIDWithOpts,
 	return nil
 }
 
+func (rplSv1 *ReplicatorSv1) RemoveRouteBreaker(args *utils.TenantIDWithOpts, reply *string) error {
+	if err := rplSv1.dm.DataDB().RemoveRouteBreakerDrv(args.Tenant, args.ID); err != nil {
+		return err
+	}
+	engine.Cache.RemoveWithoutReplicate(utils.CacheRouteBreakers, utils.ConcatenatedKey(args.Tenant, args.ID),
+		true, utils.NonTransactional)
+	*reply = utils.OK
+	return nil
+}
+
 func (rplSv1 *ReplicatorSv1) RemoveDispatcherHost(args *utils.TenantIDWithOpts, reply *string) error {
 	if err := rplSv1.dm.DataDB().RemoveDispatcherHostDrv(args.Tenant, args.ID); err != nil {
 		return err
@@ -54,6 +54,18 @@ func (rsv1 *ResourceSv1) AllocateResources(args *utils.ArgRSv1ResourceUsage, rep
 	return rsv1.rls.V1AllocateResource(*args, reply)
 }
 
+// AuthorizeResourcesWithLimits checks if there are limits imposed for event,
+// reporting also the remaining capacity of the rate limited resources
+func (rsv1 *ResourceSv1) AuthorizeResourcesWithLimits(args *utils.ArgRSv1ResourceUsage, reply *engine.ResourceAllocationReply) error {
+	return rsv1.rls.V1AuthorizeResourcesWithLimits(*args, reply)
+}
+
+// AllocateResourcesWithLimits records usage for an event,
+// reporting also the remaining capacity of the rate limited resources
+func (rsv1 *ResourceSv1) AllocateResourcesWithLimits(args *utils.ArgRSv1ResourceUsage, reply *engine.ResourceAllocationReply) error {
+	return rsv1.rls.V1AllocateResourcesWithLimits(*args, reply)
+}
+
 // V1TerminateResourceUsage releases usage for an event
 func (rsv1 *ResourceSv1) ReleaseResources(args *utils.ArgRSv1ResourceUsage, reply *string) error {
 	return rsv1.rls.V1ReleaseResource(*args, reply)
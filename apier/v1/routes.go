@@ -146,6 +146,21 @@ func (rS *RouteSv1) GetRouteProfilesForEvent(args *utils.CGREvent,
 	return rS.rS.V1GetRouteProfilesForEvent(args, reply)
 }
 
+// ProcessOutcome records the outcome of a call within the circuit breaker of the route
+func (rS *RouteSv1) ProcessOutcome(args *utils.CGREvent, reply *string) error {
+	return rS.rS.V1ProcessOutcome(args, reply)
+}
+
+// GetRouteBreaker returns the circuit breaker state of a route
+func (rS *RouteSv1) GetRouteBreaker(args *utils.TenantIDWithOpts, reply *engine.RouteBreaker) error {
+	return rS.rS.V1GetRouteBreaker(args, reply)
+}
+
+// GetRouteBreakerIDs returns the IDs of the routes with circuit breaker state
+func (rS *RouteSv1) GetRouteBreakerIDs(args *utils.TenantWithOpts, reply *[]string) error {
+	return rS.rS.V1GetRouteBreakerIDs(args, reply)
+}
+
 func (rS *RouteSv1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
 	return nil
@@ -166,3 +166,13 @@ func (ssv1 *SessionSv1) STIRAuthenticate(args *sessions.V1STIRAuthenticateArgs,
 func (ssv1 *SessionSv1) STIRIdentity(args *sessions.V1STIRIdentityArgs, reply *string) error {
 	return ssv1.sS.BiRPCv1STIRIdentity(nil, args, reply)
 }
+
+// LinkSessions moves the active sessions as legs into the same call
+func (ssv1 *SessionSv1) LinkSessions(args *sessions.V1LinkSessionsArgs, reply *string) error {
+	return ssv1.sS.BiRPCv1LinkSessions(nil, args, reply)
+}
+
+// RebillSession changes the account paying for an active session
+func (ssv1 *SessionSv1) RebillSession(args *sessions.V1RebillSessionArgs, reply *string) error {
+	return ssv1.sS.BiRPCv1RebillSession(nil, args, reply)
+}
@@ -61,6 +61,9 @@ func (ssv1 *SessionSv1) Handlers() map[string]interface{} {
 		utils.SessionSv1STIRAuthenticate: ssv1.BiRPCV1STIRAuthenticate,
 		utils.SessionSv1STIRIdentity:     ssv1.BiRPCV1STIRIdentity,
 
+		utils.SessionSv1LinkSessions:  ssv1.BiRPCV1LinkSessions,
+		utils.SessionSv1RebillSession: ssv1.BiRPCV1RebillSession,
+
 		utils.SessionSv1Sleep: ssv1.BiRPCV1Sleep, // Sleep method is used to test the concurrent requests mechanism
 	}
 }
@@ -355,6 +358,30 @@ func (ssv1 *SessionSv1) BiRPCV1STIRIdentity(clnt *rpc2.Client,
 	return ssv1.sS.BiRPCv1STIRIdentity(nil, args, reply)
 }
 
+// BiRPCV1LinkSessions moves the active sessions as legs into the same call
+func (ssv1 *SessionSv1) BiRPCV1LinkSessions(clnt *rpc2.Client,
+	args *sessions.V1LinkSessionsArgs, reply *string) (err error) {
+	if ssv1.caps.IsLimited() {
+		if err = ssv1.caps.Allocate(); err != nil {
+			return
+		}
+		defer ssv1.caps.Deallocate()
+	}
+	return ssv1.sS.BiRPCv1LinkSessions(clnt, args, reply)
+}
+
+// BiRPCV1RebillSession changes the account paying for an active session
+func (ssv1 *SessionSv1) BiRPCV1RebillSession(clnt *rpc2.Client,
+	args *sessions.V1RebillSessionArgs, reply *string) (err error) {
+	if ssv1.caps.IsLimited() {
+		if err = ssv1.caps.Allocate(); err != nil {
+			return
+		}
+		defer ssv1.caps.Deallocate()
+	}
+	return ssv1.sS.BiRPCv1RebillSession(clnt, args, reply)
+}
+
 func (ssv1 *SessionSv1) BiRPCV1Sleep(clnt *rpc2.Client, arg *utils.DurationArgs,
 	reply *string) (err error) {
 	if ssv1.caps.IsLimited() {
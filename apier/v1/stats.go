@@ -197,6 +197,11 @@ func (stsv1 *StatSv1) GetQueueFloatMetrics(args *utils.TenantIDWithOpts, reply *
 	return stsv1.sS.V1GetQueueFloatMetrics(args.TenantID, reply)
 }
 
+// GetQueueMetricsHistory returns the metrics history of a bucketed Queue
+func (stsv1 *StatSv1) GetQueueMetricsHistory(args *utils.TenantIDWithOpts, reply *map[string][]*engine.StatMetricPoint) (err error) {
+	return stsv1.sS.V1GetQueueMetricsHistory(args.TenantID, reply)
+}
+
 // ResetStatQueue resets the stat queue
 func (stsv1 *StatSv1) ResetStatQueue(tntID *utils.TenantIDWithOpts, reply *string) error {
 	return stsv1.sS.V1ResetStatQueue(tntID.TenantID, reply)
@@ -361,7 +361,7 @@ func runPreload(loader *services.LoaderService, internalLoaderSChan chan rpcclie
 	ldrs := <-internalLoaderSChan
 	internalLoaderSChan <- ldrs
 
-	var reply string
+	var reply interface{}
 	for _, loaderID := range strings.Split(*preload, utils.FieldsSep) {
 		if err := loader.GetLoaderS().V1Load(&loaders.ArgsProcessFolder{
 			ForceLock:   true, // force lock will unlock the file in case is locked and return error
@@ -553,6 +553,7 @@ func main() {
 		utils.HTTPAgent:       new(sync.WaitGroup),
 		utils.KamailioAgent:   new(sync.WaitGroup),
 		utils.LoaderS:         new(sync.WaitGroup),
+		utils.PrometheusAgent: new(sync.WaitGroup),
 		utils.RadiusAgent:     new(sync.WaitGroup),
 		utils.RALService:      new(sync.WaitGroup),
 		utils.RateS:           new(sync.WaitGroup),
@@ -674,6 +675,7 @@ func main() {
 		services.NewRadiusAgent(cfg, filterSChan, shdChan, connManager, srvDep),   // partial reload
 		services.NewDiameterAgent(cfg, filterSChan, shdChan, connManager, srvDep), // partial reload
 		services.NewHTTPAgent(cfg, filterSChan, server, connManager, srvDep),      // no reload
+		services.NewPrometheusAgent(cfg, caps, server, connManager, srvDep),
 		ldrs, anz, dspS, dspH, dmService, storDBService,
 		services.NewEventExporterService(cfg, filterSChan,
 			connManager, server, internalEEsChan, anz, srvDep),
@@ -69,12 +69,27 @@ var (
 	tenant       = cgrTesterFlags.String("tenant", "cgrates.org", "The type of record to use in queries.")
 	subject      = cgrTesterFlags.String("subject", "1001", "The rating subject to use in queries.")
 	destination  = cgrTesterFlags.String("destination", "1002", "The destination to use in queries.")
-	json         = cgrTesterFlags.Bool("json", false, "Use JSON RPC")
+	jsonRPC      = cgrTesterFlags.Bool("json", false, "Use JSON RPC")
 	version      = cgrTesterFlags.Bool("version", false, "Prints the application version.")
 	nilDuration  = time.Duration(0)
 	usage        = cgrTesterFlags.String("usage", "1m", "The duration to use in call simulation.")
 	fPath        = cgrTesterFlags.String("file_path", "", "read requests from file with path")
 	reqSep       = cgrTesterFlags.String("req_separator", "\n\n", "separator for requests in file")
+	speed        = cgrTesterFlags.Float64("speed", 1, "speed factor applied on the original timing of the replayed requests and on the simulated calls, 0 to send the requests as fast as possible")
+
+	replayFile      = cgrTesterFlags.String("replay_file", "", "replay the requests from file with path, sent over JSON RPC")
+	replayFormat    = cgrTesterFlags.String("replay_format", utils.MetaAnalyzer, "format of the replay file <*analyzer|*json_lines|*file_csv>")
+	replayMethod    = cgrTesterFlags.String("replay_method", utils.CDRsV1ProcessEvent, "the API used to replay the *json_lines and *file_csv requests")
+	replayTimeField = cgrTesterFlags.String("replay_time_field", utils.SetupTime, "the field with the original time of the *json_lines and *file_csv requests")
+
+	loadCalls        = cgrTesterFlags.Int("load_calls", 0, "simulate n sessions towards SessionS (authorize, initiate, updates and terminate)")
+	loadConcurrency  = cgrTesterFlags.Int("load_concurrency", 10, "maximum number of concurrent sessions")
+	loadRamp         = cgrTesterFlags.String("load_ramp", "0s", "time to reach the maximum number of concurrent sessions")
+	loadUpdates      = cgrTesterFlags.Int("load_updates", 1, "number of updates sent during each session")
+	loadDurationDist = cgrTesterFlags.String("load_duration_dist", distFixed, "distribution of the call durations, the average being the usage <*fixed|*uniform|*exponential|*normal>")
+	loadMinUsage     = cgrTesterFlags.String("load_min_usage", "0s", "the minimum call duration")
+	loadMaxUsage     = cgrTesterFlags.String("load_max_usage", "0s", "the maximum call duration, 0 for no limit")
+	requestType      = cgrTesterFlags.String("request_type", utils.MetaPrepaid, "the request type of the simulated sessions")
 
 	err error
 )
@@ -120,16 +135,16 @@ func durInternalRater(cd *engine.CallDescriptorWithOpts) (time.Duration, error)
 	return time.Since(start), nil
 }
 
-func durRemoteRater(cd *engine.CallDescriptorWithOpts) (time.Duration, error) {
-	result := engine.CallCost{}
-	var client *rpc.Client
-	var err error
-	if *json {
-		client, err = jsonrpc.Dial(utils.TCP, *raterAddress)
-	} else {
-		client, err = rpc.Dial(utils.TCP, *raterAddress)
+func newRPCClient(useJSON bool) (*rpc.Client, error) {
+	if useJSON {
+		return jsonrpc.Dial(utils.TCP, *raterAddress)
 	}
+	return rpc.Dial(utils.TCP, *raterAddress)
+}
 
+func durRemoteRater(cd *engine.CallDescriptorWithOpts) (time.Duration, error) {
+	result := engine.CallCost{}
+	client, err := newRPCClient(*jsonRPC)
 	if err != nil {
 		return nilDuration, fmt.Errorf("Could not connect to engine: %s", err.Error())
 	}
@@ -237,6 +252,61 @@ func main() {
 		}
 		return
 	}
+	if *replayFile != "" {
+		reqs, err := readReplayRequests(*replayFile, *replayFormat, *replayMethod,
+			*replayTimeField, *tenant, tstCfg.GeneralCfg().DefaultTimezone)
+		if err != nil {
+			log.Fatal(err)
+		}
+		client, err := newRPCClient(true) // the requests are replayed as read
+		if err != nil {
+			log.Fatalf("Could not connect to engine: %s", err.Error())
+		}
+		defer client.Close()
+		rep := newReport()
+		replayRequests(client, reqs, *speed, *parallel, rep)
+		fmt.Print(rep)
+		return
+	}
+	if *loadCalls > 0 {
+		lt := &loadTester{
+			cp: &callProfile{
+				Tenant:       *tenant,
+				ToR:          *tor,
+				Category:     *category,
+				RequestType:  *requestType,
+				Subject:      *subject,
+				Destination:  *destination,
+				DurationDist: *loadDurationDist,
+				Updates:      *loadUpdates,
+			},
+			calls:       *loadCalls,
+			concurrency: *loadConcurrency,
+			speed:       *speed,
+			rep:         newReport(),
+		}
+		if lt.cp.MeanDuration, err = utils.ParseDurationWithNanosecs(*usage); err != nil {
+			log.Fatal(err)
+		}
+		if lt.cp.MinDuration, err = utils.ParseDurationWithNanosecs(*loadMinUsage); err != nil {
+			log.Fatal(err)
+		}
+		if lt.cp.MaxDuration, err = utils.ParseDurationWithNanosecs(*loadMaxUsage); err != nil {
+			log.Fatal(err)
+		}
+		if lt.ramp, err = utils.ParseDurationWithNanosecs(*loadRamp); err != nil {
+			log.Fatal(err)
+		}
+		if lt.clnt, err = newRPCClient(*jsonRPC); err != nil {
+			log.Fatalf("Could not connect to engine: %s", err.Error())
+		}
+		defer lt.clnt.Close()
+		if err = lt.run(); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Print(lt.rep)
+		return
+	}
 
 	var timeparsed time.Duration
 	var err error
@@ -0,0 +1,186 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"net/rpc"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// call duration distributions
+const (
+	distFixed       = "*fixed"
+	distUniform     = "*uniform"
+	distExponential = "*exponential"
+	distNormal      = "*normal"
+)
+
+// callProfile describes the calls simulated by the load tester
+type callProfile struct {
+	Tenant      string
+	ToR         string
+	Category    string
+	RequestType string
+	Subject     string
+	Destination string
+
+	DurationDist string        // the distribution of the call durations
+	MeanDuration time.Duration // the average call duration
+	MinDuration  time.Duration // lower limit of the call durations
+	MaxDuration  time.Duration // upper limit of the call durations, 0 for no limit
+	Updates      int           // number of updates sent during the call
+}
+
+// duration returns a random call duration based on the distribution of the profile
+func (cp *callProfile) duration(rnd *rand.Rand) (dur time.Duration, err error) {
+	switch cp.DurationDist {
+	case distFixed:
+		return cp.MeanDuration, nil
+	case distUniform:
+		dur = cp.MinDuration
+		if cp.MaxDuration > cp.MinDuration {
+			dur += time.Duration(rnd.Int63n(int64(cp.MaxDuration - cp.MinDuration)))
+		}
+	case distExponential:
+		dur = time.Duration(rnd.ExpFloat64() * float64(cp.MeanDuration))
+	case distNormal:
+		stdDev := float64(cp.MeanDuration) / 4
+		if cp.MaxDuration > cp.MinDuration { // 99.7% of the calls within the limits
+			stdDev = float64(cp.MaxDuration-cp.MinDuration) / 6
+		}
+		dur = time.Duration(rnd.NormFloat64()*stdDev + float64(cp.MeanDuration))
+	default:
+		return 0, fmt.Errorf("unsupported duration distribution: <%s>", cp.DurationDist)
+	}
+	if dur < cp.MinDuration {
+		dur = cp.MinDuration
+	}
+	if cp.MaxDuration > 0 && dur > cp.MaxDuration {
+		dur = cp.MaxDuration
+	}
+	return
+}
+
+// loadTester simulates whole session lifecycles towards SessionS
+type loadTester struct {
+	clnt        *rpc.Client
+	cp          *callProfile
+	calls       int
+	concurrency int
+	ramp        time.Duration // time to reach the maximum concurrency
+	speed       float64       // divides the time waited between the session requests
+	rep         *report
+}
+
+// call sends one request and records its latency within the report
+func (lt *loadTester) call(method string, args, reply interface{}) (err error) {
+	sTime := time.Now()
+	err = lt.clnt.Call(method, args, reply)
+	lt.rep.record(method, time.Since(sTime), err)
+	return
+}
+
+// wait sleeps for the call time divided by the speed factor
+func (lt *loadTester) wait(callTime time.Duration) {
+	if lt.speed > 0 {
+		time.Sleep(time.Duration(float64(callTime) / lt.speed))
+	}
+}
+
+// runSession authorizes, initiates, updates and terminates one session of the given duration
+func (lt *loadTester) runSession(dur time.Duration) {
+	now := time.Now()
+	cgrEv := &utils.CGREvent{
+		Tenant: lt.cp.Tenant,
+		ID:     utils.GenUUID(),
+		Event: map[string]interface{}{
+			utils.ToR:          lt.cp.ToR,
+			utils.OriginID:     utils.GenUUID(),
+			utils.RequestType:  lt.cp.RequestType,
+			utils.Category:     lt.cp.Category,
+			utils.AccountField: lt.cp.Subject,
+			utils.Subject:      lt.cp.Subject,
+			utils.Destination:  lt.cp.Destination,
+			utils.SetupTime:    now,
+			utils.AnswerTime:   now,
+			utils.Usage:        dur,
+		},
+	}
+	var authRply sessions.V1AuthorizeReply
+	if err := lt.call(utils.SessionSv1AuthorizeEvent,
+		&sessions.V1AuthorizeArgs{GetMaxUsage: true, CGREvent: cgrEv}, &authRply); err != nil {
+		return
+	}
+	updtIvl := dur / time.Duration(lt.cp.Updates+1)
+	cgrEv.Event[utils.Usage] = updtIvl
+	var initRply sessions.V1InitSessionReply
+	if err := lt.call(utils.SessionSv1InitiateSession,
+		&sessions.V1InitSessionArgs{InitSession: true, CGREvent: cgrEv}, &initRply); err != nil {
+		return
+	}
+	for i := 0; i < lt.cp.Updates; i++ {
+		lt.wait(updtIvl)
+		var updtRply sessions.V1UpdateSessionReply
+		lt.call(utils.SessionSv1UpdateSession,
+			&sessions.V1UpdateSessionArgs{UpdateSession: true, CGREvent: cgrEv}, &updtRply)
+	}
+	lt.wait(dur - updtIvl*time.Duration(lt.cp.Updates))
+	cgrEv.Event[utils.Usage] = dur
+	var rply string
+	lt.call(utils.SessionSv1TerminateSession,
+		&sessions.V1TerminateSessionArgs{TerminateSession: true, CGREvent: cgrEv}, &rply)
+}
+
+// run simulates the sessions, ramping up linearly to the maximum concurrency
+func (lt *loadTester) run() (err error) {
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	concurrency := lt.concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sessLimiter := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < lt.calls; i++ {
+		var dur time.Duration
+		if dur, err = lt.cp.duration(rnd); err != nil {
+			return
+		}
+		if i < concurrency && lt.ramp > 0 { // the first sessions are spread over the ramp
+			time.Sleep(time.Until(start.Add(lt.ramp * time.Duration(i) / time.Duration(concurrency))))
+		}
+		sessLimiter <- struct{}{} // block till buffer will allow
+		wg.Add(1)
+		go func(dur time.Duration) {
+			lt.runSession(dur)
+			<-sessLimiter // release one session from buffer
+			wg.Done()
+		}(dur)
+	}
+	wg.Wait()
+	log.Printf("Simulated %d sessions", lt.calls)
+	return
+}
@@ -0,0 +1,215 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package main
+
+import (
+	"math/rand"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestCallProfileDuration(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	cp := &callProfile{
+		DurationDist: distFixed,
+		MeanDuration: time.Minute,
+		MinDuration:  10 * time.Second,
+		MaxDuration:  2 * time.Minute,
+	}
+	if dur, err := cp.duration(rnd); err != nil {
+		t.Fatal(err)
+	} else if dur != time.Minute {
+		t.Errorf("Expected %s, received %s", time.Minute, dur)
+	}
+	cp.DurationDist = "*poisson"
+	if _, err := cp.duration(rnd); err == nil || err.Error() != "unsupported duration distribution: <*poisson>" {
+		t.Errorf("Expected unsupported distribution error, received %v", err)
+	}
+
+	const samples = 10000
+	for _, dist := range []string{distUniform, distExponential, distNormal} {
+		cp.DurationDist = dist
+		var total time.Duration
+		var atMin, atMax int
+		for i := 0; i < samples; i++ {
+			dur, err := cp.duration(rnd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dur < cp.MinDuration || dur > cp.MaxDuration {
+				t.Fatalf("%s: duration %s out of limits", dist, dur)
+			}
+			switch dur {
+			case cp.MinDuration:
+				atMin++
+			case cp.MaxDuration:
+				atMax++
+			}
+			total += dur
+		}
+		avg := total / samples
+		switch dist {
+		case distUniform: // the mean of the limits
+			if avg < 63*time.Second || avg > 67*time.Second {
+				t.Errorf("%s: unexpected average %s", dist, avg)
+			}
+			if atMax != 0 {
+				t.Errorf("%s: expected the maximum to be excluded", dist)
+			}
+		case distExponential: // the limits clamp the long tail
+			if atMin == 0 || atMax == 0 {
+				t.Errorf("%s: expected durations clamped to the limits, min: %d, max: %d", dist, atMin, atMax)
+			}
+			if avg < 50*time.Second || avg > 60*time.Second {
+				t.Errorf("%s: unexpected average %s", dist, avg)
+			}
+		case distNormal: // the limits are 3 standard deviations away
+			if avg < 58*time.Second || avg > 62*time.Second {
+				t.Errorf("%s: unexpected average %s", dist, avg)
+			}
+			if atMin+atMax > samples/100 {
+				t.Errorf("%s: too many durations clamped, min: %d, max: %d", dist, atMin, atMax)
+			}
+		}
+	}
+
+	cp.MaxDuration = 0 // no upper limit
+	cp.DurationDist = distExponential
+	var overMean int
+	for i := 0; i < samples; i++ {
+		if dur, err := cp.duration(rnd); err != nil {
+			t.Fatal(err)
+		} else if dur > 5*cp.MeanDuration {
+			overMean++
+		}
+	}
+	if overMean == 0 {
+		t.Error("Expected durations over the mean without the upper limit")
+	}
+}
+
+// sessionsMock records the usage of the session requests received over RPC
+type sessionsMock struct {
+	sync.Mutex
+	usages map[string][]time.Duration // per OriginID
+	order  map[string][]string        // the requests per OriginID
+}
+
+func (sm *sessionsMock) record(req string, ev *utils.CGREvent) error {
+	usage, err := utils.IfaceAsDuration(ev.Event[utils.Usage])
+	if err != nil {
+		return err
+	}
+	originID := utils.IfaceAsString(ev.Event[utils.OriginID])
+	sm.Lock()
+	sm.usages[originID] = append(sm.usages[originID], usage)
+	sm.order[originID] = append(sm.order[originID], req)
+	sm.Unlock()
+	return nil
+}
+
+func (sm *sessionsMock) AuthorizeEvent(args *sessions.V1AuthorizeArgs, reply *sessions.V1AuthorizeReply) error {
+	return sm.record("auth", args.CGREvent)
+}
+
+func (sm *sessionsMock) InitiateSession(args *sessions.V1InitSessionArgs, reply *sessions.V1InitSessionReply) error {
+	return sm.record("init", args.CGREvent)
+}
+
+func (sm *sessionsMock) UpdateSession(args *sessions.V1UpdateSessionArgs, reply *sessions.V1UpdateSessionReply) error {
+	return sm.record("update", args.CGREvent)
+}
+
+func (sm *sessionsMock) TerminateSession(args *sessions.V1TerminateSessionArgs, reply *string) error {
+	*reply = utils.OK
+	return sm.record("terminate", args.CGREvent)
+}
+
+func TestLoadTesterRun(t *testing.T) {
+	sm := &sessionsMock{
+		usages: make(map[string][]time.Duration),
+		order:  make(map[string][]string),
+	}
+	srv := rpc.NewServer()
+	if err := srv.RegisterName(utils.SessionSv1, sm); err != nil {
+		t.Fatal(err)
+	}
+	srvConn, clntConn := net.Pipe()
+	go srv.ServeCodec(jsonrpc.NewServerCodec(srvConn))
+	clnt := jsonrpc.NewClient(clntConn)
+	defer clnt.Close()
+
+	lt := &loadTester{
+		clnt: clnt,
+		cp: &callProfile{
+			Tenant:       "cgrates.org",
+			ToR:          utils.MetaVoice,
+			Category:     "call",
+			RequestType:  utils.MetaPrepaid,
+			Subject:      "1001",
+			Destination:  "1002",
+			DurationDist: distFixed,
+			MeanDuration: 90 * time.Second,
+			Updates:      2,
+		},
+		calls:       4,
+		concurrency: 2,
+		rep:         newReport(),
+	}
+	if err := lt.run(); err != nil {
+		t.Fatal(err)
+	}
+	if len(sm.order) != 4 {
+		t.Fatalf("Expected 4 sessions, received %d", len(sm.order))
+	}
+	expOrder := []string{"auth", "init", "update", "update", "terminate"}
+	expUsages := []time.Duration{90 * time.Second, 30 * time.Second,
+		30 * time.Second, 30 * time.Second, 90 * time.Second}
+	for originID, order := range sm.order {
+		if !reflect.DeepEqual(expOrder, order) {
+			t.Errorf("Session %s: expected %q, received %q", originID, expOrder, order)
+		}
+		if !reflect.DeepEqual(expUsages, sm.usages[originID]) {
+			t.Errorf("Session %s: expected %v, received %v", originID, expUsages, sm.usages[originID])
+		}
+	}
+	for mthd, cnt := range map[string]int{
+		utils.SessionSv1AuthorizeEvent:   4,
+		utils.SessionSv1InitiateSession:  4,
+		utils.SessionSv1UpdateSession:    8,
+		utils.SessionSv1TerminateSession: 4,
+	} {
+		if ms, has := lt.rep.methods[mthd]; !has || len(ms.latencies) != cnt || len(ms.errors) != 0 {
+			t.Errorf("%s: unexpected report %+v", mthd, ms)
+		}
+	}
+
+	lt.cp.DurationDist = "*poisson"
+	if err := lt.run(); err == nil {
+		t.Error("Expected error for the unsupported distribution")
+	}
+}
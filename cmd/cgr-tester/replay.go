@@ -0,0 +1,194 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/rpc"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// replayRequest is one request read from the replay file
+type replayRequest struct {
+	Method string
+	Params json.RawMessage
+	Time   time.Time // when the request was originally sent, zero if not known
+}
+
+// analyzerCall is the part of a call exported by AnalyzerS used for replay
+type analyzerCall struct {
+	RequestMethod    string
+	RequestParams    json.RawMessage
+	RequestStartTime time.Time
+}
+
+// eventTime returns the time from the timeField of the request params
+// the field is searched in the Event of the params and then within the params
+func eventTime(params map[string]interface{}, timeField, tmz string) (t time.Time, err error) {
+	val, has := params[timeField]
+	if ev, canCast := params[utils.Event].(map[string]interface{}); canCast {
+		if evVal, evHas := ev[timeField]; evHas {
+			val, has = evVal, evHas
+		}
+	}
+	if !has {
+		return
+	}
+	return utils.ParseTimeDetectLayout(utils.IfaceAsString(val), tmz)
+}
+
+// readReplayRequests reads the requests out of the replay file based on its format:
+// *analyzer for the calls exported by AnalyzerS as *json_lines
+// *json_lines for the params of the method, one per line
+// *file_csv for events with the field names in the header, i.e. CDRs
+func readReplayRequests(fPath, format, method, timeField, tnt, tmz string) (reqs []*replayRequest, err error) {
+	var f *os.File
+	if f, err = os.Open(fPath); err != nil {
+		return
+	}
+	defer f.Close()
+	if format == utils.MetaFileCSV {
+		return readCSVReplayRequests(f, method, timeField, tnt, tmz)
+	}
+	scnr := bufio.NewScanner(f)
+	scnr.Buffer(make([]byte, 64*1024), 16*1024*1024) // the requests can be bigger than the default line limit
+	for lineNr := 1; scnr.Scan(); lineNr++ {
+		line := scnr.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var req *replayRequest
+		switch format {
+		case utils.MetaAnalyzer:
+			var call analyzerCall
+			if err = json.Unmarshal(line, &call); err != nil {
+				return nil, fmt.Errorf("line %d: %s", lineNr, err.Error())
+			}
+			req = &replayRequest{
+				Method: call.RequestMethod,
+				Params: call.RequestParams,
+				Time:   call.RequestStartTime,
+			}
+		case utils.MetaJSONLines:
+			var params map[string]interface{}
+			if err = json.Unmarshal(line, &params); err != nil {
+				return nil, fmt.Errorf("line %d: %s", lineNr, err.Error())
+			}
+			req = &replayRequest{
+				Method: method,
+				Params: append(json.RawMessage{}, line...),
+			}
+			if req.Time, err = eventTime(params, timeField, tmz); err != nil {
+				return nil, fmt.Errorf("line %d: %s", lineNr, err.Error())
+			}
+		default:
+			return nil, fmt.Errorf("unsupported replay format: <%s>", format)
+		}
+		reqs = append(reqs, req)
+	}
+	err = scnr.Err()
+	return
+}
+
+// readCSVReplayRequests reads the CSV records as events, the first record is the header with the field names
+func readCSVReplayRequests(rdr io.Reader, method, timeField, tnt, tmz string) (reqs []*replayRequest, err error) {
+	csvRdr := csv.NewReader(rdr)
+	csvRdr.FieldsPerRecord = -1
+	var hdr []string
+	if hdr, err = csvRdr.Read(); err != nil {
+		return
+	}
+	for {
+		var record []string
+		if record, err = csvRdr.Read(); err == io.EOF {
+			return reqs, nil
+		} else if err != nil {
+			return
+		}
+		ev := make(map[string]interface{})
+		for i, val := range record {
+			if i < len(hdr) {
+				ev[hdr[i]] = val
+			}
+		}
+		cgrEv := &utils.CGREvent{
+			Tenant: utils.IfaceAsString(ev[utils.Tenant]),
+			ID:     utils.GenUUID(),
+			Event:  ev,
+		}
+		if cgrEv.Tenant == utils.EmptyString {
+			cgrEv.Tenant = tnt
+		}
+		req := &replayRequest{Method: method}
+		if req.Params, err = json.Marshal(cgrEv); err != nil {
+			return
+		}
+		if req.Time, err = eventTime(ev, timeField, tmz); err != nil {
+			return
+		}
+		reqs = append(reqs, req)
+	}
+}
+
+// replayRequests sends the requests keeping their original timing divided by the speed factor
+// with a speed of 0 or for requests without time the requests are sent as fast as possible
+func replayRequests(clnt *rpc.Client, reqs []*replayRequest, speed float64, parallel int, rep *report) {
+	var wg sync.WaitGroup
+	var reqLimiter chan struct{}
+	if parallel > 0 {
+		reqLimiter = make(chan struct{}, parallel)
+	}
+	var firstTime time.Time
+	start := time.Now()
+	for _, req := range reqs {
+		if speed > 0 && !req.Time.IsZero() {
+			if firstTime.IsZero() {
+				firstTime = req.Time
+			}
+			if wait := time.Until(start.Add(time.Duration(float64(req.Time.Sub(firstTime)) / speed))); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+		if reqLimiter != nil {
+			reqLimiter <- struct{}{} // block till buffer will allow
+		}
+		wg.Add(1)
+		go func(req *replayRequest) {
+			var rply json.RawMessage
+			sTime := time.Now()
+			err := clnt.Call(req.Method, req.Params, &rply)
+			rep.record(req.Method, time.Since(sTime), err)
+			if reqLimiter != nil {
+				<-reqLimiter // release one request from buffer
+			}
+			wg.Done()
+		}(req)
+	}
+	wg.Wait()
+	log.Printf("Replayed %d requests", len(reqs))
+}
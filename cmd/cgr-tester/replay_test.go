@@ -0,0 +1,273 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/rpc"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestReplayEventTime(t *testing.T) {
+	params := map[string]interface{}{
+		utils.SetupTime: "2021-01-01T10:00:00Z",
+		utils.Event: map[string]interface{}{
+			utils.SetupTime: "2021-01-01T11:00:00Z",
+		},
+	}
+	exp := time.Date(2021, 1, 1, 11, 0, 0, 0, time.UTC)
+	if rcv, err := eventTime(params, utils.SetupTime, "UTC"); err != nil {
+		t.Fatal(err)
+	} else if !rcv.Equal(exp) {
+		t.Errorf("Expected %v, received %v", exp, rcv)
+	}
+	delete(params, utils.Event)
+	exp = time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	if rcv, err := eventTime(params, utils.SetupTime, "UTC"); err != nil {
+		t.Fatal(err)
+	} else if !rcv.Equal(exp) {
+		t.Errorf("Expected %v, received %v", exp, rcv)
+	}
+	if rcv, err := eventTime(params, utils.AnswerTime, "UTC"); err != nil {
+		t.Fatal(err)
+	} else if !rcv.IsZero() {
+		t.Errorf("Expected zero time, received %v", rcv)
+	}
+	params[utils.SetupTime] = "notATime"
+	if _, err := eventTime(params, utils.SetupTime, "UTC"); err == nil {
+		t.Error("Expected error for the invalid time")
+	}
+}
+
+func TestReadReplayRequestsAnalyzer(t *testing.T) {
+	fPath := path.Join(t.TempDir(), "calls.json")
+	if err := ioutil.WriteFile(fPath, []byte(`{"RequestMethod":"CoreSv1.Ping","RequestParams":{"Tenant":"cgrates.org"},"RequestStartTime":"2021-01-01T10:00:00Z","Reply":"Pong"}
+
+{"RequestMethod":"CoreSv1.Status","RequestParams":{},"RequestStartTime":"2021-01-01T10:00:02Z"}
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	exp := []*replayRequest{
+		{
+			Method: utils.CoreSv1Ping,
+			Params: json.RawMessage(`{"Tenant":"cgrates.org"}`),
+			Time:   time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			Method: utils.CoreSv1Status,
+			Params: json.RawMessage(`{}`),
+			Time:   time.Date(2021, 1, 1, 10, 0, 2, 0, time.UTC),
+		},
+	}
+	if reqs, err := readReplayRequests(fPath, utils.MetaAnalyzer, utils.EmptyString,
+		utils.SetupTime, "cgrates.org", "UTC"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, reqs) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(reqs))
+	}
+
+	if err := ioutil.WriteFile(fPath, []byte("{\"RequestMethod\":\"CoreSv1.Ping\"}\nnotJSON\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readReplayRequests(fPath, utils.MetaAnalyzer, utils.EmptyString,
+		utils.SetupTime, "cgrates.org", "UTC"); err == nil || !strings.HasPrefix(err.Error(), "line 2: ") {
+		t.Errorf("Expected error on line 2, received %v", err)
+	}
+	if _, err := readReplayRequests(fPath, "*xml", utils.EmptyString,
+		utils.SetupTime, "cgrates.org", "UTC"); err == nil || err.Error() != "unsupported replay format: <*xml>" {
+		t.Errorf("Expected unsupported format error, received %v", err)
+	}
+	if _, err := readReplayRequests(path.Join(t.TempDir(), "missing.json"), utils.MetaAnalyzer,
+		utils.EmptyString, utils.SetupTime, "cgrates.org", "UTC"); err == nil {
+		t.Error("Expected error for the missing file")
+	}
+}
+
+func TestReadReplayRequestsJSONLines(t *testing.T) {
+	fPath := path.Join(t.TempDir(), "events.json")
+	if err := ioutil.WriteFile(fPath, []byte(`{"Tenant":"cgrates.org","Event":{"Account":"1001","SetupTime":"2021-01-01T10:00:00Z"}}
+{"Tenant":"cgrates.org","Event":{"Account":"1002"}}
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	exp := []*replayRequest{
+		{
+			Method: utils.CDRsV1ProcessEvent,
+			Params: json.RawMessage(`{"Tenant":"cgrates.org","Event":{"Account":"1001","SetupTime":"2021-01-01T10:00:00Z"}}`),
+			Time:   time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			Method: utils.CDRsV1ProcessEvent,
+			Params: json.RawMessage(`{"Tenant":"cgrates.org","Event":{"Account":"1002"}}`),
+		},
+	}
+	if reqs, err := readReplayRequests(fPath, utils.MetaJSONLines, utils.CDRsV1ProcessEvent,
+		utils.SetupTime, "cgrates.org", "UTC"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, reqs) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(reqs))
+	}
+
+	if err := ioutil.WriteFile(fPath, []byte(`{"Event":{"SetupTime":"notATime"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readReplayRequests(fPath, utils.MetaJSONLines, utils.CDRsV1ProcessEvent,
+		utils.SetupTime, "cgrates.org", "UTC"); err == nil || !strings.HasPrefix(err.Error(), "line 1: ") {
+		t.Errorf("Expected error on line 1, received %v", err)
+	}
+}
+
+func TestReadReplayRequestsCSV(t *testing.T) {
+	fPath := path.Join(t.TempDir(), "cdrs.csv")
+	if err := ioutil.WriteFile(fPath, []byte(`Tenant,Account,SetupTime
+,1001,2021-01-01T10:00:00Z
+itsyscom.com,1002,2021-01-01T10:00:05Z,extra
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	reqs, err := readReplayRequests(fPath, utils.MetaFileCSV, utils.CDRsV1ProcessEvent,
+		utils.SetupTime, "cgrates.org", "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 2 {
+		t.Fatalf("Expected 2 requests, received %s", utils.ToJSON(reqs))
+	}
+	expEvs := []*utils.CGREvent{
+		{
+			Tenant: "cgrates.org",
+			Event: map[string]interface{}{
+				utils.Tenant:       utils.EmptyString,
+				utils.AccountField: "1001",
+				utils.SetupTime:    "2021-01-01T10:00:00Z",
+			},
+		},
+		{
+			Tenant: "itsyscom.com",
+			Event: map[string]interface{}{
+				utils.Tenant:       "itsyscom.com",
+				utils.AccountField: "1002",
+				utils.SetupTime:    "2021-01-01T10:00:05Z",
+			},
+		},
+	}
+	expTimes := []time.Time{
+		time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2021, 1, 1, 10, 0, 5, 0, time.UTC),
+	}
+	for i, req := range reqs {
+		if req.Method != utils.CDRsV1ProcessEvent {
+			t.Errorf("Expected %s, received %s", utils.CDRsV1ProcessEvent, req.Method)
+		}
+		if !req.Time.Equal(expTimes[i]) {
+			t.Errorf("Expected %v, received %v", expTimes[i], req.Time)
+		}
+		var ev utils.CGREvent
+		if err := json.Unmarshal(req.Params, &ev); err != nil {
+			t.Fatal(err)
+		}
+		if ev.ID == utils.EmptyString {
+			t.Error("Expected the event to have an ID")
+		}
+		ev.ID = utils.EmptyString
+		if !reflect.DeepEqual(expEvs[i], &ev) {
+			t.Errorf("Expected %s, received %s", utils.ToJSON(expEvs[i]), utils.ToJSON(ev))
+		}
+	}
+
+	if err := ioutil.WriteFile(fPath, []byte("Account,SetupTime\n1001,notATime\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readReplayRequests(fPath, utils.MetaFileCSV, utils.CDRsV1ProcessEvent,
+		utils.SetupTime, "cgrates.org", "UTC"); err == nil {
+		t.Error("Expected error for the invalid time")
+	}
+	if err := ioutil.WriteFile(fPath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readReplayRequests(fPath, utils.MetaFileCSV, utils.CDRsV1ProcessEvent,
+		utils.SetupTime, "cgrates.org", "UTC"); err == nil {
+		t.Error("Expected error for the missing header")
+	}
+}
+
+// replayService records the params it receives over RPC
+type replayService struct {
+	calls chan string
+}
+
+func (rs *replayService) Call(args json.RawMessage, reply *json.RawMessage) error {
+	rs.calls <- string(args)
+	if string(args) == `"fail"` {
+		return errors.New("FAILED")
+	}
+	*reply = json.RawMessage(`"OK"`)
+	return nil
+}
+
+func newReplayClient(t *testing.T, rs *replayService) *rpc.Client {
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("ReplaySv1", rs); err != nil {
+		t.Fatal(err)
+	}
+	srvConn, clntConn := net.Pipe()
+	go srv.ServeConn(srvConn)
+	clnt := rpc.NewClient(clntConn)
+	t.Cleanup(func() { clnt.Close() })
+	return clnt
+}
+
+func TestReplayRequests(t *testing.T) {
+	rs := &replayService{calls: make(chan string, 3)}
+	clnt := newReplayClient(t, rs)
+	tStart := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	reqs := []*replayRequest{
+		{Method: "ReplaySv1.Call", Params: json.RawMessage(`"first"`), Time: tStart},
+		{Method: "ReplaySv1.Call", Params: json.RawMessage(`"fail"`), Time: tStart.Add(10 * time.Second)},
+		{Method: "ReplaySv1.Call", Params: json.RawMessage(`"last"`), Time: tStart.Add(20 * time.Second)},
+	}
+	rep := newReport()
+	start := time.Now()
+	replayRequests(clnt, reqs, 100, 1, rep) // 20s divided by 100
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("Expected the original timing divided by the speed, replayed in %s", elapsed)
+	}
+	close(rs.calls)
+	var calls []string
+	for call := range rs.calls {
+		calls = append(calls, call)
+	}
+	if exp := []string{`"first"`, `"fail"`, `"last"`}; !reflect.DeepEqual(exp, calls) {
+		t.Errorf("Expected %q, received %q", exp, calls)
+	}
+	ms := rep.methods["ReplaySv1.Call"]
+	if ms == nil || len(ms.latencies) != 3 ||
+		!reflect.DeepEqual(map[string]int{"FAILED": 1}, ms.errors) {
+		t.Errorf("Unexpected report: %+v", ms)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// methodStats are the latencies and errors of the requests sent to one API method
+type methodStats struct {
+	latencies []time.Duration
+	errors    map[string]int
+}
+
+// report collects the latencies and errors of the requests per API method
+type report struct {
+	sync.Mutex
+	start   time.Time
+	methods map[string]*methodStats
+}
+
+func newReport() *report {
+	return &report{
+		start:   time.Now(),
+		methods: make(map[string]*methodStats),
+	}
+}
+
+// record adds the result of one request to the report
+func (r *report) record(method string, latency time.Duration, err error) {
+	r.Lock()
+	ms, has := r.methods[method]
+	if !has {
+		ms = &methodStats{errors: make(map[string]int)}
+		r.methods[method] = ms
+	}
+	ms.latencies = append(ms.latencies, latency)
+	if err != nil {
+		ms.errors[err.Error()]++
+	}
+	r.Unlock()
+}
+
+// percentile returns the latency under which the given percent of the sorted latencies are
+func percentile(sorted []time.Duration, pct float64) time.Duration {
+	idx := int(float64(len(sorted))*pct/100+0.5) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
+
+// String returns the latency and error report, one line per API method
+func (r *report) String() string {
+	r.Lock()
+	defer r.Unlock()
+	elapsed := time.Since(r.start)
+	mthds := make([]string, 0, len(r.methods))
+	for mthd := range r.methods {
+		mthds = append(mthds, mthd)
+	}
+	sort.Strings(mthds)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Elapsed: %s\n", elapsed)
+	fmt.Fprintf(&sb, "%-36s %8s %8s %10s %12s %12s %12s %12s %12s %12s\n",
+		"Method", "Requests", "Errors", "Req/s", "Min", "Avg", "P50", "P95", "P99", "Max")
+	for _, mthd := range mthds {
+		ms := r.methods[mthd]
+		lats := make([]time.Duration, len(ms.latencies))
+		copy(lats, ms.latencies)
+		sort.Slice(lats, func(i, j int) bool { return lats[i] < lats[j] })
+		var total time.Duration
+		for _, lat := range lats {
+			total += lat
+		}
+		var errs int
+		for _, cnt := range ms.errors {
+			errs += cnt
+		}
+		fmt.Fprintf(&sb, "%-36s %8d %8d %10.2f %12s %12s %12s %12s %12s %12s\n",
+			mthd, len(lats), errs, float64(len(lats))/elapsed.Seconds(),
+			lats[0], total/time.Duration(len(lats)), percentile(lats, 50),
+			percentile(lats, 95), percentile(lats, 99), lats[len(lats)-1])
+		for errMsg, cnt := range ms.errors {
+			fmt.Fprintf(&sb, "    error <%s>: %d\n", errMsg, cnt)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,90 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportPercentile(t *testing.T) {
+	lats := make([]time.Duration, 100)
+	for i := range lats {
+		lats[i] = time.Duration(i+1) * time.Millisecond
+	}
+	for pct, exp := range map[float64]time.Duration{
+		0:   time.Millisecond,
+		50:  50 * time.Millisecond,
+		95:  95 * time.Millisecond,
+		99:  99 * time.Millisecond,
+		100: 100 * time.Millisecond,
+	} {
+		if rcv := percentile(lats, pct); rcv != exp {
+			t.Errorf("P%v: expected %s, received %s", pct, exp, rcv)
+		}
+	}
+	if rcv := percentile([]time.Duration{time.Second}, 99); rcv != time.Second {
+		t.Errorf("Expected %s, received %s", time.Second, rcv)
+	}
+}
+
+func TestReportAggregation(t *testing.T) {
+	rep := newReport()
+	for _, lat := range []time.Duration{4 * time.Millisecond, time.Millisecond,
+		3 * time.Millisecond, 2 * time.Millisecond} {
+		rep.record("CoreSv1.Ping", lat, nil)
+	}
+	rep.record("CDRsV1.ProcessEvent", 10*time.Millisecond, errors.New("NOT_FOUND"))
+	rep.record("CDRsV1.ProcessEvent", 30*time.Millisecond, errors.New("NOT_FOUND"))
+	rep.record("CDRsV1.ProcessEvent", 20*time.Millisecond, nil)
+
+	if ms := rep.methods["CDRsV1.ProcessEvent"]; len(ms.latencies) != 3 ||
+		len(ms.errors) != 1 || ms.errors["NOT_FOUND"] != 2 {
+		t.Errorf("Unexpected stats: %+v", ms)
+	}
+	lines := strings.Split(strings.TrimSpace(rep.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Unexpected report:\n%s", rep)
+	}
+	if !strings.HasPrefix(lines[0], "Elapsed: ") {
+		t.Errorf("Unexpected elapsed line: %q", lines[0])
+	}
+	if hdr := strings.Fields(lines[1]); strings.Join(hdr, " ") !=
+		"Method Requests Errors Req/s Min Avg P50 P95 P99 Max" {
+		t.Errorf("Unexpected header: %q", lines[1])
+	}
+	// the methods are sorted, the error lines following their method, and the Req/s is not checked since it depends on the elapsed time
+	for lnIdx, exp := range map[int][]string{
+		2: {"CDRsV1.ProcessEvent", "3", "2", "10ms", "20ms", "20ms", "30ms", "30ms", "30ms"},
+		4: {"CoreSv1.Ping", "4", "0", "1ms", "2.5ms", "2ms", "4ms", "4ms", "4ms"},
+	} {
+		flds := strings.Fields(lines[lnIdx])
+		if len(flds) != 10 {
+			t.Fatalf("Unexpected line: %q", lines[lnIdx])
+		}
+		if rcv := append(flds[:3:3], flds[4:]...); strings.Join(rcv, " ") != strings.Join(exp, " ") {
+			t.Errorf("Expected %q, received %q", exp, rcv)
+		}
+	}
+	if strings.TrimSpace(lines[3]) != "error <NOT_FOUND>: 2" {
+		t.Errorf("Unexpected error line: %q", lines[3])
+	}
+}
@@ -18,7 +18,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 
 package config
 
-import "github.com/cgrates/cgrates/utils"
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
 
 // AccountSCfg is the configuration of ActionS
 type AccountSCfg struct {
@@ -33,6 +37,8 @@ type AccountSCfg struct {
 	NestedFields        bool
 	MaxIterations       int
 	MaxUsage            *utils.Decimal
+	StoreCharges        bool          // store the charges on debit so they can be refunded
+	ChargesTTL          time.Duration // how long the stored charges can be refunded
 }
 
 func (acS *AccountSCfg) loadFromJSONCfg(jsnCfg *AccountSJsonCfg) (err error) {
@@ -107,6 +113,14 @@ func (acS *AccountSCfg) loadFromJSONCfg(jsnCfg *AccountSJsonCfg) (err error) {
 			return err
 		}
 	}
+	if jsnCfg.Store_charges != nil {
+		acS.StoreCharges = *jsnCfg.Store_charges
+	}
+	if jsnCfg.Charges_ttl != nil {
+		if acS.ChargesTTL, err = utils.ParseDurationWithNanosecs(*jsnCfg.Charges_ttl); err != nil {
+			return
+		}
+	}
 	return
 }
 
@@ -117,6 +131,7 @@ func (acS *AccountSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		utils.IndexedSelectsCfg: acS.IndexedSelects,
 		utils.NestedFieldsCfg:   acS.NestedFields,
 		utils.MaxIterations:     acS.MaxIterations,
+		utils.StoreChargesCfg:   acS.StoreCharges,
 	}
 	if acS.AttributeSConns != nil {
 		attributeSConns := make([]string, len(acS.AttributeSConns))
@@ -172,6 +187,9 @@ func (acS *AccountSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	if acS.MaxUsage != nil {
 		initialMP[utils.MaxUsage] = acS.MaxUsage
 	}
+	if acS.ChargesTTL != 0 {
+		initialMP[utils.ChargesTTLCfg] = acS.ChargesTTL.String()
+	}
 	return
 }
 
@@ -183,6 +201,8 @@ func (acS AccountSCfg) Clone() (cln *AccountSCfg) {
 		NestedFields:   acS.NestedFields,
 		MaxIterations:  acS.MaxIterations,
 		MaxUsage:       acS.MaxUsage,
+		StoreCharges:   acS.StoreCharges,
+		ChargesTTL:     acS.ChargesTTL,
 	}
 	if acS.AttributeSConns != nil {
 		cln.AttributeSConns = make([]string, len(acS.AttributeSConns))
@@ -21,6 +21,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -38,6 +39,8 @@ func TestAccountSCfgLoadFromJSONCfg(t *testing.T) {
 		Nested_fields:         utils.BoolPointer(true),
 		Max_iterations:        utils.IntPointer(1000),
 		Max_usage:             utils.StringPointer("200h"),
+		Store_charges:         utils.BoolPointer(true),
+		Charges_ttl:           utils.StringPointer("1h"),
 	}
 	usage, err := utils.NewDecimalFromUsage("200h")
 	if err != nil {
@@ -55,6 +58,8 @@ func TestAccountSCfgLoadFromJSONCfg(t *testing.T) {
 		NestedFields:        true,
 		MaxIterations:       1000,
 		MaxUsage:            usage,
+		StoreCharges:        true,
+		ChargesTTL:          time.Hour,
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err = jsnCfg.accountSCfg.loadFromJSONCfg(jsonCfg); err != nil {
@@ -73,6 +78,13 @@ func TestAccountsCfLoadConfigError(t *testing.T) {
 	if err := actsCfg.loadFromJSONCfg(accountsJson); err == nil || err.Error() != expected {
 		t.Errorf("Expected %+v, received %+v", expected, err)
 	}
+	accountsJson = &AccountSJsonCfg{
+		Charges_ttl: utils.StringPointer("invalid_Duration"),
+	}
+	expected = "time: invalid duration \"invalid_Duration\""
+	if err := actsCfg.loadFromJSONCfg(accountsJson); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
 }
 
 func TestAccountSCfgAsMapInterface(t *testing.T) {
@@ -89,6 +101,8 @@ func TestAccountSCfgAsMapInterface(t *testing.T) {
 	"nested_fields": true,			
     "max_iterations": 100,
     "max_usage": "72h",
+    "store_charges": true,
+    "charges_ttl": "1h",
 },	
 }`
 
@@ -103,6 +117,8 @@ func TestAccountSCfgAsMapInterface(t *testing.T) {
 		utils.SuffixIndexedFieldsCfg: []string{"*req.index1"},
 		utils.NestedFieldsCfg:        true,
 		utils.MaxIterations:          100,
+		utils.StoreChargesCfg:        true,
+		utils.ChargesTTLCfg:          "1h0m0s",
 	}
 	usage, err := utils.NewDecimalFromUsage("72h")
 	if err != nil {
@@ -134,6 +150,8 @@ func TestAccountSCfgClone(t *testing.T) {
 		NestedFields:        true,
 		MaxIterations:       1000,
 		MaxUsage:            usage,
+		StoreCharges:        true,
+		ChargesTTL:          time.Hour,
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
@@ -27,6 +27,7 @@ type ActionSCfg struct {
 	EEsConns            []string
 	ThresholdSConns     []string
 	StatSConns          []string
+	AccountSConns       []string
 	Tenants             *[]string
 	IndexedSelects      bool
 	StringIndexedFields *[]string
@@ -79,6 +80,16 @@ func (acS *ActionSCfg) loadFromJSONCfg(jsnCfg *ActionSJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Accounts_conns != nil {
+		acS.AccountSConns = make([]string, len(*jsnCfg.Accounts_conns))
+		for idx, connID := range *jsnCfg.Accounts_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			acS.AccountSConns[idx] = connID
+			if connID == utils.MetaInternal {
+				acS.AccountSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts)
+			}
+		}
+	}
 	if jsnCfg.Enabled != nil {
 		acS.Enabled = *jsnCfg.Enabled
 	}
@@ -166,6 +177,16 @@ func (acS *ActionSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		}
 		initialMP[utils.EEsConnsCfg] = eesConns
 	}
+	if acS.AccountSConns != nil {
+		acntSConns := make([]string, len(acS.AccountSConns))
+		for i, item := range acS.AccountSConns {
+			acntSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts) {
+				acntSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.AccountSConnsCfg] = acntSConns
+	}
 	if acS.Tenants != nil {
 		Tenants := make([]string, len(*acS.Tenants))
 		for i, item := range *acS.Tenants {
@@ -228,6 +249,12 @@ func (acS ActionSCfg) Clone() (cln *ActionSCfg) {
 			cln.EEsConns[i] = k
 		}
 	}
+	if acS.AccountSConns != nil {
+		cln.AccountSConns = make([]string, len(acS.AccountSConns))
+		for i, con := range acS.AccountSConns {
+			cln.AccountSConns[i] = con
+		}
+	}
 	if acS.Tenants != nil {
 		tnt := make([]string, len(*acS.Tenants))
 		for i, dx := range *acS.Tenants {
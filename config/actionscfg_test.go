@@ -32,6 +32,7 @@ func TestActionSCfgLoadFromJSONCfg(t *testing.T) {
 		Cdrs_conns:            &[]string{utils.MetaInternal},
 		Thresholds_conns:      &[]string{utils.MetaInternal},
 		Stats_conns:           &[]string{utils.MetaInternal},
+		Accounts_conns:        &[]string{utils.MetaInternal},
 		Indexed_selects:       utils.BoolPointer(false),
 		Tenants:               &[]string{"itsyscom.com"},
 		String_indexed_fields: &[]string{"*req.index1"},
@@ -45,6 +46,7 @@ func TestActionSCfgLoadFromJSONCfg(t *testing.T) {
 		CDRsConns:           []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs)},
 		ThresholdSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)},
 		StatSConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)},
+		AccountSConns:       []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts)},
 		IndexedSelects:      false,
 		Tenants:             &[]string{"itsyscom.com"},
 		StringIndexedFields: &[]string{"*req.index1"},
@@ -68,6 +70,7 @@ func TestActionSCfgAsMapInterface(t *testing.T) {
 	"ees_conns": ["*internal:*ees"],						
 	"thresholds_conns": ["*internal:*thresholds"],					
 	"stats_conns": ["*internal:*stats"],						
+	"accounts_conns": ["*internal:*accounts"],
 	"tenants": ["itsyscom.com"],
 	"indexed_selects": false,
 	"string_indexed_fields": ["*req.index1"],			
@@ -82,6 +85,7 @@ func TestActionSCfgAsMapInterface(t *testing.T) {
 		utils.EEsConnsCfg:            []string{utils.MetaInternal},
 		utils.ThresholdSConnsCfg:     []string{utils.MetaInternal},
 		utils.StatSConnsCfg:          []string{utils.MetaInternal},
+		utils.AccountSConnsCfg:       []string{utils.MetaInternal},
 		utils.CDRsConnsCfg:           []string{utils.MetaInternal},
 		utils.Tenants:                []string{"itsyscom.com"},
 		utils.IndexedSelectsCfg:      false,
@@ -104,6 +108,7 @@ func TestActionSCfgClone(t *testing.T) {
 		CDRsConns:           []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs)},
 		ThresholdSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)},
 		StatSConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)},
+		AccountSConns:       []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts)},
 		Tenants:             &[]string{"itsyscom.com"},
 		IndexedSelects:      false,
 		StringIndexedFields: &[]string{"*req.index1"},
@@ -139,4 +144,7 @@ func TestActionSCfgClone(t *testing.T) {
 	if rcv.StatSConns[0] = ""; ban.StatSConns[0] != utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats) {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.AccountSConns[0] = ""; ban.AccountSConns[0] != utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAccounts) {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
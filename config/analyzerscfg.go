@@ -31,6 +31,7 @@ type AnalyzerSCfg struct {
 	IndexType       string
 	TTL             time.Duration
 	CleanupInterval time.Duration
+	ExportPath      string
 }
 
 func (alS *AnalyzerSCfg) loadFromJSONCfg(jsnCfg *AnalyzerSJsonCfg) (err error) {
@@ -56,6 +57,9 @@ func (alS *AnalyzerSCfg) loadFromJSONCfg(jsnCfg *AnalyzerSJsonCfg) (err error) {
 			return
 		}
 	}
+	if jsnCfg.Export_path != nil {
+		alS.ExportPath = *jsnCfg.Export_path
+	}
 	return nil
 }
 
@@ -67,6 +71,7 @@ func (alS *AnalyzerSCfg) AsMapInterface() map[string]interface{} {
 		utils.IndexTypeCfg:       alS.IndexType,
 		utils.TTLCfg:             alS.TTL.String(),
 		utils.CleanupIntervalCfg: alS.CleanupInterval.String(),
+		utils.ExportPathCfg:      alS.ExportPath,
 	}
 }
 
@@ -78,5 +83,6 @@ func (alS AnalyzerSCfg) Clone() *AnalyzerSCfg {
 		IndexType:       alS.IndexType,
 		TTL:             alS.TTL,
 		CleanupInterval: alS.CleanupInterval,
+		ExportPath:      alS.ExportPath,
 	}
 }
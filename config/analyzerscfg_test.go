@@ -33,6 +33,7 @@ func TestAnalyzerSCfgloadFromJsonCfg(t *testing.T) {
 		Enabled:         false,
 		CleanupInterval: time.Hour,
 		DBPath:          "/var/spool/cgrates/analyzers",
+		ExportPath:      "/var/spool/cgrates/analyzers/exports",
 		IndexType:       utils.MetaScorch,
 		TTL:             24 * time.Hour,
 	}
@@ -53,6 +54,7 @@ func TestAnalyzerSCfgAsMapInterface(t *testing.T) {
 		utils.EnabledCfg:         false,
 		utils.CleanupIntervalCfg: "1h0m0s",
 		utils.DBPathCfg:          "/var/spool/cgrates/analyzers",
+		utils.ExportPathCfg:      "/var/spool/cgrates/analyzers/exports",
 		utils.IndexTypeCfg:       utils.MetaScorch,
 		utils.TTLCfg:             "24h0m0s",
 	}
@@ -74,6 +76,7 @@ func TestAnalyzerSCfgAsMapInterface1(t *testing.T) {
 		utils.EnabledCfg:         true,
 		utils.CleanupIntervalCfg: "1h0m0s",
 		utils.DBPathCfg:          "/var/spool/cgrates/analyzers",
+		utils.ExportPathCfg:      "/var/spool/cgrates/analyzers/exports",
 		utils.IndexTypeCfg:       utils.MetaScorch,
 		utils.TTLCfg:             "24h0m0s",
 	}
@@ -106,6 +109,7 @@ func TestAnalyzerSCfgClone(t *testing.T) {
 		Enabled:         false,
 		CleanupInterval: time.Hour,
 		DBPath:          "/var/spool/cgrates/analyzers",
+		ExportPath:      "/var/spool/cgrates/analyzers/exports",
 		IndexType:       utils.MetaScorch,
 		TTL:             24 * time.Hour,
 	}
@@ -33,6 +33,7 @@ type CdrsCfg struct {
 	AttributeSConns  []string
 	ThresholdSConns  []string
 	StatSConns       []string
+	RouteSConns      []string
 	OnlineCDRExports []string // list of CDRE templates to use for real-time CDR exports
 	SchedulerConns   []string
 	EEsConns         []string
@@ -107,6 +108,16 @@ func (cdrscfg *CdrsCfg) loadFromJSONCfg(jsnCdrsCfg *CdrsJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCdrsCfg.Routes_conns != nil {
+		cdrscfg.RouteSConns = make([]string, len(*jsnCdrsCfg.Routes_conns))
+		for idx, connID := range *jsnCdrsCfg.Routes_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			cdrscfg.RouteSConns[idx] = connID
+			if connID == utils.MetaInternal {
+				cdrscfg.RouteSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRoutes)
+			}
+		}
+	}
 	if jsnCdrsCfg.Online_cdr_exports != nil {
 		for _, expProfile := range *jsnCdrsCfg.Online_cdr_exports {
 			cdrscfg.OnlineCDRExports = append(cdrscfg.OnlineCDRExports, expProfile)
@@ -206,6 +217,16 @@ func (cdrscfg *CdrsCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		}
 		initialMP[utils.StatSConnsCfg] = statSConns
 	}
+	if cdrscfg.RouteSConns != nil {
+		routeSConns := make([]string, len(cdrscfg.RouteSConns))
+		for i, item := range cdrscfg.RouteSConns {
+			routeSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRoutes) {
+				routeSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.RouteSConnsCfg] = routeSConns
+	}
 	if cdrscfg.SchedulerConns != nil {
 		schedulerConns := make([]string, len(cdrscfg.SchedulerConns))
 		for i, item := range cdrscfg.SchedulerConns {
@@ -267,6 +288,12 @@ func (cdrscfg CdrsCfg) Clone() (cln *CdrsCfg) {
 			cln.StatSConns[i] = con
 		}
 	}
+	if cdrscfg.RouteSConns != nil {
+		cln.RouteSConns = make([]string, len(cdrscfg.RouteSConns))
+		for i, con := range cdrscfg.RouteSConns {
+			cln.RouteSConns[i] = con
+		}
+	}
 	if cdrscfg.OnlineCDRExports != nil {
 		cln.OnlineCDRExports = make([]string, len(cdrscfg.OnlineCDRExports))
 		for i, con := range cdrscfg.OnlineCDRExports {
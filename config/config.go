@@ -60,6 +60,11 @@ func newDbDefaults() dbDefaults {
 			"DbPort": "5432",
 			"DbPass": "CGRateS.org",
 		},
+		utils.SQLite: map[string]string{
+			"DbName": "/var/lib/cgrates/cgrates.db",
+			"DbPort": "",
+			"DbPass": "",
+		},
 		utils.Mongo: map[string]string{
 			"DbName": "cgrates",
 			"DbPort": "27017",
@@ -201,6 +206,7 @@ func newCGRConfig(config []byte) (cfg *CGRConfig, err error) {
 	cfg.apiBanCfg = new(APIBanCfg)
 	cfg.coreSCfg = new(CoreSCfg)
 	cfg.accountSCfg = new(AccountSCfg)
+	cfg.promAgentCfg = new(PrometheusAgentCfg)
 
 	cfg.cacheDP = make(map[string]utils.MapStorage)
 
@@ -337,6 +343,7 @@ type CGRConfig struct {
 	apiBanCfg        *APIBanCfg        // APIBan config
 	coreSCfg         *CoreSCfg         // CoreS config
 	accountSCfg      *AccountSCfg      // AccountS config
+	promAgentCfg     *PrometheusAgentCfg
 
 	cacheDP    map[string]utils.MapStorage
 	cacheDPMux sync.RWMutex
@@ -347,13 +354,17 @@ var posibleLoaderTypes = utils.NewStringSet([]string{utils.MetaAttributes,
 	utils.MetaRoutes, utils.MetaThresholds, utils.MetaChargers,
 	utils.MetaDispatchers, utils.MetaDispatcherHosts, utils.MetaRateProfiles})
 
+var possibleLoaderFormats = utils.NewStringSet([]string{utils.MetaCSV,
+	utils.MetaJSON, utils.MetaXml, utils.MetaXLSX})
+
 var possibleReaderTypes = utils.NewStringSet([]string{utils.MetaFileCSV,
 	utils.MetaKafkajsonMap, utils.MetaFileXML, utils.MetaSQL, utils.MetaFileFWV,
-	utils.MetaPartialCSV, utils.MetaFlatstore, utils.MetaFileJSON, utils.MetaNone})
+	utils.MetaPartialCSV, utils.MetaFlatstore, utils.MetaFileJSON, utils.MetaNATSjsonMap, utils.MetaNone})
 
 var possibleExporterTypes = utils.NewStringSet([]string{utils.MetaFileCSV, utils.MetaNone, utils.MetaFileFWV,
+	utils.MetaFileParquet, utils.MetaFileAvro, utils.MetaFileJSON, utils.MetaFilePDF,
 	utils.MetaHTTPPost, utils.MetaHTTPjsonMap, utils.MetaAMQPjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaSQSjsonMap,
-	utils.MetaKafkajsonMap, utils.MetaS3jsonMap, utils.MetaElastic, utils.MetaVirt, utils.MetaSQL})
+	utils.MetaKafkajsonMap, utils.MetaS3jsonMap, utils.MetaNATSjsonMap, utils.MetaElastic, utils.MetaVirt, utils.MetaSQL})
 
 // LazySanityCheck used after check config sanity to display warnings related to the config
 func (cfg *CGRConfig) LazySanityCheck() {
@@ -408,7 +419,7 @@ func (cfg *CGRConfig) loadFromJSONCfg(jsnCfg *CgrJsonCfg) (err error) {
 		cfg.loadAnalyzerCgrCfg, cfg.loadApierCfg, cfg.loadErsCfg, cfg.loadEesCfg,
 		cfg.loadRateSCfg, cfg.loadSIPAgentCfg, cfg.loadDispatcherHCfg,
 		cfg.loadConfigSCfg, cfg.loadAPIBanCgrCfg, cfg.loadCoreSCfg, cfg.loadActionSCfg,
-		cfg.loadAccountSCfg} {
+		cfg.loadAccountSCfg, cfg.loadPrometheusAgentCfg} {
 		if err = loadFunc(jsnCfg); err != nil {
 			return
 		}
@@ -459,7 +470,11 @@ func (cfg *CGRConfig) loadCacheCfg(jsnCfg *CgrJsonCfg) (err error) {
 	if jsnCacheCfg, err = jsnCfg.CacheJsonCfg(); err != nil {
 		return
 	}
-	return cfg.cacheCfg.loadFromJSONCfg(jsnCacheCfg)
+	if err = cfg.cacheCfg.loadFromJSONCfg(jsnCacheCfg); err != nil {
+		return
+	}
+	cfg.setAccountChargesTTL()
+	return
 }
 
 // loadListenCfg loads the Listen section of the configuration
@@ -818,6 +833,15 @@ func (cfg *CGRConfig) loadSIPAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
 	return cfg.sipAgentCfg.loadFromJSONCfg(jsnSIPAgentCfg, cfg.generalCfg.RSRSep)
 }
 
+// loadPrometheusAgentCfg loads the prometheus_agent section of the configuration
+func (cfg *CGRConfig) loadPrometheusAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnPrometheusAgentCfg *PrometheusAgentJsonCfg
+	if jsnPrometheusAgentCfg, err = jsnCfg.PrometheusAgentJsonCfg(); err != nil {
+		return
+	}
+	return cfg.promAgentCfg.loadFromJSONCfg(jsnPrometheusAgentCfg)
+}
+
 // loadTemplateSCfg loads the Template section of the configuration
 func (cfg *CGRConfig) loadTemplateSCfg(jsnCfg *CgrJsonCfg) (err error) {
 	var jsnTemplateCfg map[string][]*FcTemplateJsonCfg
@@ -857,7 +881,20 @@ func (cfg *CGRConfig) loadAccountSCfg(jsnCfg *CgrJsonCfg) (err error) {
 	if jsnActionCfg, err = jsnCfg.AccountSCfgJson(); err != nil {
 		return
 	}
-	return cfg.accountSCfg.loadFromJSONCfg(jsnActionCfg)
+	if err = cfg.accountSCfg.loadFromJSONCfg(jsnActionCfg); err != nil {
+		return
+	}
+	cfg.setAccountChargesTTL()
+	return
+}
+
+// setAccountChargesTTL derives the ttl of the *account_charges cache partition out of
+// accounts charges_ttl so the charges stored in the *internal DataDB expire at the same time
+func (cfg *CGRConfig) setAccountChargesTTL() {
+	if cParam, has := cfg.cacheCfg.Partitions[utils.CacheAccountCharges]; has {
+		cParam.TTL = cfg.accountSCfg.ChargesTTL
+		cParam.StaticTTL = true
+	}
 }
 
 // SureTaxCfg use locking to retrieve the configuration, possibility later for runtime reload
@@ -1180,6 +1217,13 @@ func (cfg *CGRConfig) CoreSCfg() *CoreSCfg {
 	return cfg.coreSCfg
 }
 
+// PrometheusAgentCfg reads the PrometheusAgent configuration
+func (cfg *CGRConfig) PrometheusAgentCfg() *PrometheusAgentCfg {
+	cfg.lks[PrometheusAgentJSN].Lock()
+	defer cfg.lks[PrometheusAgentJSN].Unlock()
+	return cfg.promAgentCfg
+}
+
 // GetReloadChan returns the reload chanel for the given section
 func (cfg *CGRConfig) GetReloadChan(sectID string) chan struct{} {
 	return cfg.rldChans[sectID]
@@ -1278,6 +1322,7 @@ func (cfg *CGRConfig) getLoadFunctions() map[string]func(*CgrJsonCfg) error {
 		CoreSCfgJson:       cfg.loadCoreSCfg,
 		ActionSJson:        cfg.loadActionSCfg,
 		AccountSCfgJson:    cfg.loadAccountSCfg,
+		PrometheusAgentJSN: cfg.loadPrometheusAgentCfg,
 	}
 }
 
@@ -1535,6 +1580,8 @@ func (cfg *CGRConfig) reloadSections(sections ...string) {
 			cfg.rldChans[AccountSCfgJson] <- struct{}{}
 		case ActionSJson:
 			cfg.rldChans[ActionSJson] <- struct{}{}
+		case PrometheusAgentJSN:
+			cfg.rldChans[PrometheusAgentJSN] <- struct{}{}
 		}
 	}
 	return
@@ -1588,6 +1635,7 @@ func (cfg *CGRConfig) AsMapInterface(separator string) (mp map[string]interface{
 		CoreSCfgJson:       cfg.coreSCfg.AsMapInterface(),
 		ActionSJson:        cfg.actionSCfg.AsMapInterface(),
 		AccountSCfgJson:    cfg.accountSCfg.AsMapInterface(),
+		PrometheusAgentJSN: cfg.promAgentCfg.AsMapInterface(),
 	}
 }
 
@@ -1754,6 +1802,8 @@ func (cfg *CGRConfig) V1GetConfig(args *SectionWithOpts, reply *map[string]inter
 		mp = cfg.ActionSCfg().AsMapInterface()
 	case AccountSCfgJson:
 		mp = cfg.AccountSCfg().AsMapInterface()
+	case PrometheusAgentJSN:
+		mp = cfg.PrometheusAgentCfg().AsMapInterface()
 	default:
 		return errors.New("Invalid section")
 	}
@@ -1922,6 +1972,8 @@ func (cfg *CGRConfig) V1GetConfigAsJSON(args *SectionWithOpts, reply *string) (e
 		mp = cfg.CoreSCfg().AsMapInterface()
 	case AccountSCfgJson:
 		mp = cfg.AccountSCfg().AsMapInterface()
+	case PrometheusAgentJSN:
+		mp = cfg.PrometheusAgentCfg().AsMapInterface()
 	default:
 		return errors.New("Invalid section")
 	}
@@ -2021,6 +2073,7 @@ func (cfg *CGRConfig) Clone() (cln *CGRConfig) {
 		coreSCfg:         cfg.coreSCfg.Clone(),
 		actionSCfg:       cfg.actionSCfg.Clone(),
 		accountSCfg:      cfg.accountSCfg.Clone(),
+		promAgentCfg:     cfg.promAgentCfg.Clone(),
 
 		cacheDP: make(map[string]utils.MapStorage),
 	}
@@ -107,6 +107,7 @@ const CGRATES_CFG_JSON = `
 		"*rate_profiles":{"remote":false, "replicate":false},
 		"*action_profiles":{"remote":false, "replicate":false},
 		"*account_profiles":{"remote":false, "replicate":false},
+		"*route_breakers":{"remote":false, "replicate":false},
 		"*load_ids":{"remote":false, "replicate":false}, 
 		"*indexes":{"remote":false, "replicate":false}, 
 	},
@@ -120,15 +121,19 @@ const CGRATES_CFG_JSON = `
 		"redis_client_certificate":"",		// path to client certificate
 		"redis_client_key":"",				// path to client key
 		"redis_ca_certificate":"",			// path to CA certificate (populate for self-signed certificate otherwise let it empty)
+		"internal_db_dump_path": "",			// folder where the *internal database is persisted, empty to keep it only in memory
+		"internal_db_snapshot_interval": "1h",	// interval between the snapshots of the *internal database, 0 to write it only at shutdown
+		"internal_db_fsync_interval": "1s",		// interval to fsync the write-ahead log of the *internal database, 0 to fsync after each write, -1 to leave it to the OS
+		"internal_db_compact_size": 104857600,	// size in bytes of the write-ahead log after which a new snapshot is written, 0 to disable
 	}
 },
 
 
 "stor_db": {								// database used to store offline tariff plans and CDRs
-	"db_type": "*mysql",					// stor database type to use: <*mongo|*mysql|*postgres|*internal>
+	"db_type": "*mysql",					// stor database type to use: <*mongo|*mysql|*postgres|*sqlite|*internal>
 	"db_host": "127.0.0.1",					// the host to connect to
 	"db_port": 3306,						// the port to reach the stor_db
-	"db_name": "cgrates",					// stor database name
+	"db_name": "cgrates",					// stor database name, the database file path in case of *sqlite
 	"db_user": "cgrates",					// username to use when connecting to stor_db
 	"db_password": "",						// password to use when connecting to stor_db
 	"string_indexed_fields": [],			// indexes on cdrs table to speed up queries, used in case of *mongo and *internal
@@ -139,6 +144,10 @@ const CGRATES_CFG_JSON = `
 		"conn_max_lifetime": 0, 				// maximum amount of time in seconds a connection may be reused (0 for unlimited), not applying for mongo
 		"query_timeout":"10s",
 		"sslmode":"disable",					// sslmode in case of *postgres
+		"internal_db_dump_path": "",			// folder where the *internal database is persisted, empty to keep it only in memory
+		"internal_db_snapshot_interval": "1h",	// interval between the snapshots of the *internal database, 0 to write it only at shutdown
+		"internal_db_fsync_interval": "1s",		// interval to fsync the write-ahead log of the *internal database, 0 to fsync after each write, -1 to leave it to the OS
+		"internal_db_compact_size": 104857600,	// size in bytes of the write-ahead log after which a new snapshot is written, 0 to disable
 	},
 	"items":{
 		"*session_costs": {"remote":false, "replicate":false}, 
@@ -251,6 +260,9 @@ const CGRATES_CFG_JSON = `
 		"*thresholds": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},			// control thresholds caching
 		"*filters": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},				// control filters caching
 		"*route_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},		// control route profile caching
+		"*route_breakers": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},							// route circuit breakers, storage for the *internal DataDB
+		"*session_backups": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},							// active sessions backup, storage for the *internal DataDB
+		"*invoices": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},								// invoices, storage for the *internal DataDB
 		"*attribute_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},	// control attribute profile caching
 		"*charger_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},		// control charger profile caching
 		"*dispatcher_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},	// control dispatcher profile caching
@@ -274,9 +286,11 @@ const CGRATES_CFG_JSON = `
 		"*dispatcher_loads": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},							// control dispatcher load( in case of *ratio ConnParams is present)
 		"*dispatchers": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 								// control dispatcher interface
 		"*diameter_messages": {"limit": -1, "ttl": "3h", "static_ttl": false, "replicate": false},						// diameter messages caching
+		"*radius_packets": {"limit": -1, "ttl": "3h", "static_ttl": false, "replicate": false},						// radius packets caching, used for Disconnect-Request and CoA-Request
 		"*rpc_responses": {"limit": 0, "ttl": "2s", "static_ttl": false, "replicate": false},							// RPC responses caching
 		"*closed_sessions": {"limit": -1, "ttl": "10s", "static_ttl": false, "replicate": false},						// closed sessions cached for CDRs
 		"*event_charges": {"limit": -1, "ttl": "10s", "static_ttl": false, "replicate": false},							// events proccessed by ChargerS
+		"*account_charges": {"limit": -1, "ttl": "", "static_ttl": true, "replicate": false},						// charges debited by AccountS, storage for the *internal DataDB, the ttl is taken from accounts charges_ttl
 		"*cdr_ids": {"limit": -1, "ttl": "10m", "static_ttl": false, "replicate": false},								// protects CDRs against double-charging
 		"*load_ids": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},				// control the load_ids for items
 		"*rpc_connections": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},							// RPC connections caching
@@ -359,6 +373,7 @@ const CGRATES_CFG_JSON = `
 	"attributes_conns": [],					// connection to AttributeS for altering *raw CDRs, empty to disable attributes functionality: <""|*internal|$rpc_conns_id>
 	"thresholds_conns": [],					// connection to ThresholdS for CDR reporting, empty to disable thresholds functionality: <""|*internal|$rpc_conns_id>
 	"stats_conns": [],						// connections to StatS for CDR reporting, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
+	"routes_conns": [],						// connections to RouteS for reporting the call outcomes to the route circuit breakers: <""|*internal|$rpc_conns_id>
 	"online_cdr_exports":[],				// list of CDRE profiles to use for real-time CDR exports
 	"scheduler_conns": [],					// connections to SchedulerS in case of *dynaprepaid request
 	"ees_conns": [],						// connections to EventExporter
@@ -446,6 +461,7 @@ const CGRATES_CFG_JSON = `
 	"replication_conns": [],				// replicate sessions towards these session services
 	"debit_interval": "0s",					// interval to perform debits on.
 	"store_session_costs": false,			// enable storing of the session costs within CDRs
+	"backup_sessions": false,				// checkpoint the active sessions into DataDB on each debit so they can be restored after a restart
 	"default_usage":{						// the usage if the event is missing the usage field
 			"*any": "3h",
 			"*voice": "3h",
@@ -542,7 +558,10 @@ const CGRATES_CFG_JSON = `
 	"client_dictionaries": {									// per client path towards directory holding additional dictionaries to load (extra to RFC)
 		"*default": "/usr/share/cgrates/radius/dict/",			// key represents the client IP or catch-all <*default|$client_ip>
 	},
-	"sessions_conns": ["*internal"],
+	"client_da_addresses": {},									// address of the Dynamic Authorization server(RFC 5176) per client, defaults to the client IP on port 3799 <*default|$client_ip>
+	"sessions_conns": ["*internal"],							// connections to SessionS, *birpc_internal or bidirectional ones are needed for session disconnects <*internal|*birpc_internal|$rpc_conns_id>
+	"dmr_template": "",											// template used to build the Disconnect-Request sent to the client, empty to disable <""|$template_id>
+	"coa_template": "",											// template used to build the CoA-Request sent to the client on reauthorization, empty to disable <""|$template_id>
 	"request_processors": [										// request processors to be applied to Radius messages
 	],
 },
@@ -605,6 +624,7 @@ const CGRATES_CFG_JSON = `
 	"store_interval": "",					// dump cache regularly to dataDB, 0 - dump at start/shutdown: <""|$dur>
 	"store_uncompressed_limit": 0,			// used to compress data
 	"thresholds_conns": [],					// connections to ThresholdS for StatUpdates, empty to disable thresholds functionality: <""|*internal|$rpc_conns_id>
+	"routes_conns": [],						// connections to RouteS for reporting the call outcomes to the route circuit breakers: <""|*internal|$rpc_conns_id>
 	"indexed_selects": true,				// enable profile matching exclusively on indexes
 	//"string_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"prefix_indexed_fields": [],			// query indexes based on these fields for faster processing
@@ -635,7 +655,12 @@ const CGRATES_CFG_JSON = `
 	"resources_conns": [],					// connections to ResourceS for *res sorting, empty to disable functionality: <""|*internal|$rpc_conns_id>
 	"stats_conns": [],						// connections to StatS for *stats sorting, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
 	"rals_conns": [],						// connections to Rater for calculating cost, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
-	"default_ratio":1						// default ratio used in case of *load strategy
+	"default_ratio":1,						// default ratio used in case of *load strategy
+	"breaker_failures": 0,					// open the circuit breaker of a route after this number of failures within the breaker_window, 0 to disable
+	"breaker_min_asr": 0,					// open the circuit breaker of a route if the ASR within the breaker_window drops under this value, 0 to disable
+	"breaker_min_calls": 10,				// minimum number of calls within the breaker_window before checking the ASR
+	"breaker_window": "5m",					// the window in which the call outcomes are considered by the circuit breaker
+	"breaker_cooldown": "1m"				// time after which an opened route is probed again (half-open)
 },
 
 
@@ -977,6 +1002,7 @@ const CGRATES_CFG_JSON = `
 	"index_type": "*scorch",					// the type of index for the storage: <*scorch|*boltdb|*leveldb|*mossdb>
 	"ttl": "24h",								// time to wait before removing the API capture
 	"cleanup_interval": "1h",					// the interval we clean the db
+	"export_path": "/var/spool/cgrates/analyzers/exports",	// the folder where the API calls can be exported
 },
 
 
@@ -1017,6 +1043,17 @@ const CGRATES_CFG_JSON = `
 },
 
 
+"prometheus_agent": {
+	"enabled": false,						// enables the prometheus agent: <true|false>
+	"path": "/metrics",						// HTTP endpoint path where the metrics are exposed
+	"caches_conns": ["*internal"],			// connections to CacheS for cache metrics <""|*internal|$rpc_conns_id>
+	"cache_ids": [],						// cache partitions to export the metrics for, empty for all
+	"sessions_conns": [],					// connections to SessionS for active/passive session counts <""|*internal|$rpc_conns_id>
+	"stats_conns": [],						// connections to StatS for StatQueue metrics <""|*internal|$rpc_conns_id>
+	"stat_queue_ids": [],					// StatQueue IDs to export the metrics for <[tenant:]ID>
+},
+
+
 "templates": {
 	"*err": [
 			{"tag": "SessionId", "path": "*rep.Session-Id", "type": "*variable",
@@ -1126,6 +1163,7 @@ const CGRATES_CFG_JSON = `
 	"ees_conns": [],						// connections to Ees for exporting event <""|*internal|$rpc_conns_id>
 	"thresholds_conns": [],					// connections to ThresholdS for *reset_threshold action <""|*internal|$rpc_conns_id>
 	"stats_conns": [],						// connections to StatS for *reset_stat_queue action: <""|*internal|$rpc_conns_id>
+	"accounts_conns": [],					// connections to AccountS for balance actions: <""|*internal|$rpc_conns_id>
 	"tenants":[],							// List of tenants to operate on
 	"indexed_selects": true,				// enable profile matching exclusively on indexes
 	//"string_indexed_fields": [],			// query indexes based on these fields for faster processing
@@ -1147,6 +1185,8 @@ const CGRATES_CFG_JSON = `
 	"nested_fields": false,					// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
     "max_iterations": 1000,                 // maximum number of iterations
     "max_usage": "72h",                     // maximum time of usage
+	"store_charges": false,					// store the charges on debit so they can be refunded, can be overwritten with *accountsStoreCharges in APIOpts
+	"charges_ttl": "24h",					// how long the stored charges can be refunded, 0 to keep them until refunded
 },
 
 
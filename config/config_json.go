@@ -68,6 +68,7 @@ const (
 	APIBanCfgJson      = "apiban"
 	CoreSCfgJson       = "cores"
 	AccountSCfgJson    = "accounts"
+	PrometheusAgentJSN = "prometheus_agent"
 )
 
 var (
@@ -76,7 +77,7 @@ var (
 		KamailioAgentJSN, DA_JSN, RA_JSN, HttpAgentJson, DNSAgentJson, ATTRIBUTE_JSN, ChargerSCfgJson, RESOURCES_JSON, STATS_JSON,
 		THRESHOLDS_JSON, RouteSJson, LoaderJson, MAILER_JSN, SURETAX_JSON, CgrLoaderCfgJson, CgrMigratorCfgJson, DispatcherSJson,
 		AnalyzerCfgJson, ApierS, EEsJson, RateSJson, SIPAgentJson, DispatcherHJson, TemplatesJson, ConfigSJson, APIBanCfgJson, CoreSCfgJson,
-		ActionSJson, AccountSCfgJson}
+		ActionSJson, AccountSCfgJson, PrometheusAgentJSN}
 )
 
 // Loads the json config out of io.Reader, eg other sources than file, maybe over http
@@ -539,6 +540,18 @@ func (self CgrJsonCfg) SIPAgentJsonCfg() (*SIPAgentJsonCfg, error) {
 	return sipAgnt, nil
 }
 
+func (self CgrJsonCfg) PrometheusAgentJsonCfg() (*PrometheusAgentJsonCfg, error) {
+	rawCfg, hasKey := self[PrometheusAgentJSN]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(PrometheusAgentJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 func (self CgrJsonCfg) TemplateSJsonCfg() (map[string][]*FcTemplateJsonCfg, error) {
 	rawCfg, hasKey := self[TemplatesJson]
 	if !hasKey {
@@ -82,6 +82,27 @@ func TestDfCoreSJsonCfg(t *testing.T) {
 	}
 }
 
+func TestDfPrometheusAgentJsonCfg(t *testing.T) {
+	eCfg := &PrometheusAgentJsonCfg{
+		Enabled:        utils.BoolPointer(false),
+		Path:           utils.StringPointer("/metrics"),
+		Caches_conns:   &[]string{utils.MetaInternal},
+		Cache_ids:      &[]string{},
+		Sessions_conns: &[]string{},
+		Stats_conns:    &[]string{},
+		Stat_queue_ids: &[]string{},
+	}
+	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
+	if err != nil {
+		t.Error(err)
+	}
+	if gCfg, err := dfCgrJSONCfg.PrometheusAgentJsonCfg(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, gCfg) {
+		t.Errorf("expecting: %s, \nreceived: %s", utils.ToIJSON(eCfg), utils.ToIJSON(gCfg))
+	}
+}
+
 func TestCacheJsonCfg(t *testing.T) {
 	eCfg := &CacheJsonCfg{
 		Partitions: &map[string]*CacheParamJsonCfg{
@@ -142,6 +163,15 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheRouteProfiles: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CacheRouteBreakers: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
+			utils.CacheSessionBackups: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
+			utils.CacheInvoices: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 			utils.CacheAttributeProfiles: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
@@ -211,6 +241,9 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheDiameterMessages: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer("3h"), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
+			utils.CacheRadiusPackets: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer("3h"), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 			utils.CacheRPCResponses: {Limit: utils.IntPointer(0),
 				Ttl: utils.StringPointer("2s"), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
@@ -220,6 +253,9 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheEventCharges: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer("10s"), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
+			utils.CacheAccountCharges: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(utils.EmptyString), Static_ttl: utils.BoolPointer(true),
+				Replicate: utils.BoolPointer(false)},
 			utils.CacheCDRIDs: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer("10m"), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
@@ -371,15 +407,19 @@ func TestDfDataDbJsonCfg(t *testing.T) {
 		Replication_conns: &[]string{},
 		Remote_conns:      &[]string{},
 		Opts: map[string]interface{}{
-			utils.RedisSentinelNameCfg:       "",
-			utils.QueryTimeoutCfg:            "10s",
-			utils.RedisClusterCfg:            false,
-			utils.RedisClusterOnDownDelayCfg: "0",
-			utils.RedisClusterSyncCfg:        "5s",
-			utils.RedisTLS:                   false,
-			utils.RedisClientCertificate:     "",
-			utils.RedisClientKey:             "",
-			utils.RedisCACertificate:         "",
+			utils.RedisSentinelNameCfg:          "",
+			utils.QueryTimeoutCfg:               "10s",
+			utils.RedisClusterCfg:               false,
+			utils.RedisClusterOnDownDelayCfg:    "0",
+			utils.RedisClusterSyncCfg:           "5s",
+			utils.RedisTLS:                      false,
+			utils.RedisClientCertificate:        "",
+			utils.RedisClientKey:                "",
+			utils.RedisCACertificate:            "",
+			utils.InternalDBDumpPathCfg:         "",
+			utils.InternalDBSnapshotIntervalCfg: "1h",
+			utils.InternalDBFsyncIntervalCfg:    "1s",
+			utils.InternalDBCompactSizeCfg:      104857600.,
 		},
 		Items: &map[string]*ItemOptJson{
 			utils.MetaAccounts: {
@@ -478,6 +518,10 @@ func TestDfDataDbJsonCfg(t *testing.T) {
 				Replicate: utils.BoolPointer(false),
 				Remote:    utils.BoolPointer(false),
 			},
+			utils.MetaRouteBreakers: {
+				Replicate: utils.BoolPointer(false),
+				Remote:    utils.BoolPointer(false),
+			},
 			utils.MetaChargerProfiles: {
 				Replicate: utils.BoolPointer(false),
 				Remote:    utils.BoolPointer(false),
@@ -518,11 +562,15 @@ func TestDfStorDBJsonCfg(t *testing.T) {
 		String_indexed_fields: &[]string{},
 		Prefix_indexed_fields: &[]string{},
 		Opts: map[string]interface{}{
-			utils.QueryTimeoutCfg:    "10s",
-			utils.MaxOpenConnsCfg:    100.,
-			utils.MaxIdleConnsCfg:    10.,
-			utils.ConnMaxLifetimeCfg: 0.,
-			utils.SSLModeCfg:         utils.PostgressSSLModeDisable,
+			utils.QueryTimeoutCfg:               "10s",
+			utils.MaxOpenConnsCfg:               100.,
+			utils.MaxIdleConnsCfg:               10.,
+			utils.ConnMaxLifetimeCfg:            0.,
+			utils.SSLModeCfg:                    utils.PostgressSSLModeDisable,
+			utils.InternalDBDumpPathCfg:         "",
+			utils.InternalDBSnapshotIntervalCfg: "1h",
+			utils.InternalDBFsyncIntervalCfg:    "1s",
+			utils.InternalDBCompactSizeCfg:      104857600.,
 		},
 		Items: &map[string]*ItemOptJson{
 			utils.CacheTBLTPTimings: {
@@ -705,6 +753,7 @@ func TestDfCdrsJsonCfg(t *testing.T) {
 		Attributes_conns:     &[]string{},
 		Thresholds_conns:     &[]string{},
 		Stats_conns:          &[]string{},
+		Routes_conns:         &[]string{},
 		Online_cdr_exports:   &[]string{},
 		Scheduler_conns:      &[]string{},
 		Ees_conns:            &[]string{},
@@ -736,6 +785,7 @@ func TestSmgJsonCfg(t *testing.T) {
 		Replication_conns:     &[]string{},
 		Debit_interval:        utils.StringPointer("0s"),
 		Store_session_costs:   utils.BoolPointer(false),
+		Backup_sessions:       utils.BoolPointer(false),
 		Session_ttl:           utils.StringPointer("0s"),
 		Session_indexes:       &[]string{},
 		Client_protocol:       utils.Float64Pointer(1.0),
@@ -890,8 +940,11 @@ func TestRadiusAgentJsonCfg(t *testing.T) {
 		Client_dictionaries: utils.MapStringStringPointer(map[string]string{
 			utils.MetaDefault: "/usr/share/cgrates/radius/dict/",
 		}),
-		Sessions_conns:     &[]string{utils.MetaInternal},
-		Request_processors: &[]*ReqProcessorJsnCfg{},
+		Client_da_addresses: utils.MapStringStringPointer(map[string]string{}),
+		Sessions_conns:      &[]string{utils.MetaInternal},
+		Dmr_template:        utils.StringPointer(""),
+		Coa_template:        utils.StringPointer(""),
+		Request_processors:  &[]*ReqProcessorJsnCfg{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -1029,6 +1082,7 @@ func TestDfStatServiceJsonCfg(t *testing.T) {
 		Store_interval:           utils.StringPointer(""),
 		Store_uncompressed_limit: utils.IntPointer(0),
 		Thresholds_conns:         &[]string{},
+		Routes_conns:             &[]string{},
 		String_indexed_fields:    nil,
 		Prefix_indexed_fields:    &[]string{},
 		Suffix_indexed_fields:    &[]string{},
@@ -1079,6 +1133,11 @@ func TestDfRouteSJsonCfg(t *testing.T) {
 		Rals_conns:            &[]string{},
 		Default_ratio:         utils.IntPointer(1),
 		Nested_fields:         utils.BoolPointer(false),
+		Breaker_failures:      utils.IntPointer(0),
+		Breaker_min_asr:       utils.Float64Pointer(0),
+		Breaker_min_calls:     utils.IntPointer(10),
+		Breaker_window:        utils.StringPointer("5m"),
+		Breaker_cooldown:      utils.StringPointer("1m"),
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -1999,6 +2058,7 @@ func TestDfAnalyzerCfg(t *testing.T) {
 		Enabled:          utils.BoolPointer(false),
 		Cleanup_interval: utils.StringPointer("1h"),
 		Db_path:          utils.StringPointer("/var/spool/cgrates/analyzers"),
+		Export_path:      utils.StringPointer("/var/spool/cgrates/analyzers/exports"),
 		Index_type:       utils.StringPointer(utils.MetaScorch),
 		Ttl:              utils.StringPointer("24h"),
 	}
@@ -2427,6 +2487,7 @@ func TestDfActionSJsonCfg(t *testing.T) {
 		Ees_conns:             &[]string{},
 		Thresholds_conns:      &[]string{},
 		Stats_conns:           &[]string{},
+		Accounts_conns:        &[]string{},
 		Tenants:               &[]string{},
 		Indexed_selects:       utils.BoolPointer(true),
 		String_indexed_fields: nil,
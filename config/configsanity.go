@@ -86,6 +86,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.CDRs, connID)
 			}
 		}
+		for _, connID := range cfg.cdrsCfg.RouteSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.routeSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.RouteS, utils.CDRs)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.CDRs, connID)
+			}
+		}
 		for _, connID := range cfg.cdrsCfg.ThresholdSConns {
 			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.thresholdSCfg.Enabled {
 				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.ThresholdS, utils.CDRs)
@@ -132,6 +140,12 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			if !posibleLoaderTypes.Has(data.Type) {
 				return fmt.Errorf("<%s> unsupported data type %s", utils.LoaderS, data.Type)
 			}
+			if data.Format != utils.EmptyString && !possibleLoaderFormats.Has(data.Format) {
+				return fmt.Errorf("<%s> unsupported format %s for %s", utils.LoaderS, data.Format, data.Type)
+			}
+			if data.Format == utils.MetaXml && len(data.RootPath) == 0 {
+				return fmt.Errorf("<%s> %s for %s", utils.LoaderS, utils.NewErrMandatoryIeMissing(utils.RootPathCfg), data.Type)
+			}
 
 			for _, field := range data.Fields {
 				if field.Type != utils.MetaComposed && field.Type != utils.MetaString && field.Type != utils.MetaVariable {
@@ -322,13 +336,22 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				utils.RadiusAgent, utils.SessionS)
 		}
 		for _, connID := range cfg.radiusAgentCfg.SessionSConns {
-			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.sessionSCfg.Enabled {
+			isInternal := strings.HasPrefix(connID, utils.MetaInternal) || strings.HasPrefix(connID, rpcclient.BiRPCInternal)
+			if isInternal && !cfg.sessionSCfg.Enabled {
 				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.SessionS, utils.RadiusAgent)
 			}
-			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+			if _, has := cfg.rpcConns[connID]; !has && !isInternal {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RadiusAgent, connID)
 			}
 		}
+		for _, tmplID := range []string{cfg.radiusAgentCfg.DMRTemplate, cfg.radiusAgentCfg.CoATemplate} {
+			if tmplID == utils.EmptyString {
+				continue
+			}
+			if _, has := cfg.templates[tmplID]; !has {
+				return fmt.Errorf("<%s> template with id: <%s> not defined", utils.RadiusAgent, tmplID)
+			}
+		}
 		for _, req := range cfg.radiusAgentCfg.RequestProcessors {
 			for _, field := range req.RequestFields {
 				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
@@ -429,6 +452,34 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 		}
 	}
 
+	// PrometheusAgent checks
+	if cfg.promAgentCfg.Enabled {
+		if cfg.promAgentCfg.Path == utils.EmptyString {
+			return fmt.Errorf("<%s> %s", utils.PrometheusAgent, utils.NewErrMandatoryIeMissing(utils.PathCfg))
+		}
+		for _, connID := range cfg.promAgentCfg.CachesConns {
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.PrometheusAgent, connID)
+			}
+		}
+		for _, connID := range cfg.promAgentCfg.SessionSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.sessionSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.SessionS, utils.PrometheusAgent)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.PrometheusAgent, connID)
+			}
+		}
+		for _, connID := range cfg.promAgentCfg.StatSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.statsCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.StatService, utils.PrometheusAgent)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.PrometheusAgent, connID)
+			}
+		}
+	}
+
 	if cfg.attributeSCfg.Enabled {
 		if cfg.attributeSCfg.ProcessRuns < 1 {
 			return fmt.Errorf("<%s> process_runs needs to be bigger than 0", utils.AttributeS)
@@ -465,6 +516,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.StatS, connID)
 			}
 		}
+		for _, connID := range cfg.statsCfg.RouteSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.routeSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.RouteS, utils.StatS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.StatS, connID)
+			}
+		}
 	}
 	// RouteS checks
 	if cfg.routeSCfg.Enabled {
@@ -500,6 +559,15 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RouteS, connID)
 			}
 		}
+		if cfg.routeSCfg.BreakerEnabled() {
+			// without window the outcomes of a route would be kept forever
+			if cfg.routeSCfg.BreakerWindow <= 0 {
+				return fmt.Errorf("<%s> '%s' should be bigger than zero", utils.RouteS, utils.BreakerWindowCfg)
+			}
+			if cfg.routeSCfg.BreakerCooldown <= 0 {
+				return fmt.Errorf("<%s> '%s' should be bigger than zero", utils.RouteS, utils.BreakerCooldownCfg)
+			}
+		}
 	}
 	// Scheduler check connection with CDR Server
 	if cfg.schedulerCfg.Enabled {
@@ -553,7 +621,7 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				if rdr.FieldSep == utils.EmptyString {
 					return fmt.Errorf("<%s> empty FieldSep for reader with ID: %s", utils.ERs, rdr.ID)
 				}
-			case utils.MetaKafkajsonMap:
+			case utils.MetaKafkajsonMap, utils.MetaNATSjsonMap:
 				if rdr.RunDelay > 0 {
 					return fmt.Errorf("<%s> the RunDelay field can not be bigger than zero for reader with ID: %s", utils.ERs, rdr.ID)
 				}
@@ -601,12 +669,19 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				if exp.FieldSep == utils.EmptyString {
 					return fmt.Errorf("<%s> empty FieldSep for exporter with ID: %s", utils.EEs, exp.ID)
 				}
-			case utils.MetaFileFWV:
+			case utils.MetaFileFWV, utils.MetaFileJSON, utils.MetaFilePDF:
 				for _, dir := range []string{exp.ExportPath} {
 					if _, err := os.Stat(dir); err != nil && os.IsNotExist(err) {
 						return fmt.Errorf("<%s> nonexistent folder: %s for exporter with ID: %s", utils.EEs, dir, exp.ID)
 					}
 				}
+			case utils.MetaFileParquet, utils.MetaFileAvro:
+				if _, err := os.Stat(exp.ExportPath); err != nil && os.IsNotExist(err) {
+					return fmt.Errorf("<%s> nonexistent folder: %s for exporter with ID: %s", utils.EEs, exp.ExportPath, exp.ID)
+				}
+				if len(exp.ContentFields()) == 0 {
+					return fmt.Errorf("<%s> empty content fields for exporter with ID: %s", utils.EEs, exp.ID)
+				}
 			case utils.MetaSQL:
 				if len(exp.ContentFields()) == 0 {
 					return fmt.Errorf("<%s> empty content fields for exporter with ID: %s", utils.EEs, exp.ID)
@@ -19,6 +19,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -118,6 +119,18 @@ func TestConfigSanityCDRServer(t *testing.T) {
 	}
 	cfg.cdrsCfg.StatSConns = []string{}
 
+	cfg.cdrsCfg.RouteSConns = []string{utils.MetaInternal}
+	expected = "<RouteS> not enabled but requested by <CDRs> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.cdrsCfg.RouteSConns = []string{"test"}
+	expected = "<CDRs> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.cdrsCfg.RouteSConns = []string{}
+
 	cfg.cdrsCfg.ThresholdSConns = []string{utils.MetaInternal}
 	expected = "<ThresholdS> not enabled but requested by <CDRs> component"
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
@@ -184,6 +197,28 @@ func TestConfigSanityLoaders(t *testing.T) {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
 
+	cfg.loaderCfg = LoaderSCfgs{
+		&LoaderSCfg{
+			Enabled:  true,
+			TpInDir:  "/",
+			TpOutDir: "/",
+			Data: []*LoaderDataType{{
+				Type:   utils.MetaStats,
+				Format: "*yaml",
+			}},
+		},
+	}
+	expected = "<LoaderS> unsupported format *yaml for *stats"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+
+	cfg.loaderCfg[0].Data[0].Format = utils.MetaXml
+	expected = "<LoaderS> MANDATORY_IE_MISSING: [root_path] for *stats"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+
 	cfg.loaderCfg = LoaderSCfgs{
 		&LoaderSCfg{
 			Enabled:  true,
@@ -527,6 +562,12 @@ func TestConfigSanityRadiusAgent(t *testing.T) {
 	}
 
 	cfg.rpcConns["test"] = nil
+	cfg.radiusAgentCfg.DMRTemplate = "*dmr"
+	expected = "<RadiusAgent> template with id: <*dmr> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.radiusAgentCfg.DMRTemplate = utils.EmptyString
 	expected = "<RadiusAgent> MANDATORY_IE_MISSING: [Path] for cgrates at SessionId"
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
@@ -539,6 +580,49 @@ func TestConfigSanityRadiusAgent(t *testing.T) {
 	}
 }
 
+func TestConfigSanityPrometheusAgent(t *testing.T) {
+	cfg = NewDefaultCGRConfig()
+	cfg.promAgentCfg = &PrometheusAgentCfg{
+		Enabled: true,
+	}
+	expected := "<PrometheusAgent> MANDATORY_IE_MISSING: [path]"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.promAgentCfg.Path = "/metrics"
+	cfg.promAgentCfg.CachesConns = []string{"test"}
+	expected = "<PrometheusAgent> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.promAgentCfg.CachesConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)}
+	cfg.promAgentCfg.SessionSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)}
+	expected = "<SessionS> not enabled but requested by <PrometheusAgent> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.promAgentCfg.SessionSConns = []string{"test"}
+	expected = "<PrometheusAgent> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.promAgentCfg.SessionSConns = nil
+	cfg.promAgentCfg.StatSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)}
+	expected = "<StatS> not enabled but requested by <PrometheusAgent> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.promAgentCfg.StatSConns = []string{"test"}
+	expected = "<PrometheusAgent> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.rpcConns["test"] = nil
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestConfigSanityDNSAgent(t *testing.T) {
 	cfg = NewDefaultCGRConfig()
 	cfg.dnsAgentCfg = &DNSAgentCfg{
@@ -757,6 +841,18 @@ func TestConfigSanityStatS(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.statsCfg.ThresholdSConns = []string{}
+
+	cfg.statsCfg.RouteSConns = []string{utils.MetaInternal}
+	expected = "<RouteS> not enabled but requested by <Stats> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.statsCfg.RouteSConns = []string{"test"}
+	expected = "<Stats> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 }
 
 func TestConfigSanityRouteS(t *testing.T) {
@@ -811,6 +907,22 @@ func TestConfigSanityRouteS(t *testing.T) {
 	}
 	cfg.routeSCfg.RALsConns = []string{}
 
+	cfg.routeSCfg.BreakerFailures = 3
+	cfg.routeSCfg.BreakerWindow = 0
+	expected = "<RouteS> 'breaker_window' should be bigger than zero"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.routeSCfg.BreakerWindow = 5 * time.Minute
+	cfg.routeSCfg.BreakerCooldown = 0
+	expected = "<RouteS> 'breaker_cooldown' should be bigger than zero"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.routeSCfg.BreakerCooldown = time.Minute
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
 }
 
 func TestConfigSanityScheduler(t *testing.T) {
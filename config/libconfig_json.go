@@ -146,6 +146,7 @@ type CdrsJsonCfg struct {
 	Attributes_conns     *[]string
 	Thresholds_conns     *[]string
 	Stats_conns          *[]string
+	Routes_conns         *[]string
 	Online_cdr_exports   *[]string
 	Scheduler_conns      *[]string
 	Ees_conns            *[]string
@@ -224,6 +225,7 @@ type SessionSJsonCfg struct {
 	Attributes_conns       *[]string
 	Debit_interval         *string
 	Store_session_costs    *bool
+	Backup_sessions        *bool
 	Session_ttl            *string
 	Session_ttl_max_delay  *string
 	Session_ttl_last_used  *string
@@ -354,7 +356,10 @@ type RadiusAgentJsonCfg struct {
 	Listen_acct         *string
 	Client_secrets      *map[string]string
 	Client_dictionaries *map[string]string
+	Client_da_addresses *map[string]string
 	Sessions_conns      *[]string
+	Dmr_template        *string
+	Coa_template        *string
 	Timezone            *string
 	Request_processors  *[]*ReqProcessorJsnCfg
 }
@@ -433,6 +438,7 @@ type StatServJsonCfg struct {
 	Store_interval           *string
 	Store_uncompressed_limit *int
 	Thresholds_conns         *[]string
+	Routes_conns             *[]string
 	String_indexed_fields    *[]string
 	Prefix_indexed_fields    *[]string
 	Suffix_indexed_fields    *[]string
@@ -463,11 +469,19 @@ type RouteSJsonCfg struct {
 	Stats_conns           *[]string
 	Rals_conns            *[]string
 	Default_ratio         *int
+	Breaker_failures      *int
+	Breaker_min_asr       *float64
+	Breaker_min_calls     *int
+	Breaker_window        *string
+	Breaker_cooldown      *string
 }
 
 type LoaderJsonDataType struct {
 	Type      *string
 	File_name *string
+	Format    *string
+	Sheet     *string
+	Root_path *string
 	Flags     *[]string
 	Fields    *[]*FcTemplateJsonCfg
 }
@@ -607,6 +621,7 @@ type AnalyzerSJsonCfg struct {
 	Index_type       *string
 	Ttl              *string
 	Cleanup_interval *string
+	Export_path      *string
 }
 
 type ApierJsonCfg struct {
@@ -651,6 +666,17 @@ type SIPAgentJsonCfg struct {
 	Request_processors   *[]*ReqProcessorJsnCfg
 }
 
+// PrometheusAgent config section
+type PrometheusAgentJsonCfg struct {
+	Enabled        *bool
+	Path           *string
+	Caches_conns   *[]string
+	Cache_ids      *[]string
+	Sessions_conns *[]string
+	Stats_conns    *[]string
+	Stat_queue_ids *[]string
+}
+
 type ConfigSCfgJson struct {
 	Enabled  *bool
 	Url      *string
@@ -676,6 +702,7 @@ type ActionSJsonCfg struct {
 	Ees_conns             *[]string
 	Thresholds_conns      *[]string
 	Stats_conns           *[]string
+	Accounts_conns        *[]string
 	Tenants               *[]string
 	Indexed_selects       *bool
 	String_indexed_fields *[]string
@@ -697,4 +724,6 @@ type AccountSJsonCfg struct {
 	Nested_fields         *bool // applies when indexed fields is not defined
 	Max_iterations        *int
 	Max_usage             *string
+	Store_charges         *bool
+	Charges_ttl           *string
 }
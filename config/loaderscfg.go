@@ -83,6 +83,9 @@ type LoaderSCfg struct {
 type LoaderDataType struct {
 	Type     string
 	Filename string
+	Format   string              // format of the files: <*csv|*json|*xml|*xlsx>, *csv if empty
+	Sheet    string              // sheet read out of the *xlsx files, the first one if empty
+	RootPath utils.HierarchyPath // path towards the records in the *json and *xml files
 	Flags    utils.FlagsWithParams
 	Fields   []*FCTemplate
 }
@@ -97,6 +100,15 @@ func (lData *LoaderDataType) loadFromJSONCfg(jsnCfg *LoaderJsonDataType, msgTemp
 	if jsnCfg.File_name != nil {
 		lData.Filename = *jsnCfg.File_name
 	}
+	if jsnCfg.Format != nil {
+		lData.Format = *jsnCfg.Format
+	}
+	if jsnCfg.Sheet != nil {
+		lData.Sheet = *jsnCfg.Sheet
+	}
+	if jsnCfg.Root_path != nil {
+		lData.RootPath = utils.ParseHierarchyPath(*jsnCfg.Root_path, utils.EmptyString)
+	}
 	if jsnCfg.Flags != nil {
 		lData.Flags = utils.FlagsWithParamsFromSlice(*jsnCfg.Flags)
 	}
@@ -178,6 +190,9 @@ func (lData LoaderDataType) Clone() (cln *LoaderDataType) {
 	cln = &LoaderDataType{
 		Type:     lData.Type,
 		Filename: lData.Filename,
+		Format:   lData.Format,
+		Sheet:    lData.Sheet,
+		RootPath: lData.RootPath.Clone(),
 		Flags:    lData.Flags.Clone(),
 		Fields:   make([]*FCTemplate, len(lData.Fields)),
 	}
@@ -218,6 +233,15 @@ func (lData *LoaderDataType) AsMapInterface(separator string) (initialMP map[str
 		utils.FilenameCfg: lData.Filename,
 		utils.FlagsCfg:    lData.Flags.SliceFlags(),
 	}
+	if lData.Format != utils.EmptyString {
+		initialMP[utils.FormatCfg] = lData.Format
+	}
+	if lData.Sheet != utils.EmptyString {
+		initialMP[utils.SheetCfg] = lData.Sheet
+	}
+	if len(lData.RootPath) != 0 {
+		initialMP[utils.RootPathCfg] = lData.RootPath.AsString(utils.NestingSep, false)
+	}
 
 	fields := make([]map[string]interface{}, len(lData.Fields))
 	for i, item := range lData.Fields {
@@ -203,6 +203,61 @@ func TestLoaderSCfgloadFromJsonCfgCase5(t *testing.T) {
 	}
 }
 
+func TestLoaderSCfgloadFromJsonCfgFormat(t *testing.T) {
+	cfg := &LoaderJsonCfg{
+		Data: &[]*LoaderJsonDataType{
+			{
+				Type:      utils.StringPointer(utils.MetaRateProfiles),
+				File_name: utils.StringPointer("Rates.xlsx"),
+				Format:    utils.StringPointer(utils.MetaXLSX),
+				Sheet:     utils.StringPointer("Rates"),
+			},
+			{
+				Type:      utils.StringPointer(utils.MetaAttributes),
+				File_name: utils.StringPointer("Attributes.xml"),
+				Format:    utils.StringPointer(utils.MetaXml),
+				Root_path: utils.StringPointer("attributes.attribute"),
+			},
+		},
+	}
+	expected := []*LoaderDataType{
+		{
+			Type:     utils.MetaRateProfiles,
+			Filename: "Rates.xlsx",
+			Format:   utils.MetaXLSX,
+			Sheet:    "Rates",
+		},
+		{
+			Type:     utils.MetaAttributes,
+			Filename: "Attributes.xml",
+			Format:   utils.MetaXml,
+			RootPath: utils.HierarchyPath{"attributes", "attribute"},
+		},
+	}
+	jsonCfg := NewDefaultCGRConfig()
+	if err = jsonCfg.loaderCfg[0].loadFromJSONCfg(cfg, jsonCfg.templates, jsonCfg.generalCfg.RSRSep); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(expected, jsonCfg.loaderCfg[0].Data) {
+		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(expected), utils.ToJSON(jsonCfg.loaderCfg[0].Data))
+	}
+	if rcv := jsonCfg.loaderCfg[0].Clone(); rcv.Data[0].Sheet != expected[0].Sheet ||
+		rcv.Data[1].Format != expected[1].Format ||
+		!reflect.DeepEqual(rcv.Data[1].RootPath, expected[1].RootPath) {
+		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(expected), utils.ToJSON(rcv.Data))
+	}
+	expMp := map[string]interface{}{
+		utils.TypeCf:      utils.MetaAttributes,
+		utils.FilenameCfg: "Attributes.xml",
+		utils.FormatCfg:   utils.MetaXml,
+		utils.RootPathCfg: "attributes.attribute",
+		utils.FlagsCfg:    expected[1].Flags.SliceFlags(),
+		utils.FieldsCfg:   []map[string]interface{}{},
+	}
+	if rcv := expected[1].AsMapInterface(utils.InfieldSep); !reflect.DeepEqual(expMp, rcv) {
+		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(expMp), utils.ToJSON(rcv))
+	}
+}
+
 func TestLoaderSCfgloadFromJsonCfgCase6(t *testing.T) {
 	cfg := &LoaderJsonCfg{
 		Data: &[]*LoaderJsonDataType{nil},
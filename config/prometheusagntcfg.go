@@ -0,0 +1,161 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// PrometheusAgentCfg the config for the PrometheusAgent
+type PrometheusAgentCfg struct {
+	Enabled       bool
+	Path          string
+	CachesConns   []string
+	CacheIDs      []string
+	SessionSConns []string
+	StatSConns    []string
+	StatQueueIDs  []string
+}
+
+func (pa *PrometheusAgentCfg) loadFromJSONCfg(jsnCfg *PrometheusAgentJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		pa.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Path != nil {
+		pa.Path = *jsnCfg.Path
+	}
+	if jsnCfg.Caches_conns != nil {
+		pa.CachesConns = make([]string, len(*jsnCfg.Caches_conns))
+		for idx, connID := range *jsnCfg.Caches_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			pa.CachesConns[idx] = connID
+			if connID == utils.MetaInternal {
+				pa.CachesConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)
+			}
+		}
+	}
+	if jsnCfg.Cache_ids != nil {
+		pa.CacheIDs = make([]string, len(*jsnCfg.Cache_ids))
+		copy(pa.CacheIDs, *jsnCfg.Cache_ids)
+	}
+	if jsnCfg.Sessions_conns != nil {
+		pa.SessionSConns = make([]string, len(*jsnCfg.Sessions_conns))
+		for idx, connID := range *jsnCfg.Sessions_conns {
+			pa.SessionSConns[idx] = connID
+			if connID == utils.MetaInternal {
+				pa.SessionSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)
+			}
+		}
+	}
+	if jsnCfg.Stats_conns != nil {
+		pa.StatSConns = make([]string, len(*jsnCfg.Stats_conns))
+		for idx, connID := range *jsnCfg.Stats_conns {
+			pa.StatSConns[idx] = connID
+			if connID == utils.MetaInternal {
+				pa.StatSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)
+			}
+		}
+	}
+	if jsnCfg.Stat_queue_ids != nil {
+		pa.StatQueueIDs = make([]string, len(*jsnCfg.Stat_queue_ids))
+		copy(pa.StatQueueIDs, *jsnCfg.Stat_queue_ids)
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (pa *PrometheusAgentCfg) AsMapInterface() (initialMP map[string]interface{}) {
+	initialMP = map[string]interface{}{
+		utils.EnabledCfg: pa.Enabled,
+		utils.PathCfg:    pa.Path,
+	}
+	if pa.CacheIDs != nil {
+		cacheIDs := make([]string, len(pa.CacheIDs))
+		copy(cacheIDs, pa.CacheIDs)
+		initialMP[utils.CacheIDsCfg] = cacheIDs
+	}
+	if pa.StatQueueIDs != nil {
+		statQueueIDs := make([]string, len(pa.StatQueueIDs))
+		copy(statQueueIDs, pa.StatQueueIDs)
+		initialMP[utils.StatQueueIDsCfg] = statQueueIDs
+	}
+	if pa.CachesConns != nil {
+		cachesConns := make([]string, len(pa.CachesConns))
+		for i, item := range pa.CachesConns {
+			cachesConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches) {
+				cachesConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.CachesConnsCfg] = cachesConns
+	}
+	if pa.SessionSConns != nil {
+		sessionSConns := make([]string, len(pa.SessionSConns))
+		for i, item := range pa.SessionSConns {
+			sessionSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS) {
+				sessionSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.SessionSConnsCfg] = sessionSConns
+	}
+	if pa.StatSConns != nil {
+		statSConns := make([]string, len(pa.StatSConns))
+		for i, item := range pa.StatSConns {
+			statSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats) {
+				statSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.StatSConnsCfg] = statSConns
+	}
+	return
+}
+
+// Clone returns a deep copy of PrometheusAgentCfg
+func (pa PrometheusAgentCfg) Clone() (cln *PrometheusAgentCfg) {
+	cln = &PrometheusAgentCfg{
+		Enabled: pa.Enabled,
+		Path:    pa.Path,
+	}
+	if pa.CachesConns != nil {
+		cln.CachesConns = make([]string, len(pa.CachesConns))
+		copy(cln.CachesConns, pa.CachesConns)
+	}
+	if pa.CacheIDs != nil {
+		cln.CacheIDs = make([]string, len(pa.CacheIDs))
+		copy(cln.CacheIDs, pa.CacheIDs)
+	}
+	if pa.SessionSConns != nil {
+		cln.SessionSConns = make([]string, len(pa.SessionSConns))
+		copy(cln.SessionSConns, pa.SessionSConns)
+	}
+	if pa.StatSConns != nil {
+		cln.StatSConns = make([]string, len(pa.StatSConns))
+		copy(cln.StatSConns, pa.StatSConns)
+	}
+	if pa.StatQueueIDs != nil {
+		cln.StatQueueIDs = make([]string, len(pa.StatQueueIDs))
+		copy(cln.StatQueueIDs, pa.StatQueueIDs)
+	}
+	return
+}
@@ -0,0 +1,112 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestPrometheusAgentCfgloadFromJsonCfg(t *testing.T) {
+	var pa, expected PrometheusAgentCfg
+	if err := pa.loadFromJSONCfg(nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(pa, expected) {
+		t.Errorf("Expected: %+v ,received: %+v", expected, pa)
+	}
+	cfgJSONStr := `{
+		"prometheus_agent": {
+			"enabled": true,
+			"path": "/prometheus",
+			"caches_conns": ["*internal", "*conn1"],
+			"cache_ids": ["*attribute_profiles"],
+			"sessions_conns": ["*internal"],
+			"stats_conns": ["*internal"],
+			"stat_queue_ids": ["cgrates.org:SQ_1", "SQ_2"],
+		},
+}`
+	expected = PrometheusAgentCfg{
+		Enabled:       true,
+		Path:          "/prometheus",
+		CachesConns:   []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches), "*conn1"},
+		CacheIDs:      []string{utils.CacheAttributeProfiles},
+		SessionSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		StatSConns:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)},
+		StatQueueIDs:  []string{"cgrates.org:SQ_1", "SQ_2"},
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnPaCfg, err := jsnCfg.PrometheusAgentJsonCfg(); err != nil {
+		t.Error(err)
+	} else if err = pa.loadFromJSONCfg(jsnPaCfg); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, pa) {
+		t.Errorf("Expected: %+v , received: %+v", utils.ToJSON(expected), utils.ToJSON(pa))
+	}
+}
+
+func TestPrometheusAgentCfgAsMapInterface(t *testing.T) {
+	cfgJSONStr := `{
+		"prometheus_agent": {
+			"enabled": true,
+			"caches_conns": ["*internal", "*conn1"],
+			"sessions_conns": ["*internal"],
+			"stats_conns": ["*conn1"],
+			"stat_queue_ids": ["SQ_1"],
+		},
+}`
+	eMap := map[string]interface{}{
+		utils.EnabledCfg:       true,
+		utils.PathCfg:          "/metrics",
+		utils.CachesConnsCfg:   []string{utils.MetaInternal, "*conn1"},
+		utils.CacheIDsCfg:      []string{},
+		utils.SessionSConnsCfg: []string{utils.MetaInternal},
+		utils.StatSConnsCfg:    []string{"*conn1"},
+		utils.StatQueueIDsCfg:  []string{"SQ_1"},
+	}
+	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
+		t.Error(err)
+	} else if rcv := cgrCfg.promAgentCfg.AsMapInterface(); !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("Expected: %+v\nReceived: %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+}
+
+func TestPrometheusAgentCfgClone(t *testing.T) {
+	pa := &PrometheusAgentCfg{
+		Enabled:       true,
+		Path:          "/metrics",
+		CachesConns:   []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)},
+		CacheIDs:      []string{utils.CacheAttributeProfiles},
+		SessionSConns: []string{"*conn1"},
+		StatSConns:    []string{"*conn1"},
+		StatQueueIDs:  []string{"SQ_1"},
+	}
+	rcv := pa.Clone()
+	if !reflect.DeepEqual(pa, rcv) {
+		t.Errorf("Expected: %+v\nReceived: %+v", utils.ToJSON(pa), utils.ToJSON(rcv))
+	}
+	if rcv.StatQueueIDs[0] = ""; pa.StatQueueIDs[0] != "SQ_1" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if rcv.CachesConns[0] = ""; pa.CachesConns[0] != utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches) {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+}
@@ -34,7 +34,10 @@ func TestRadiusAgentCfgloadFromJsonCfgCase1(t *testing.T) {
 		Listen_acct:         utils.StringPointer("127.0.0.1:1813"),
 		Client_secrets:      &map[string]string{utils.MetaDefault: "CGRateS.org"},
 		Client_dictionaries: &map[string]string{utils.MetaDefault: "/usr/share/cgrates/radius/dict/"},
-		Sessions_conns:      &[]string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		Client_da_addresses: &map[string]string{"127.0.0.1": "127.0.0.1:3799"},
+		Sessions_conns:      &[]string{rpcclient.BiRPCInternal},
+		Dmr_template:        utils.StringPointer("*dmr"),
+		Coa_template:        utils.StringPointer("*coa"),
 		Request_processors: &[]*ReqProcessorJsnCfg{
 			{
 				ID:             utils.StringPointer("OutboundAUTHDryRun"),
@@ -63,7 +66,10 @@ func TestRadiusAgentCfgloadFromJsonCfgCase1(t *testing.T) {
 		ListenAcct:         "127.0.0.1:1813",
 		ClientSecrets:      map[string]string{utils.MetaDefault: "CGRateS.org"},
 		ClientDictionaries: map[string]string{utils.MetaDefault: "/usr/share/cgrates/radius/dict/"},
-		SessionSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		ClientDaAddresses:  map[string]string{"127.0.0.1": "127.0.0.1:3799"},
+		SessionSConns:      []string{utils.ConcatenatedKey(rpcclient.BiRPCInternal, utils.MetaSessionS)},
+		DMRTemplate:        "*dmr",
+		CoATemplate:        "*coa",
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:            "OutboundAUTHDryRun",
@@ -155,7 +161,11 @@ func TestRadiusAgentCfgAsMapInterface(t *testing.T) {
 	     "client_dictionaries": {									
 	    	"*default": "/usr/share/cgrates/",			
 	     },
+	     "client_da_addresses": {
+	    	"127.0.0.1": "127.0.0.1:3799",
+	     },
 	     "sessions_conns": ["*birpc_internal", "*conn1","*conn2"],
+	     "dmr_template": "*dmr",
          "request_processors": [
 			{
 				"id": "OutboundAUTHDryRun",
@@ -181,7 +191,12 @@ func TestRadiusAgentCfgAsMapInterface(t *testing.T) {
 		utils.ClientDictionariesCfg: map[string]string{
 			utils.MetaDefault: "/usr/share/cgrates/",
 		},
+		utils.ClientDaAddressesCfg: map[string]string{
+			"127.0.0.1": "127.0.0.1:3799",
+		},
 		utils.SessionSConnsCfg: []string{rpcclient.BiRPCInternal, "*conn1", "*conn2"},
+		utils.DMRTemplateCfg:   "*dmr",
+		utils.CoATemplateCfg:   "",
 		utils.RequestProcessorsCfg: []map[string]interface{}{
 			{
 				utils.IDCfg:            "OutboundAUTHDryRun",
@@ -218,7 +233,10 @@ func TestRadiusAgentCfgAsMapInterface1(t *testing.T) {
 		utils.ClientDictionariesCfg: map[string]string{
 			utils.MetaDefault: "/usr/share/cgrates/radius/dict/",
 		},
+		utils.ClientDaAddressesCfg: map[string]string{},
 		utils.SessionSConnsCfg:     []string{"*internal"},
+		utils.DMRTemplateCfg:       "",
+		utils.CoATemplateCfg:       "",
 		utils.RequestProcessorsCfg: []map[string]interface{}{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
@@ -236,7 +254,10 @@ func TestRadiusAgentCfgClone(t *testing.T) {
 		ListenAcct:         "127.0.0.1:1813",
 		ClientSecrets:      map[string]string{utils.MetaDefault: "CGRateS.org"},
 		ClientDictionaries: map[string]string{utils.MetaDefault: "/usr/share/cgrates/radius/dict/"},
+		ClientDaAddresses:  map[string]string{"127.0.0.1": "127.0.0.1:3799"},
 		SessionSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
+		DMRTemplate:        "*dmr",
+		CoATemplate:        "*coa",
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:            "OutboundAUTHDryRun",
@@ -274,4 +295,7 @@ func TestRadiusAgentCfgClone(t *testing.T) {
 	if rcv.ClientDictionaries[utils.MetaDefault] = ""; ban.ClientDictionaries[utils.MetaDefault] != "/usr/share/cgrates/radius/dict/" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.ClientDaAddresses["127.0.0.1"] = ""; ban.ClientDaAddresses["127.0.0.1"] != "127.0.0.1:3799" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -35,6 +37,16 @@ type RouteSCfg struct {
 	RALsConns           []string
 	DefaultRatio        int
 	NestedFields        bool
+	BreakerFailures     int           // open the circuit breaker of a route after this number of failures within the window
+	BreakerMinASR       float64       // open the circuit breaker of a route if the ASR within the window drops under this value
+	BreakerMinCalls     int           // minimum number of calls within the window before checking the ASR
+	BreakerWindow       time.Duration // the window in which the call outcomes are considered
+	BreakerCooldown     time.Duration // time after which an opened route is probed again
+}
+
+// BreakerEnabled returns true if the circuit breaker is configured
+func (rts *RouteSCfg) BreakerEnabled() bool {
+	return rts.BreakerFailures > 0 || rts.BreakerMinASR > 0
 }
 
 func (rts *RouteSCfg) loadFromJSONCfg(jsnCfg *RouteSJsonCfg) (err error) {
@@ -114,16 +126,46 @@ func (rts *RouteSCfg) loadFromJSONCfg(jsnCfg *RouteSJsonCfg) (err error) {
 	if jsnCfg.Nested_fields != nil {
 		rts.NestedFields = *jsnCfg.Nested_fields
 	}
+	if jsnCfg.Breaker_failures != nil {
+		rts.BreakerFailures = *jsnCfg.Breaker_failures
+	}
+	if jsnCfg.Breaker_min_asr != nil {
+		rts.BreakerMinASR = *jsnCfg.Breaker_min_asr
+	}
+	if jsnCfg.Breaker_min_calls != nil {
+		rts.BreakerMinCalls = *jsnCfg.Breaker_min_calls
+	}
+	if jsnCfg.Breaker_window != nil {
+		if rts.BreakerWindow, err = utils.ParseDurationWithNanosecs(*jsnCfg.Breaker_window); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Breaker_cooldown != nil {
+		if rts.BreakerCooldown, err = utils.ParseDurationWithNanosecs(*jsnCfg.Breaker_cooldown); err != nil {
+			return
+		}
+	}
 	return nil
 }
 
 // AsMapInterface returns the config as a map[string]interface{}
 func (rts *RouteSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.EnabledCfg:        rts.Enabled,
-		utils.IndexedSelectsCfg: rts.IndexedSelects,
-		utils.DefaultRatioCfg:   rts.DefaultRatio,
-		utils.NestedFieldsCfg:   rts.NestedFields,
+		utils.EnabledCfg:         rts.Enabled,
+		utils.IndexedSelectsCfg:  rts.IndexedSelects,
+		utils.DefaultRatioCfg:    rts.DefaultRatio,
+		utils.NestedFieldsCfg:    rts.NestedFields,
+		utils.BreakerFailuresCfg: rts.BreakerFailures,
+		utils.BreakerMinASRCfg:   rts.BreakerMinASR,
+		utils.BreakerMinCallsCfg: rts.BreakerMinCalls,
+		utils.BreakerWindowCfg:   utils.EmptyString,
+		utils.BreakerCooldownCfg: utils.EmptyString,
+	}
+	if rts.BreakerWindow != 0 {
+		initialMP[utils.BreakerWindowCfg] = rts.BreakerWindow.String()
+	}
+	if rts.BreakerCooldown != 0 {
+		initialMP[utils.BreakerCooldownCfg] = rts.BreakerCooldown.String()
 	}
 	if rts.StringIndexedFields != nil {
 		stringIndexedFields := make([]string, len(*rts.StringIndexedFields))
@@ -192,10 +234,15 @@ func (rts *RouteSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 // Clone returns a deep copy of RouteSCfg
 func (rts RouteSCfg) Clone() (cln *RouteSCfg) {
 	cln = &RouteSCfg{
-		Enabled:        rts.Enabled,
-		IndexedSelects: rts.IndexedSelects,
-		DefaultRatio:   rts.DefaultRatio,
-		NestedFields:   rts.NestedFields,
+		Enabled:         rts.Enabled,
+		IndexedSelects:  rts.IndexedSelects,
+		DefaultRatio:    rts.DefaultRatio,
+		NestedFields:    rts.NestedFields,
+		BreakerFailures: rts.BreakerFailures,
+		BreakerMinASR:   rts.BreakerMinASR,
+		BreakerMinCalls: rts.BreakerMinCalls,
+		BreakerWindow:   rts.BreakerWindow,
+		BreakerCooldown: rts.BreakerCooldown,
 	}
 	if rts.AttributeSConns != nil {
 		cln.AttributeSConns = make([]string, len(rts.AttributeSConns))
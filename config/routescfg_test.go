@@ -20,6 +20,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -37,6 +38,11 @@ func TestRouteSCfgloadFromJsonCfg(t *testing.T) {
 		Rals_conns:            &[]string{utils.MetaInternal, "conn1"},
 		Default_ratio:         utils.IntPointer(10),
 		Nested_fields:         utils.BoolPointer(true),
+		Breaker_failures:      utils.IntPointer(5),
+		Breaker_min_asr:       utils.Float64Pointer(40),
+		Breaker_min_calls:     utils.IntPointer(20),
+		Breaker_window:        utils.StringPointer("10m"),
+		Breaker_cooldown:      utils.StringPointer("30s"),
 	}
 	expected := &RouteSCfg{
 		Enabled:             true,
@@ -50,6 +56,11 @@ func TestRouteSCfgloadFromJsonCfg(t *testing.T) {
 		RALsConns:           []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResponder), "conn1"},
 		DefaultRatio:        10,
 		NestedFields:        true,
+		BreakerFailures:     5,
+		BreakerMinASR:       40,
+		BreakerMinCalls:     20,
+		BreakerWindow:       10 * time.Minute,
+		BreakerCooldown:     30 * time.Second,
 	}
 	jsonCfg := NewDefaultCGRConfig()
 	if err = jsonCfg.routeSCfg.loadFromJSONCfg(cfgJSON); err != nil {
@@ -74,6 +85,11 @@ func TestRouteSCfgAsMapInterface(t *testing.T) {
 		utils.StatSConnsCfg:          []string{},
 		utils.RALsConnsCfg:           []string{},
 		utils.DefaultRatioCfg:        1,
+		utils.BreakerFailuresCfg:     0,
+		utils.BreakerMinASRCfg:       0.,
+		utils.BreakerMinCallsCfg:     10,
+		utils.BreakerWindowCfg:       "5m0s",
+		utils.BreakerCooldownCfg:     "1m0s",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -96,6 +112,11 @@ func TestRouteSCfgAsMapInterface1(t *testing.T) {
 			"stats_conns": ["*internal:*stats", "conn1"],
 			"rals_conns": ["*internal:*responder", "conn1"],
 			"default_ratio":2,
+			"breaker_failures": 3,
+			"breaker_min_asr": 30,
+			"breaker_min_calls": 5,
+			"breaker_window": "0",
+			"breaker_cooldown": "2m",
 		},
 	}`
 	eMap := map[string]interface{}{
@@ -110,6 +131,11 @@ func TestRouteSCfgAsMapInterface1(t *testing.T) {
 		utils.StatSConnsCfg:          []string{utils.MetaInternal, "conn1"},
 		utils.RALsConnsCfg:           []string{utils.MetaInternal, "conn1"},
 		utils.DefaultRatioCfg:        2,
+		utils.BreakerFailuresCfg:     3,
+		utils.BreakerMinASRCfg:       30.,
+		utils.BreakerMinCallsCfg:     5,
+		utils.BreakerWindowCfg:       utils.EmptyString,
+		utils.BreakerCooldownCfg:     "2m0s",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -131,6 +157,11 @@ func TestRouteSCfgClone(t *testing.T) {
 		RALsConns:           []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResponder), "conn1"},
 		DefaultRatio:        10,
 		NestedFields:        true,
+		BreakerFailures:     5,
+		BreakerMinASR:       40,
+		BreakerMinCalls:     20,
+		BreakerWindow:       10 * time.Minute,
+		BreakerCooldown:     30 * time.Second,
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
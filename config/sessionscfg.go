@@ -101,6 +101,7 @@ type SessionSCfg struct {
 	ReplicationConns    []string
 	DebitInterval       time.Duration
 	StoreSCosts         bool
+	BackupSessions      bool
 	SessionTTL          time.Duration
 	SessionTTLMaxDelay  *time.Duration
 	SessionTTLLastUsed  *time.Duration
@@ -227,6 +228,9 @@ func (scfg *SessionSCfg) loadFromJSONCfg(jsnCfg *SessionSJsonCfg) (err error) {
 	if jsnCfg.Store_session_costs != nil {
 		scfg.StoreSCosts = *jsnCfg.Store_session_costs
 	}
+	if jsnCfg.Backup_sessions != nil {
+		scfg.BackupSessions = *jsnCfg.Backup_sessions
+	}
 	if jsnCfg.Session_ttl != nil {
 		if scfg.SessionTTL, err = utils.ParseDurationWithNanosecs(*jsnCfg.Session_ttl); err != nil {
 			return err
@@ -325,6 +329,7 @@ func (scfg *SessionSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		utils.ListenBigobCfg:         scfg.ListenBigob,
 		utils.ReplicationConnsCfg:    scfg.ReplicationConns,
 		utils.StoreSCostsCfg:         scfg.StoreSCosts,
+		utils.BackupSessionsCfg:      scfg.BackupSessions,
 		utils.SessionIndexesCfg:      scfg.SessionIndexes.AsSlice(),
 		utils.ClientProtocolCfg:      scfg.ClientProtocol,
 		utils.TerminateAttemptsCfg:   scfg.TerminateAttempts,
@@ -460,6 +465,7 @@ func (scfg SessionSCfg) Clone() (cln *SessionSCfg) {
 		ListenBijson:        scfg.ListenBijson,
 		DebitInterval:       scfg.DebitInterval,
 		StoreSCosts:         scfg.StoreSCosts,
+		BackupSessions:      scfg.BackupSessions,
 		SessionTTL:          scfg.SessionTTL,
 		ClientProtocol:      scfg.ClientProtocol,
 		ChannelSyncInterval: scfg.ChannelSyncInterval,
@@ -322,6 +322,7 @@ func TestSessionSCfgAsMapInterfaceCase1(t *testing.T) {
 		utils.ReplicationConnsCfg:    []string{},
 		utils.DebitIntervalCfg:       "0",
 		utils.StoreSCostsCfg:         false,
+		utils.BackupSessionsCfg:      false,
 		utils.SessionTTLCfg:          "0",
 		utils.SessionTTLMaxDelayCfg:  "3h0m0s",
 		utils.SessionTTLLastUsedCfg:  "0s",
@@ -400,6 +401,7 @@ func TestSessionSCfgAsMapInterfaceCase2(t *testing.T) {
 		utils.ReplicationConnsCfg:    []string{utils.MetaLocalHost},
 		utils.DebitIntervalCfg:       "8s",
 		utils.StoreSCostsCfg:         true,
+		utils.BackupSessionsCfg:      false,
 		utils.MinDurLowBalanceCfg:    "1s",
 		utils.SessionTTLCfg:          "1s",
 		utils.SessionIndexesCfg:      []string{},
@@ -31,6 +31,7 @@ type StatSCfg struct {
 	StoreInterval          time.Duration // Dump regularly from cache into dataDB
 	StoreUncompressedLimit int
 	ThresholdSConns        []string
+	RouteSConns            []string
 	StringIndexedFields    *[]string
 	PrefixIndexedFields    *[]string
 	SuffixIndexedFields    *[]string
@@ -65,6 +66,16 @@ func (st *StatSCfg) loadFromJSONCfg(jsnCfg *StatServJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Routes_conns != nil {
+		st.RouteSConns = make([]string, len(*jsnCfg.Routes_conns))
+		for idx, conn := range *jsnCfg.Routes_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			st.RouteSConns[idx] = conn
+			if conn == utils.MetaInternal {
+				st.RouteSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRoutes)
+			}
+		}
+	}
 	if jsnCfg.String_indexed_fields != nil {
 		sif := make([]string, len(*jsnCfg.String_indexed_fields))
 		for i, fID := range *jsnCfg.String_indexed_fields {
@@ -138,6 +149,16 @@ func (st *StatSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		}
 		initialMP[utils.ThresholdSConnsCfg] = thresholdSConns
 	}
+	if st.RouteSConns != nil {
+		routeSConns := make([]string, len(st.RouteSConns))
+		for i, item := range st.RouteSConns {
+			routeSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRoutes) {
+				routeSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.RouteSConnsCfg] = routeSConns
+	}
 	return
 }
 
@@ -156,6 +177,12 @@ func (st StatSCfg) Clone() (cln *StatSCfg) {
 			cln.ThresholdSConns[i] = con
 		}
 	}
+	if st.RouteSConns != nil {
+		cln.RouteSConns = make([]string, len(st.RouteSConns))
+		for i, con := range st.RouteSConns {
+			cln.RouteSConns[i] = con
+		}
+	}
 
 	if st.StringIndexedFields != nil {
 		idx := make([]string, len(*st.StringIndexedFields))
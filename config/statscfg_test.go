@@ -31,6 +31,7 @@ func TestStatSCfgloadFromJsonCfgCase1(t *testing.T) {
 		Store_interval:           utils.StringPointer("2"),
 		Store_uncompressed_limit: utils.IntPointer(10),
 		Thresholds_conns:         &[]string{utils.MetaInternal, "*conn1"},
+		Routes_conns:             &[]string{utils.MetaInternal, "*conn1"},
 		String_indexed_fields:    &[]string{"*req.string"},
 		Prefix_indexed_fields:    &[]string{"*req.index1", "*req.index2"},
 		Suffix_indexed_fields:    &[]string{"*req.index1", "*req.index2"},
@@ -42,6 +43,7 @@ func TestStatSCfgloadFromJsonCfgCase1(t *testing.T) {
 		StoreInterval:          2,
 		StoreUncompressedLimit: 10,
 		ThresholdSConns:        []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds), "*conn1"},
+		RouteSConns:            []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRoutes), "*conn1"},
 		StringIndexedFields:    &[]string{"*req.string"},
 		PrefixIndexedFields:    &[]string{"*req.index1", "*req.index2"},
 		SuffixIndexedFields:    &[]string{"*req.index1", "*req.index2"},
@@ -75,6 +77,7 @@ func TestStatSCfgAsMapInterface(t *testing.T) {
 		utils.StoreIntervalCfg:          utils.EmptyString,
 		utils.StoreUncompressedLimitCfg: 0,
 		utils.ThresholdSConnsCfg:        []string{},
+		utils.RouteSConnsCfg:            []string{},
 		utils.IndexedSelectsCfg:         true,
 		utils.PrefixIndexedFieldsCfg:    []string{},
 		utils.SuffixIndexedFieldsCfg:    []string{},
@@ -94,6 +97,7 @@ func TestStatSCfgAsMapInterface1(t *testing.T) {
 			"store_interval": "72h",			
 			"store_uncompressed_limit": 1,	
 			"thresholds_conns": ["*internal:*thresholds", "*conn1"],			
+			"routes_conns": ["*internal:*routes", "*conn1"],
 			"indexed_selects":false,			
             "string_indexed_fields": ["*req.string"],
 			"prefix_indexed_fields": ["*req.prefix_indexed_fields1","*req.prefix_indexed_fields2"],
@@ -106,6 +110,7 @@ func TestStatSCfgAsMapInterface1(t *testing.T) {
 		utils.StoreIntervalCfg:          "72h0m0s",
 		utils.StoreUncompressedLimitCfg: 1,
 		utils.ThresholdSConnsCfg:        []string{utils.MetaInternal, "*conn1"},
+		utils.RouteSConnsCfg:            []string{utils.MetaInternal, "*conn1"},
 		utils.IndexedSelectsCfg:         false,
 		utils.StringIndexedFieldsCfg:    []string{"*req.string"},
 		utils.PrefixIndexedFieldsCfg:    []string{"*req.prefix_indexed_fields1", "*req.prefix_indexed_fields2"},
@@ -74,11 +74,15 @@ func TestStoreDbCfgloadFromJsonCfgCase1(t *testing.T) {
 			},
 		},
 		Opts: map[string]interface{}{
-			utils.MaxOpenConnsCfg:    100.,
-			utils.MaxIdleConnsCfg:    10.,
-			utils.ConnMaxLifetimeCfg: 0.,
-			utils.QueryTimeoutCfg:    "10s",
-			utils.SSLModeCfg:         "disable",
+			utils.MaxOpenConnsCfg:               100.,
+			utils.MaxIdleConnsCfg:               10.,
+			utils.ConnMaxLifetimeCfg:            0.,
+			utils.QueryTimeoutCfg:               "10s",
+			utils.SSLModeCfg:                    "disable",
+			utils.InternalDBDumpPathCfg:         "",
+			utils.InternalDBSnapshotIntervalCfg: "1h",
+			utils.InternalDBFsyncIntervalCfg:    "1s",
+			utils.InternalDBCompactSizeCfg:      104857600.,
 		},
 	}
 	jsonCfg := NewDefaultCGRConfig()
@@ -228,11 +232,15 @@ func TestStorDbCfgAsMapInterface(t *testing.T) {
 		utils.RmtConnsCfg:            []string{"*conn1"},
 		utils.RplConnsCfg:            []string{"*conn1"},
 		utils.OptsCfg: map[string]interface{}{
-			utils.MaxOpenConnsCfg:    100.,
-			utils.MaxIdleConnsCfg:    10.,
-			utils.ConnMaxLifetimeCfg: 0.,
-			utils.QueryTimeoutCfg:    "10s",
-			utils.SSLModeCfg:         "disable",
+			utils.MaxOpenConnsCfg:               100.,
+			utils.MaxIdleConnsCfg:               10.,
+			utils.ConnMaxLifetimeCfg:            0.,
+			utils.QueryTimeoutCfg:               "10s",
+			utils.SSLModeCfg:                    "disable",
+			utils.InternalDBDumpPathCfg:         "",
+			utils.InternalDBSnapshotIntervalCfg: "1h",
+			utils.InternalDBFsyncIntervalCfg:    "1s",
+			utils.InternalDBCompactSizeCfg:      104857600.,
 		},
 		utils.ItemsCfg: map[string]interface{}{
 			utils.SessionCostsTBL: map[string]interface{}{utils.RemoteCfg: false, utils.ReplicateCfg: false},
@@ -282,11 +290,15 @@ func TestStorDbCfgClone(t *testing.T) {
 			},
 		},
 		Opts: map[string]interface{}{
-			utils.MaxOpenConnsCfg:    100.,
-			utils.MaxIdleConnsCfg:    10.,
-			utils.ConnMaxLifetimeCfg: 0.,
-			utils.QueryTimeoutCfg:    "10s",
-			utils.SSLModeCfg:         "disable",
+			utils.MaxOpenConnsCfg:               100.,
+			utils.MaxIdleConnsCfg:               10.,
+			utils.ConnMaxLifetimeCfg:            0.,
+			utils.QueryTimeoutCfg:               "10s",
+			utils.SSLModeCfg:                    "disable",
+			utils.InternalDBDumpPathCfg:         "",
+			utils.InternalDBSnapshotIntervalCfg: "1h",
+			utils.InternalDBFsyncIntervalCfg:    "1s",
+			utils.InternalDBCompactSizeCfg:      104857600.,
 		},
 	}
 	rcv := ban.Clone()
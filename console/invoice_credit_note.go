@@ -0,0 +1,67 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdCreateCreditNote{
+		name:      "invoice_credit_note",
+		rpcMethod: utils.CDRsV1CreateCreditNote,
+		rpcParams: &engine.ArgsCreditNote{},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+type CmdCreateCreditNote struct {
+	name      string
+	rpcMethod string
+	rpcParams *engine.ArgsCreditNote
+	*CommandExecuter
+}
+
+func (self *CmdCreateCreditNote) Name() string {
+	return self.name
+}
+
+func (self *CmdCreateCreditNote) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdCreateCreditNote) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &engine.ArgsCreditNote{
+			Opts: make(map[string]interface{}),
+		}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdCreateCreditNote) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdCreateCreditNote) RpcResult() interface{} {
+	var atr engine.Invoice
+	return &atr
+}
@@ -0,0 +1,67 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdExportInvoice{
+		name:      "invoice_export",
+		rpcMethod: utils.CDRsV1ExportInvoice,
+		rpcParams: &engine.ArgsExportInvoice{},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+type CmdExportInvoice struct {
+	name      string
+	rpcMethod string
+	rpcParams *engine.ArgsExportInvoice
+	*CommandExecuter
+}
+
+func (self *CmdExportInvoice) Name() string {
+	return self.name
+}
+
+func (self *CmdExportInvoice) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdExportInvoice) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &engine.ArgsExportInvoice{
+			Opts: make(map[string]interface{}),
+		}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdExportInvoice) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdExportInvoice) RpcResult() interface{} {
+	var atr string
+	return &atr
+}
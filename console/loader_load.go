@@ -60,6 +60,6 @@ func (self *CmdLoaderLoad) PostprocessRpcParams() error {
 }
 
 func (self *CmdLoaderLoad) RpcResult() interface{} {
-	var s string
+	var s interface{}
 	return &s
 }
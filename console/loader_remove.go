@@ -60,6 +60,6 @@ func (self *CmdLoaderRemove) PostprocessRpcParams() error {
 }
 
 func (self *CmdLoaderRemove) RpcResult() interface{} {
-	var s string
+	var s interface{}
 	return &s
 }
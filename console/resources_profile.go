@@ -67,6 +67,7 @@ func (self *CmdGetResourceProfile) RpcResult() interface{} {
 
 func (self *CmdGetResourceProfile) GetFormatedResult(result interface{}) string {
 	return GetFormatedResult(result, utils.StringSet{
-		utils.UsageTTL: {},
+		utils.UsageTTL:   {},
+		utils.RateWindow: {},
 	})
 }
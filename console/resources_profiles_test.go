@@ -54,7 +54,8 @@ func TestCmdResourcesProfile(t *testing.T) {
 	// for coverage purpose
 	formatedResult := command.GetFormatedResult(command.RpcResult())
 	expected := GetFormatedResult(command.RpcResult(), utils.StringSet{
-		utils.UsageTTL: {},
+		utils.UsageTTL:   {},
+		utils.RateWindow: {},
 	})
 	if !reflect.DeepEqual(formatedResult, expected) {
 		t.Errorf("Expected <%+v>, Received <%+v>", expected, formatedResult)
@@ -67,6 +67,7 @@ func (self *CmdGetStatQueueProfile) RpcResult() interface{} {
 
 func (self *CmdGetStatQueueProfile) GetFormatedResult(result interface{}) string {
 	return GetFormatedResult(result, utils.StringSet{
-		utils.TTL: {},
+		utils.TTL:            {},
+		utils.BucketInterval: {},
 	})
 }
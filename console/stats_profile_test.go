@@ -53,7 +53,8 @@ func TestCmdStatsProfile(t *testing.T) {
 	// for coverage purpose
 	formatedResult := command.GetFormatedResult(command.RpcResult())
 	expected := GetFormatedResult(command.RpcResult(), utils.StringSet{
-		utils.TTL: {},
+		utils.TTL:            {},
+		utils.BucketInterval: {},
 	})
 	if !reflect.DeepEqual(formatedResult, expected) {
 		t.Errorf("Expected <%+v>, Received <%+v>", expected, formatedResult)
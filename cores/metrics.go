@@ -0,0 +1,105 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"net/rpc"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	rpcCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: utils.MetricsNamespace,
+		Name:      "rpc_calls_total",
+		Help:      "Number of RPC calls handled, per method and status",
+	}, []string{utils.MetricsLabelMethod, utils.MetricsLabelStatus})
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: utils.MetricsNamespace,
+		Name:      "rpc_duration_seconds",
+		Help:      "Duration of the RPC calls, per method",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{utils.MetricsLabelMethod})
+)
+
+// MetricsCollectors returns the metrics recorded for the RPC calls, registered by the PrometheusAgent
+func MetricsCollectors() []prometheus.Collector {
+	return []prometheus.Collector{rpcCalls, rpcDuration}
+}
+
+// newMetricsServerCodec wraps the codec in order to record the
+// number of calls and their duration for each method
+func newMetricsServerCodec(sc rpc.ServerCodec) rpc.ServerCodec {
+	return &metricsServerCodec{
+		sc:   sc,
+		reqs: make(map[uint64]*metricsReq),
+	}
+}
+
+type metricsReq struct {
+	method    string
+	startTime time.Time
+}
+
+type metricsServerCodec struct {
+	sc rpc.ServerCodec
+
+	// keep the requests in memory because the write is async
+	reqs   map[uint64]*metricsReq
+	reqsLk sync.Mutex
+}
+
+func (c *metricsServerCodec) ReadRequestHeader(r *rpc.Request) (err error) {
+	if err = c.sc.ReadRequestHeader(r); err != nil {
+		return
+	}
+	c.reqsLk.Lock()
+	c.reqs[r.Seq] = &metricsReq{
+		method:    r.ServiceMethod,
+		startTime: time.Now(),
+	}
+	c.reqsLk.Unlock()
+	return
+}
+
+func (c *metricsServerCodec) ReadRequestBody(x interface{}) error {
+	return c.sc.ReadRequestBody(x)
+}
+
+func (c *metricsServerCodec) WriteResponse(r *rpc.Response, x interface{}) error {
+	c.reqsLk.Lock()
+	req, has := c.reqs[r.Seq]
+	delete(c.reqs, r.Seq)
+	c.reqsLk.Unlock()
+	if has && !strings.HasPrefix(r.Error, "rpc: can't find") { // do not create labels for unknown methods
+		status := utils.MetricsStatusSuccess
+		if r.Error != utils.EmptyString {
+			status = utils.MetricsStatusError
+		}
+		rpcCalls.WithLabelValues(req.method, status).Inc()
+		rpcDuration.WithLabelValues(req.method).Observe(time.Since(req.startTime).Seconds())
+	}
+	return c.sc.WriteResponse(r, x)
+}
+
+func (c *metricsServerCodec) Close() error { return c.sc.Close() }
@@ -0,0 +1,72 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"net/rpc"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsServerCodec(t *testing.T) {
+	codec := newMetricsServerCodec(new(mockServerCodec))
+	okCalls := testutil.ToFloat64(rpcCalls.WithLabelValues(utils.CoreSv1Ping, utils.MetricsStatusSuccess))
+	errCalls := testutil.ToFloat64(rpcCalls.WithLabelValues(utils.CoreSv1Ping, utils.MetricsStatusError))
+
+	r := new(rpc.Request)
+	if err := codec.ReadRequestHeader(r); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.WriteResponse(&rpc.Response{Seq: r.Seq, ServiceMethod: r.ServiceMethod}, utils.Pong); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.ReadRequestHeader(r); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.WriteResponse(&rpc.Response{Seq: r.Seq, ServiceMethod: r.ServiceMethod,
+		Error: utils.ErrNotImplemented.Error()}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if rcv := testutil.ToFloat64(rpcCalls.WithLabelValues(utils.CoreSv1Ping, utils.MetricsStatusSuccess)); rcv != okCalls+1 {
+		t.Errorf("Expected %v successful calls, received %v", okCalls+1, rcv)
+	}
+	if rcv := testutil.ToFloat64(rpcCalls.WithLabelValues(utils.CoreSv1Ping, utils.MetricsStatusError)); rcv != errCalls+1 {
+		t.Errorf("Expected %v failed calls, received %v", errCalls+1, rcv)
+	}
+	if mc := codec.(*metricsServerCodec); len(mc.reqs) != 0 {
+		t.Errorf("Expected the requests to be removed after response, received: %+v", mc.reqs)
+	}
+
+	// responses for unknown methods are not recorded
+	if err := codec.ReadRequestHeader(r); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.WriteResponse(&rpc.Response{Seq: r.Seq, ServiceMethod: r.ServiceMethod,
+		Error: "rpc: can't find method " + r.ServiceMethod}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if rcv := testutil.ToFloat64(rpcCalls.WithLabelValues(utils.CoreSv1Ping, utils.MetricsStatusError)); rcv != errCalls+1 {
+		t.Errorf("Expected %v failed calls, received %v", errCalls+1, rcv)
+	}
+	if err := codec.Close(); err != nil {
+		t.Error(err)
+	}
+}
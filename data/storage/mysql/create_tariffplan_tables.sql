@@ -261,6 +261,8 @@ CREATE TABLE tp_resources (
   `stored` BOOLEAN NOT NULL,
   `weight` decimal(8,2) NOT NULL,
   `threshold_ids` varchar(64) NOT NULL,
+  `rate_window` varchar(32) NOT NULL,
+  `burst` decimal(8,2) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -288,6 +290,8 @@ CREATE TABLE tp_stats (
   `blocker` BOOLEAN NOT NULL,
   `weight` decimal(8,2) NOT NULL,
   `threshold_ids` varchar(64) NOT NULL,
+  `bucket_interval` varchar(32) NOT NULL,
+  `buckets_limit` int(11) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -313,6 +317,8 @@ CREATE TABLE tp_thresholds (
   `weight` decimal(8,2) NOT NULL,
   `action_ids` varchar(64) NOT NULL,
   `async` BOOLEAN NOT NULL,
+  `recovery_filter_ids` varchar(64) NOT NULL,
+  `recovery_action_ids` varchar(64) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
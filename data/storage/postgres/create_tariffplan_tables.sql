@@ -257,6 +257,8 @@ CREATE TABLE tp_resources (
   "stored" BOOLEAN NOT NULL,
   "weight" NUMERIC(8,2) NOT NULL,
   "threshold_ids" varchar(64) NOT NULL,
+  "rate_window" varchar(32) NOT NULL,
+  "burst" NUMERIC(8,2) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_resources_idx ON tp_resources (tpid);
@@ -284,6 +286,8 @@ CREATE TABLE tp_stats (
   "blocker" BOOLEAN NOT NULL,
   "weight" decimal(8,2) NOT NULL,
   "threshold_ids" varchar(64) NOT NULL,
+  "bucket_interval" varchar(32) NOT NULL,
+  "buckets_limit" INTEGER NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_stats_idx ON tp_stats (tpid);
@@ -308,6 +312,8 @@ CREATE TABLE tp_thresholds (
   "weight" decimal(8,2) NOT NULL,
   "action_ids" varchar(64) NOT NULL,
   "async" BOOLEAN NOT NULL,
+  "recovery_filter_ids" varchar(64) NOT NULL,
+  "recovery_action_ids" varchar(64) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_thresholds_idx ON tp_thresholds (tpid);
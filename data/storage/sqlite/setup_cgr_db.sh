@@ -0,0 +1,21 @@
+#! /usr/bin/env sh
+
+
+db=$1
+if [ -z "$1" ]; then
+	db="/var/lib/cgrates/cgrates.db"
+fi
+
+DIR="$(dirname "$(readlink -f "$0")")"
+
+mkdir -p "$(dirname "$db")"
+
+sqlite3 "$db" < "$DIR"/create_cdrs_tables.sql
+cdrt=$?
+sqlite3 "$db" < "$DIR"/create_tariffplan_tables.sql
+tpt=$?
+
+if [ $cdrt = 0 ] && [ $tpt = 0 ]; then
+	echo "\n\t+++ CGR-DB successfully set-up! +++\n"
+	exit 0
+fi
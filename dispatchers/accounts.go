@@ -18,7 +18,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 
 package dispatchers
 
-import "github.com/cgrates/cgrates/utils"
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
 
 func (dS *DispatcherService) AccountSv1Ping(args *utils.CGREvent, rpl *string) (err error) {
 	if args == nil {
@@ -33,3 +37,84 @@ func (dS *DispatcherService) AccountSv1Ping(args *utils.CGREvent, rpl *string) (
 	}
 	return dS.Dispatch(args, utils.AccountS, utils.AccountSv1Ping, args, rpl)
 }
+
+func (dS *DispatcherService) AccountSv1MaxCost(args *utils.ArgsAccountsForEvent, eEc *utils.ExtEventCharges) (err error) {
+	if args == nil {
+		args = &utils.ArgsAccountsForEvent{CGREvent: new(utils.CGREvent)}
+	}
+	args.CGREvent.Tenant = utils.FirstNonEmpty(args.CGREvent.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.AccountSv1MaxCost, args.CGREvent.Tenant,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), args.CGREvent.Time); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(args.CGREvent, utils.AccountS, utils.AccountSv1MaxCost, args, eEc)
+}
+
+func (dS *DispatcherService) AccountSv1DebitCost(args *utils.ArgsAccountsForEvent, eEc *utils.ExtEventCharges) (err error) {
+	if args == nil {
+		args = &utils.ArgsAccountsForEvent{CGREvent: new(utils.CGREvent)}
+	}
+	args.CGREvent.Tenant = utils.FirstNonEmpty(args.CGREvent.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.AccountSv1DebitCost, args.CGREvent.Tenant,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), args.CGREvent.Time); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(args.CGREvent, utils.AccountS, utils.AccountSv1DebitCost, args, eEc)
+}
+
+func (dS *DispatcherService) AccountSv1RefundCharges(args *utils.ArgsRefundCharges, rply *string) (err error) {
+	if args == nil {
+		args = new(utils.ArgsRefundCharges)
+	}
+	args.Tenant = utils.FirstNonEmpty(args.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.AccountSv1RefundCharges, args.Tenant,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: args.Tenant,
+		Opts:   args.Opts,
+	}, utils.AccountS, utils.AccountSv1RefundCharges, args, rply)
+}
+
+func (dS *DispatcherService) AccountSv1ActionSetBalance(args *utils.ArgsActSetBalance, rply *string) (err error) {
+	if args == nil {
+		args = new(utils.ArgsActSetBalance)
+	}
+	args.Tenant = utils.FirstNonEmpty(args.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.AccountSv1ActionSetBalance, args.Tenant,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: args.Tenant,
+		ID:     args.AccountID,
+		Opts:   args.Opts,
+	}, utils.AccountS, utils.AccountSv1ActionSetBalance, args, rply)
+}
+
+func (dS *DispatcherService) AccountSv1ActionRemoveBalance(args *utils.ArgsActRemoveBalance, rply *string) (err error) {
+	if args == nil {
+		args = new(utils.ArgsActRemoveBalance)
+	}
+	args.Tenant = utils.FirstNonEmpty(args.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.AccountSv1ActionRemoveBalance, args.Tenant,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: args.Tenant,
+		ID:     args.AccountID,
+		Opts:   args.Opts,
+	}, utils.AccountS, utils.AccountSv1ActionRemoveBalance, args, rply)
+}
@@ -54,6 +54,13 @@ type DispatcherService struct {
 // Shutdown is called to shutdown the service
 func (dS *DispatcherService) Shutdown() {
 	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown initialized", utils.DispatcherS))
+	for _, tntID := range engine.Cache.GetItemIDs(utils.CacheDispatchers, utils.EmptyString) {
+		if x, ok := engine.Cache.Get(utils.CacheDispatchers, tntID); ok {
+			if hd, canCast := x.(*HealthDispatcher); canCast { // stop the health checks
+				hd.Stop()
+			}
+		}
+	}
 	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown complete", utils.DispatcherS))
 }
 
@@ -194,7 +201,7 @@ func (dS *DispatcherService) Dispatch(ev *utils.CGREvent, subsys string,
 	if errCh := engine.Cache.Set(utils.CacheDispatchers, tntID, d, nil, true, utils.EmptyString); errCh != nil {
 		return utils.NewErrDispatcherS(errCh)
 	}
-	return d.Dispatch(utils.IfaceAsString(ev.Opts[utils.OptsRouteID]), subsys, serviceMethod, args, reply)
+	return d.Dispatch(ev, subsys, serviceMethod, args, reply)
 }
 
 func (dS *DispatcherService) V1GetProfileForEvent(ev *utils.CGREvent,
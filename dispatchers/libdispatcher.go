@@ -21,8 +21,12 @@ package dispatchers
 import (
 	"encoding/gob"
 	"fmt"
+	"hash/crc32"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
@@ -47,7 +51,7 @@ type Dispatcher interface {
 	// HostIDs returns the ordered list of host IDs
 	HostIDs() (hostIDs engine.DispatcherHostIDs)
 	// Dispatch is used to send the method over the connections given
-	Dispatch(routeID string, subsystem,
+	Dispatch(ev *utils.CGREvent, subsystem,
 		serviceMethod string, args interface{}, reply interface{}) (err error)
 }
 
@@ -95,6 +99,55 @@ func newDispatcher(dm *engine.DataManager, pfl *engine.DispatcherProfile) (d Dis
 			hosts:    hosts,
 			strategy: strDsp,
 		}
+	case utils.MetaLoad:
+		ratio := int64(1)
+		if dflt, has := pfl.StrategyParams[utils.MetaDefaultRatio]; has {
+			if ratio, err = utils.IfaceAsTInt64(dflt); err != nil {
+				return
+			}
+		}
+		d = &WeightDispatcher{
+			dm:    dm,
+			tnt:   pfl.Tenant,
+			hosts: hosts,
+			strategy: &loadStrategyDispatcher{
+				tntID:        pfl.TenantID(),
+				hosts:        hosts.Clone(),
+				defaultRatio: ratio,
+			},
+		}
+	case utils.MetaHash:
+		hashField := utils.IfaceAsString(pfl.StrategyParams[utils.MetaHashField])
+		if hashField == utils.EmptyString {
+			return nil, fmt.Errorf("missing <%s> for strategy <%s>", utils.MetaHashField, utils.MetaHash)
+		}
+		d = &HashDispatcher{
+			dm:        dm,
+			tnt:       pfl.Tenant,
+			hosts:     hosts,
+			hashField: hashField,
+			ring:      newHashRing(hosts.HostIDs()),
+			strategy:  new(singleResultstrategyDispatcher), // keep the order given by the hash ring
+		}
+	case utils.MetaFirstHealthy:
+		var strDsp strategyDispatcher
+		if strDsp, err = newSingleStrategyDispatcher(hosts, pfl.StrategyParams, pfl.TenantID()); err != nil {
+			return
+		}
+		var pingIntvl time.Duration
+		if pingIntvl, err = healthPingInterval(pfl.StrategyParams); err != nil {
+			return
+		}
+		hd := &HealthDispatcher{
+			dm:        dm,
+			tnt:       pfl.Tenant,
+			tntID:     pfl.TenantID(),
+			hosts:     hosts,
+			unhealthy: make(utils.StringSet),
+			strategy:  strDsp,
+		}
+		hd.setPingInterval(pingIntvl)
+		d = hd
 	case rpcclient.PoolBroadcast,
 		rpcclient.PoolBroadcastSync,
 		rpcclient.PoolBroadcastAsync:
@@ -137,9 +190,9 @@ func (wd *WeightDispatcher) HostIDs() (hostIDs engine.DispatcherHostIDs) {
 }
 
 // Dispatch used to implement Dispatcher interface
-func (wd *WeightDispatcher) Dispatch(routeID string, subsystem,
+func (wd *WeightDispatcher) Dispatch(ev *utils.CGREvent, subsystem,
 	serviceMethod string, args interface{}, reply interface{}) (err error) {
-	return wd.strategy.dispatch(wd.dm, routeID, subsystem, wd.tnt, wd.HostIDs(),
+	return wd.strategy.dispatch(wd.dm, utils.IfaceAsString(ev.Opts[utils.OptsRouteID]), subsystem, wd.tnt, wd.HostIDs(),
 		serviceMethod, args, reply)
 }
 
@@ -171,9 +224,9 @@ func (d *RandomDispatcher) HostIDs() (hostIDs engine.DispatcherHostIDs) {
 }
 
 // Dispatch used to implement Dispatcher interface
-func (d *RandomDispatcher) Dispatch(routeID string, subsystem,
+func (d *RandomDispatcher) Dispatch(ev *utils.CGREvent, subsystem,
 	serviceMethod string, args interface{}, reply interface{}) (err error) {
-	return d.strategy.dispatch(d.dm, routeID, subsystem, d.tnt, d.HostIDs(),
+	return d.strategy.dispatch(d.dm, utils.IfaceAsString(ev.Opts[utils.OptsRouteID]), subsystem, d.tnt, d.HostIDs(),
 		serviceMethod, args, reply)
 }
 
@@ -209,12 +262,261 @@ func (d *RoundRobinDispatcher) HostIDs() (hostIDs engine.DispatcherHostIDs) {
 }
 
 // Dispatch used to implement Dispatcher interface
-func (d *RoundRobinDispatcher) Dispatch(routeID string, subsystem,
+func (d *RoundRobinDispatcher) Dispatch(ev *utils.CGREvent, subsystem,
+	serviceMethod string, args interface{}, reply interface{}) (err error) {
+	return d.strategy.dispatch(d.dm, utils.IfaceAsString(ev.Opts[utils.OptsRouteID]), subsystem, d.tnt, d.HostIDs(),
+		serviceMethod, args, reply)
+}
+
+// HashDispatcher selects the connection using consistent hashing on an event field
+// so the same field value sticks to the same host, only the keys of a failing host move
+type HashDispatcher struct {
+	sync.RWMutex
+	dm        *engine.DataManager
+	tnt       string
+	hosts     engine.DispatcherHostProfiles
+	hashField string
+	ring      *hashRing
+	strategy  strategyDispatcher
+}
+
+// SetProfile used to implement Dispatcher interface
+func (d *HashDispatcher) SetProfile(pfl *engine.DispatcherProfile) {
+	d.Lock()
+	pfl.Hosts.Sort()
+	d.hosts = pfl.Hosts.Clone() // avoid concurrency on profile
+	d.ring = newHashRing(d.hosts.HostIDs())
+	if hashField := utils.IfaceAsString(pfl.StrategyParams[utils.MetaHashField]); hashField != utils.EmptyString {
+		d.hashField = hashField
+	}
+	d.Unlock()
+	return
+}
+
+// HostIDs used to implement Dispatcher interface
+func (d *HashDispatcher) HostIDs() (hostIDs engine.DispatcherHostIDs) {
+	d.RLock()
+	hostIDs = d.hosts.HostIDs()
+	d.RUnlock()
+	return
+}
+
+// hostIDsForEvent returns the hosts in the order given by the hash ring
+// in case the hash field is not present in the event, the hosts are ordered based on weight
+func (d *HashDispatcher) hostIDsForEvent(ev *utils.CGREvent) (hostIDs []string) {
+	d.RLock()
+	defer d.RUnlock()
+	fldPath := d.hashField
+	if !strings.HasPrefix(fldPath, utils.DynamicDataPrefix) {
+		fldPath = utils.DynamicDataPrefix + utils.MetaReq + utils.NestingSep + fldPath
+	}
+	key, err := utils.DPDynamicString(fldPath, utils.MapStorage{
+		utils.MetaReq:  ev.Event,
+		utils.MetaOpts: ev.Opts,
+	})
+	if err != nil || key == utils.EmptyString {
+		return d.hosts.HostIDs()
+	}
+	return d.ring.hostIDs(key)
+}
+
+// Dispatch used to implement Dispatcher interface
+func (d *HashDispatcher) Dispatch(ev *utils.CGREvent, subsystem,
+	serviceMethod string, args interface{}, reply interface{}) (err error) {
+	return d.strategy.dispatch(d.dm, utils.IfaceAsString(ev.Opts[utils.OptsRouteID]), subsystem, d.tnt,
+		d.hostIDsForEvent(ev), serviceMethod, args, reply)
+}
+
+// hashRingReplicas is the number of points each host has on the hash ring
+const hashRingReplicas = 100
+
+type hashRingPoint struct {
+	hash   uint32
+	hostID string
+}
+
+// hashRing implements consistent hashing over the host IDs
+type hashRing struct {
+	points  []*hashRingPoint // sorted by hash
+	nrHosts int
+}
+
+func newHashRing(hostIDs []string) (hr *hashRing) {
+	hr = &hashRing{
+		points:  make([]*hashRingPoint, 0, len(hostIDs)*hashRingReplicas),
+		nrHosts: len(hostIDs),
+	}
+	for _, hostID := range hostIDs {
+		for i := 0; i < hashRingReplicas; i++ {
+			hr.points = append(hr.points, &hashRingPoint{
+				hash:   crc32.ChecksumIEEE([]byte(hostID + utils.InInFieldSep + strconv.Itoa(i))),
+				hostID: hostID,
+			})
+		}
+	}
+	sort.Slice(hr.points, func(i, j int) bool { return hr.points[i].hash < hr.points[j].hash })
+	return
+}
+
+// hostIDs returns all the hosts ordered by their distance on the ring from the key
+func (hr *hashRing) hostIDs(key string) (hostIDs []string) {
+	if len(hr.points) == 0 {
+		return
+	}
+	hash := crc32.ChecksumIEEE([]byte(key))
+	idx := sort.Search(len(hr.points), func(i int) bool { return hr.points[i].hash >= hash })
+	hostIDs = make([]string, 0, hr.nrHosts)
+	seen := make(utils.StringSet)
+	for i := 0; i < len(hr.points) && len(hostIDs) < hr.nrHosts; i++ {
+		pnt := hr.points[(idx+i)%len(hr.points)]
+		if seen.Has(pnt.hostID) {
+			continue
+		}
+		seen.Add(pnt.hostID)
+		hostIDs = append(hostIDs, pnt.hostID)
+	}
+	return
+}
+
+// defaultPingInterval is used by the *first_healthy strategy when *ping_interval is not specified
+const defaultPingInterval = 5 * time.Second
+
+// healthPingInterval returns the *ping_interval out of the strategy params, defaultPingInterval if missing
+func healthPingInterval(params map[string]interface{}) (pingIntvl time.Duration, err error) {
+	pIntvl, has := params[utils.MetaPingInterval]
+	if !has {
+		return defaultPingInterval, nil
+	}
+	return utils.IfaceAsDuration(pIntvl)
+}
+
+// HealthDispatcher selects the first healthy connection based on weight
+// the hosts are pinged in the background and skipped while failing
+type HealthDispatcher struct {
+	sync.RWMutex
+	dm        *engine.DataManager
+	tnt       string
+	tntID     string // the key of the dispatcher within CacheDispatchers
+	hosts     engine.DispatcherHostProfiles
+	unhealthy utils.StringSet // hosts failing the health check
+	pingIntvl time.Duration   // interval between two health checks, 0 to disable them
+	lastPing  time.Time       // start of the last health check
+	stopPing  chan struct{}   // stops the health checks
+	strategy  strategyDispatcher
+}
+
+// SetProfile used to implement Dispatcher interface
+func (d *HealthDispatcher) SetProfile(pfl *engine.DispatcherProfile) {
+	d.Lock()
+	pfl.Hosts.Sort()
+	d.hosts = pfl.Hosts.Clone() // avoid concurrency on profile
+	d.Unlock()
+	pingIntvl, err := healthPingInterval(pfl.StrategyParams)
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> keeping the ping interval of dispatcher %q, error: %s",
+			utils.DispatcherS, pfl.TenantID(), err.Error()))
+		return
+	}
+	d.setPingInterval(pingIntvl)
+	return
+}
+
+// HostIDs used to implement Dispatcher interface
+// in case none of the hosts is healthy all of them are returned
+func (d *HealthDispatcher) HostIDs() (hostIDs engine.DispatcherHostIDs) {
+	d.RLock()
+	hostIDs = make(engine.DispatcherHostIDs, 0, len(d.hosts))
+	for _, hostID := range d.hosts.HostIDs() {
+		if !d.unhealthy.Has(hostID) {
+			hostIDs = append(hostIDs, hostID)
+		}
+	}
+	if len(hostIDs) == 0 {
+		hostIDs = d.hosts.HostIDs()
+	}
+	d.RUnlock()
+	return
+}
+
+// Dispatch used to implement Dispatcher interface
+func (d *HealthDispatcher) Dispatch(ev *utils.CGREvent, subsystem,
 	serviceMethod string, args interface{}, reply interface{}) (err error) {
-	return d.strategy.dispatch(d.dm, routeID, subsystem, d.tnt, d.HostIDs(),
+	return d.strategy.dispatch(d.dm, utils.IfaceAsString(ev.Opts[utils.OptsRouteID]), subsystem, d.tnt, d.HostIDs(),
 		serviceMethod, args, reply)
 }
 
+// Stop ends the health checks
+func (d *HealthDispatcher) Stop() {
+	d.setPingInterval(0)
+}
+
+// setPingInterval restarts the health checks if the interval changed
+func (d *HealthDispatcher) setPingInterval(pingIntvl time.Duration) {
+	d.Lock()
+	defer d.Unlock()
+	if pingIntvl == d.pingIntvl {
+		return
+	}
+	if d.stopPing != nil {
+		close(d.stopPing)
+		d.stopPing = nil
+	}
+	d.pingIntvl = pingIntvl
+	if pingIntvl <= 0 {
+		return
+	}
+	d.stopPing = make(chan struct{})
+	go d.checkHealth(pingIntvl, d.stopPing)
+}
+
+// checkHealth pings the hosts on each interval until stopped
+// the checks end also once the dispatcher is removed out of cache since it will not be used anymore
+func (d *HealthDispatcher) checkHealth(pingIntvl time.Duration, stopPing chan struct{}) {
+	tkr := time.NewTicker(pingIntvl)
+	defer tkr.Stop()
+	d.pingHosts()
+	for {
+		select {
+		case <-stopPing:
+			return
+		case <-tkr.C:
+			if x, ok := engine.Cache.Get(utils.CacheDispatchers, d.tntID); !ok || x != Dispatcher(d) {
+				d.Stop()
+				return
+			}
+			d.pingHosts()
+		}
+	}
+}
+
+// pingHosts updates the health of the hosts, a host is unhealthy if it cannot be reached
+func (d *HealthDispatcher) pingHosts() {
+	d.Lock()
+	d.lastPing = time.Now()
+	hostIDs := d.hosts.HostIDs()
+	d.Unlock()
+	for _, hostID := range hostIDs {
+		dH, err := d.dm.GetDispatcherHost(d.tnt, hostID, true, true, utils.NonTransactional)
+		if err != nil {
+			continue
+		}
+		var reply string
+		err = dH.Call(utils.CoreSv1Ping, &utils.CGREvent{Tenant: d.tnt}, &reply)
+		healthy := !rpcclient.IsNetworkError(err)
+		d.Lock()
+		if wasUnhealthy := d.unhealthy.Has(hostID); healthy && wasUnhealthy {
+			d.unhealthy.Remove(hostID)
+			utils.Logger.Info(fmt.Sprintf("<%s> host with ID %q recovered",
+				utils.DispatcherS, hostID))
+		} else if !healthy && !wasUnhealthy {
+			d.unhealthy.Add(hostID)
+			utils.Logger.Warning(fmt.Sprintf("<%s> host with ID %q failed the health check, error: %s",
+				utils.DispatcherS, hostID, err.Error()))
+		}
+		d.Unlock()
+	}
+}
+
 type singleResultstrategyDispatcher struct{}
 
 func (*singleResultstrategyDispatcher) dispatch(dm *engine.DataManager, routeID string, subsystem, tnt string,
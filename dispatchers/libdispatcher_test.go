@@ -19,9 +19,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package dispatchers
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
@@ -235,3 +238,244 @@ func TestLoadMetricsGetHosts2(t *testing.T) {
 		lm.incrementLoad(exp[0], utils.EmptyString)
 	}
 }
+
+func TestHashRingHostIDs(t *testing.T) {
+	hostIDs := []string{"DSP_1", "DSP_2", "DSP_3", "DSP_4"}
+	hr := newHashRing(hostIDs)
+	keys := make([]string, 1000)
+	owners := make(map[string]string)
+	dist := make(map[string]int)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("OriginID%d", i)
+		rcv := hr.hostIDs(keys[i])
+		if len(rcv) != len(hostIDs) {
+			t.Fatalf("Expected all the hosts, received: %+v", rcv)
+		}
+		if !reflect.DeepEqual(rcv, hr.hostIDs(keys[i])) {
+			t.Fatalf("Expected the same order for key: %s", keys[i])
+		}
+		owners[keys[i]] = rcv[0]
+		dist[rcv[0]]++
+	}
+	for _, hostID := range hostIDs {
+		if dist[hostID] == 0 {
+			t.Errorf("Expected keys for host: %s, received distribution: %+v", hostID, dist)
+		}
+	}
+	// removing one host should move only its own keys
+	hr2 := newHashRing([]string{"DSP_1", "DSP_2", "DSP_4"})
+	for _, key := range keys {
+		rcv := hr2.hostIDs(key)
+		if owners[key] != "DSP_3" && rcv[0] != owners[key] {
+			t.Errorf("Key %s moved from %s to %s", key, owners[key], rcv[0])
+		}
+		if owners[key] == "DSP_3" && rcv[0] != hr.hostIDs(key)[1] {
+			t.Errorf("Key %s expected to move to the next host on ring, received: %s", key, rcv[0])
+		}
+	}
+	if rcv := newHashRing(nil).hostIDs("key"); len(rcv) != 0 {
+		t.Errorf("Expected no hosts, received: %+v", rcv)
+	}
+}
+
+func TestNewDispatcherHash(t *testing.T) {
+	pfl := &engine.DispatcherProfile{
+		Tenant:   "cgrates.org",
+		ID:       "DSP_HASH",
+		Strategy: utils.MetaHash,
+		Hosts: engine.DispatcherHostProfiles{
+			{ID: "DSP_1", Weight: 10},
+			{ID: "DSP_2", Weight: 20},
+		},
+	}
+	expErr := "missing <*hash_field> for strategy <*hash>"
+	if _, err := newDispatcher(nil, pfl); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+	pfl.StrategyParams = map[string]interface{}{utils.MetaHashField: utils.AccountField}
+	d, err := newDispatcher(nil, pfl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hd, canCast := d.(*HashDispatcher)
+	if !canCast {
+		t.Fatalf("Expected *HashDispatcher, received %T", d)
+	}
+	if rcv := hd.HostIDs(); !reflect.DeepEqual(engine.DispatcherHostIDs{"DSP_2", "DSP_1"}, rcv) {
+		t.Errorf("Unexpected hosts: %+v", rcv)
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		Event:  map[string]interface{}{utils.AccountField: "1001"},
+	}
+	if rcv := hd.hostIDsForEvent(ev); !reflect.DeepEqual(hd.ring.hostIDs("1001"), rcv) {
+		t.Errorf("Unexpected hosts: %+v", rcv)
+	}
+	if rcv := hd.hostIDsForEvent(&utils.CGREvent{}); !reflect.DeepEqual([]string{"DSP_2", "DSP_1"}, rcv) {
+		t.Errorf("Unexpected hosts: %+v", rcv)
+	}
+	// the load ratios do not change the order given by the hash ring
+	pfl.StrategyParams[utils.MetaDefaultRatio] = 2
+	if d, err = newDispatcher(nil, pfl); err != nil {
+		t.Fatal(err)
+	}
+	if _, canCast := d.(*HashDispatcher).strategy.(*singleResultstrategyDispatcher); !canCast {
+		t.Errorf("Expected *singleResultstrategyDispatcher, received %T", d.(*HashDispatcher).strategy)
+	}
+}
+
+func TestNewDispatcherLoad(t *testing.T) {
+	pfl := &engine.DispatcherProfile{
+		Tenant:   "cgrates.org",
+		ID:       "DSP_LOAD",
+		Strategy: utils.MetaLoad,
+		Hosts: engine.DispatcherHostProfiles{
+			{ID: "DSP_1", Weight: 10},
+			{ID: "DSP_2", Weight: 20},
+		},
+	}
+	d, err := newDispatcher(nil, pfl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := &loadStrategyDispatcher{
+		tntID:        "cgrates.org:DSP_LOAD",
+		hosts:        pfl.Hosts.Clone(),
+		defaultRatio: 1,
+	}
+	if rcv := d.(*WeightDispatcher).strategy; !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %+v, received %+v", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	lm, err := newLoadMetrics(pfl.Hosts, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lm.HostsLoad["DSP_2"] = 2
+	lm.HostsLoad["DSP_1"] = 1
+	if rcv := lm.getHosts(d.HostIDs()); !reflect.DeepEqual([]string{"DSP_1", "DSP_2"}, rcv) {
+		t.Errorf("Unexpected hosts: %+v", rcv)
+	}
+}
+
+func TestHealthDispatcherHostIDs(t *testing.T) {
+	pfl := &engine.DispatcherProfile{
+		Tenant:   "cgrates.org",
+		ID:       "DSP_HEALTH",
+		Strategy: utils.MetaFirstHealthy,
+		StrategyParams: map[string]interface{}{
+			utils.MetaPingInterval: "0",
+		},
+		Hosts: engine.DispatcherHostProfiles{
+			{ID: "DSP_1", Weight: 10},
+			{ID: "DSP_2", Weight: 20},
+		},
+	}
+	d, err := newDispatcher(nil, pfl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hd := d.(*HealthDispatcher)
+	if rcv := hd.HostIDs(); !reflect.DeepEqual(engine.DispatcherHostIDs{"DSP_2", "DSP_1"}, rcv) {
+		t.Errorf("Unexpected hosts: %+v", rcv)
+	}
+	hd.unhealthy.Add("DSP_2")
+	if rcv := hd.HostIDs(); !reflect.DeepEqual(engine.DispatcherHostIDs{"DSP_1"}, rcv) {
+		t.Errorf("Unexpected hosts: %+v", rcv)
+	}
+	hd.unhealthy.Add("DSP_1")
+	if rcv := hd.HostIDs(); !reflect.DeepEqual(engine.DispatcherHostIDs{"DSP_2", "DSP_1"}, rcv) {
+		t.Errorf("Unexpected hosts: %+v", rcv)
+	}
+	pfl.StrategyParams[utils.MetaPingInterval] = "notADuration"
+	if _, err := newDispatcher(nil, pfl); err == nil {
+		t.Error("Expected error for invalid ping interval")
+	}
+}
+
+func TestHealthDispatcherCheckHealth(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	pfl := &engine.DispatcherProfile{
+		Tenant:   "cgrates.org",
+		ID:       "DSP_HEALTH_CHECK",
+		Strategy: utils.MetaFirstHealthy,
+		StrategyParams: map[string]interface{}{
+			utils.MetaPingInterval: "10ms",
+		},
+		Hosts: engine.DispatcherHostProfiles{{ID: "DSP_1"}},
+	}
+	d, err := newDispatcher(dm, pfl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hd := d.(*HealthDispatcher)
+	if err := engine.Cache.Set(utils.CacheDispatchers, pfl.TenantID(), d, nil, true, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Cache.Remove(utils.CacheDispatchers, pfl.TenantID(), true, utils.EmptyString)
+	lastPing := func() time.Time {
+		hd.RLock()
+		defer hd.RUnlock()
+		return hd.lastPing
+	}
+	// the hosts are pinged in the background, without using the dispatcher
+	firstPing := lastPing()
+	for i := 0; i < 100 && !lastPing().After(firstPing); i++ {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !lastPing().After(firstPing) {
+		t.Fatal("Expecting the health checks to run on each ping interval")
+	}
+	// the ping interval is updated together with the profile
+	pfl.StrategyParams[utils.MetaPingInterval] = "1h"
+	hd.SetProfile(pfl)
+	hd.RLock()
+	pingIntvl := hd.pingIntvl
+	hd.RUnlock()
+	if pingIntvl != time.Hour {
+		t.Errorf("Expecting the ping interval updated, received: %v", pingIntvl)
+	}
+	pfl.StrategyParams[utils.MetaPingInterval] = "notADuration"
+	hd.SetProfile(pfl)
+	hd.RLock()
+	pingIntvl = hd.pingIntvl
+	hd.RUnlock()
+	if pingIntvl != time.Hour {
+		t.Errorf("Expecting the ping interval kept, received: %v", pingIntvl)
+	}
+	// the health checks end on shutdown
+	NewDispatcherService(dm, cfg, nil, nil).Shutdown()
+	hd.RLock()
+	defer hd.RUnlock()
+	if hd.pingIntvl != 0 || hd.stopPing != nil {
+		t.Errorf("Expecting the health checks stopped, interval: %v", hd.pingIntvl)
+	}
+}
+
+func TestHealthDispatcherStopsOutOfCache(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	d, err := newDispatcher(dm, &engine.DispatcherProfile{
+		Tenant:   "cgrates.org",
+		ID:       "DSP_HEALTH_UNCACHED",
+		Strategy: utils.MetaFirstHealthy,
+		StrategyParams: map[string]interface{}{
+			utils.MetaPingInterval: "5ms",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hd := d.(*HealthDispatcher)
+	stopped := func() bool {
+		hd.RLock()
+		defer hd.RUnlock()
+		return hd.stopPing == nil
+	}
+	for i := 0; i < 100 && !stopped(); i++ {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !stopped() {
+		t.Error("Expecting the health checks stopped for the dispatcher out of cache")
+	}
+}
@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package dispatchers
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
@@ -50,3 +52,37 @@ func (dS *DispatcherService) RateSv1CostForEvent(args *utils.ArgsCostForEvent, r
 	}
 	return dS.Dispatch(args.CGREvent, utils.RateS, utils.RateSv1CostForEvent, args, rpCost)
 }
+
+func (dS *DispatcherService) RateSv1CostForEvents(args *utils.ArgsCostForEvents, rpCosts *[]*engine.RateProfileCostForEvent) (err error) {
+	if args == nil {
+		args = new(utils.ArgsCostForEvents)
+	}
+	args.Tenant = utils.FirstNonEmpty(args.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.RateSv1CostForEvents, args.Tenant,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: args.Tenant,
+		Opts:   args.Opts,
+	}, utils.RateS, utils.RateSv1CostForEvents, args, rpCosts)
+}
+
+func (dS *DispatcherService) RateSv1RateProfilesCostForEvent(args *utils.ArgsCostForEvent, rpCosts *[]*engine.RateProfileCostForProfile) (err error) {
+	if args == nil {
+		args = new(utils.ArgsCostForEvent)
+	}
+	if args.CGREvent == nil {
+		args.CGREvent = new(utils.CGREvent)
+	}
+	args.CGREvent.Tenant = utils.FirstNonEmpty(args.CGREvent.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.RateSv1RateProfilesCostForEvent, args.CGREvent.Tenant,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), args.CGREvent.Time); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(args.CGREvent, utils.RateS, utils.RateSv1RateProfilesCostForEvent, args, rpCosts)
+}
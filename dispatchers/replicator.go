@@ -1467,3 +1467,55 @@ func (dS *DispatcherService) ReplicatorSv1RemoveAccountProfile(args *utils.Tenan
 		Opts:   args.Opts,
 	}, utils.MetaReplicator, utils.ReplicatorSv1RemoveAccountProfile, args, rpl)
 }
+
+func (dS *DispatcherService) ReplicatorSv1GetRouteBreaker(args *utils.TenantIDWithOpts, reply *engine.RouteBreaker) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.TenantID != nil && args.TenantID.Tenant != utils.EmptyString {
+		tnt = args.TenantID.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.ReplicatorSv1GetRouteBreaker, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		ID:     args.ID,
+		Opts:   args.Opts,
+	}, utils.MetaReplicator, utils.ReplicatorSv1GetRouteBreaker, args, reply)
+}
+
+func (dS *DispatcherService) ReplicatorSv1SetRouteBreaker(args *engine.RouteBreakerWithOpts, rpl *string) (err error) {
+	if args == nil {
+		args = &engine.RouteBreakerWithOpts{RouteBreaker: new(engine.RouteBreaker)}
+	}
+	args.Tenant = utils.FirstNonEmpty(args.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.ReplicatorSv1SetRouteBreaker, args.Tenant,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: args.Tenant,
+		Opts:   args.Opts,
+	}, utils.MetaReplicator, utils.ReplicatorSv1SetRouteBreaker, args, rpl)
+}
+
+func (dS *DispatcherService) ReplicatorSv1RemoveRouteBreaker(args *utils.TenantIDWithOpts, rpl *string) (err error) {
+	if args == nil {
+		args = &utils.TenantIDWithOpts{}
+	}
+	args.Tenant = utils.FirstNonEmpty(args.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.ReplicatorSv1RemoveRouteBreaker, args.Tenant,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: args.Tenant,
+		Opts:   args.Opts,
+	}, utils.MetaReplicator, utils.ReplicatorSv1RemoveRouteBreaker, args, rpl)
+}
@@ -84,6 +84,36 @@ func (dS *DispatcherService) ResourceSv1AllocateResources(args utils.ArgRSv1Reso
 	return dS.Dispatch(args.CGREvent, utils.MetaResources, utils.ResourceSv1AllocateResources, args, reply)
 }
 
+func (dS *DispatcherService) ResourceSv1AuthorizeResourcesWithLimits(args utils.ArgRSv1ResourceUsage,
+	reply *engine.ResourceAllocationReply) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.CGREvent != nil && args.CGREvent.Tenant != utils.EmptyString {
+		tnt = args.CGREvent.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.ResourceSv1AuthorizeResourcesWithLimits, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), args.CGREvent.Time); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(args.CGREvent, utils.MetaResources, utils.ResourceSv1AuthorizeResourcesWithLimits, args, reply)
+}
+
+func (dS *DispatcherService) ResourceSv1AllocateResourcesWithLimits(args utils.ArgRSv1ResourceUsage,
+	reply *engine.ResourceAllocationReply) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.CGREvent != nil && args.CGREvent.Tenant != utils.EmptyString {
+		tnt = args.CGREvent.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.ResourceSv1AllocateResourcesWithLimits, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), args.CGREvent.Time); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(args.CGREvent, utils.MetaResources, utils.ResourceSv1AllocateResourcesWithLimits, args, reply)
+}
+
 func (dS *DispatcherService) ResourceSv1ReleaseResources(args utils.ArgRSv1ResourceUsage,
 	reply *string) (err error) {
 	tnt := dS.cfg.GeneralCfg().DefaultTenant
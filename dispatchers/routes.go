@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package dispatchers
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
@@ -63,3 +65,57 @@ func (dS *DispatcherService) RouteSv1GetRouteProfilesForEvent(args *utils.CGREve
 	}
 	return dS.Dispatch(args, utils.MetaRoutes, utils.RouteSv1GetRouteProfilesForEvent, args, reply)
 }
+
+func (dS *DispatcherService) RouteSv1ProcessOutcome(args *utils.CGREvent,
+	reply *string) (err error) {
+	args.Tenant = utils.FirstNonEmpty(args.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.RouteSv1ProcessOutcome,
+			args.Tenant,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), args.Time); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(args, utils.MetaRoutes, utils.RouteSv1ProcessOutcome, args, reply)
+}
+
+func (dS *DispatcherService) RouteSv1GetRouteBreaker(args *utils.TenantIDWithOpts,
+	reply *engine.RouteBreaker) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.TenantID != nil && args.TenantID.Tenant != utils.EmptyString {
+		tnt = args.TenantID.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.RouteSv1GetRouteBreaker, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	var id string
+	if args.TenantID != nil {
+		id = args.ID
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		ID:     id,
+		Opts:   args.Opts,
+	}, utils.MetaRoutes, utils.RouteSv1GetRouteBreaker, args, reply)
+}
+
+func (dS *DispatcherService) RouteSv1GetRouteBreakerIDs(args *utils.TenantWithOpts,
+	reply *[]string) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.RouteSv1GetRouteBreakerIDs,
+			tnt, utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		Opts:   args.Opts,
+	}, utils.MetaRoutes, utils.RouteSv1GetRouteBreakerIDs, args, reply)
+}
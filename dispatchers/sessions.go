@@ -361,3 +361,39 @@ func (dS *DispatcherService) SessionSv1STIRIdentity(args *sessions.V1STIRIdentit
 		Opts:   args.Opts,
 	}, utils.MetaSessionS, utils.SessionSv1STIRIdentity, args, reply)
 }
+
+func (dS *DispatcherService) SessionSv1LinkSessions(args *sessions.V1LinkSessionsArgs, reply *string) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.SessionSv1LinkSessions,
+			tnt, utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		ID:     args.CallContextID,
+		Opts:   args.Opts,
+	}, utils.MetaSessionS, utils.SessionSv1LinkSessions, args, reply)
+}
+
+func (dS *DispatcherService) SessionSv1RebillSession(args *sessions.V1RebillSessionArgs, reply *string) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.SessionSv1RebillSession,
+			tnt, utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		ID:     args.CGRID,
+		Opts:   args.Opts,
+	}, utils.MetaSessionS, utils.SessionSv1RebillSession, args, reply)
+}
@@ -46,11 +46,20 @@ func NewEventExporter(cgrCfg *config.CGRConfig, cfgIdx int, filterS *engine.Filt
 		return NewFileCSVee(cgrCfg, cfgIdx, filterS, dc)
 	case utils.MetaFileFWV:
 		return NewFileFWVee(cgrCfg, cfgIdx, filterS, dc)
+	case utils.MetaFileParquet:
+		return NewFileParquetEE(cgrCfg, cfgIdx, filterS, dc)
+	case utils.MetaFileAvro:
+		return NewFileAvroEE(cgrCfg, cfgIdx, filterS, dc)
+	case utils.MetaFileJSON:
+		return NewFileJSONee(cgrCfg, cfgIdx, filterS, dc)
+	case utils.MetaFilePDF:
+		return NewFilePDFee(cgrCfg, cfgIdx, filterS, dc)
 	case utils.MetaHTTPPost:
 		return NewHTTPPostEe(cgrCfg, cfgIdx, filterS, dc)
 	case utils.MetaHTTPjsonMap:
 		return NewHTTPjsonMapEE(cgrCfg, cfgIdx, filterS, dc)
-	case utils.MetaAMQPjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaSQSjsonMap, utils.MetaKafkajsonMap, utils.MetaS3jsonMap:
+	case utils.MetaAMQPjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaSQSjsonMap, utils.MetaKafkajsonMap, utils.MetaS3jsonMap,
+		utils.MetaNATSjsonMap:
 		return NewPosterJSONMapEE(cgrCfg, cfgIdx, filterS, dc)
 	case utils.MetaVirt:
 		return NewVirtualExporter(cgrCfg, cfgIdx, filterS, dc)
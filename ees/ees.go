@@ -27,8 +27,29 @@ import (
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/ltcache"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+var (
+	exportedEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: utils.MetricsNamespace,
+		Subsystem: "ees",
+		Name:      "exported_events_total",
+		Help:      "Number of events successfully exported, per exporter",
+	}, []string{utils.MetricsLabelExporter})
+	failedEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: utils.MetricsNamespace,
+		Subsystem: "ees",
+		Name:      "failed_events_total",
+		Help:      "Number of events failing the export, per exporter",
+	}, []string{utils.MetricsLabelExporter})
+)
+
+// MetricsCollectors returns the metrics recorded by EEs, registered by the PrometheusAgent
+func MetricsCollectors() []prometheus.Collector {
+	return []prometheus.Collector{exportedEvents, failedEvents}
+}
+
 // onCacheEvicted is called by ltcache when evicting an item
 func onCacheEvicted(itmID string, value interface{}) {
 	ee := value.(EventExporter)
@@ -163,9 +184,10 @@ func (eeS *EventExporterS) V1ProcessEvent(cgrEv *utils.CGREventWithEeIDs, rply *
 			if eeTnt, errTnt := eeCfg.Tenant.ParseDataProvider(cgrDp); errTnt == nil && eeTnt != utils.EmptyString {
 				tnt = eeTnt
 			}
-			if pass, errPass := eeS.filterS.Pass(tnt,
-				eeCfg.Filters, cgrDp); errPass != nil {
-				return errPass
+			var pass bool
+			if pass, err = eeS.filterS.Pass(tnt,
+				eeCfg.Filters, cgrDp); err != nil {
+				break // wait for the exporters already started so they can close
 			} else if !pass {
 				continue // does not pass the filters, ignore the exporter
 			}
@@ -179,7 +201,7 @@ func (eeS *EventExporterS) V1ProcessEvent(cgrEv *utils.CGREventWithEeIDs, rply *
 					eeCfg.AttributeSCtx,
 					utils.IfaceAsString(cgrEv.Opts[utils.OptsContext]),
 					utils.MetaEEs)); err != nil {
-				return
+				break
 			}
 		}
 
@@ -196,7 +218,7 @@ func (eeS *EventExporterS) V1ProcessEvent(cgrEv *utils.CGREventWithEeIDs, rply *
 		}
 		if !isCached {
 			if ee, err = NewEventExporter(eeS.cfg, cfgIdx, eeS.filterS); err != nil {
-				return
+				break
 			}
 			if hasCache {
 				eeCache.Set(eeCfg.ID, ee, nil)
@@ -216,10 +238,13 @@ func (eeS *EventExporterS) V1ProcessEvent(cgrEv *utils.CGREventWithEeIDs, rply *
 		}
 		go func(evict, sync bool, ee EventExporter) {
 			if err := ee.ExportEvent(cgrEv.CGREvent); err != nil {
+				failedEvents.WithLabelValues(ee.ID()).Inc()
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> with id <%s>, error: <%s>",
 						utils.EventExporterS, ee.ID(), err.Error()))
 				withErr = true
+			} else {
+				exportedEvents.WithLabelValues(ee.ID()).Inc()
 			}
 			if evict {
 				ee.OnEvicted("", nil) // so we can close ie the file
@@ -235,13 +260,22 @@ func (eeS *EventExporterS) V1ProcessEvent(cgrEv *utils.CGREventWithEeIDs, rply *
 		}(!hasCache, eeCfg.Synchronous, ee)
 	}
 	wg.Wait()
+	if err != nil {
+		return
+	}
 	if withErr {
 		err = utils.ErrPartiallyExecuted
 		return
 	}
 
-	*rply = make(map[string]map[string]interface{})
 	metricMapLock.Lock()
+	defer metricMapLock.Unlock()
+	return metricsReply(metricsMap, rply)
+}
+
+// metricsReply converts the metrics of the exporters into the API reply
+func metricsReply(metricsMap map[string]utils.MapStorage, rply *map[string]map[string]interface{}) (err error) {
+	*rply = make(map[string]map[string]interface{})
 	for exporterID, metrics := range metricsMap {
 		(*rply)[exporterID] = make(map[string]interface{})
 		for key, val := range metrics {
@@ -258,13 +292,99 @@ func (eeS *EventExporterS) V1ProcessEvent(cgrEv *utils.CGREventWithEeIDs, rply *
 		}
 
 	}
-	metricMapLock.Unlock()
 	if len(*rply) == 0 {
 		return utils.ErrNotFound
 	}
 	return
 }
 
+// V1ProcessEvents exports a batch of events synchronously, each exporter building one file out of the whole batch
+// the cached exporters are not used so the batch is never mixed with other events
+func (eeS *EventExporterS) V1ProcessEvents(args *utils.CGREventsWithEeIDs, rply *map[string]map[string]interface{}) (err error) {
+	eeS.cfg.RLocks(config.EEsJson)
+	defer eeS.cfg.RUnlocks(config.EEsJson)
+
+	expIDs := utils.NewStringSet(args.EeIDs)
+	var withErr bool
+	metricsMap := make(map[string]utils.MapStorage)
+	for cfgIdx, eeCfg := range eeS.cfg.EEsNoLksCfg().Exporters {
+		if eeCfg.Type == utils.MetaNone || // ignore *none type exporter
+			(expIDs.Size() != 0 && !expIDs.Has(eeCfg.ID)) {
+			continue
+		}
+		ee, expErr, err := eeS.exportEvents(cfgIdx, eeCfg, args)
+		if ee != nil {
+			ee.OnEvicted(utils.EmptyString, nil) // closes the file, also when the batch was interrupted
+			metricsMap[ee.ID()] = ee.GetMetrics()
+		}
+		if err != nil {
+			return err
+		}
+		withErr = withErr || expErr
+	}
+	if withErr {
+		return utils.ErrPartiallyExecuted
+	}
+	return metricsReply(metricsMap, rply)
+}
+
+// exportEvents exports the events of the batch passing the filters with a new exporter
+// the exporter is created with the first event passing the filters and returned even on error so it can be closed
+func (eeS *EventExporterS) exportEvents(cfgIdx int, eeCfg *config.EventExporterCfg,
+	args *utils.CGREventsWithEeIDs) (ee EventExporter, withErr bool, err error) {
+	for _, cgrEv := range args.Events {
+		if cgrEv.Tenant == utils.EmptyString {
+			cgrEv.Tenant = args.Tenant
+		}
+		if cgrEv.Opts == nil {
+			cgrEv.Opts = args.Opts
+		}
+		if len(eeCfg.Filters) != 0 {
+			cgrDp := utils.MapStorage{
+				utils.MetaReq:  cgrEv.Event,
+				utils.MetaOpts: cgrEv.Opts,
+			}
+			tnt := utils.FirstNonEmpty(cgrEv.Tenant, eeS.cfg.GeneralCfg().DefaultTenant)
+			if eeTnt, errTnt := eeCfg.Tenant.ParseDataProvider(cgrDp); errTnt == nil && eeTnt != utils.EmptyString {
+				tnt = eeTnt
+			}
+			var pass bool
+			if pass, err = eeS.filterS.Pass(tnt,
+				eeCfg.Filters, cgrDp); err != nil {
+				return
+			} else if !pass {
+				continue // does not pass the filters, ignore the event for this exporter
+			}
+		}
+		if eeCfg.Flags.GetBool(utils.MetaAttributes) {
+			if err = eeS.attrSProcessEvent(
+				cgrEv,
+				eeCfg.AttributeSIDs,
+				utils.FirstNonEmpty(
+					eeCfg.AttributeSCtx,
+					utils.IfaceAsString(cgrEv.Opts[utils.OptsContext]),
+					utils.MetaEEs)); err != nil {
+				return
+			}
+		}
+		if ee == nil {
+			if ee, err = NewEventExporter(eeS.cfg, cfgIdx, eeS.filterS); err != nil {
+				return
+			}
+		}
+		if errExp := ee.ExportEvent(cgrEv); errExp != nil {
+			failedEvents.WithLabelValues(ee.ID()).Inc()
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> with id <%s>, error: <%s>",
+					utils.EventExporterS, ee.ID(), errExp.Error()))
+			withErr = true
+		} else {
+			exportedEvents.WithLabelValues(ee.ID()).Inc()
+		}
+	}
+	return
+}
+
 func newEEMetrics(location string) (utils.MapStorage, error) {
 	tNow := time.Now()
 	loc, err := time.LoadLocation(location)
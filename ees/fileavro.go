@@ -0,0 +1,144 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"math/big"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/linkedin/goavro/v2"
+)
+
+// avroBlockRecords is the maximum number of records appended into one block of the object container file
+const avroBlockRecords = 1000
+
+// NewFileAvroEE returns an exporter writing .avro object container files
+func NewFileAvroEE(cgrCfg *config.CGRConfig, cfgIdx int, filterS *engine.FilterS,
+	dc utils.MapStorage) (*FileColumnarEE, error) {
+	return newFileColumnarEE(cgrCfg, cfgIdx, filterS, dc, utils.AvroSuffix, newAvroWriter)
+}
+
+// avroSchema returns the record schema for the columns, all of them being nullable
+func avroSchema(cols []*exportColumn) (string, error) {
+	fields := make([]map[string]interface{}, len(cols))
+	for i, col := range cols {
+		var typ interface{}
+		switch col.typ {
+		case timeColumn:
+			typ = map[string]interface{}{"type": "long", "logicalType": "timestamp-micros"}
+		case durationColumn, intColumn:
+			typ = "long"
+		case decimalColumn:
+			typ = map[string]interface{}{"type": "bytes", "logicalType": "decimal",
+				"precision": decimalPrecision, "scale": col.scale}
+		default:
+			typ = "string"
+		}
+		fields[i] = map[string]interface{}{
+			"name":    col.name,
+			"type":    []interface{}{"null", typ},
+			"default": nil,
+		}
+	}
+	schema, err := json.Marshal(map[string]interface{}{
+		"type":   "record",
+		"name":   "CGREvent",
+		"fields": fields,
+	})
+	return string(schema), err
+}
+
+func newAvroWriter(w io.Writer, cols []*exportColumn, rollSize int64) (columnarWriter, error) {
+	schema, err := avroSchema(cols)
+	if err != nil {
+		return nil, err
+	}
+	ocfw, err := goavro.NewOCFWriter(goavro.OCFConfig{
+		W:               w,
+		Schema:          schema,
+		CompressionName: goavro.CompressionSnappyLabel,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &avroWriter{ocfw: ocfw, cols: cols, rollSize: rollSize}, nil
+}
+
+// avroWriter implements columnarWriter for avro object container files
+// the records are buffered and appended in blocks of avroBlockRecords
+type avroWriter struct {
+	ocfw      *goavro.OCFWriter
+	cols      []*exportColumn
+	rollSize  int64         // append the block earlier so the file can be rolled by size
+	block     []interface{} // records waiting to be appended
+	blockSize int64         // estimated size of the block, in bytes
+}
+
+func (aWr *avroWriter) Write(record []interface{}) error {
+	datum := make(map[string]interface{}, len(record))
+	for i, val := range record {
+		if val == nil {
+			datum[aWr.cols[i].name] = nil
+			continue
+		}
+		if str, isStr := val.(string); isStr {
+			aWr.blockSize += int64(len(str))
+		} else {
+			aWr.blockSize += 8
+		}
+		switch aWr.cols[i].typ {
+		case timeColumn:
+			datum[aWr.cols[i].name] = goavro.Union("long.timestamp-micros", val)
+		case durationColumn, intColumn:
+			datum[aWr.cols[i].name] = goavro.Union("long", val)
+		case decimalColumn:
+			datum[aWr.cols[i].name] = goavro.Union("bytes.decimal",
+				big.NewRat(val.(int64), int64(math.Pow10(aWr.cols[i].scale))))
+		default:
+			datum[aWr.cols[i].name] = goavro.Union("string", val)
+		}
+	}
+	aWr.block = append(aWr.block, datum)
+	if len(aWr.block) < avroBlockRecords &&
+		(aWr.rollSize <= 0 || aWr.blockSize < aWr.rollSize) {
+		return nil
+	}
+	return aWr.flush()
+}
+
+// flush appends the buffered records as one block
+func (aWr *avroWriter) flush() (err error) {
+	if len(aWr.block) == 0 {
+		return
+	}
+	err = aWr.ocfw.Append(aWr.block)
+	aWr.block = nil
+	aWr.blockSize = 0
+	return
+}
+
+// Close appends the records still buffered as the last block
+func (aWr *avroWriter) Close() error {
+	return aWr.flush()
+}
@@ -0,0 +1,351 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// column types used when building the schema of the columnar files
+const (
+	stringColumn = iota
+	timeColumn
+	durationColumn
+	intColumn
+	decimalColumn
+)
+
+// decimalPrecision is the number of digits stored for decimal columns
+const decimalPrecision = 18
+
+// exportColumn is one typed column built out of a fields template
+type exportColumn struct {
+	name  string
+	path  []string // path inside the exported navigable map
+	typ   int
+	scale int // used by the decimal columns
+}
+
+// newExportColumn derives the column type out of the template
+func newExportColumn(fc *config.FCTemplate, roundDec int) (col *exportColumn) {
+	pathSlice := fc.GetPathSlice()[1:] // remove the *exp prefix
+	col = &exportColumn{
+		name:  avroName(strings.Join(pathSlice, utils.Underline)),
+		path:  pathSlice,
+		scale: roundDec,
+	}
+	if fc.RoundingDecimals != nil {
+		col.scale = *fc.RoundingDecimals
+	}
+	switch {
+	case fc.Type == utils.MetaUsageDifference,
+		fc.Type == utils.MetaCCUsage:
+		col.typ = durationColumn
+	case fc.Type == utils.MetaUnixTimestamp:
+		col.typ = intColumn
+	case fc.RoundingDecimals != nil:
+		col.typ = decimalColumn
+	default: // the well known CDR fields
+		switch pathSlice[len(pathSlice)-1] {
+		case utils.SetupTime, utils.AnswerTime:
+			col.typ = timeColumn
+		case utils.Usage:
+			col.typ = durationColumn
+		case utils.Cost:
+			col.typ = decimalColumn
+		}
+	}
+	return
+}
+
+// avroName replaces the characters not accepted inside the field names
+func avroName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' ||
+			(r >= 'a' && r <= 'z') ||
+			(r >= 'A' && r <= 'Z') ||
+			(r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// pathKey returns the path of the exported item without indexes
+func pathKey(pathItms utils.PathItems) string {
+	flds := make([]string, len(pathItms))
+	for i, pItm := range pathItms {
+		flds[i] = pItm.Field
+	}
+	return strings.Join(flds, utils.NestingSep)
+}
+
+// value converts the exported item to the column type, nil for the empty ones
+func (col *exportColumn) value(itm interface{}, tmz string) (val interface{}, err error) {
+	if itm == nil || utils.IfaceAsString(itm) == utils.EmptyString {
+		return
+	}
+	switch col.typ {
+	case timeColumn:
+		var t time.Time
+		if t, err = utils.IfaceAsTime(itm, tmz); err != nil {
+			return
+		}
+		return t, nil
+	case durationColumn:
+		var d time.Duration
+		if d, err = utils.IfaceAsDuration(itm); err != nil {
+			return
+		}
+		return int64(d), nil
+	case intColumn:
+		return utils.IfaceAsTInt64(itm)
+	case decimalColumn:
+		var f float64
+		if f, err = utils.IfaceAsFloat64(itm); err != nil {
+			return
+		}
+		return int64(math.Round(f * math.Pow10(col.scale))), nil // unscaled value
+	default:
+		return utils.IfaceAsString(itm), nil
+	}
+}
+
+// columnarWriter writes typed records into one columnar file
+type columnarWriter interface {
+	Write(record []interface{}) error
+	Close() error // flushes the remaining data without closing the underlying writer
+}
+
+// newColumnarWriterFunc builds the columnarWriter over the opened file
+type newColumnarWriterFunc func(w io.Writer, cols []*exportColumn, rollSize int64) (columnarWriter, error)
+
+// countWriter counts the bytes written so we can roll the files by size
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countWriter) Write(p []byte) (n int, err error) {
+	n, err = cw.w.Write(p)
+	cw.n += int64(n)
+	return
+}
+
+func newFileColumnarEE(cgrCfg *config.CGRConfig, cfgIdx int, filterS *engine.FilterS,
+	dc utils.MapStorage, suffix string, newWriter newColumnarWriterFunc) (fClm *FileColumnarEE, err error) {
+	fClm = &FileColumnarEE{id: cgrCfg.EEsCfg().Exporters[cfgIdx].ID,
+		cgrCfg: cgrCfg, cfgIdx: cfgIdx, filterS: filterS, dc: dc,
+		suffix: suffix, newWriter: newWriter}
+	err = fClm.init()
+	return
+}
+
+// FileColumnarEE implements EventExporter interface for the columnar files (parquet and avro)
+type FileColumnarEE struct {
+	id        string
+	cgrCfg    *config.CGRConfig
+	cfgIdx    int // index of config instance within ERsCfg.Readers
+	filterS   *engine.FilterS
+	suffix    string
+	newWriter newColumnarWriterFunc
+	cols      []*exportColumn
+	colIdx    map[string]int // index of the column based on its path
+	rollSize  int64          // start a new file after this number of bytes, 0 to disable
+	rollIntvl time.Duration  // start a new file after this interval, 0 to disable
+	file      *os.File
+	cntr      *countWriter
+	wrtr      columnarWriter
+	fileStart time.Time
+	fileRecs  int // number of records in the current file
+	dc        utils.MapStorage
+	sync.RWMutex
+}
+
+// init will create all the necessary dependencies, including opening the file
+func (fClm *FileColumnarEE) init() (err error) {
+	eeCfg := fClm.cgrCfg.EEsCfg().Exporters[fClm.cfgIdx]
+	if len(eeCfg.ContentFields()) == 0 {
+		return fmt.Errorf("<%s> %s for exporter with id: <%s>",
+			utils.EventExporterS, utils.NewErrMandatoryIeMissing(utils.FieldsCfg), fClm.id)
+	}
+	if val, has := eeCfg.Opts[utils.FileRollSize]; has {
+		if fClm.rollSize, err = utils.IfaceAsTInt64(val); err != nil {
+			return
+		}
+	}
+	if val, has := eeCfg.Opts[utils.FileRollInterval]; has {
+		if fClm.rollIntvl, err = utils.IfaceAsDuration(val); err != nil {
+			return
+		}
+	}
+	fClm.cols = make([]*exportColumn, 0, len(eeCfg.ContentFields()))
+	fClm.colIdx = make(map[string]int)
+	for _, fc := range eeCfg.ContentFields() {
+		if fc.GetPathSlice()[0] != utils.MetaExp { // *uch fields are not exported
+			continue
+		}
+		col := newExportColumn(fc, fClm.cgrCfg.GeneralCfg().RoundingDecimals)
+		if _, has := fClm.colIdx[strings.Join(col.path, utils.NestingSep)]; has {
+			continue // same path written multiple times
+		}
+		fClm.colIdx[strings.Join(col.path, utils.NestingSep)] = len(fClm.cols)
+		fClm.cols = append(fClm.cols, col)
+	}
+	fClm.Lock()
+	err = fClm.openFile()
+	fClm.Unlock()
+	return
+}
+
+// openFile creates a new file in the export path together with its writer
+func (fClm *FileColumnarEE) openFile() (err error) {
+	filePath := path.Join(fClm.cgrCfg.EEsCfg().Exporters[fClm.cfgIdx].ExportPath,
+		fClm.id+utils.Underline+utils.UUIDSha1Prefix()+fClm.suffix)
+	fClm.dc[utils.ExportPath] = filePath
+	if fClm.file, err = os.Create(filePath); err != nil {
+		return
+	}
+	fClm.cntr = &countWriter{w: fClm.file}
+	fClm.fileStart = time.Now()
+	fClm.fileRecs = 0
+	if fClm.wrtr, err = fClm.newWriter(fClm.cntr, fClm.cols, fClm.rollSize); err != nil {
+		fClm.file.Close()
+		fClm.file = nil
+	}
+	return
+}
+
+// closeFile finishes the current file
+func (fClm *FileColumnarEE) closeFile() (err error) {
+	if fClm.file == nil {
+		return
+	}
+	err = fClm.wrtr.Close()
+	if errClose := fClm.file.Close(); err == nil {
+		err = errClose
+	}
+	fClm.file = nil
+	return
+}
+
+// needsRoll checks if one of the roll limits was reached by the current file
+func (fClm *FileColumnarEE) needsRoll() bool {
+	if fClm.file == nil {
+		return true
+	}
+	if fClm.fileRecs == 0 { // never leave empty files behind
+		return false
+	}
+	return (fClm.rollSize > 0 && fClm.cntr.n >= fClm.rollSize) ||
+		(fClm.rollIntvl > 0 && time.Since(fClm.fileStart) >= fClm.rollIntvl)
+}
+
+// rollFile starts a new file if one of the roll limits was reached
+func (fClm *FileColumnarEE) rollFile() (err error) {
+	if !fClm.needsRoll() {
+		return
+	}
+	if err = fClm.closeFile(); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> Exporter with id: <%s> received error: <%s> when closing the file",
+			utils.EventExporterS, fClm.id, err.Error()))
+	}
+	return fClm.openFile()
+}
+
+// ID returns the identificator of this exporter
+func (fClm *FileColumnarEE) ID() string {
+	return fClm.id
+}
+
+// OnEvicted implements EventExporter, doing the cleanup before exit
+func (fClm *FileColumnarEE) OnEvicted(_ string, _ interface{}) {
+	fClm.Lock()
+	if err := fClm.closeFile(); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> Exporter with id: <%s> received error: <%s> when closing the file",
+			utils.EventExporterS, fClm.id, err.Error()))
+	}
+	fClm.Unlock()
+}
+
+// ExportEvent implements EventExporter
+func (fClm *FileColumnarEE) ExportEvent(cgrEv *utils.CGREvent) (err error) {
+	fClm.Lock()
+	defer func() {
+		if err != nil {
+			fClm.dc[utils.NegativeExports].(utils.StringSet).Add(cgrEv.ID)
+		} else {
+			fClm.dc[utils.PositiveExports].(utils.StringSet).Add(cgrEv.ID)
+		}
+		fClm.Unlock()
+	}()
+	fClm.dc[utils.NumberOfEvents] = fClm.dc[utils.NumberOfEvents].(int64) + 1
+
+	tmz := utils.FirstNonEmpty(fClm.cgrCfg.EEsCfg().Exporters[fClm.cfgIdx].Timezone,
+		fClm.cgrCfg.GeneralCfg().DefaultTimezone)
+	oNm := map[string]*utils.OrderedNavigableMap{
+		utils.MetaExp: utils.NewOrderedNavigableMap(),
+	}
+	eeReq := engine.NewEventRequest(utils.MapStorage(cgrEv.Event), fClm.dc, cgrEv.Opts,
+		fClm.cgrCfg.EEsCfg().Exporters[fClm.cfgIdx].Tenant,
+		fClm.cgrCfg.GeneralCfg().DefaultTenant,
+		tmz, fClm.filterS, oNm)
+	if err = eeReq.SetFields(fClm.cgrCfg.EEsCfg().Exporters[fClm.cfgIdx].ContentFields()); err != nil {
+		return
+	}
+	record := make([]interface{}, len(fClm.cols)) // the fields not populated(ie. filtered out) remain nil
+	for el := eeReq.OrdNavMP[utils.MetaExp].GetFirstElement(); el != nil; el = el.Next() {
+		colIdx, has := fClm.colIdx[pathKey(el.Value)]
+		if !has {
+			continue
+		}
+		var nmIt utils.NMInterface
+		if nmIt, err = eeReq.OrdNavMP[utils.MetaExp].Field(el.Value); err != nil {
+			return
+		}
+		if record[colIdx], err = fClm.cols[colIdx].value(nmIt.Interface(), tmz); err != nil {
+			return
+		}
+	}
+	updateEEMetrics(fClm.dc, cgrEv.Event, tmz)
+	if err = fClm.rollFile(); err != nil {
+		return
+	}
+	if err = fClm.wrtr.Write(record); err != nil {
+		return
+	}
+	fClm.fileRecs++
+	return
+}
+
+// GetMetrics returns the metrics of this exporter
+func (fClm *FileColumnarEE) GetMetrics() utils.MapStorage {
+	return fClm.dc.Clone()
+}
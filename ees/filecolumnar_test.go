@@ -0,0 +1,261 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/linkedin/goavro/v2"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func newColumnarTestCfg(t *testing.T, expType, opts string) *config.CGRConfig {
+	cgrCfg, err := config.NewCGRConfigFromJSONStringWithDefaults(fmt.Sprintf(`{
+"ees": {
+	"exporters": [
+		{
+			"id": "columnar",
+			"type": "%s",
+			"export_path": "%s",
+			"opts": %s,
+			"fields":[
+				{"tag": "CGRID", "path": "*exp.CGRID", "type": "*variable", "value": "~*req.CGRID"},
+				{"tag": "AnswerTime", "path": "*exp.AnswerTime", "type": "*variable", "value": "~*req.AnswerTime"},
+				{"tag": "Usage", "path": "*exp.Usage", "type": "*variable", "value": "~*req.Usage"},
+				{"tag": "Cost", "path": "*exp.Cost", "type": "*variable", "value": "~*req.Cost", "rounding_decimals": 2},
+				{"tag": "CacheCGRID", "path": "*uch.CGRID", "type": "*variable", "value": "~*req.CGRID"},
+			],
+		},
+	],
+},
+}`, expType, t.TempDir(), opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cgrCfg
+}
+
+var columnarTestEvents = []*utils.CGREvent{
+	{
+		Tenant: "cgrates.org",
+		ID:     "ev1",
+		Event: map[string]interface{}{
+			utils.CGRID:      "cgrid1",
+			utils.AnswerTime: time.Date(2021, 1, 5, 10, 0, 0, 0, time.UTC),
+			utils.Usage:      time.Minute,
+			utils.Cost:       1.2345,
+		},
+	},
+	{
+		Tenant: "cgrates.org",
+		ID:     "ev2",
+		Event: map[string]interface{}{
+			utils.CGRID: "cgrid2",
+			utils.Usage: "10s",
+		},
+	},
+}
+
+func TestNewExportColumn(t *testing.T) {
+	cgrCfg := newColumnarTestCfg(t, utils.MetaFileParquet, "{}")
+	var rcv []*exportColumn
+	for _, fc := range cgrCfg.EEsCfg().Exporters[1].ContentFields()[:4] {
+		rcv = append(rcv, newExportColumn(fc, 4))
+	}
+	fc := &config.FCTemplate{Path: "*exp.Extra.Field-1", Type: utils.MetaCCUsage}
+	fc.ComputePath()
+	rcv = append(rcv, newExportColumn(fc, 4))
+	exp := []*exportColumn{
+		{name: "CGRID", path: []string{"CGRID"}, typ: stringColumn, scale: 4},
+		{name: "AnswerTime", path: []string{"AnswerTime"}, typ: timeColumn, scale: 4},
+		{name: "Usage", path: []string{"Usage"}, typ: durationColumn, scale: 4},
+		{name: "Cost", path: []string{"Cost"}, typ: decimalColumn, scale: 2},
+		{name: "Extra_Field_1", path: []string{"Extra", "Field-1"}, typ: durationColumn, scale: 4},
+	}
+	if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
+
+type parquetTestRow struct {
+	CGRID      *string `parquet:"name=CGRID, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	AnswerTime *int64  `parquet:"name=AnswerTime, type=INT64, convertedtype=TIMESTAMP_MICROS, repetitiontype=OPTIONAL"`
+	Usage      *int64  `parquet:"name=Usage, type=INT64, repetitiontype=OPTIONAL"`
+	Cost       *int64  `parquet:"name=Cost, type=INT64, convertedtype=DECIMAL, scale=2, precision=18, repetitiontype=OPTIONAL"`
+}
+
+func TestFileParquetExportEvent(t *testing.T) {
+	cgrCfg := newColumnarTestCfg(t, utils.MetaFileParquet, "{}")
+	dc, _ := newEEMetrics(utils.EmptyString)
+	ee, err := NewEventExporter(cgrCfg, 1, new(engine.FilterS))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ev := range columnarTestEvents {
+		if err = ee.ExportEvent(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ee.OnEvicted(utils.EmptyString, nil)
+	if dc = ee.GetMetrics(); dc[utils.NumberOfEvents] != int64(2) {
+		t.Errorf("Unexpected metrics: %s", utils.ToJSON(dc))
+	}
+	content, err := ioutil.ReadFile(utils.IfaceAsString(dc[utils.ExportPath]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pf, err := buffer.NewBufferFile(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pr, err := reader.NewParquetReader(pf, new(parquetTestRow), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pr.ReadStop()
+	rows := make([]parquetTestRow, pr.GetNumRows())
+	if err = pr.Read(&rows); err != nil {
+		t.Fatal(err)
+	}
+	exp := []parquetTestRow{
+		{
+			CGRID:      utils.StringPointer("cgrid1"),
+			AnswerTime: utils.Int64Pointer(time.Date(2021, 1, 5, 10, 0, 0, 0, time.UTC).UnixNano() / 1000),
+			Usage:      utils.Int64Pointer(int64(time.Minute)),
+			Cost:       utils.Int64Pointer(123),
+		},
+		{
+			CGRID: utils.StringPointer("cgrid2"),
+			Usage: utils.Int64Pointer(int64(10 * time.Second)),
+		},
+	}
+	if !reflect.DeepEqual(exp, rows) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rows))
+	}
+}
+
+func TestFileAvroExportEvent(t *testing.T) {
+	cgrCfg := newColumnarTestCfg(t, utils.MetaFileAvro, "{}")
+	ee, err := NewEventExporter(cgrCfg, 1, new(engine.FilterS))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ev := range columnarTestEvents {
+		if err = ee.ExportEvent(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ee.OnEvicted(utils.EmptyString, nil)
+	content, err := ioutil.ReadFile(utils.IfaceAsString(ee.GetMetrics()[utils.ExportPath]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ocfr, err := goavro.NewOCFReader(bytes.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rcv []interface{}
+	for ocfr.Scan() {
+		datum, err := ocfr.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rcv) == 0 && ocfr.RemainingBlockItems() != 1 {
+			t.Errorf("Expected the records appended in one block, remaining: %d", ocfr.RemainingBlockItems())
+		}
+		rcv = append(rcv, datum)
+	}
+	exp := []interface{}{
+		map[string]interface{}{
+			"CGRID":      goavro.Union("string", "cgrid1"),
+			"AnswerTime": goavro.Union("long.timestamp-micros", time.Date(2021, 1, 5, 10, 0, 0, 0, time.UTC)),
+			"Usage":      goavro.Union("long", int64(time.Minute)),
+			"Cost":       goavro.Union("bytes.decimal", big.NewRat(123, 100)),
+		},
+		map[string]interface{}{
+			"CGRID":      goavro.Union("string", "cgrid2"),
+			"AnswerTime": nil,
+			"Usage":      goavro.Union("long", int64(10*time.Second)),
+			"Cost":       nil,
+		},
+	}
+	if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %+v, received %+v", exp, rcv)
+	}
+}
+
+func TestFileColumnarRollFiles(t *testing.T) {
+	cgrCfg := newColumnarTestCfg(t, utils.MetaFileAvro, `{"fileRollSize": 1}`)
+	ee, err := NewEventExporter(cgrCfg, 1, new(engine.FilterS))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ev := range columnarTestEvents {
+		if err = ee.ExportEvent(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ee.OnEvicted(utils.EmptyString, nil)
+	if files, err := filepath.Glob(filepath.Join(cgrCfg.EEsCfg().Exporters[1].ExportPath, "*"+utils.AvroSuffix)); err != nil {
+		t.Error(err)
+	} else if len(files) != 2 {
+		t.Errorf("Expected one file per event, received: %+v", files)
+	}
+
+	cgrCfg = newColumnarTestCfg(t, utils.MetaFileParquet, `{"fileRollInterval": "1h"}`)
+	if ee, err = NewEventExporter(cgrCfg, 1, new(engine.FilterS)); err != nil {
+		t.Fatal(err)
+	}
+	for _, ev := range columnarTestEvents {
+		if err = ee.ExportEvent(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fClm := ee.(*FileColumnarEE)
+	fClm.fileStart = fClm.fileStart.Add(-time.Hour) // simulate the interval passing
+	if err = ee.ExportEvent(columnarTestEvents[0]); err != nil {
+		t.Fatal(err)
+	}
+	ee.OnEvicted(utils.EmptyString, nil)
+	if files, err := filepath.Glob(filepath.Join(cgrCfg.EEsCfg().Exporters[1].ExportPath, "*"+utils.ParquetSuffix)); err != nil {
+		t.Error(err)
+	} else if len(files) != 2 {
+		t.Errorf("Expected a new file after the interval, received: %+v", files)
+	}
+}
+
+func TestFileColumnarNoFields(t *testing.T) {
+	cgrCfg := config.NewDefaultCGRConfig()
+	cgrCfg.EEsCfg().Exporters[0].Type = utils.MetaFileParquet
+	expErr := "<EventExporterS> MANDATORY_IE_MISSING: [fields] for exporter with id: <*default>"
+	if _, err := NewEventExporter(cgrCfg, 0, new(engine.FilterS)); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+}
@@ -0,0 +1,248 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// docField is one field of the exported document
+type docField struct {
+	Tag   string // the tag of the template
+	Path  string // the path of the template, without the *hdr, *exp or *trl prefix
+	Value string
+}
+
+// exportDoc is the document built out of all the events exported till the exporter is evicted
+type exportDoc struct {
+	Header  []*docField   // composed out of the first event
+	Content [][]*docField // one record for each event
+	Trailer []*docField   // composed out of the last event
+}
+
+// docWriterFunc writes the document in the file format
+type docWriterFunc func(w io.Writer, doc *exportDoc) error
+
+func newFileDocEE(cgrCfg *config.CGRConfig, cfgIdx int, filterS *engine.FilterS,
+	dc utils.MapStorage, suffix string, writeDoc docWriterFunc) (fDoc *FileDocEE, err error) {
+	fDoc = &FileDocEE{id: cgrCfg.EEsCfg().Exporters[cfgIdx].ID,
+		cgrCfg: cgrCfg, cfgIdx: cfgIdx, filterS: filterS, dc: dc,
+		suffix: suffix, writeDoc: writeDoc, doc: new(exportDoc)}
+	err = fDoc.init()
+	return
+}
+
+// NewFileJSONee exports the events into one JSON document
+func NewFileJSONee(cgrCfg *config.CGRConfig, cfgIdx int, filterS *engine.FilterS,
+	dc utils.MapStorage) (*FileDocEE, error) {
+	return newFileDocEE(cgrCfg, cfgIdx, filterS, dc, utils.JSNSuffix, writeJSONDoc)
+}
+
+// NewFilePDFee exports the events into one PDF document
+func NewFilePDFee(cgrCfg *config.CGRConfig, cfgIdx int, filterS *engine.FilterS,
+	dc utils.MapStorage) (*FileDocEE, error) {
+	return newFileDocEE(cgrCfg, cfgIdx, filterS, dc, utils.PDFSuffix, writePDFDoc)
+}
+
+// FileDocEE implements EventExporter interface for the documents (*file_json and *file_pdf)
+// the file is written once the exporter is evicted, out of all the events exported till then
+type FileDocEE struct {
+	id       string
+	cgrCfg   *config.CGRConfig
+	cfgIdx   int // index of config instance within ERsCfg.Readers
+	filterS  *engine.FilterS
+	suffix   string
+	writeDoc docWriterFunc
+	filePath string
+	doc      *exportDoc
+	lastEv   *utils.CGREvent // used to compose the trailer
+	dc       utils.MapStorage
+	sync.RWMutex
+}
+
+// init will create all the necessary dependencies, the file being created when evicted
+func (fDoc *FileDocEE) init() (err error) {
+	fDoc.filePath = path.Join(fDoc.cgrCfg.EEsCfg().Exporters[fDoc.cfgIdx].ExportPath,
+		fDoc.id+utils.Underline+utils.UUIDSha1Prefix()+fDoc.suffix)
+	fDoc.Lock()
+	fDoc.dc[utils.ExportPath] = fDoc.filePath
+	fDoc.Unlock()
+	return
+}
+
+// ID returns the identificator of this exporter
+func (fDoc *FileDocEE) ID() string {
+	return fDoc.id
+}
+
+// OnEvicted implements EventExporter, writing the document
+func (fDoc *FileDocEE) OnEvicted(_ string, _ interface{}) {
+	fDoc.Lock()
+	defer fDoc.Unlock()
+	if fDoc.lastEv == nil { // no event exported, no document
+		return
+	}
+	var err error
+	if fDoc.doc.Trailer, err = fDoc.composeFields(utils.MetaTrl,
+		fDoc.cgrCfg.EEsCfg().Exporters[fDoc.cfgIdx].TrailerFields(), fDoc.lastEv); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> Exporter with id: <%s> received error: <%s> when composed trailer",
+			utils.EventExporterS, fDoc.id, err.Error()))
+	}
+	var file *os.File
+	if file, err = os.Create(fDoc.filePath); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> Exporter with id: <%s> received error: <%s> when creating the file",
+			utils.EventExporterS, fDoc.id, err.Error()))
+		return
+	}
+	if err = fDoc.writeDoc(file, fDoc.doc); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> Exporter with id: <%s> received error: <%s> when writing the file",
+			utils.EventExporterS, fDoc.id, err.Error()))
+	}
+	if err = file.Close(); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> Exporter with id: <%s> received error: <%s> when closing the file",
+			utils.EventExporterS, fDoc.id, err.Error()))
+	}
+}
+
+// ExportEvent implements EventExporter
+func (fDoc *FileDocEE) ExportEvent(cgrEv *utils.CGREvent) (err error) {
+	fDoc.Lock()
+	defer func() {
+		if err != nil {
+			fDoc.dc[utils.NegativeExports].(utils.StringSet).Add(cgrEv.ID)
+		} else {
+			fDoc.dc[utils.PositiveExports].(utils.StringSet).Add(cgrEv.ID)
+		}
+		fDoc.Unlock()
+	}()
+	fDoc.dc[utils.NumberOfEvents] = fDoc.dc[utils.NumberOfEvents].(int64) + 1
+
+	if fDoc.lastEv == nil { // first event
+		if fDoc.doc.Header, err = fDoc.composeFields(utils.MetaHdr,
+			fDoc.cgrCfg.EEsCfg().Exporters[fDoc.cfgIdx].HeaderFields(), cgrEv); err != nil {
+			return
+		}
+	}
+	var rcrd []*docField
+	if len(fDoc.cgrCfg.EEsCfg().Exporters[fDoc.cfgIdx].ContentFields()) == 0 {
+		flds := make([]string, 0, len(cgrEv.Event))
+		for fld := range cgrEv.Event {
+			flds = append(flds, fld)
+		}
+		sort.Strings(flds)
+		rcrd = make([]*docField, len(flds))
+		for i, fld := range flds {
+			rcrd[i] = &docField{Tag: fld, Path: fld, Value: utils.IfaceAsString(cgrEv.Event[fld])}
+		}
+	} else if rcrd, err = fDoc.composeFields(utils.MetaExp,
+		fDoc.cgrCfg.EEsCfg().Exporters[fDoc.cfgIdx].ContentFields(), cgrEv); err != nil {
+		return
+	}
+	updateEEMetrics(fDoc.dc, cgrEv.Event, utils.FirstNonEmpty(fDoc.cgrCfg.EEsCfg().Exporters[fDoc.cfgIdx].Timezone,
+		fDoc.cgrCfg.GeneralCfg().DefaultTimezone))
+	fDoc.doc.Content = append(fDoc.doc.Content, rcrd)
+	fDoc.lastEv = cgrEv
+	return
+}
+
+// composeFields builds the fields of the templates out of the event
+func (fDoc *FileDocEE) composeFields(prfx string, tpls []*config.FCTemplate,
+	cgrEv *utils.CGREvent) (flds []*docField, err error) {
+	if len(tpls) == 0 {
+		return
+	}
+	tags := make(map[string]string)
+	for _, tpl := range tpls {
+		if pathSlice := tpl.GetPathSlice(); len(pathSlice) > 1 {
+			key := strings.Join(pathSlice[1:], utils.NestingSep)
+			if _, has := tags[key]; !has {
+				tags[key] = tpl.Tag
+			}
+		}
+	}
+	oNm := map[string]*utils.OrderedNavigableMap{
+		prfx: utils.NewOrderedNavigableMap(),
+	}
+	eeReq := engine.NewEventRequest(utils.MapStorage(cgrEv.Event), fDoc.dc, cgrEv.Opts,
+		fDoc.cgrCfg.EEsCfg().Exporters[fDoc.cfgIdx].Tenant,
+		fDoc.cgrCfg.GeneralCfg().DefaultTenant,
+		utils.FirstNonEmpty(fDoc.cgrCfg.EEsCfg().Exporters[fDoc.cfgIdx].Timezone,
+			fDoc.cgrCfg.GeneralCfg().DefaultTimezone),
+		fDoc.filterS, oNm)
+	if err = eeReq.SetFields(tpls); err != nil {
+		return
+	}
+	for el := eeReq.OrdNavMP[prfx].GetFirstElement(); el != nil; el = el.Next() {
+		var strVal string
+		if strVal, err = eeReq.OrdNavMP[prfx].FieldAsString(el.Value.Slice()); err != nil {
+			return
+		}
+		key := pathKey(el.Value)
+		flds = append(flds, &docField{Tag: utils.FirstNonEmpty(tags[key], key), Path: key, Value: strVal})
+	}
+	return
+}
+
+// GetMetrics returns the metrics of this exporter
+func (fDoc *FileDocEE) GetMetrics() utils.MapStorage {
+	return fDoc.dc.Clone()
+}
+
+// docFieldsAsMap returns the fields indexed on their path
+func docFieldsAsMap(flds []*docField) (mp map[string]string) {
+	mp = make(map[string]string)
+	for _, fld := range flds {
+		mp[fld.Path] = fld.Value
+	}
+	return
+}
+
+// writeJSONDoc writes the document as one JSON object
+func writeJSONDoc(w io.Writer, doc *exportDoc) (err error) {
+	jsnDoc := struct {
+		Header  map[string]string   `json:",omitempty"`
+		Content []map[string]string // one object for each event
+		Trailer map[string]string   `json:",omitempty"`
+	}{
+		Content: make([]map[string]string, len(doc.Content)),
+	}
+	if len(doc.Header) != 0 {
+		jsnDoc.Header = docFieldsAsMap(doc.Header)
+	}
+	for i, rcrd := range doc.Content {
+		jsnDoc.Content[i] = docFieldsAsMap(rcrd)
+	}
+	if len(doc.Trailer) != 0 {
+		jsnDoc.Trailer = docFieldsAsMap(doc.Trailer)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent(utils.EmptyString, "\t")
+	return enc.Encode(jsnDoc)
+}
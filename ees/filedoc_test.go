@@ -0,0 +1,244 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func newDocTestCfg(t *testing.T, expType string) *config.CGRConfig {
+	cgrCfg, err := config.NewCGRConfigFromJSONStringWithDefaults(fmt.Sprintf(`{
+"ees": {
+	"exporters": [
+		{
+			"id": "doc",
+			"type": "%s",
+			"export_path": "%s",
+			"fields":[
+				{"tag": "Invoice", "path": "*hdr.InvoiceID", "type": "*variable", "value": "~*req.InvoiceID"},
+				{"tag": "Account", "path": "*hdr.Account", "type": "*variable", "value": "~*req.Account"},
+				{"tag": "Destination", "path": "*exp.Destination", "type": "*variable", "value": "~*req.Destination"},
+				{"tag": "Calls", "path": "*exp.Count", "type": "*variable", "value": "~*req.Count"},
+				{"tag": "Cost", "path": "*exp.Cost", "type": "*variable", "value": "~*req.Cost", "rounding_decimals": 2},
+				{"tag": "Total", "path": "*trl.Total", "type": "*variable", "value": "~*req.Total"},
+			],
+		},
+	],
+},
+}`, expType, t.TempDir()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cgrCfg
+}
+
+var docTestEvents = []*utils.CGREvent{
+	{
+		Tenant: "cgrates.org",
+		ID:     "INV1:1002",
+		Event: map[string]interface{}{
+			utils.InvoiceID:    "INV1",
+			utils.AccountField: "1001",
+			utils.Destination:  "1002",
+			utils.Count:        2,
+			utils.Cost:         1.8,
+			utils.Usage:        3 * time.Minute,
+			utils.Total:        3,
+		},
+	},
+	{
+		Tenant: "cgrates.org",
+		ID:     "INV1:1003",
+		Event: map[string]interface{}{
+			utils.InvoiceID:    "INV1",
+			utils.AccountField: "1001",
+			utils.Destination:  "1003",
+			utils.Count:        1,
+			utils.Cost:         1.2,
+			utils.Usage:        time.Minute,
+			utils.Total:        3,
+		},
+	},
+}
+
+func exportDocTestEvents(t *testing.T, expType string) []byte {
+	ee, err := NewEventExporter(newDocTestCfg(t, expType), 1, new(engine.FilterS))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ev := range docTestEvents {
+		if err = ee.ExportEvent(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ee.OnEvicted(utils.EmptyString, nil)
+	dc := ee.GetMetrics()
+	if dc[utils.NumberOfEvents] != int64(2) {
+		t.Errorf("Unexpected metrics: %s", utils.ToJSON(dc))
+	}
+	content, err := ioutil.ReadFile(utils.IfaceAsString(dc[utils.ExportPath]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func TestFileJSONExportEvent(t *testing.T) {
+	var rcv map[string]interface{}
+	if err := json.Unmarshal(exportDocTestEvents(t, utils.MetaFileJSON), &rcv); err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]interface{}{
+		"Header": map[string]interface{}{"InvoiceID": "INV1", "Account": "1001"},
+		"Content": []interface{}{
+			map[string]interface{}{"Destination": "1002", "Count": "2", "Cost": "1.8"},
+			map[string]interface{}{"Destination": "1003", "Count": "1", "Cost": "1.2"},
+		},
+		"Trailer": map[string]interface{}{"Total": "3"},
+	}
+	if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
+
+func TestFilePDFExportEvent(t *testing.T) {
+	content := exportDocTestEvents(t, utils.MetaFilePDF)
+	if !bytes.HasPrefix(content, []byte("%PDF-1.4\n")) ||
+		!bytes.HasSuffix(content, []byte("%%EOF\n")) {
+		t.Fatalf("Unexpected document: %s", content)
+	}
+	for _, line := range []string{
+		"(Invoice: INV1) Tj T*",
+		"(Account: 1001) Tj T*",
+		"(Destination  Calls  Cost) Tj T*",
+		"(-----------  -----  ----) Tj T*",
+		"(1002         2      1.8) Tj T*",
+		"(1003         1      1.2) Tj T*",
+		"(Total: 3) Tj T*",
+	} {
+		if !bytes.Contains(content, []byte(line)) {
+			t.Errorf("Expected %q within document: %s", line, content)
+		}
+	}
+	// the cross-reference table points to the objects
+	var xrefOffset int
+	if _, err := fmt.Sscanf(string(content[bytes.LastIndex(content, []byte("startxref\n")):]),
+		"startxref\n%d", &xrefOffset); err != nil {
+		t.Fatal(err)
+	}
+	xref := strings.Split(string(content[xrefOffset:]), "\n")
+	for i, entry := range xref[3:8] { // the catalog, pages, font, page and page content objects
+		var offset int
+		if _, err := fmt.Sscanf(entry, "%d", &offset); err != nil {
+			t.Fatal(err)
+		}
+		if objHdr := fmt.Sprintf("%d 0 obj", i+1); !bytes.HasPrefix(content[offset:], []byte(objHdr)) {
+			t.Errorf("Expected %q at offset %d", objHdr, offset)
+		}
+	}
+}
+
+func TestPDFText(t *testing.T) {
+	if rcv, exp := pdfText(`Cost (EUR) \ Ünterwegs ✓`), `Cost \(EUR\) \\ \334nterwegs ?`; rcv != exp {
+		t.Errorf("Expected %q, received %q", exp, rcv)
+	}
+}
+
+func TestPDFTableLinesRunes(t *testing.T) {
+	lines := pdfTableLines([][]*docField{
+		{{Tag: "Städt", Path: "City", Value: strings.Repeat("ü", 50)}},
+		{{Tag: "Städt", Path: "City", Value: "Köln"}},
+	})
+	exp := []string{
+		"Städt",
+		strings.Repeat("-", pdfMaxColumn),
+		strings.Repeat("ü", pdfMaxColumn),
+		"Köln",
+	}
+	if !reflect.DeepEqual(exp, lines) {
+		t.Errorf("Expected %q, received %q", exp, lines)
+	}
+}
+
+func TestWritePDFDocPages(t *testing.T) {
+	doc := &exportDoc{Content: make([][]*docField, 200)}
+	for i := range doc.Content {
+		doc.Content[i] = []*docField{ // too wide for portrait
+			{Tag: "Field1", Path: "Field1", Value: strings.Repeat("x", 50)},
+			{Tag: "Field2", Path: "Field2", Value: strings.Repeat("y", 50)},
+			{Tag: "Field3", Path: "Field3", Value: strings.Repeat("z", 50)},
+		}
+	}
+	var buf bytes.Buffer
+	if err := writePDFDoc(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	// the table of 202 lines goes in landscape on 5 pages
+	if !bytes.Contains(buf.Bytes(), []byte("/Count 5 >>")) ||
+		!bytes.Contains(buf.Bytes(), []byte("/MediaBox [0 0 842 595]")) {
+		t.Errorf("Unexpected document: %s", buf.Bytes())
+	}
+}
+
+func TestV1ProcessEventsClosesExporterOnError(t *testing.T) {
+	cgrCfg := newDocTestCfg(t, utils.MetaFileJSON)
+	cgrCfg.EEsCfg().Exporters[1].Filters = []string{"FLTR_DOC"}
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cgrCfg.CacheCfg(), nil)
+	if err := dm.SetFilter(&engine.Filter{
+		Tenant: "cgrates.org",
+		ID:     "FLTR_DOC",
+		Rules: []*engine.FilterRule{{
+			Type:    utils.MetaString,
+			Element: "~*req.InvoiceID",
+			Values:  []string{"INV1"},
+		}},
+	}, true); err != nil {
+		t.Fatal(err)
+	}
+	eeS, err := NewEventExporterS(cgrCfg, engine.NewFilterS(cgrCfg, nil, dm), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the filter is missing for the tenant of the second event
+	evs := []*utils.CGREvent{docTestEvents[0].Clone(), docTestEvents[1].Clone()}
+	evs[1].Tenant = "itsyscom.com"
+	var rply map[string]map[string]interface{}
+	if err = eeS.V1ProcessEvents(&utils.CGREventsWithEeIDs{Events: evs}, &rply); err == nil {
+		t.Fatal("Expected error for the missing filter")
+	}
+	// the exporter created for the first event was closed, writing the document
+	files, err := ioutil.ReadDir(cgrCfg.EEsCfg().Exporters[1].ExportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("Expected the document to be written, received %d files", len(files))
+	}
+}
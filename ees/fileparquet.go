@@ -0,0 +1,84 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// NewFileParquetEE returns an exporter writing .parquet files
+func NewFileParquetEE(cgrCfg *config.CGRConfig, cfgIdx int, filterS *engine.FilterS,
+	dc utils.MapStorage) (*FileColumnarEE, error) {
+	return newFileColumnarEE(cgrCfg, cfgIdx, filterS, dc, utils.ParquetSuffix, newParquetWriter)
+}
+
+// parquetSchema returns the schema metadata for the columns
+func parquetSchema(cols []*exportColumn) (md []string) {
+	md = make([]string, len(cols))
+	for i, col := range cols {
+		switch col.typ {
+		case timeColumn:
+			md[i] = fmt.Sprintf("name=%s, type=INT64, convertedtype=TIMESTAMP_MICROS, repetitiontype=OPTIONAL", col.name)
+		case durationColumn, intColumn:
+			md[i] = fmt.Sprintf("name=%s, type=INT64, repetitiontype=OPTIONAL", col.name)
+		case decimalColumn:
+			md[i] = fmt.Sprintf("name=%s, type=INT64, convertedtype=DECIMAL, scale=%d, precision=%d, repetitiontype=OPTIONAL",
+				col.name, col.scale, decimalPrecision)
+		default:
+			md[i] = fmt.Sprintf("name=%s, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL", col.name)
+		}
+	}
+	return
+}
+
+func newParquetWriter(w io.Writer, cols []*exportColumn, rollSize int64) (columnarWriter, error) {
+	pw, err := writer.NewCSVWriterFromWriter(parquetSchema(cols), w, 1)
+	if err != nil {
+		return nil, err
+	}
+	if rollSize > 0 && rollSize < pw.RowGroupSize { // flush the rows often enough to be able to roll by size
+		pw.RowGroupSize = rollSize
+	}
+	return &parquetWriter{pw: pw}, nil
+}
+
+// parquetWriter implements columnarWriter for parquet files
+type parquetWriter struct {
+	pw *writer.CSVWriter
+}
+
+func (pWr *parquetWriter) Write(record []interface{}) error {
+	for i, val := range record {
+		if t, isTime := val.(time.Time); isTime {
+			record[i] = t.UnixNano() / int64(time.Microsecond)
+		}
+	}
+	return pWr.pw.Write(record)
+}
+
+func (pWr *parquetWriter) Close() error {
+	return pWr.pw.WriteStop()
+}
@@ -0,0 +1,193 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// layout of the PDF documents, in points
+const (
+	pdfShortSide  = 595 // A4
+	pdfLongSide   = 842
+	pdfMargin     = 36
+	pdfFontSize   = 9
+	pdfLeading    = 11
+	pdfCharWidth  = 0.6 * pdfFontSize // Courier has fixed width characters
+	pdfMaxColumn  = 40                // longer values are truncated within the table
+	pdfColumnSep  = "  "
+	pdfHeaderLine = "-"
+)
+
+// pdfLineWidth returns the number of characters fitting within one line of the page
+func pdfLineWidth(pageWidth float64) int {
+	return int((pageWidth - 2*pdfMargin) / pdfCharWidth)
+}
+
+// pdfTableLines renders the content of the document as a table with the tags as column header
+func pdfTableLines(content [][]*docField) (lines []string) {
+	var cols []string // the paths of the fields, in the order of their first appearance
+	colIdx := make(map[string]int)
+	var tags []string
+	for _, rcrd := range content {
+		for _, fld := range rcrd {
+			if _, has := colIdx[fld.Path]; !has {
+				colIdx[fld.Path] = len(cols)
+				cols = append(cols, fld.Path)
+				tags = append(tags, fld.Tag)
+			}
+		}
+	}
+	widths := make([]int, len(cols))
+	for i, tag := range tags {
+		widths[i] = utf8.RuneCountInString(tag)
+	}
+	rows := make([][]string, len(content))
+	for i, rcrd := range content {
+		rows[i] = make([]string, len(cols))
+		for _, fld := range rcrd {
+			idx := colIdx[fld.Path]
+			val := fld.Value
+			if rns := []rune(val); len(rns) > pdfMaxColumn { // never cut a character in half
+				val = string(rns[:pdfMaxColumn])
+			}
+			rows[i][idx] = val
+			if valLen := utf8.RuneCountInString(val); valLen > widths[idx] {
+				widths[idx] = valLen
+			}
+		}
+	}
+	if len(tags) == 0 {
+		return
+	}
+	fmtRow := func(vals []string) string {
+		cells := make([]string, len(vals))
+		for i, val := range vals {
+			cells[i] = val + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(val))
+		}
+		return strings.TrimRight(strings.Join(cells, pdfColumnSep), " ")
+	}
+	lines = append(lines, fmtRow(tags))
+	seps := make([]string, len(cols))
+	for i, width := range widths {
+		seps[i] = strings.Repeat(pdfHeaderLine, width)
+	}
+	lines = append(lines, fmtRow(seps))
+	for _, row := range rows {
+		lines = append(lines, fmtRow(row))
+	}
+	return
+}
+
+// pdfFieldLines renders the header or the trailer, one field per line
+func pdfFieldLines(flds []*docField) (lines []string) {
+	for _, fld := range flds {
+		lines = append(lines, fld.Tag+": "+fld.Value)
+	}
+	return
+}
+
+// pdfText escapes the text for a PDF string, using WinAnsiEncoding
+func pdfText(txt string) string {
+	var buf strings.Builder
+	for _, r := range txt {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case r < ' ' || r > 0xff:
+			buf.WriteByte('?')
+		case r > '~':
+			fmt.Fprintf(&buf, "\\%03o", r)
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
+
+// writePDFDoc writes the document as PDF: the header lines, the content as table and the trailer lines
+// the pages are turned to landscape if the table does not fit in portrait
+func writePDFDoc(w io.Writer, doc *exportDoc) (err error) {
+	tblLines := pdfTableLines(doc.Content)
+	var tblWidth int
+	for _, ln := range tblLines {
+		if lnLen := utf8.RuneCountInString(ln); lnLen > tblWidth {
+			tblWidth = lnLen
+		}
+	}
+	pageWidth, pageHeight := float64(pdfShortSide), float64(pdfLongSide)
+	if tblWidth > pdfLineWidth(pageWidth) {
+		pageWidth, pageHeight = pageHeight, pageWidth
+	}
+	var lines []string
+	if hdrLines := pdfFieldLines(doc.Header); len(hdrLines) != 0 {
+		lines = append(hdrLines, "")
+	}
+	lines = append(lines, tblLines...)
+	if trlLines := pdfFieldLines(doc.Trailer); len(trlLines) != 0 {
+		lines = append(append(lines, ""), trlLines...)
+	}
+	pageLines := int((pageHeight - 2*pdfMargin) / pdfLeading)
+	var pages [][]string
+	for len(lines) > pageLines {
+		pages = append(pages, lines[:pageLines])
+		lines = lines[pageLines:]
+	}
+	pages = append(pages, lines)
+
+	var buf bytes.Buffer
+	var offsets []int // offset of each object, the object number being the index + 1
+	addObj := func(obj string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), obj)
+	}
+	buf.WriteString("%PDF-1.4\n")
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	addObj("<< /Type /Catalog /Pages 2 0 R >>")
+	addObj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	addObj("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	for i, pgLines := range pages {
+		var cntnt strings.Builder
+		fmt.Fprintf(&cntnt, "BT\n/F1 %d Tf\n%d TL\n%d %g Td\n", pdfFontSize, pdfLeading,
+			pdfMargin, pageHeight-pdfMargin-pdfFontSize)
+		for _, ln := range pgLines {
+			fmt.Fprintf(&cntnt, "(%s) Tj T*\n", pdfText(ln))
+		}
+		cntnt.WriteString("ET")
+		addObj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 5+2*i))
+		addObj(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", cntnt.Len(), cntnt.String()))
+	}
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xrefOffset)
+	_, err = w.Write(buf.Bytes())
+	return
+}
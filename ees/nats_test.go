@@ -0,0 +1,101 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+func TestNATSExportEvent(t *testing.T) {
+	srv, err := server.NewServer(&server.Options{
+		Host:   "127.0.0.1",
+		Port:   -1,
+		NoLog:  true,
+		NoSigs: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Start()
+	defer srv.Shutdown()
+	if !srv.ReadyForConnections(5 * time.Second) {
+		t.Fatal("embedded nats server not ready")
+	}
+	nc, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nc.Close()
+	sub, err := nc.SubscribeSync("cgrates_exports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = nc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	cgrCfg := config.NewDefaultCGRConfig()
+	cgrCfg.EEsCfg().Exporters[0].Type = utils.MetaNATSjsonMap
+	cgrCfg.EEsCfg().Exporters[0].ExportPath = srv.ClientURL()
+	cgrCfg.EEsCfg().Exporters[0].Opts = map[string]interface{}{
+		utils.NATSSubject: "cgrates_exports",
+	}
+	ee, err := NewEventExporter(cgrCfg, 0, new(engine.FilterS))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ee.OnEvicted(utils.EmptyString, nil)
+	if err = ee.ExportEvent(&utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "natsExport",
+		Event: map[string]interface{}{
+			utils.CGRID:        "cgrid1",
+			utils.AccountField: "1001",
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := sub.NextMsg(5 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rcv map[string]interface{}
+	if err = json.Unmarshal(msg.Data, &rcv); err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]interface{}{
+		utils.CGRID:        "cgrid1",
+		utils.AccountField: "1001",
+	}
+	if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %+v, received %+v", exp, rcv)
+	}
+	if rcv := ee.GetMetrics()[utils.PositiveExports]; !reflect.DeepEqual(utils.StringSet{"natsExport": {}}, rcv) {
+		t.Errorf("Unexpected positive exports: %+v", rcv)
+	}
+}
@@ -30,10 +30,24 @@ import (
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/ltcache"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 var Cache *CacheS
 
+var (
+	cacheHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: utils.MetricsNamespace,
+		Name:      "cache_hits_total",
+		Help:      "Number of cache lookups finding the item, per partition",
+	}, []string{utils.MetricsLabelPart})
+	cacheMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: utils.MetricsNamespace,
+		Name:      "cache_misses_total",
+		Help:      "Number of cache lookups not finding the item, per partition",
+	}, []string{utils.MetricsLabelPart})
+)
+
 func init() {
 	Cache = NewCacheS(config.CgrConfig(), nil, nil)
 	gob.Register(new(AttributeProfile))
@@ -85,6 +99,10 @@ func init() {
 	gob.Register(new(StatSum))
 	gob.Register(new(StatAverage))
 	gob.Register(new(StatDistinct))
+	gob.Register(new(StatPercentile))
+	gob.Register(new(StatStdDev))
+	gob.Register(new(StatMin))
+	gob.Register(new(StatMax))
 
 	gob.Register(new(HTTPPosterRequest))
 
@@ -106,8 +124,10 @@ func init() {
 }
 
 //SetCache shared the cache from other subsystems
+// the persisted internal databases are restored into the new cache
 func SetCache(chS *CacheS) {
 	Cache = chS
+	restoreInternalDBPersisters()
 }
 
 // NewCacheS initializes the Cache service and executes the precaching
@@ -176,9 +196,19 @@ func (chS *CacheS) HasItem(chID, itmID string) (has bool) {
 	return chS.tCache.HasItem(chID, itmID)
 }
 
+// MetricsCollectors returns the metrics recorded by the cache, registered by the PrometheusAgent
+func MetricsCollectors() []prometheus.Collector {
+	return []prometheus.Collector{cacheHits, cacheMisses}
+}
+
 // Get is an exported method from TransCache
-func (chS *CacheS) Get(chID, itmID string) (interface{}, bool) {
-	return chS.tCache.Get(chID, itmID)
+func (chS *CacheS) Get(chID, itmID string) (itm interface{}, has bool) {
+	if itm, has = chS.tCache.Get(chID, itmID); has {
+		cacheHits.WithLabelValues(chID).Inc()
+	} else {
+		cacheMisses.WithLabelValues(chID).Inc()
+	}
+	return
 }
 
 // GetItemIDs is an exported method from TransCache
@@ -208,13 +238,17 @@ func (chS *CacheS) BeginTransaction() string {
 }
 
 // RollbackTransaction is an exported method from TransCache
+// the changes staged for the persisted internal databases are discarded as well
 func (chS *CacheS) RollbackTransaction(transID string) {
 	chS.tCache.RollbackTransaction(transID)
+	rollbackInternalDBTransaction(transID)
 }
 
 // CommitTransaction is an exported method from TransCache
+// the changes staged for the persisted internal databases are logged as well
 func (chS *CacheS) CommitTransaction(transID string) {
 	chS.tCache.CommitTransaction(transID)
+	commitInternalDBTransaction(transID)
 }
 
 // GetCloned is an exported method from TransCache
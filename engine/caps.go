@@ -22,6 +22,7 @@ import (
 	"net"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cgrates/cgrates/utils"
@@ -31,6 +32,7 @@ import (
 type Caps struct {
 	strategy string
 	aReqs    chan struct{}
+	queued   int64 // requests waiting for a free slot, accessed atomically
 }
 
 // NewCaps creates a new caps
@@ -51,6 +53,16 @@ func (cR *Caps) Allocated() int {
 	return len(cR.aReqs)
 }
 
+// Limit returns the maximum number of requests serviced concurrently
+func (cR *Caps) Limit() int {
+	return cap(cR.aReqs)
+}
+
+// Queued returns the number of requests waiting for a free slot
+func (cR *Caps) Queued() int {
+	return int(atomic.LoadInt64(&cR.queued))
+}
+
 // Allocate will reserve a channel for the API call
 func (cR *Caps) Allocate() (err error) {
 	switch cR.strategy {
@@ -60,7 +72,13 @@ func (cR *Caps) Allocate() (err error) {
 		}
 		fallthrough
 	case utils.MetaQueue:
-		cR.aReqs <- struct{}{}
+		select {
+		case cR.aReqs <- struct{}{}:
+		default: // wait in queue for a free slot
+			atomic.AddInt64(&cR.queued, 1)
+			cR.aReqs <- struct{}{}
+			atomic.AddInt64(&cR.queued, -1)
+		}
 	}
 	return
 }
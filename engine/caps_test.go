@@ -57,6 +57,34 @@ func TestNewCaps(t *testing.T) {
 	cs.Deallocate()
 }
 
+func TestCapsQueued(t *testing.T) {
+	cs := NewCaps(1, utils.MetaQueue)
+	if err := cs.Allocate(); err != nil {
+		t.Fatal(err)
+	}
+	allocated := make(chan struct{})
+	go func() {
+		cs.Allocate()
+		close(allocated)
+	}()
+	for i := 0; i < 100 && cs.Queued() == 0; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if q := cs.Queued(); q != 1 {
+		t.Errorf("Expected 1 queued request, received: %d", q)
+	}
+	cs.Deallocate()
+	select {
+	case <-allocated:
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for the queued request")
+	}
+	if q := cs.Queued(); q != 0 {
+		t.Errorf("Expected no queued request, received: %d", q)
+	}
+	cs.Deallocate()
+}
+
 func TestCapsStats(t *testing.T) {
 	st, err := NewStatAverage(1, utils.MetaDynReq, nil)
 	if err != nil {
@@ -431,6 +431,22 @@ func (cdrS *CDRServer) statSProcessEvent(cgrEv *utils.CGREvent) (err error) {
 	return
 }
 
+// routeSProcessOutcome will report the outcome of the call to the circuit breaker of the route
+// only the events routed through a route(having the RouteID field) are sent to RouteS
+func (cdrS *CDRServer) routeSProcessOutcome(cgrEv *utils.CGREvent) (err error) {
+	if _, has := cgrEv.Event[utils.RouteID]; !has {
+		return
+	}
+	var reply string
+	if err = cdrS.connMgr.Call(cdrS.cgrCfg.CdrsCfg().RouteSConns, nil,
+		utils.RouteSv1ProcessOutcome,
+		cgrEv.Clone(), &reply); err != nil &&
+		err.Error() == utils.ErrNotImplemented.Error() {
+		err = nil // the circuit breaker is not configured on RouteS
+	}
+	return
+}
+
 // eeSProcessEvent will process the event with the EEs component
 func (cdrS *CDRServer) eeSProcessEvent(cgrEv *utils.CGREventWithEeIDs) (err error) {
 	var reply map[string]map[string]interface{}
@@ -446,7 +462,7 @@ func (cdrS *CDRServer) eeSProcessEvent(cgrEv *utils.CGREventWithEeIDs) (err erro
 // processEvent processes a CGREvent based on arguments
 // in case of partially executed, both error and evs will be returned
 func (cdrS *CDRServer) processEvent(ev *utils.CGREvent,
-	chrgS, attrS, refund, ralS, store, reRate, export, thdS, stS bool) (evs []*utils.EventWithFlags, err error) {
+	chrgS, attrS, refund, ralS, store, reRate, export, thdS, stS, rtS bool) (evs []*utils.EventWithFlags, err error) {
 	if attrS {
 		if err = cdrS.attrSProcessEvent(ev); err != nil {
 			utils.Logger.Warning(
@@ -625,6 +641,14 @@ func (cdrS *CDRServer) processEvent(ev *utils.CGREvent,
 			}
 		}
 	}
+	if rtS { // the outcome is reported once per call, not for each of the charged events
+		if err = cdrS.routeSProcessOutcome(ev); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: <%s> processing event %+v with %s",
+					utils.CDRs, err.Error(), utils.ToJSON(ev), utils.RouteS))
+			partiallyExecuted = true
+		}
+	}
 	if partiallyExecuted {
 		err = utils.ErrPartiallyExecuted
 	}
@@ -716,7 +740,8 @@ func (cdrS *CDRServer) V1ProcessCDR(cdr *CDRWithOpts, reply *string) (err error)
 		false, // no rerate
 		len(cdrS.cgrCfg.CdrsCfg().OnlineCDRExports) != 0 || len(cdrS.cgrCfg.CdrsCfg().EEsConns) != 0,
 		len(cdrS.cgrCfg.CdrsCfg().ThresholdSConns) != 0,
-		len(cdrS.cgrCfg.CdrsCfg().StatSConns) != 0); err != nil {
+		len(cdrS.cgrCfg.CdrsCfg().StatSConns) != 0,
+		len(cdrS.cgrCfg.CdrsCfg().RouteSConns) != 0); err != nil {
 		return
 	}
 	*reply = utils.OK
@@ -808,6 +833,10 @@ func (cdrS *CDRServer) V1ProcessEvent(arg *ArgV1ProcessEvent, reply *string) (er
 	if flgs.Has(utils.MetaStats) {
 		stS = flgs.GetBool(utils.MetaStats)
 	}
+	rtS := len(cdrS.cgrCfg.CdrsCfg().RouteSConns) != 0
+	if flgs.Has(utils.MetaRoutes) {
+		rtS = flgs.GetBool(utils.MetaRoutes)
+	}
 	chrgS := len(cdrS.cgrCfg.CdrsCfg().ChargerSConns) != 0 // activate charging for the Event
 	if flgs.Has(utils.MetaChargers) {
 		chrgS = flgs.GetBool(utils.MetaChargers)
@@ -829,7 +858,7 @@ func (cdrS *CDRServer) V1ProcessEvent(arg *ArgV1ProcessEvent, reply *string) (er
 	// end of processing options
 
 	if _, err = cdrS.processEvent(&arg.CGREvent, chrgS, attrS, refund,
-		ralS, store, reRate, export, thdS, stS); err != nil {
+		ralS, store, reRate, export, thdS, stS, rtS); err != nil {
 		return
 	}
 	*reply = utils.OK
@@ -883,6 +912,10 @@ func (cdrS *CDRServer) V2ProcessEvent(arg *ArgV1ProcessEvent, evs *[]*utils.Even
 	if flgs.Has(utils.MetaStats) {
 		stS = flgs.GetBool(utils.MetaStats)
 	}
+	rtS := len(cdrS.cgrCfg.CdrsCfg().RouteSConns) != 0
+	if flgs.Has(utils.MetaRoutes) {
+		rtS = flgs.GetBool(utils.MetaRoutes)
+	}
 	chrgS := len(cdrS.cgrCfg.CdrsCfg().ChargerSConns) != 0 // activate charging for the Event
 	if flgs.Has(utils.MetaChargers) {
 		chrgS = flgs.GetBool(utils.MetaChargers)
@@ -906,7 +939,7 @@ func (cdrS *CDRServer) V2ProcessEvent(arg *ArgV1ProcessEvent, evs *[]*utils.Even
 
 	var procEvs []*utils.EventWithFlags
 	if procEvs, err = cdrS.processEvent(&arg.CGREvent, chrgS, attrS, refund,
-		ralS, store, reRate, export, thdS, stS); err != nil {
+		ralS, store, reRate, export, thdS, stS, rtS); err != nil {
 		return
 	}
 	*evs = procEvs
@@ -1061,7 +1094,8 @@ func (cdrS *CDRServer) V1RateCDRs(arg *ArgRateCDRs, reply *string) (err error) {
 		cgrEv := cdr.AsCGREvent()
 		cgrEv.Opts = arg.Opts
 		if _, err = cdrS.processEvent(cgrEv, chrgS, attrS, false,
-			true, store, true, export, thdS, statS); err != nil {
+			true, store, true, export, thdS, statS, false); err != nil { // the outcome was reported on the first processing
+
 			return utils.NewErrServerError(err)
 		}
 	}
@@ -322,6 +322,54 @@ func (dbM *DataDBMock) RemoveRouteProfileDrv(string, string) error {
 	return utils.ErrNotImplemented
 }
 
+func (dbM *DataDBMock) GetRouteBreakerDrv(string, string) (*RouteBreaker, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetRouteBreakerDrv(*RouteBreaker) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveRouteBreakerDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetInvoiceDrv(string, string) (*Invoice, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetInvoiceDrv(*Invoice) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveInvoiceDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetAccountChargesDrv(string, string) (*utils.AccountCharges, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetAccountChargesDrv(*utils.AccountCharges) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveAccountChargesDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetSessionBackupsDrv(string) ([]*SessionBackup, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetSessionBackupDrv(*SessionBackup) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveSessionBackupDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
 func (dbM *DataDBMock) GetAttributeProfileDrv(string, string) (*AttributeProfile, error) {
 	return nil, utils.ErrNotImplemented
 }
@@ -2597,6 +2597,201 @@ func (dm *DataManager) RemoveRouteProfile(tenant, id, transactionID string, with
 	return
 }
 
+// GetRouteBreaker returns the circuit breaker state of a route
+// the returned breaker can be shared through cache so it should be cloned before being modified
+func (dm *DataManager) GetRouteBreaker(tenant, id string, cacheRead, cacheWrite bool,
+	transactionID string) (rb *RouteBreaker, err error) {
+	tntID := utils.ConcatenatedKey(tenant, id)
+	if cacheRead {
+		if x, ok := Cache.Get(utils.CacheRouteBreakers, tntID); ok {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x.(*RouteBreaker), nil
+		}
+	}
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	rb, err = dm.dataDB.GetRouteBreakerDrv(tenant, id)
+	if err != nil {
+		if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRouteBreakers]; err == utils.ErrNotFound && itm.Remote {
+			if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RmtConns, nil,
+				utils.ReplicatorSv1GetRouteBreaker, &utils.TenantIDWithOpts{
+					TenantID: &utils.TenantID{Tenant: tenant, ID: id},
+					Opts: map[string]interface{}{
+						utils.OptsAPIKey:  itm.APIKey,
+						utils.OptsRouteID: itm.RouteID,
+					}}, &rb); err == nil {
+				err = dm.dataDB.SetRouteBreakerDrv(rb)
+			}
+		}
+		if err != nil {
+			err = utils.CastRPCErr(err)
+			if err == utils.ErrNotFound && cacheWrite {
+				if errCh := Cache.Set(utils.CacheRouteBreakers, tntID, nil, nil,
+					cacheCommit(transactionID), transactionID); errCh != nil {
+					return nil, errCh
+				}
+			}
+			return nil, err
+		}
+	}
+	if cacheWrite {
+		if errCh := Cache.Set(utils.CacheRouteBreakers, tntID, rb, nil,
+			cacheCommit(transactionID), transactionID); errCh != nil {
+			return nil, errCh
+		}
+	}
+	return
+}
+
+// SetRouteBreaker stores the circuit breaker state of a route
+// the cached breaker is replaced so the RouteS sees the new state
+func (dm *DataManager) SetRouteBreaker(rb *RouteBreaker) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if err = dm.DataDB().SetRouteBreakerDrv(rb); err != nil {
+		return
+	}
+	if err = Cache.Set(utils.CacheRouteBreakers, rb.TenantID(), rb, nil,
+		true, utils.NonTransactional); err != nil {
+		return
+	}
+	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRouteBreakers]; itm.Replicate {
+		var reply string
+		if err = dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
+			utils.ReplicatorSv1SetRouteBreaker,
+			&RouteBreakerWithOpts{
+				RouteBreaker: rb,
+				Opts: map[string]interface{}{
+					utils.OptsAPIKey:  itm.APIKey,
+					utils.OptsRouteID: itm.RouteID,
+				}}, &reply); err != nil {
+			err = utils.CastRPCErr(err)
+			return
+		}
+	}
+	return
+}
+
+// RemoveRouteBreaker removes the circuit breaker state of a route
+func (dm *DataManager) RemoveRouteBreaker(tenant, id string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if err = dm.DataDB().RemoveRouteBreakerDrv(tenant, id); err != nil {
+		return
+	}
+	if err = Cache.Remove(utils.CacheRouteBreakers, utils.ConcatenatedKey(tenant, id),
+		true, utils.NonTransactional); err != nil {
+		return
+	}
+	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRouteBreakers]; itm.Replicate {
+		var reply string
+		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil, utils.ReplicatorSv1RemoveRouteBreaker,
+			&utils.TenantIDWithOpts{
+				TenantID: &utils.TenantID{Tenant: tenant, ID: id},
+				Opts: map[string]interface{}{
+					utils.OptsAPIKey:  itm.APIKey,
+					utils.OptsRouteID: itm.RouteID,
+				}}, &reply)
+	}
+	return
+}
+
+// GetSessionBackups returns the backups of the active sessions owned by the node
+func (dm *DataManager) GetSessionBackups(nodeID string) (sbs []*SessionBackup, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.GetSessionBackupsDrv(nodeID)
+}
+
+// SetSessionBackup stores the backup of an active session
+func (dm *DataManager) SetSessionBackup(sb *SessionBackup) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.SetSessionBackupDrv(sb)
+}
+
+// RemoveSessionBackup removes the backup of a session
+func (dm *DataManager) RemoveSessionBackup(nodeID, cgrID string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.RemoveSessionBackupDrv(nodeID, cgrID)
+}
+
+// GetInvoice returns the invoice with the given id
+// invoices are not cached since they are only queried on demand
+func (dm *DataManager) GetInvoice(tenant, id string) (inv *Invoice, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.GetInvoiceDrv(tenant, id)
+}
+
+// SetInvoice stores the invoice
+func (dm *DataManager) SetInvoice(inv *Invoice) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.SetInvoiceDrv(inv)
+}
+
+// RemoveInvoice removes the invoice
+func (dm *DataManager) RemoveInvoice(tenant, id string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.RemoveInvoiceDrv(tenant, id)
+}
+
+// GetAccountCharges returns the charges debited by AccountS with the given id
+func (dm *DataManager) GetAccountCharges(tenant, id string) (aC *utils.AccountCharges, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if aC, err = dm.dataDB.GetAccountChargesDrv(tenant, id); err != nil {
+		return
+	}
+	if aC.IsExpired(time.Now()) { // not yet removed by the DataDB
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+// SetAccountCharges stores the charges debited by AccountS
+func (dm *DataManager) SetAccountCharges(aC *utils.AccountCharges) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.SetAccountChargesDrv(aC)
+}
+
+// RemoveAccountCharges removes the charges debited by AccountS
+func (dm *DataManager) RemoveAccountCharges(tenant, id string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.RemoveAccountChargesDrv(tenant, id)
+}
+
 // GetAttributeProfile returns the AttributeProfile with the given id
 func (dm *DataManager) GetAttributeProfile(tenant, id string, cacheRead, cacheWrite bool,
 	transactionID string) (attrPrfl *AttributeProfile, err error) {
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"regexp"
 	"strings"
 	"time"
 
@@ -225,18 +226,18 @@ var supportedFiltersType utils.StringSet = utils.NewStringSet([]string{
 	utils.MetaEmpty, utils.MetaExists, utils.MetaLessThan, utils.MetaLessOrEqual,
 	utils.MetaGreaterThan, utils.MetaGreaterOrEqual, utils.MetaEqual,
 	utils.MetaNotEqual, utils.MetaIPNet, utils.MetaAPIBan,
-	utils.MetaActivationInterval})
+	utils.MetaActivationInterval, utils.MetaRegex, utils.MetaRange})
 var needsFieldName utils.StringSet = utils.NewStringSet([]string{
 	utils.MetaString, utils.MetaPrefix, utils.MetaSuffix,
 	utils.MetaTimings, utils.MetaRSR, utils.MetaDestinations, utils.MetaLessThan,
 	utils.MetaEmpty, utils.MetaExists, utils.MetaLessOrEqual, utils.MetaGreaterThan,
 	utils.MetaGreaterOrEqual, utils.MetaEqual, utils.MetaNotEqual, utils.MetaIPNet, utils.MetaAPIBan,
-	utils.MetaActivationInterval})
+	utils.MetaActivationInterval, utils.MetaRegex, utils.MetaRange})
 var needsValues utils.StringSet = utils.NewStringSet([]string{utils.MetaString, utils.MetaPrefix,
 	utils.MetaSuffix, utils.MetaTimings, utils.MetaRSR, utils.MetaDestinations,
 	utils.MetaLessThan, utils.MetaLessOrEqual, utils.MetaGreaterThan, utils.MetaGreaterOrEqual,
 	utils.MetaEqual, utils.MetaNotEqual, utils.MetaIPNet, utils.MetaAPIBan,
-	utils.MetaActivationInterval})
+	utils.MetaActivationInterval, utils.MetaRegex, utils.MetaRange})
 
 // NewFilterRule returns a new filter
 func NewFilterRule(rfType, fieldName string, vals []string) (*FilterRule, error) {
@@ -276,6 +277,7 @@ type FilterRule struct {
 	rsrValues  config.RSRParsers // Cache here the
 	rsrElement *config.RSRParser // Cache here the
 	rsrFilters utils.RSRFilters  // Cache here the RSRFilter Values
+	regexes    []*regexp.Regexp  // Cache here the compiled *regex Values
 	negative   *bool
 }
 
@@ -310,6 +312,39 @@ func (fltr *FilterRule) CompileValues() (err error) {
 			}
 			fltr.rsrValues = append(fltr.rsrValues, rsrPrsr)
 		}
+	case utils.MetaRegex, utils.MetaNotRegex:
+		if fltr.rsrElement, err = config.NewRSRParser(fltr.Element); err != nil {
+			return
+		} else if fltr.rsrElement == nil {
+			return fmt.Errorf("emtpy RSRParser in rule: <%s>", fltr.Element)
+		}
+		fltr.regexes = make([]*regexp.Regexp, len(fltr.Values))
+		for i, strVal := range fltr.Values {
+			if fltr.regexes[i], err = regexp.Compile(strVal); err != nil {
+				return
+			}
+		}
+	case utils.MetaRange, utils.MetaNotRange:
+		if fltr.rsrElement, err = config.NewRSRParser(fltr.Element); err != nil {
+			return
+		} else if fltr.rsrElement == nil {
+			return fmt.Errorf("emtpy RSRParser in rule: <%s>", fltr.Element)
+		}
+		// each value is a min;max pair, kept in rsrValues as two consecutive parsers(nil for an open end)
+		fltr.rsrValues = make(config.RSRParsers, 0, 2*len(fltr.Values))
+		for _, strVal := range fltr.Values {
+			limits := strings.Split(strVal, utils.InfieldSep)
+			if len(limits) != 2 {
+				return fmt.Errorf("invalid range: <%s> in rule: <%s>", strVal, fltr.Element)
+			}
+			for _, limit := range limits {
+				rsrPrsr, err := config.NewRSRParser(limit)
+				if err != nil {
+					return err
+				}
+				fltr.rsrValues = append(fltr.rsrValues, rsrPrsr)
+			}
+		}
 	default:
 		if fltr.rsrValues, err = config.NewRSRParsersFromSlice(fltr.Values); err != nil {
 			return
@@ -356,6 +391,10 @@ func (fltr *FilterRule) Pass(dDP utils.DataProvider) (result bool, err error) {
 		result, err = fltr.passAPIBan(dDP)
 	case utils.MetaActivationInterval, utils.MetaNotActivationInterval:
 		result, err = fltr.passActivationInterval(dDP)
+	case utils.MetaRegex, utils.MetaNotRegex:
+		result, err = fltr.passRegex(dDP)
+	case utils.MetaRange, utils.MetaNotRange:
+		result, err = fltr.passRange(dDP)
 	default:
 		err = utils.ErrPrefixNotErrNotImplemented(fltr.Type)
 	}
@@ -678,6 +717,82 @@ func (fltr *FilterRule) passActivationInterval(dDp utils.DataProvider) (bool, er
 	return startTime.Before(timeStrVal), nil
 }
 
+func (fltr *FilterRule) passRegex(dDP utils.DataProvider) (bool, error) {
+	strVal, err := fltr.rsrElement.ParseDataProvider(dDP)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, rgx := range fltr.regexes {
+		if rgx.MatchString(strVal) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// passRange checks if the Element is between the limits of at least one range, both ends included
+func (fltr *FilterRule) passRange(dDP utils.DataProvider) (bool, error) {
+	path, err := fltr.rsrElement.CompileDynRule(dDP)
+	if err != nil {
+		return false, err
+	}
+	fldIf, err := utils.DPDynamicInterface(path, dDP)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	if fldStr, castStr := fldIf.(string); castStr { // attempt converting string since deserialization fails here (ie: time.Time fields)
+		fldIf = utils.StringToInterface(fldStr)
+	}
+	for i := 0; i < len(fltr.rsrValues); i += 2 {
+		inRange := true
+		if fltr.rsrValues[i] != nil {
+			minIf, err := rangeLimit(fltr.rsrValues[i], dDP)
+			if err != nil {
+				continue
+			}
+			if inRange, err = utils.GreaterThan(fldIf, minIf, true); err != nil {
+				return false, err
+			}
+		}
+		if inRange && fltr.rsrValues[i+1] != nil {
+			maxIf, err := rangeLimit(fltr.rsrValues[i+1], dDP)
+			if err != nil {
+				continue
+			}
+			var gt bool
+			if gt, err = utils.GreaterThan(fldIf, maxIf, false); err != nil {
+				return false, err
+			}
+			inRange = !gt
+		}
+		if inRange {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func rangeLimit(limit *config.RSRParser, dDP utils.DataProvider) (interface{}, error) {
+	limitPath, err := limit.CompileDynRule(dDP)
+	if err != nil {
+		return nil, err
+	}
+	limitIf, err := utils.DPDynamicInterface(limitPath, dDP)
+	if err != nil {
+		return nil, err
+	}
+	if limitStr, castStr := limitIf.(string); castStr { // dynamic limits are not converted when read from the event
+		limitIf = utils.StringToInterface(limitStr)
+	}
+	return limitIf, nil
+}
+
 func verifyInlineFilterS(fltrs []string) (err error) {
 	for _, fl := range fltrs {
 		if strings.HasPrefix(fl, utils.Meta) {
@@ -1620,3 +1620,133 @@ func TestAPIBan(t *testing.T) {
 		t.Errorf("Expected error %s received: %v", expErr, err)
 	}
 }
+
+func TestFilterPassRegex(t *testing.T) {
+	cd := utils.MapStorage{
+		utils.MetaReq: utils.MapStorage{
+			utils.Destination:  "+4917612345",
+			utils.AccountField: "1001",
+		},
+	}
+	rf, err := NewFilterRule(utils.MetaRegex, "~*req.Destination", []string{`^\+49(15|16|17)\d+$`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passes, err := rf.Pass(cd); err != nil {
+		t.Error(err)
+	} else if !passes {
+		t.Error("Not passes filter")
+	}
+	rf, err = NewFilterRule(utils.MetaRegex, "~*req.Destination", []string{`^\+40`, `^\+33`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passes, err := rf.Pass(cd); err != nil {
+		t.Error(err)
+	} else if passes {
+		t.Error("Filter passes")
+	}
+	rf, err = NewFilterRule(utils.MetaRegex, "~*req.Missing", []string{`.*`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passes, err := rf.Pass(cd); err != nil {
+		t.Error(err)
+	} else if passes {
+		t.Error("Filter passes")
+	}
+	//not
+	rf, err = NewFilterRule(utils.MetaNotRegex, "~*req.Account", []string{`^10\d\d$`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if passes, err := rf.Pass(cd); err != nil {
+		t.Error(err)
+	} else if passes {
+		t.Error("Filter passes")
+	}
+	if _, err = NewFilterRule(utils.MetaRegex, "~*req.Account", []string{`^10(\d$`}); err == nil {
+		t.Error("Expected error for invalid pattern")
+	}
+	// inline
+	if fltr, err := NewFilterFromInline("cgrates.org", `*regex:~*req.Account:^1\d{3}$`); err != nil {
+		t.Error(err)
+	} else if passes, err := fltr.Rules[0].Pass(cd); err != nil {
+		t.Error(err)
+	} else if !passes {
+		t.Error("Not passes filter")
+	}
+}
+
+func TestFilterPassRange(t *testing.T) {
+	cd := utils.MapStorage{
+		utils.MetaReq: utils.MapStorage{
+			utils.Usage:    2 * time.Minute,
+			utils.Cost:     "12.5",
+			"MaxUsage":     "5m",
+			utils.Category: "call",
+		},
+	}
+	for _, tc := range []struct {
+		rule string
+		pass bool
+	}{
+		{"*range:~*req.Usage:1m;5m", true},
+		{"*range:~*req.Usage:2m;2m", true},
+		{"*range:~*req.Usage:3m;5m", false},
+		{"*range:~*req.Usage:;1m|1m;~*req.MaxUsage", true},
+		{"*range:~*req.Usage:5m;", false},
+		{"*range:~*req.Cost:10;12.5", true},
+		{"*range:~*req.Cost:12.6;20", false},
+		{"*range:~*req.Missing:1;5", false},
+		{"*notrange:~*req.Usage:1m;5m", false},
+		{"*notrange:~*req.Cost:0;10", true},
+	} {
+		fltr, err := NewFilterFromInline("cgrates.org", tc.rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if passes, err := fltr.Rules[0].Pass(cd); err != nil {
+			t.Errorf("%s: %v", tc.rule, err)
+		} else if passes != tc.pass {
+			t.Errorf("%s: expected %v, received %v", tc.rule, tc.pass, passes)
+		}
+	}
+	if fltr, err := NewFilterFromInline("cgrates.org", "*range:~*req.Category:1;5"); err != nil {
+		t.Fatal(err)
+	} else if _, err = fltr.Rules[0].Pass(cd); err == nil {
+		t.Error("Expected incomparable error")
+	}
+	expErr := "invalid range: <1m> in rule: <~*req.Usage>"
+	if _, err := NewFilterRule(utils.MetaRange, "~*req.Usage", []string{"1m"}); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+}
+
+func TestFilterRuleIndexKeys(t *testing.T) {
+	for _, tc := range []struct {
+		rfType  string
+		element string
+		vals    []string
+		exp     []string
+	}{
+		{utils.MetaString, "~*req.Account", []string{"1001", "~*req.Subject"}, []string{"*string:*req.Account:1001"}},
+		{utils.MetaPrefix, "1001", []string{"~*req.Account"}, []string{"*prefix:*req.Account:1001"}},
+		{utils.MetaRegex, "~*req.Destination", []string{`^\+4917\d+`, `^1001$`},
+			[]string{"*prefix:*req.Destination:+4917", "*prefix:*req.Destination:1001"}},
+		{utils.MetaRegex, "~*req.Destination", []string{`^\+4917`, `\+4917`}, nil},
+		{utils.MetaRegex, "~*req.Destination", []string{`^(?i)abc`}, nil},
+		{utils.MetaRegex, "~*req.Destination", []string{`^abc|^d`}, nil},
+		{utils.MetaNotRegex, "~*req.Destination", []string{`^\+4917`}, nil},
+		{utils.MetaRange, "~*req.Usage", []string{"1m;5m"}, nil},
+		{utils.MetaGreaterThan, "~*req.Usage", []string{"1m"}, nil},
+	} {
+		rf, err := NewFilterRule(tc.rfType, tc.element, tc.vals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rcv := rf.IndexKeys(); !reflect.DeepEqual(tc.exp, rcv) {
+			t.Errorf("%s %v: expected %+v, received %+v", tc.rfType, tc.vals, tc.exp, rcv)
+		}
+	}
+}
@@ -0,0 +1,572 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// InvoiceAdjustment is one discount or tax applied on an invoice
+type InvoiceAdjustment struct {
+	ID      string
+	Percent float64 // out of the subtotal for discounts, out of the discounted subtotal for taxes
+	Amount  float64 // fixed amount for the discounts without Percent, the computed value once applied
+}
+
+// InvoiceLine groups the CDRs having the same values for the GroupBy fields
+type InvoiceLine struct {
+	ID     string            // the values of the GroupBy fields, concatenated
+	Fields map[string]string // the value of each GroupBy field
+	CDRs   int64             // number of CDRs within the line
+	Usage  time.Duration
+	Cost   float64
+}
+
+// Invoice is built out of the rated CDRs of an account or tenant within a billing period
+type Invoice struct {
+	Tenant            string
+	ID                string
+	Type              string // *invoice or *credit_note
+	OriginalInvoiceID string // the corrected invoice in case of *credit_note
+	Account           string // empty for the invoices of the whole tenant
+	PeriodStart       time.Time
+	PeriodEnd         time.Time
+	GroupBy           []string
+	Lines             []*InvoiceLine
+	Subtotal          float64
+	Discounts         []*InvoiceAdjustment
+	Taxes             []*InvoiceAdjustment
+	Total             float64
+	State             string // *draft or *issued, the issued invoices cannot be changed anymore
+	CreatedAt         time.Time
+	IssuedAt          time.Time
+	Reason            string   // the reason of the credit note
+	CreditedLineIDs   []string // the lines already corrected by credit notes in case of *invoice
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (inv *Invoice) TenantID() string {
+	return utils.ConcatenatedKey(inv.Tenant, inv.ID)
+}
+
+// computeTotals applies the discounts and the taxes on the subtotal of the lines
+func (inv *Invoice) computeTotals(roundDec int) {
+	var subtotal float64
+	for _, ln := range inv.Lines {
+		subtotal += ln.Cost
+	}
+	inv.Subtotal = utils.Round(subtotal, roundDec, utils.MetaRoundingMiddle)
+	var discount float64
+	for _, dsc := range inv.Discounts {
+		if dsc.Percent != 0 {
+			dsc.Amount = utils.Round(inv.Subtotal*dsc.Percent/100, roundDec, utils.MetaRoundingMiddle)
+		}
+		discount += dsc.Amount
+	}
+	if math.Abs(discount) > math.Abs(inv.Subtotal) { // never discount more than the subtotal
+		discount = inv.Subtotal
+	}
+	taxable := inv.Subtotal - discount
+	total := taxable
+	for _, tax := range inv.Taxes {
+		tax.Amount = utils.Round(taxable*tax.Percent/100, roundDec, utils.MetaRoundingMiddle)
+		total += tax.Amount
+	}
+	inv.Total = utils.Round(total, roundDec, utils.MetaRoundingMiddle)
+}
+
+// discountAmount returns the sum of the applied discounts
+func (inv *Invoice) discountAmount() (amnt float64) {
+	for _, dsc := range inv.Discounts {
+		amnt += dsc.Amount
+	}
+	return
+}
+
+// taxAmount returns the sum of the applied taxes
+func (inv *Invoice) taxAmount() (amnt float64) {
+	for _, tax := range inv.Taxes {
+		amnt += tax.Amount
+	}
+	return
+}
+
+// AsCGREvents returns one event for each line of the invoice
+// each event contains the invoice data and totals so the exporters can also build the header and trailer out of them
+func (inv *Invoice) AsCGREvents() (evs []*utils.CGREvent) {
+	evs = make([]*utils.CGREvent, len(inv.Lines))
+	for i, ln := range inv.Lines {
+		ev := map[string]interface{}{
+			utils.Tenant:            inv.Tenant,
+			utils.InvoiceID:         inv.ID,
+			utils.InvoiceType:       inv.Type,
+			utils.OriginalInvoiceID: inv.OriginalInvoiceID,
+			utils.AccountField:      inv.Account,
+			utils.PeriodStart:       inv.PeriodStart,
+			utils.PeriodEnd:         inv.PeriodEnd,
+			utils.IssuedAt:          inv.IssuedAt,
+			utils.Subtotal:          inv.Subtotal,
+			utils.DiscountAmount:    inv.discountAmount(),
+			utils.TaxAmount:         inv.taxAmount(),
+			utils.Total:             inv.Total,
+		}
+		for fld, val := range ln.Fields {
+			ev[fld] = val
+		}
+		ev[utils.LineID] = ln.ID
+		ev[utils.Count] = ln.CDRs
+		ev[utils.Usage] = ln.Usage
+		ev[utils.Cost] = ln.Cost
+		evs[i] = &utils.CGREvent{
+			Tenant: inv.Tenant,
+			ID:     utils.ConcatenatedKey(inv.ID, ln.ID),
+			Event:  ev,
+		}
+	}
+	return
+}
+
+// invoiceCDRsLimit is the number of CDRs queried at once out of StorDB when building an invoice
+const invoiceCDRsLimit = 1000
+
+// invoiceLines groups the CDRs into lines based on the values of the groupBy fields
+type invoiceLines struct {
+	groupBy []string
+	lnIdx   map[string]*InvoiceLine
+	lines   []*InvoiceLine
+}
+
+// newInvoiceLines returns the grouping for the given fields
+func newInvoiceLines(groupBy []string) *invoiceLines {
+	return &invoiceLines{
+		groupBy: groupBy,
+		lnIdx:   make(map[string]*InvoiceLine),
+	}
+}
+
+// addCDRs adds the CDRs to their lines, creating the missing ones
+func (il *invoiceLines) addCDRs(cdrs []*CDR) {
+	for _, cdr := range cdrs {
+		mp := cdr.AsMapStringIface()
+		vals := make([]string, len(il.groupBy))
+		for i, fld := range il.groupBy {
+			vals[i] = utils.IfaceAsString(mp[fld])
+		}
+		lnID := utils.ConcatenatedKey(vals...)
+		ln, has := il.lnIdx[lnID]
+		if !has {
+			ln = &InvoiceLine{ID: lnID, Fields: make(map[string]string)}
+			for i, fld := range il.groupBy {
+				ln.Fields[fld] = vals[i]
+			}
+			il.lnIdx[lnID] = ln
+			il.lines = append(il.lines, ln)
+		}
+		ln.CDRs++
+		ln.Usage += cdr.Usage
+		ln.Cost += cdr.Cost
+	}
+}
+
+// asInvoiceLines returns the lines sorted by ID with their cost rounded
+func (il *invoiceLines) asInvoiceLines(roundDec int) []*InvoiceLine {
+	for _, ln := range il.lines {
+		ln.Cost = utils.Round(ln.Cost, roundDec, utils.MetaRoundingMiddle)
+	}
+	sort.Slice(il.lines, func(i, j int) bool {
+		return il.lines[i].ID < il.lines[j].ID
+	})
+	return il.lines
+}
+
+// ArgsGenerateInvoice are the arguments for CDRsV1.GenerateInvoice
+type ArgsGenerateInvoice struct {
+	Tenant      string
+	ID          string // generated if missing
+	Account     string // empty to invoice the whole tenant
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	RunIDs      []string // the runs of the CDRs considered, *default if missing
+	GroupBy     []string // the CDR fields building the lines, ToR and Destination if missing
+	Discounts   []*InvoiceAdjustment
+	Taxes       []*InvoiceAdjustment
+	Opts        map[string]interface{}
+}
+
+// ArgsCreditNote are the arguments for CDRsV1.CreateCreditNote
+type ArgsCreditNote struct {
+	Tenant    string
+	ID        string // the ID of the credit note, generated if missing
+	InvoiceID string // the issued invoice to correct
+	LineIDs   []string
+	Reason    string
+	Opts      map[string]interface{}
+}
+
+// ArgsExportInvoice are the arguments for CDRsV1.ExportInvoice
+type ArgsExportInvoice struct {
+	Tenant      string
+	ID          string
+	ExporterIDs []string // the EEs exporters rendering the invoice, all when missing
+	Opts        map[string]interface{}
+}
+
+// V1GenerateInvoice builds a draft invoice out of the rated CDRs within the billing period
+// generating again a draft invoice with the same ID will overwrite it
+func (cdrS *CDRServer) V1GenerateInvoice(args *ArgsGenerateInvoice, reply *Invoice) (err error) {
+	if args.PeriodStart.IsZero() {
+		return utils.NewErrMandatoryIeMissing(utils.PeriodStart)
+	}
+	if args.PeriodEnd.IsZero() {
+		return utils.NewErrMandatoryIeMissing(utils.PeriodEnd)
+	}
+	if !args.PeriodEnd.After(args.PeriodStart) {
+		return fmt.Errorf("invalid billing period: <%s - %s>", args.PeriodStart, args.PeriodEnd)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	invID := args.ID
+	if invID == utils.EmptyString {
+		invID = utils.GenUUID()
+	}
+	runIDs := args.RunIDs
+	if len(runIDs) == 0 {
+		runIDs = []string{utils.MetaDefault}
+	}
+	groupBy := args.GroupBy
+	if len(groupBy) == 0 {
+		groupBy = []string{utils.ToR, utils.Destination}
+	}
+	cdrFltr := &utils.CDRsFilter{
+		Tenants:         []string{tnt},
+		RunIDs:          runIDs,
+		AnswerTimeStart: &args.PeriodStart,
+		AnswerTimeEnd:   &args.PeriodEnd,
+		MinCost:         utils.Float64Pointer(0), // only the rated CDRs
+		OrderBy:         utils.OrderID,           // stable pages
+		Paginator:       utils.Paginator{Limit: utils.IntPointer(invoiceCDRsLimit)},
+	}
+	if args.Account != utils.EmptyString {
+		cdrFltr.Accounts = []string{args.Account}
+	}
+	inv := &Invoice{
+		Tenant:      tnt,
+		ID:          invID,
+		Type:        utils.MetaInvoice,
+		Account:     args.Account,
+		PeriodStart: args.PeriodStart,
+		PeriodEnd:   args.PeriodEnd,
+		GroupBy:     groupBy,
+		Discounts:   args.Discounts,
+		Taxes:       args.Taxes,
+		State:       utils.MetaDraft,
+		CreatedAt:   time.Now(),
+	}
+	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		var oldInv *Invoice
+		if oldInv, err = cdrS.dm.GetInvoice(tnt, invID); err == nil &&
+			oldInv.State == utils.MetaIssued {
+			err = utils.ErrInvoiceIssued
+			return
+		} else if err != nil && err != utils.ErrNotFound {
+			return
+		}
+		lines := newInvoiceLines(groupBy)
+		for offset := 0; ; offset += invoiceCDRsLimit {
+			pgFltr := *cdrFltr // the StorDB may consume the filter fields
+			pgFltr.Paginator.Offset = utils.IntPointer(offset)
+			var cdrs []*CDR
+			if cdrs, _, err = cdrS.cdrDb.GetCDRs(&pgFltr, false); err != nil {
+				if err != utils.ErrNotFound {
+					return
+				}
+				err = nil
+			}
+			lines.addCDRs(cdrs)
+			if len(cdrs) < invoiceCDRsLimit {
+				break
+			}
+		}
+		if len(lines.lines) == 0 {
+			err = utils.ErrNotFound
+			return
+		}
+		inv.Lines = lines.asInvoiceLines(cdrS.cgrCfg.GeneralCfg().RoundingDecimals)
+		inv.computeTotals(cdrS.cgrCfg.GeneralCfg().RoundingDecimals)
+		err = cdrS.dm.SetInvoice(inv)
+		return
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.InvoicePrefix+inv.TenantID())
+	if err != nil {
+		if err != utils.ErrNotFound &&
+			err != utils.ErrInvoiceIssued {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	*reply = *inv
+	return
+}
+
+// V1IssueInvoice issues the draft invoice, making it immutable
+func (cdrS *CDRServer) V1IssueInvoice(args *utils.TenantIDWithOpts, reply *Invoice) (err error) {
+	if args.TenantID == nil || args.ID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ID)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	var inv *Invoice
+	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		if inv, err = cdrS.dm.GetInvoice(tnt, args.ID); err != nil {
+			return
+		}
+		if inv.State == utils.MetaIssued {
+			err = utils.ErrInvoiceIssued
+			return
+		}
+		inv.State = utils.MetaIssued
+		inv.IssuedAt = time.Now()
+		err = cdrS.dm.SetInvoice(inv)
+		return
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.InvoicePrefix+utils.ConcatenatedKey(tnt, args.ID))
+	if err != nil {
+		return
+	}
+	*reply = *inv
+	return
+}
+
+// V1CreateCreditNote corrects an issued invoice with an issued credit note
+// the credit note reverses the given lines of the invoice or all of them if LineIDs is missing
+func (cdrS *CDRServer) V1CreateCreditNote(args *ArgsCreditNote, reply *Invoice) (err error) {
+	if args.InvoiceID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.InvoiceID)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	cnID := args.ID
+	if cnID == utils.EmptyString {
+		cnID = utils.GenUUID()
+	}
+	if cnID == args.InvoiceID {
+		return utils.ErrExists
+	}
+	// lock the credit note ID as well so V1GenerateInvoice cannot write over it
+	// the locks are sorted so two credit notes with swapped IDs cannot deadlock
+	lkIDs := []string{
+		utils.InvoicePrefix + utils.ConcatenatedKey(tnt, args.InvoiceID),
+		utils.InvoicePrefix + utils.ConcatenatedKey(tnt, cnID),
+	}
+	sort.Strings(lkIDs)
+	var cn *Invoice
+	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		var inv *Invoice
+		if inv, err = cdrS.dm.GetInvoice(tnt, args.InvoiceID); err != nil {
+			return
+		}
+		if inv.State != utils.MetaIssued ||
+			inv.Type != utils.MetaInvoice {
+			err = fmt.Errorf("only issued invoices can be credited, <%s> is %s %s",
+				inv.TenantID(), inv.State, inv.Type)
+			return
+		}
+		if _, err = cdrS.dm.GetInvoice(tnt, cnID); err == nil {
+			err = utils.ErrExists
+			return
+		} else if err != utils.ErrNotFound {
+			return
+		}
+		credited := utils.NewStringSet(inv.CreditedLineIDs)
+		lnIDs := utils.NewStringSet(args.LineIDs)
+		unknown := utils.NewStringSet(args.LineIDs) // the requested lines not found on the invoice
+		cn = &Invoice{
+			Tenant:            tnt,
+			ID:                cnID,
+			Type:              utils.MetaCreditNote,
+			OriginalInvoiceID: inv.ID,
+			Account:           inv.Account,
+			PeriodStart:       inv.PeriodStart,
+			PeriodEnd:         inv.PeriodEnd,
+			GroupBy:           inv.GroupBy,
+			State:             utils.MetaIssued,
+			Reason:            args.Reason,
+		}
+		for _, ln := range inv.Lines {
+			if lnIDs.Size() != 0 && !lnIDs.Has(ln.ID) {
+				continue
+			}
+			if credited.Has(ln.ID) {
+				err = fmt.Errorf("line <%s> of invoice <%s> was already credited", ln.ID, inv.TenantID())
+				return
+			}
+			unknown.Remove(ln.ID)
+			cn.Lines = append(cn.Lines, &InvoiceLine{
+				ID:     ln.ID,
+				Fields: ln.Fields,
+				CDRs:   ln.CDRs,
+				Usage:  ln.Usage,
+				Cost:   -ln.Cost,
+			})
+		}
+		if unknown.Size() != 0 {
+			err = fmt.Errorf("unknown lines %v for invoice <%s>", unknown.AsSlice(), inv.TenantID())
+			return
+		}
+		// the invoice is fully credited once this credit note covers the lines not credited before
+		fullCredit := len(cn.Lines)+credited.Size() == len(inv.Lines)
+		for _, dsc := range inv.Discounts {
+			if dsc.Percent == 0 && !fullCredit { // the fixed discounts are only reversed together with the last lines of the invoice
+				continue
+			}
+			cn.Discounts = append(cn.Discounts, &InvoiceAdjustment{ID: dsc.ID, Percent: dsc.Percent, Amount: -dsc.Amount})
+		}
+		for _, tax := range inv.Taxes {
+			cn.Taxes = append(cn.Taxes, &InvoiceAdjustment{ID: tax.ID, Percent: tax.Percent})
+		}
+		cn.computeTotals(cdrS.cgrCfg.GeneralCfg().RoundingDecimals)
+		cn.CreatedAt = time.Now()
+		cn.IssuedAt = cn.CreatedAt
+		if err = cdrS.dm.SetInvoice(cn); err != nil {
+			return
+		}
+		crdInv := *inv // keep the cached invoice untouched
+		crdInv.CreditedLineIDs = make([]string, len(inv.CreditedLineIDs), len(inv.CreditedLineIDs)+len(cn.Lines))
+		copy(crdInv.CreditedLineIDs, inv.CreditedLineIDs)
+		for _, ln := range cn.Lines {
+			crdInv.CreditedLineIDs = append(crdInv.CreditedLineIDs, ln.ID)
+		}
+		err = cdrS.dm.SetInvoice(&crdInv)
+		return
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, lkIDs...)
+	if err != nil {
+		return
+	}
+	*reply = *cn
+	return
+}
+
+// V1GetInvoice returns the invoice or the credit note
+func (cdrS *CDRServer) V1GetInvoice(args *utils.TenantIDWithOpts, reply *Invoice) (err error) {
+	if args.TenantID == nil || args.ID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ID)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	inv, err := cdrS.dm.GetInvoice(tnt, args.ID)
+	if err != nil {
+		return
+	}
+	*reply = *inv
+	return
+}
+
+// V1GetInvoiceIDs returns the IDs of the invoices and credit notes of the tenant
+func (cdrS *CDRServer) V1GetInvoiceIDs(args *utils.TenantWithOpts, reply *[]string) (err error) {
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	prfx := utils.InvoicePrefix + tnt + utils.ConcatenatedKeySep
+	keys, err := cdrS.dm.DataDB().GetKeysForPrefix(prfx)
+	if err != nil {
+		return
+	}
+	if len(keys) == 0 {
+		return utils.ErrNotFound
+	}
+	invIDs := make([]string, len(keys))
+	for i, key := range keys {
+		invIDs[i] = key[len(prfx):]
+	}
+	sort.Strings(invIDs)
+	*reply = invIDs
+	return
+}
+
+// V1RemoveInvoice removes a draft invoice, the issued ones can only be corrected with credit notes
+func (cdrS *CDRServer) V1RemoveInvoice(args *utils.TenantIDWithOpts, reply *string) (err error) {
+	if args.TenantID == nil || args.ID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ID)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		var inv *Invoice
+		if inv, err = cdrS.dm.GetInvoice(tnt, args.ID); err != nil {
+			return
+		}
+		if inv.State == utils.MetaIssued {
+			err = utils.ErrInvoiceIssued
+			return
+		}
+		err = cdrS.dm.RemoveInvoice(tnt, args.ID)
+		return
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.InvoicePrefix+utils.ConcatenatedKey(tnt, args.ID))
+	if err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1ExportInvoice renders the invoice through the EEs exporters, as one batch so each exporter builds one document
+func (cdrS *CDRServer) V1ExportInvoice(args *ArgsExportInvoice, reply *string) (err error) {
+	if args.ID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ID)
+	}
+	if len(cdrS.cgrCfg.CdrsCfg().EEsConns) == 0 {
+		return utils.NewErrNotConnected(utils.EEs)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	inv, err := cdrS.dm.GetInvoice(tnt, args.ID)
+	if err != nil {
+		return
+	}
+	var rply map[string]map[string]interface{}
+	if err = cdrS.connMgr.Call(cdrS.cgrCfg.CdrsCfg().EEsConns, nil,
+		utils.EeSv1ProcessEvents, &utils.CGREventsWithEeIDs{
+			EeIDs:  args.ExporterIDs,
+			Tenant: tnt,
+			Events: inv.AsCGREvents(),
+			Opts:   args.Opts,
+		}, &rply); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
@@ -0,0 +1,280 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestInvoiceComputeTotals(t *testing.T) {
+	inv := &Invoice{
+		Lines: []*InvoiceLine{{ID: "1", Cost: 60}, {ID: "2", Cost: 40}},
+		Discounts: []*InvoiceAdjustment{
+			{ID: "LOYALTY", Percent: 10},
+			{ID: "PROMO", Amount: 5},
+		},
+		Taxes: []*InvoiceAdjustment{{ID: "VAT", Percent: 20}},
+	}
+	inv.computeTotals(4)
+	if inv.Subtotal != 100 ||
+		inv.Discounts[0].Amount != 10 ||
+		inv.Taxes[0].Amount != 17 ||
+		inv.Total != 102 {
+		t.Errorf("Unexpected invoice: %s", utils.ToJSON(inv))
+	}
+	// never discount more than the subtotal
+	inv.Discounts = []*InvoiceAdjustment{{ID: "PROMO", Amount: 150}}
+	inv.computeTotals(4)
+	if inv.Total != 0 || inv.Taxes[0].Amount != 0 {
+		t.Errorf("Unexpected invoice: %s", utils.ToJSON(inv))
+	}
+}
+
+func TestInvoiceLifecycle(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	storDB := NewInternalDB(nil, nil, false)
+	dm := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	storDBChan := make(chan StorDB, 1)
+	storDBChan <- storDB
+	cdrS := NewCDRServer(cfg, storDBChan, dm, nil, nil)
+
+	answTime := time.Date(2021, 3, 10, 10, 0, 0, 0, time.UTC)
+	for i, cdr := range []*CDR{
+		{Account: "1001", Destination: "1002", Usage: time.Minute, Cost: 0.6},
+		{Account: "1001", Destination: "1002", Usage: 2 * time.Minute, Cost: 1.2},
+		{Account: "1001", Destination: "1003", Usage: time.Minute, Cost: 1.2},
+		{Account: "1001", Destination: "1003", Usage: time.Minute, Cost: -1},  // not rated
+		{Account: "1002", Destination: "1001", Usage: time.Minute, Cost: 0.6}, // other account
+		{Account: "1001", Destination: "1002", Usage: time.Minute, Cost: 0.6,
+			AnswerTime: answTime.AddDate(0, 1, 0)}, // outside the period
+	} {
+		cdr.CGRID = utils.Sha1("invoice", utils.IfaceAsString(i))
+		cdr.RunID = utils.MetaDefault
+		cdr.Tenant = "cgrates.org"
+		cdr.ToR = utils.MetaVoice
+		if cdr.AnswerTime.IsZero() {
+			cdr.AnswerTime = answTime
+		}
+		if err := storDB.SetCDR(cdr, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var inv Invoice
+	args := &ArgsGenerateInvoice{
+		Tenant:      "cgrates.org",
+		ID:          "INV1",
+		Account:     "1001",
+		PeriodStart: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+		Taxes:       []*InvoiceAdjustment{{ID: "VAT", Percent: 10}},
+	}
+	if err := cdrS.V1GenerateInvoice(args, &inv); err != nil {
+		t.Fatal(err)
+	}
+	expLines := []*InvoiceLine{
+		{
+			ID:     utils.ConcatenatedKey(utils.MetaVoice, "1002"),
+			Fields: map[string]string{utils.ToR: utils.MetaVoice, utils.Destination: "1002"},
+			CDRs:   2,
+			Usage:  3 * time.Minute,
+			Cost:   1.8,
+		},
+		{
+			ID:     utils.ConcatenatedKey(utils.MetaVoice, "1003"),
+			Fields: map[string]string{utils.ToR: utils.MetaVoice, utils.Destination: "1003"},
+			CDRs:   1,
+			Usage:  time.Minute,
+			Cost:   1.2,
+		},
+	}
+	if !reflect.DeepEqual(expLines, inv.Lines) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expLines), utils.ToJSON(inv.Lines))
+	}
+	if inv.State != utils.MetaDraft || inv.Subtotal != 3 || inv.Total != 3.3 {
+		t.Errorf("Unexpected invoice: %s", utils.ToJSON(inv))
+	}
+
+	// credit notes are only accepted for issued invoices
+	var cn Invoice
+	if err := cdrS.V1CreateCreditNote(&ArgsCreditNote{Tenant: "cgrates.org", ID: "CN1", InvoiceID: "INV1"},
+		&cn); err == nil {
+		t.Error("Expected error for draft invoice")
+	}
+	tntID := &utils.TenantIDWithOpts{TenantID: &utils.TenantID{Tenant: "cgrates.org", ID: "INV1"}}
+	if err := cdrS.V1IssueInvoice(tntID, &inv); err != nil {
+		t.Fatal(err)
+	}
+	if inv.State != utils.MetaIssued || inv.IssuedAt.IsZero() {
+		t.Errorf("Unexpected invoice: %s", utils.ToJSON(inv))
+	}
+
+	// issued invoices are immutable
+	if err := cdrS.V1GenerateInvoice(args, &Invoice{}); err != utils.ErrInvoiceIssued {
+		t.Errorf("Expected %v, received %v", utils.ErrInvoiceIssued, err)
+	}
+	if err := cdrS.V1IssueInvoice(tntID, &Invoice{}); err != utils.ErrInvoiceIssued {
+		t.Errorf("Expected %v, received %v", utils.ErrInvoiceIssued, err)
+	}
+	var reply string
+	if err := cdrS.V1RemoveInvoice(tntID, &reply); err != utils.ErrInvoiceIssued {
+		t.Errorf("Expected %v, received %v", utils.ErrInvoiceIssued, err)
+	}
+
+	// correct one of the lines
+	if err := cdrS.V1CreateCreditNote(&ArgsCreditNote{Tenant: "cgrates.org", ID: "CN1", InvoiceID: "INV1",
+		LineIDs: []string{expLines[1].ID}, Reason: "wrong rate"}, &cn); err != nil {
+		t.Fatal(err)
+	}
+	if cn.Type != utils.MetaCreditNote || cn.OriginalInvoiceID != "INV1" ||
+		cn.State != utils.MetaIssued || len(cn.Lines) != 1 ||
+		cn.Subtotal != -1.2 || cn.Total != -1.32 {
+		t.Errorf("Unexpected credit note: %s", utils.ToJSON(cn))
+	}
+	if err := cdrS.V1GetInvoice(tntID, &inv); err != nil {
+		t.Fatal(err)
+	} else if exp := []string{expLines[1].ID}; !reflect.DeepEqual(exp, inv.CreditedLineIDs) {
+		t.Errorf("Expected %v, received %v", exp, inv.CreditedLineIDs)
+	}
+	if err := cdrS.V1CreateCreditNote(&ArgsCreditNote{Tenant: "cgrates.org", ID: "CN2", InvoiceID: "INV1",
+		LineIDs: []string{expLines[1].ID}}, &cn); err == nil {
+		t.Error("Expected error for line already credited")
+	}
+	if err := cdrS.V1CreateCreditNote(&ArgsCreditNote{Tenant: "cgrates.org", ID: "INV1", InvoiceID: "INV1"},
+		&cn); err != utils.ErrExists {
+		t.Errorf("Expected %v, received %v", utils.ErrExists, err)
+	}
+	if err := cdrS.V1CreateCreditNote(&ArgsCreditNote{Tenant: "cgrates.org", ID: "CN2", InvoiceID: "INV1",
+		LineIDs: []string{"*voice:1004"}}, &cn); err == nil {
+		t.Error("Expected error for unknown line")
+	}
+
+	var ids []string
+	if err := cdrS.V1GetInvoiceIDs(&utils.TenantWithOpts{Tenant: "cgrates.org"}, &ids); err != nil {
+		t.Fatal(err)
+	} else if exp := []string{"CN1", "INV1"}; !reflect.DeepEqual(exp, ids) {
+		t.Errorf("Expected %v, received %v", exp, ids)
+	}
+
+	// drafts can be removed
+	args.ID = "INV2"
+	if err := cdrS.V1GenerateInvoice(args, &inv); err != nil {
+		t.Fatal(err)
+	}
+	tntID.ID = "INV2"
+	if err := cdrS.V1RemoveInvoice(tntID, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := cdrS.V1GetInvoice(tntID, &inv); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestInvoiceCreditNoteFixedDiscounts(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dm := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	storDBChan := make(chan StorDB, 1)
+	storDBChan <- NewInternalDB(nil, nil, false)
+	cdrS := NewCDRServer(cfg, storDBChan, dm, nil, nil)
+	inv := &Invoice{
+		Tenant: "cgrates.org",
+		ID:     "INV3",
+		Type:   utils.MetaInvoice,
+		Lines: []*InvoiceLine{
+			{ID: "*voice:1002", CDRs: 1, Usage: time.Minute, Cost: 10},
+			{ID: "*voice:1003", CDRs: 1, Usage: time.Minute, Cost: 5},
+		},
+		Discounts: []*InvoiceAdjustment{{ID: "PROMO", Amount: 3}},
+		State:     utils.MetaIssued,
+	}
+	inv.computeTotals(cfg.GeneralCfg().RoundingDecimals)
+	if err := dm.SetInvoice(inv); err != nil {
+		t.Fatal(err)
+	}
+
+	var cn Invoice
+	if err := cdrS.V1CreateCreditNote(&ArgsCreditNote{Tenant: "cgrates.org", ID: "CN3", InvoiceID: "INV3",
+		LineIDs: []string{"*voice:1002"}}, &cn); err != nil {
+		t.Fatal(err)
+	}
+	if len(cn.Discounts) != 0 || cn.Total != -10 {
+		t.Errorf("Unexpected credit note: %s", utils.ToJSON(cn))
+	}
+	// the fixed discount is reversed with the last credited line
+	if err := cdrS.V1CreateCreditNote(&ArgsCreditNote{Tenant: "cgrates.org", ID: "CN4", InvoiceID: "INV3",
+		LineIDs: []string{"*voice:1003"}}, &cn); err != nil {
+		t.Fatal(err)
+	}
+	if len(cn.Discounts) != 1 || cn.Total != -2 {
+		t.Errorf("Unexpected credit note: %s", utils.ToJSON(cn))
+	}
+	if inv.Total != 12 {
+		t.Errorf("Unexpected invoice: %s", utils.ToJSON(inv))
+	}
+}
+
+func TestInvoiceGenerateCDRsPages(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	storDB := NewInternalDB(nil, nil, false)
+	dm := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	storDBChan := make(chan StorDB, 1)
+	storDBChan <- storDB
+	cdrS := NewCDRServer(cfg, storDBChan, dm, nil, nil)
+
+	answTime := time.Date(2021, 3, 10, 10, 0, 0, 0, time.UTC)
+	nrCDRs := 2*invoiceCDRsLimit + 1
+	for i := 0; i < nrCDRs; i++ {
+		if err := storDB.SetCDR(&CDR{
+			CGRID:       utils.Sha1("invoicePages", utils.IfaceAsString(i)),
+			OrderID:     int64(i),
+			RunID:       utils.MetaDefault,
+			Tenant:      "cgrates.org",
+			ToR:         utils.MetaVoice,
+			Account:     "1010",
+			Destination: "100" + utils.IfaceAsString(i%2),
+			AnswerTime:  answTime,
+			Usage:       time.Second,
+			Cost:        0.01,
+		}, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var inv Invoice
+	if err := cdrS.V1GenerateInvoice(&ArgsGenerateInvoice{
+		Tenant:      "cgrates.org",
+		ID:          "INV_PAGES",
+		Account:     "1010",
+		PeriodStart: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+	}, &inv); err != nil {
+		t.Fatal(err)
+	}
+	var cdrs int64
+	for _, ln := range inv.Lines {
+		cdrs += ln.CDRs
+	}
+	if len(inv.Lines) != 2 || cdrs != int64(nrCDRs) {
+		t.Errorf("Unexpected invoice lines: %s", utils.ToJSON(inv.Lines))
+	}
+}
@@ -60,9 +60,10 @@ func writeFailedPosts(itmID string, value interface{}) {
 
 func AddFailedPost(expPath, format, module string, ev interface{}, opts map[string]interface{}) {
 	key := utils.ConcatenatedKey(expPath, format, module)
-	// also in case of amqp,amqpv1,s3,sqs and kafka also separe them after queue id
+	// also in case of amqp,amqpv1,s3,sqs,kafka and nats also separe them after queue id
 	if qID := utils.FirstNonEmpty(utils.IfaceAsString(opts[utils.QueueID]),
-		utils.IfaceAsString(opts[utils.KafkaTopic])); len(qID) != 0 {
+		utils.IfaceAsString(opts[utils.KafkaTopic]),
+		utils.IfaceAsString(opts[utils.NATSSubject])); len(qID) != 0 {
 		key = utils.ConcatenatedKey(key, qID)
 	}
 	var failedPost *ExportEvents
@@ -188,6 +189,8 @@ func (expEv *ExportEvents) ReplayFailedPosts(attempts int) (failedEvents *Export
 	case utils.MetaS3jsonMap:
 		pstr = NewS3Poster(expEv.Path, attempts, expEv.Opts)
 		keyFunc = utils.UUIDSha1Prefix
+	case utils.MetaNATSjsonMap:
+		pstr = NewNATSPoster(expEv.Path, attempts, expEv.Opts)
 	}
 	for _, ev := range expEv.Events {
 		if err = pstr.Post(ev.([]byte), keyFunc()); err != nil {
@@ -20,6 +20,7 @@ package engine
 
 import (
 	"fmt"
+	"regexp/syntax"
 	"strings"
 
 	"github.com/cgrates/cgrates/config"
@@ -31,6 +32,64 @@ var (
 	FilterIndexTypes = utils.NewStringSet([]string{utils.MetaPrefix, utils.MetaString, utils.MetaSuffix})
 )
 
+// IndexKeys returns the keys of the filter indexes the rule is reachable through
+// a *regex rule is indexed as *prefix when all its patterns start with an anchored literal
+func (fltr *FilterRule) IndexKeys() (idxKeys []string) {
+	if fltr.Type == utils.MetaRegex {
+		return fltr.regexIndexKeys()
+	}
+	if !FilterIndexTypes.Has(fltr.Type) {
+		return
+	}
+	isDyn := strings.HasPrefix(fltr.Element, utils.DynamicDataPrefix)
+	for _, fldVal := range fltr.Values {
+		if isDyn {
+			if strings.HasPrefix(fldVal, utils.DynamicDataPrefix) { // do not index if both the element and the value is dynamic
+				continue
+			}
+			idxKeys = append(idxKeys, utils.ConcatenatedKey(fltr.Type, fltr.Element[1:], fldVal))
+		} else if strings.HasPrefix(fldVal, utils.DynamicDataPrefix) {
+			idxKeys = append(idxKeys, utils.ConcatenatedKey(fltr.Type, fldVal[1:], fltr.Element))
+		}
+		// do not index not dynamic filters
+	}
+	return
+}
+
+// regexIndexKeys returns the *prefix index keys for a *regex rule
+// if one of the patterns can not be indexed the rule is not indexed at all
+// as the item would not be found for events matching only that pattern
+func (fltr *FilterRule) regexIndexKeys() (idxKeys []string) {
+	if !strings.HasPrefix(fltr.Element, utils.DynamicDataPrefix) {
+		return
+	}
+	idxKeys = make([]string, len(fltr.Values))
+	for i, pattern := range fltr.Values {
+		prfx := regexIndexPrefix(pattern)
+		if prfx == utils.EmptyString {
+			return nil
+		}
+		idxKeys[i] = utils.ConcatenatedKey(utils.MetaPrefix, fltr.Element[1:], prfx)
+	}
+	return
+}
+
+// regexIndexPrefix returns the literal prefix a pattern is anchored to(ie: "+4917" for "^\+4917\d+")
+func regexIndexPrefix(pattern string) (prfx string) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil || re.Op != syntax.OpConcat ||
+		len(re.Sub) < 2 || re.Sub[0].Op != syntax.OpBeginText {
+		return
+	}
+	for _, sub := range re.Sub[1:] {
+		if sub.Op != syntax.OpLiteral || sub.Flags&syntax.FoldCase != 0 {
+			break
+		}
+		prfx += string(sub.Rune)
+	}
+	return
+}
+
 // newFilterIndex will get the index from DataManager if is not found it will create it
 // is used to update the mentioned index
 func newFilterIndex(dm *DataManager, idxItmType, tnt, ctx, itemID string, filterIDs []string) (indexes map[string]utils.StringSet, err error) {
@@ -70,24 +129,7 @@ func newFilterIndex(dm *DataManager, idxItmType, tnt, ctx, itemID string, filter
 			return
 		}
 		for _, flt := range fltr.Rules {
-			if !FilterIndexTypes.Has(flt.Type) {
-				continue
-			}
-
-			isDyn := strings.HasPrefix(flt.Element, utils.DynamicDataPrefix)
-			for _, fldVal := range flt.Values {
-				var idxKey string
-				if isDyn {
-					if strings.HasPrefix(fldVal, utils.DynamicDataPrefix) { // do not index if both the element and the value is dynamic
-						continue
-					}
-					idxKey = utils.ConcatenatedKey(flt.Type, flt.Element[1:], fldVal)
-				} else if strings.HasPrefix(fldVal, utils.DynamicDataPrefix) {
-					idxKey = utils.ConcatenatedKey(flt.Type, fldVal[1:], flt.Element)
-				} else {
-					// do not index not dynamic filters
-					continue
-				}
+			for _, idxKey := range flt.IndexKeys() {
 				var rcvIndx map[string]utils.StringSet
 				// only read from cache in case if we do not find the index to not cache the negative response
 				if rcvIndx, err = dm.GetIndexes(idxItmType, tntCtx,
@@ -497,44 +539,12 @@ func UpdateFilterIndex(dm *DataManager, oldFlt, newFlt *Filter) (err error) {
 	newRules := utils.StringSet{}    // we only need to determine if we added new rules to rebuild
 	removeRules := utils.StringSet{} // but we need to know what indexes to remove
 	for _, flt := range newFlt.Rules {
-		if !FilterIndexTypes.Has(flt.Type) {
-			continue
-		}
-		isDyn := strings.HasPrefix(flt.Element, utils.DynamicDataPrefix)
-		for _, fldVal := range flt.Values {
-			var idxKey string
-			if isDyn {
-				if strings.HasPrefix(fldVal, utils.DynamicDataPrefix) { // do not index if both the element and the value is dynamic
-					continue
-				}
-				idxKey = utils.ConcatenatedKey(flt.Type, flt.Element[1:], fldVal)
-			} else if strings.HasPrefix(fldVal, utils.DynamicDataPrefix) {
-				idxKey = utils.ConcatenatedKey(flt.Type, fldVal[1:], flt.Element)
-			} else {
-				// do not index not dynamic filters
-				continue
-			}
+		for _, idxKey := range flt.IndexKeys() {
 			newRules.Add(idxKey)
 		}
 	}
 	for _, flt := range oldFlt.Rules {
-		if !FilterIndexTypes.Has(flt.Type) {
-			continue
-		}
-		isDyn := strings.HasPrefix(flt.Element, utils.DynamicDataPrefix)
-		for _, fldVal := range flt.Values {
-			var idxKey string
-			if isDyn {
-				if strings.HasPrefix(fldVal, utils.DynamicDataPrefix) { // do not index if both the element and the value is dynamic
-					continue
-				}
-				idxKey = utils.ConcatenatedKey(flt.Type, flt.Element[1:], fldVal)
-			} else if strings.HasPrefix(fldVal, utils.DynamicDataPrefix) {
-				idxKey = utils.ConcatenatedKey(flt.Type, fldVal[1:], flt.Element)
-			} else {
-				// do not index not dynamic filters
-				continue
-			}
+		for _, idxKey := range flt.IndexKeys() {
 			if !newRules.Has(idxKey) {
 				removeRules.Add(idxKey)
 			} else {
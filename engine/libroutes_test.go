@@ -1081,3 +1081,86 @@ func TestSortedRoutesAsNavigableMap(t *testing.T) {
 		t.Errorf("Expected %+v, received %+v", utils.ToJSON(expNavMap), utils.ToJSON(rcv))
 	}
 }
+
+func TestNewScoreExprs(t *testing.T) {
+	exprs, err := newScoreExprs([]string{"0.6*Cost", "0.3*(1-*asr)", "-0.1 * ResourceUsage/2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vals := map[string]float64{utils.Cost: 0.5, utils.MetaASR: 0.2, utils.ResourceUsage: 1}
+	exp := []float64{0.3, 0.24, -0.05}
+	for i, expr := range exprs {
+		if rcv := expr.node.eval(vals); strconv.FormatFloat(rcv, 'f', 6, 64) != strconv.FormatFloat(exp[i], 'f', 6, 64) {
+			t.Errorf("Expected %v for <%s>, received: %v", exp[i], expr.param, rcv)
+		}
+	}
+	flds := make(utils.StringSet)
+	for _, expr := range exprs {
+		expr.node.fields(flds)
+	}
+	if expFlds := []string{utils.MetaASR, utils.Cost, utils.ResourceUsage}; !reflect.DeepEqual(expFlds, flds.AsOrderedSlice()) {
+		t.Errorf("Expected %+v, received: %+v", expFlds, flds.AsOrderedSlice())
+	}
+	for _, param := range []string{"0.6*", "(Cost", "Cost)", "0.6**", "1..2"} {
+		if _, err = newScoreExprs([]string{param}); err == nil {
+			t.Errorf("Expected error for <%s>", param)
+		}
+	}
+	if _, err = newScoreExprs(nil); err == nil || err.Error() != "MANDATORY_IE_MISSING: [SortingParameters]" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestSortScore(t *testing.T) {
+	sRoutes := &SortedRoutes{
+		SortedRoutes: []*SortedRoute{
+			{
+				RouteID: "route1",
+				SortingData: map[string]interface{}{
+					utils.Cost:          0.1,
+					utils.MetaASR:       40.0,
+					utils.ResourceUsage: 5.0,
+					utils.Weight:        10.0,
+				},
+			},
+			{
+				RouteID: "route2",
+				SortingData: map[string]interface{}{
+					utils.Cost:          0.15,
+					utils.MetaASR:       80.0,
+					utils.ResourceUsage: 5.0,
+					utils.Weight:        10.0,
+				},
+			},
+			{
+				RouteID: "route3",
+				SortingData: map[string]interface{}{
+					utils.Cost:          0.3,
+					utils.MetaASR:       80.0,
+					utils.ResourceUsage: 0.0,
+					utils.Weight:        10.0,
+				},
+			},
+		},
+	}
+	exprs, err := newScoreExprs([]string{"0.6*Cost", "0.3*(1-*asr)", "0.1*ResourceUsage"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sRoutes.computeScore(exprs)
+	sRoutes.SortScore()
+	if rIDs := sRoutes.RouteIDs(); !reflect.DeepEqual([]string{"route2", "route1", "route3"}, rIDs) {
+		t.Errorf("Unexpected order: %+v", rIDs)
+	}
+	expComps := map[string]float64{
+		"0.6*Cost":          0,
+		"0.3*(1-*asr)":      0.3,
+		"0.1*ResourceUsage": 0.1,
+	}
+	if rcv := sRoutes.SortedRoutes[1].SortingData[utils.ScoreComponents]; !reflect.DeepEqual(expComps, rcv) {
+		t.Errorf("Expected %+v, received: %+v", expComps, rcv)
+	}
+	if rcv := sRoutes.SortedRoutes[1].SortingData[utils.Score]; rcv != 0.4 {
+		t.Errorf("Expected 0.4, received: %+v", rcv)
+	}
+}
@@ -41,7 +41,9 @@ type StatQueueProfile struct {
 	Stored             bool
 	Blocker            bool // blocker flag to stop processing on filters matched
 	Weight             float64
-	ThresholdIDs       []string // list of thresholds to be checked after changes
+	ThresholdIDs       []string      // list of thresholds to be checked after changes
+	BucketInterval     time.Duration // aggregate the metrics into time buckets instead of keeping the items, 0 to disable
+	BucketsLimit       int           // number of historic buckets to keep, defaultStatBucketsLimit if not set
 }
 
 // StatQueueProfileWithOpts is used in replicatorV1 for dispatcher
@@ -72,8 +74,10 @@ func NewStoredStatQueue(sq *StatQueue, ms Marshaler) (sSQ *StoredStatQueue, err
 		ID:     sq.ID,
 		Compressed: sq.Compress(int64(config.CgrConfig().StatSCfg().StoreUncompressedLimit),
 			config.CgrConfig().GeneralCfg().RoundingDecimals),
-		SQItems:   make([]SQItem, len(sq.SQItems)),
-		SQMetrics: make(map[string][]byte, len(sq.SQMetrics)),
+		SQItems:     make([]SQItem, len(sq.SQItems)),
+		SQMetrics:   make(map[string][]byte, len(sq.SQMetrics)),
+		SQBuckets:   sq.SQBuckets,
+		BucketStart: sq.BucketStart,
 	}
 	for i, sqItm := range sq.SQItems {
 		sSQ.SQItems[i] = sqItm
@@ -90,11 +94,13 @@ func NewStoredStatQueue(sq *StatQueue, ms Marshaler) (sSQ *StoredStatQueue, err
 
 // StoredStatQueue differs from StatQueue due to serialization of SQMetrics
 type StoredStatQueue struct {
-	Tenant     string
-	ID         string
-	SQItems    []SQItem
-	SQMetrics  map[string][]byte
-	Compressed bool
+	Tenant      string
+	ID          string
+	SQItems     []SQItem
+	SQMetrics   map[string][]byte
+	SQBuckets   []*StatBucket
+	BucketStart *time.Time
+	Compressed  bool
 }
 
 type StoredStatQueueWithOpts struct {
@@ -113,10 +119,12 @@ func (ssq *StoredStatQueue) AsStatQueue(ms Marshaler) (sq *StatQueue, err error)
 		return
 	}
 	sq = &StatQueue{
-		Tenant:    ssq.Tenant,
-		ID:        ssq.ID,
-		SQItems:   make([]SQItem, len(ssq.SQItems)),
-		SQMetrics: make(map[string]StatMetric, len(ssq.SQMetrics)),
+		Tenant:      ssq.Tenant,
+		ID:          ssq.ID,
+		SQItems:     make([]SQItem, len(ssq.SQItems)),
+		SQMetrics:   make(map[string]StatMetric, len(ssq.SQMetrics)),
+		SQBuckets:   ssq.SQBuckets,
+		BucketStart: ssq.BucketStart,
 	}
 	for i, sqItm := range ssq.SQItems {
 		sq.SQItems[i] = sqItm
@@ -141,6 +149,21 @@ type SQItem struct {
 	ExpiryTime *time.Time // Used to auto-expire events
 }
 
+// defaultStatBucketsLimit is the number of historic buckets kept when the profile does not limit them
+const defaultStatBucketsLimit = 100
+
+// StatBucket is the snapshot of the metric values at the end of a time bucket
+type StatBucket struct {
+	StartTime time.Time
+	Metrics   map[string]float64 // map[metricID]value
+}
+
+// StatMetricPoint is the value of a metric for the time bucket starting at Time
+type StatMetricPoint struct {
+	Time  time.Time
+	Value float64
+}
+
 func NewStatQueue(tnt, id string, metrics []*MetricWithFilters, minItems int) (sq *StatQueue, err error) {
 	sq = &StatQueue{
 		Tenant:    tnt,
@@ -159,14 +182,16 @@ func NewStatQueue(tnt, id string, metrics []*MetricWithFilters, minItems int) (s
 
 // StatQueue represents an individual stats instance
 type StatQueue struct {
-	lk        sync.RWMutex // protect the elements from within
-	Tenant    string
-	ID        string
-	SQItems   []SQItem
-	SQMetrics map[string]StatMetric
-	sqPrfl    *StatQueueProfile
-	dirty     *bool          // needs save
-	ttl       *time.Duration // timeToLeave, picked on each init
+	lk          sync.RWMutex // protect the elements from within
+	Tenant      string
+	ID          string
+	SQItems     []SQItem
+	SQMetrics   map[string]StatMetric
+	SQBuckets   []*StatBucket // closed time buckets, oldest first
+	BucketStart *time.Time    // start of the current time bucket
+	sqPrfl      *StatQueueProfile
+	dirty       *bool          // needs save
+	ttl         *time.Duration // timeToLeave, picked on each init
 }
 
 // RLock only to implement sync.RWMutex methods
@@ -188,6 +213,21 @@ func (sq *StatQueue) TenantID() string {
 
 // ProcessEvent processes a utils.CGREvent, returns true if processed
 func (sq *StatQueue) ProcessEvent(tnt, evID string, filterS *FilterS, evNm utils.MapStorage) (err error) {
+	if sq.bucketed() {
+		if _, err = sq.rotateBuckets(time.Now(),
+			config.CgrConfig().GeneralCfg().RoundingDecimals); err != nil {
+			return
+		}
+		if err = sq.addStatEvent(tnt, evID, filterS, evNm); err != nil {
+			return
+		}
+		// events are never removed one by one out of a bucket so we can keep the metrics compressed
+		for _, metric := range sq.SQMetrics {
+			metric.Compress(1, sq.BucketStart.String(),
+				config.CgrConfig().GeneralCfg().RoundingDecimals)
+		}
+		return
+	}
 	if _, err = sq.remExpired(); err != nil {
 		return
 	}
@@ -256,7 +296,9 @@ func (sq *StatQueue) addStatEvent(tnt, evID string, filterS *FilterS, evNm utils
 	if sq.ttl != nil {
 		expTime = utils.TimePointer(time.Now().Add(*sq.ttl))
 	}
-	sq.SQItems = append(sq.SQItems, SQItem{EventID: evID, ExpiryTime: expTime})
+	if !sq.bucketed() {
+		sq.SQItems = append(sq.SQItems, SQItem{EventID: evID, ExpiryTime: expTime})
+	}
 	var pass bool
 	// recreate the request without *opts
 	dDP := newDynamicDP(config.CgrConfig().FilterSCfg().ResourceSConns, config.CgrConfig().FilterSCfg().StatSConns,
@@ -277,6 +319,76 @@ func (sq *StatQueue) addStatEvent(tnt, evID string, filterS *FilterS, evNm utils
 	return
 }
 
+// bucketed returns true if the metrics are aggregated into time buckets
+func (sq *StatQueue) bucketed() bool {
+	return sq.sqPrfl != nil && sq.sqPrfl.BucketInterval > 0
+}
+
+// rotateBuckets closes the current bucket if its interval has passed,
+// saving the metric values into history and starting with new metrics
+// buckets without events are not recorded
+func (sq *StatQueue) rotateBuckets(now time.Time, roundDec int) (rotated bool, err error) {
+	bStart := now.Truncate(sq.sqPrfl.BucketInterval)
+	if sq.BucketStart == nil {
+		sq.BucketStart = &bStart
+		return
+	}
+	if !bStart.After(*sq.BucketStart) {
+		return
+	}
+	sq.SQBuckets = append(sq.SQBuckets, &StatBucket{
+		StartTime: *sq.BucketStart,
+		Metrics:   sq.float64Metrics(roundDec),
+	})
+	lmt := sq.sqPrfl.BucketsLimit
+	if lmt <= 0 {
+		lmt = defaultStatBucketsLimit
+	}
+	if len(sq.SQBuckets) > lmt {
+		sq.SQBuckets = sq.SQBuckets[len(sq.SQBuckets)-lmt:]
+	}
+	metrics := sq.SQMetrics
+	sq.SQMetrics = make(map[string]StatMetric, len(metrics))
+	for id, m := range metrics {
+		var metric StatMetric
+		if metric, err = NewStatMetric(id,
+			m.GetMinItems(), m.GetFilterIDs()); err != nil {
+			return
+		}
+		sq.SQMetrics[id] = metric
+	}
+	sq.SQItems = nil
+	sq.BucketStart = &bStart
+	return true, nil
+}
+
+// float64Metrics returns the current values of the metrics
+func (sq *StatQueue) float64Metrics(roundDec int) (metrics map[string]float64) {
+	metrics = make(map[string]float64, len(sq.SQMetrics))
+	for metricID, metric := range sq.SQMetrics {
+		metrics[metricID] = metric.GetFloat64Value(roundDec)
+	}
+	return
+}
+
+// MetricsHistory returns the time series for each metric, including the current bucket as the last point
+func (sq *StatQueue) MetricsHistory(roundDec int) (hist map[string][]*StatMetricPoint) {
+	hist = make(map[string][]*StatMetricPoint, len(sq.SQMetrics))
+	for _, bkt := range sq.SQBuckets {
+		for metricID, val := range bkt.Metrics {
+			hist[metricID] = append(hist[metricID], &StatMetricPoint{Time: bkt.StartTime, Value: val})
+		}
+	}
+	crntTime := time.Now()
+	if sq.BucketStart != nil {
+		crntTime = *sq.BucketStart
+	}
+	for metricID, val := range sq.float64Metrics(roundDec) {
+		hist[metricID] = append(hist[metricID], &StatMetricPoint{Time: crntTime, Value: val})
+	}
+	return
+}
+
 func (sq *StatQueue) Compress(maxQL int64, roundDec int) bool {
 	if int64(len(sq.SQItems)) < maxQL || maxQL == 0 {
 		return false
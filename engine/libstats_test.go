@@ -18,6 +18,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"fmt"
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -693,3 +695,171 @@ func TestStatRemoveExpiredQueue(t *testing.T) {
 		t.Errorf("Expecting: 2, received: %+v", len(sq.SQItems))
 	}
 }
+
+func TestStatRotateBuckets(t *testing.T) {
+	asr, _ := NewASR(0, utils.EmptyString, nil)
+	sq := &StatQueue{
+		Tenant: "cgrates.org",
+		ID:     "TestStatRotateBuckets",
+		SQMetrics: map[string]StatMetric{
+			utils.MetaASR: asr,
+		},
+		sqPrfl: &StatQueueProfile{
+			BucketInterval: 5 * time.Minute,
+			BucketsLimit:   2,
+		},
+	}
+	if !sq.bucketed() {
+		t.Fatal("expecting bucketed StatQueue")
+	}
+	t0 := time.Date(2021, 1, 1, 10, 2, 0, 0, time.UTC)
+	if rotated, err := sq.rotateBuckets(t0, 5); err != nil {
+		t.Error(err)
+	} else if rotated {
+		t.Error("not expecting rotation on the first bucket")
+	} else if exp := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC); !sq.BucketStart.Equal(exp) {
+		t.Errorf("Expecting %v, received: %v", exp, sq.BucketStart)
+	}
+	ev := utils.MapStorage{utils.MetaReq: map[string]interface{}{utils.AnswerTime: time.Now()}}
+	if err := sq.addStatEvent("cgrates.org", "EV1", nil, ev); err != nil {
+		t.Error(err)
+	}
+	if err := sq.addStatEvent("cgrates.org", "EV2", nil, utils.MapStorage{utils.MetaReq: map[string]interface{}{}}); err != nil {
+		t.Error(err)
+	}
+	if len(sq.SQItems) != 0 {
+		t.Errorf("not expecting items in bucketed StatQueue: %+v", sq.SQItems)
+	}
+	if rotated, err := sq.rotateBuckets(t0.Add(time.Minute), 5); err != nil {
+		t.Error(err)
+	} else if rotated {
+		t.Error("not expecting rotation within the same bucket")
+	}
+	for i, tm := range []time.Time{t0.Add(5 * time.Minute), t0.Add(10 * time.Minute), t0.Add(20 * time.Minute)} {
+		if rotated, err := sq.rotateBuckets(tm, 5); err != nil {
+			t.Error(err)
+		} else if !rotated {
+			t.Errorf("expecting rotation for bucket %d", i)
+		}
+		if err := sq.addStatEvent("cgrates.org", "EV3", nil, ev); err != nil {
+			t.Error(err)
+		}
+	}
+	expBkts := []*StatBucket{
+		{
+			StartTime: time.Date(2021, 1, 1, 10, 5, 0, 0, time.UTC),
+			Metrics:   map[string]float64{utils.MetaASR: 100},
+		},
+		{
+			StartTime: time.Date(2021, 1, 1, 10, 10, 0, 0, time.UTC),
+			Metrics:   map[string]float64{utils.MetaASR: 100},
+		},
+	}
+	if !reflect.DeepEqual(expBkts, sq.SQBuckets) {
+		t.Errorf("Expecting %s, received: %s", utils.ToJSON(expBkts), utils.ToJSON(sq.SQBuckets))
+	}
+	expHist := map[string][]*StatMetricPoint{
+		utils.MetaASR: {
+			{Time: time.Date(2021, 1, 1, 10, 5, 0, 0, time.UTC), Value: 100},
+			{Time: time.Date(2021, 1, 1, 10, 10, 0, 0, time.UTC), Value: 100},
+			{Time: time.Date(2021, 1, 1, 10, 20, 0, 0, time.UTC), Value: 100},
+		},
+	}
+	if rcv := sq.MetricsHistory(5); !reflect.DeepEqual(expHist, rcv) {
+		t.Errorf("Expecting %s, received: %s", utils.ToJSON(expHist), utils.ToJSON(rcv))
+	}
+}
+
+func TestStatRotateBucketsDefaultLimit(t *testing.T) {
+	sq := &StatQueue{
+		Tenant:    "cgrates.org",
+		ID:        "TestStatRotateBucketsDefaultLimit",
+		SQMetrics: map[string]StatMetric{},
+		sqPrfl: &StatQueueProfile{
+			BucketInterval: time.Minute,
+		},
+	}
+	t0 := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	for i := 0; i <= defaultStatBucketsLimit+5; i++ {
+		if _, err := sq.rotateBuckets(t0.Add(time.Duration(i)*time.Minute), 5); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(sq.SQBuckets) != defaultStatBucketsLimit {
+		t.Errorf("Expecting %d buckets, received: %d", defaultStatBucketsLimit, len(sq.SQBuckets))
+	}
+}
+
+func TestStatBucketedMetricsBounded(t *testing.T) {
+	metrics := make(map[string]StatMetric)
+	for _, metricID := range []string{utils.MetaPercentile + "#95:~*req.Cost",
+		utils.MetaStdDev + "#~*req.Cost", utils.MetaMin + "#~*req.Cost",
+		utils.MetaMax + "#~*req.Cost", utils.MetaSum + "#~*req.Cost",
+		utils.MetaDistinct + "#~*req.Category", utils.MetaDDC} {
+		metric, err := NewStatMetric(metricID, 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		metrics[metricID] = metric
+	}
+	sq := &StatQueue{
+		Tenant:    "cgrates.org",
+		ID:        "TestStatBucketedMetricsBounded",
+		SQMetrics: metrics,
+		sqPrfl: &StatQueueProfile{
+			BucketInterval: 1000 * time.Hour,
+		},
+	}
+	const evsNo = 10000
+	for i := 1; i <= evsNo; i++ {
+		if err := sq.ProcessEvent("cgrates.org", fmt.Sprintf("EV_%d", i), nil,
+			utils.MapStorage{utils.MetaReq: map[string]interface{}{utils.Cost: i,
+				utils.Category: i % 10, utils.Destination: i % 7}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(sq.SQItems) != 0 {
+		t.Errorf("not expecting items in bucketed StatQueue: %d", len(sq.SQItems))
+	}
+	prc := metrics[utils.MetaPercentile+"#95:~*req.Cost"].(*StatPercentile)
+	if len(prc.Events) != 1 {
+		t.Errorf("expecting the percentile events compressed, received: %d", len(prc.Events))
+	}
+	// the sketch grows with the range of the values, not with their number
+	if maxBins := int(math.Log(evsNo)/statSketchLogGamma) + 1; len(prc.Bins) > maxBins {
+		t.Errorf("expecting maximum %d bins, received: %d", maxBins, len(prc.Bins))
+	}
+	for _, sa := range []map[string]*StatAggregate{
+		metrics[utils.MetaStdDev+"#~*req.Cost"].(*StatStdDev).Events,
+		metrics[utils.MetaMin+"#~*req.Cost"].(*StatMin).Events,
+		metrics[utils.MetaMax+"#~*req.Cost"].(*StatMax).Events} {
+		if len(sa) != 1 {
+			t.Errorf("expecting the events compressed, received: %d", len(sa))
+		}
+	}
+	// the distinct metrics keep only the distinct values, not the events
+	dst := metrics[utils.MetaDistinct+"#~*req.Category"].(*StatDistinct)
+	if len(dst.Events) != 1 || len(dst.FieldValues) != 10 {
+		t.Errorf("expecting the distinct events compressed, received: %s", utils.ToJSON(dst))
+	}
+	ddc := metrics[utils.MetaDDC].(*StatDDC)
+	if len(ddc.Events) != 1 || len(ddc.FieldValues) != 7 {
+		t.Errorf("expecting the ddc events compressed, received: %s", utils.ToJSON(ddc))
+	}
+	if v := prc.GetFloat64Value(-1); math.Abs(v-9500) > 9500*statSketchAccuracy {
+		t.Errorf("wrong percentile value: %v", v)
+	}
+	exp := map[string]float64{
+		utils.MetaStdDev + "#~*req.Cost":       2886.75,
+		utils.MetaMin + "#~*req.Cost":          1,
+		utils.MetaMax + "#~*req.Cost":          evsNo,
+		utils.MetaSum + "#~*req.Cost":          evsNo * (evsNo + 1) / 2,
+		utils.MetaDistinct + "#~*req.Category": 10,
+		utils.MetaDDC:                          7,
+	}
+	for metricID, val := range exp {
+		if v := metrics[metricID].GetFloat64Value(2); v != val {
+			t.Errorf("wrong value for %s: %v", metricID, v)
+		}
+	}
+}
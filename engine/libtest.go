@@ -229,21 +229,21 @@ cgrates.org,vf,,,false,false
 cgrates.org,round,TOPUP10_AT,,false,false
 `
 	ResourcesCSVContent = `
-#Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],TTL[4],Limit[5],AllocationMessage[6],Blocker[7],Stored[8],Weight[9],Thresholds[10]
-cgrates.org,ResGroup21,*string:~*req.Account:1001,2014-07-29T15:00:00Z,1s,2,call,true,true,10,
-cgrates.org,ResGroup22,*string:~*req.Account:dan,2014-07-29T15:00:00Z,3600s,2,premium_call,true,true,10,
+#Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],TTL[4],Limit[5],AllocationMessage[6],Blocker[7],Stored[8],Weight[9],Thresholds[10],RateWindow[11],Burst[12]
+cgrates.org,ResGroup21,*string:~*req.Account:1001,2014-07-29T15:00:00Z,1s,2,call,true,true,10,,,
+cgrates.org,ResGroup22,*string:~*req.Account:dan,2014-07-29T15:00:00Z,3600s,2,premium_call,true,true,10,,,
 `
 	StatsCSVContent = `
-#Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],QueueLength[4],TTL[5],MinItems[6],Metrics[7],MetricFilterIDs[8],Stored[9],Blocker[10],Weight[11],ThresholdIDs[12]
-cgrates.org,TestStats,*string:~*req.Account:1001,2014-07-29T15:00:00Z,100,1s,2,*sum#~*req.Value;*average#~*req.Value,,true,true,20,Th1;Th2
-cgrates.org,TestStats,,,,,2,*sum#~*req.Usage,,true,true,20,
-cgrates.org,TestStats2,FLTR_1,2014-07-29T15:00:00Z,100,1s,2,*sum#~*req.Value;*sum#~*req.Usage;*average#~*req.Value;*average#~*req.Usage,,true,true,20,Th
-cgrates.org,TestStats2,,,,,2,*sum#~*req.Cost;*average#~*req.Cost,,true,true,20,
+#Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],QueueLength[4],TTL[5],MinItems[6],Metrics[7],MetricFilterIDs[8],Stored[9],Blocker[10],Weight[11],ThresholdIDs[12],BucketInterval[13],BucketsLimit[14]
+cgrates.org,TestStats,*string:~*req.Account:1001,2014-07-29T15:00:00Z,100,1s,2,*sum#~*req.Value;*average#~*req.Value,,true,true,20,Th1;Th2,,
+cgrates.org,TestStats,,,,,2,*sum#~*req.Usage,,true,true,20,,,
+cgrates.org,TestStats2,FLTR_1,2014-07-29T15:00:00Z,100,1s,2,*sum#~*req.Value;*sum#~*req.Usage;*average#~*req.Value;*average#~*req.Usage,,true,true,20,Th,,
+cgrates.org,TestStats2,,,,,2,*sum#~*req.Cost;*average#~*req.Cost,,true,true,20,,,
 `
 
 	ThresholdsCSVContent = `
-#Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],MaxHits[4],MinHits[5],MinSleep[6],Blocker[7],Weight[8],ActionIDs[9],Async[10]
-cgrates.org,Threshold1,*string:~*req.Account:1001;*string:~*req.RunID:*default,2014-07-29T15:00:00Z,12,10,1s,true,10,THRESH1,true
+#Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],MaxHits[4],MinHits[5],MinSleep[6],Blocker[7],Weight[8],ActionIDs[9],Async[10],RecoveryFilterIDs[11],RecoveryActionIDs[12]
+cgrates.org,Threshold1,*string:~*req.Account:1001;*string:~*req.RunID:*default,2014-07-29T15:00:00Z,12,10,1s,true,10,THRESH1,true,,
 `
 
 	FiltersCSVContent = `
@@ -339,7 +339,7 @@ func InitStorDb(cfg *config.CGRConfig) error {
 		cfg.StorDbCfg().Type)); err != nil {
 		return err
 	}
-	if utils.IsSliceMember([]string{utils.Mongo, utils.MySQL, utils.Postgres},
+	if utils.IsSliceMember([]string{utils.Mongo, utils.MySQL, utils.Postgres, utils.SQLite},
 		cfg.StorDbCfg().Type) {
 		if err := SetDBVersions(storDb); err != nil {
 			return err
@@ -547,6 +547,7 @@ func GetDefaultEmptyCacheStats() map[string]*ltcache.CacheStats {
 		utils.CacheRateFilterIndexes:            {},
 		utils.CacheTimings:                      {},
 		utils.CacheDiameterMessages:             {},
+		utils.CacheRadiusPackets:                {},
 		utils.CacheClosedSessions:               {},
 		utils.CacheLoadIDs:                      {},
 		utils.CacheRPCConnections:               {},
@@ -554,6 +555,7 @@ func GetDefaultEmptyCacheStats() map[string]*ltcache.CacheStats {
 		utils.CacheRatingProfilesTmp:            {},
 		utils.CacheUCH:                          {},
 		utils.CacheEventCharges:                 {},
+		utils.CacheAccountCharges:               {},
 		utils.CacheReverseFilterIndexes:         {},
 		utils.MetaAPIBan:                        {},
 		utils.CacheCapsEvents:                   {},
@@ -1047,7 +1047,7 @@ type ResourceMdls []*ResourceMdl
 func (tps ResourceMdls) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.UsageTTL, utils.Limit, utils.AllocationMessage, utils.Blocker, utils.Stored,
-		utils.Weight, utils.ThresholdIDs}
+		utils.Weight, utils.ThresholdIDs, utils.RateWindow, utils.Burst}
 }
 
 func (tps ResourceMdls) AsTPResources() (result []*utils.TPResourceProfile) {
@@ -1078,6 +1078,12 @@ func (tps ResourceMdls) AsTPResources() (result []*utils.TPResourceProfile) {
 		if tp.AllocationMessage != utils.EmptyString {
 			rl.AllocationMessage = tp.AllocationMessage
 		}
+		if tp.RateWindow != utils.EmptyString {
+			rl.RateWindow = tp.RateWindow
+		}
+		if tp.Burst != 0 {
+			rl.Burst = tp.Burst
+		}
 		rl.Blocker = tp.Blocker
 		rl.Stored = tp.Stored
 		if len(tp.ActivationInterval) != 0 {
@@ -1131,6 +1137,8 @@ func APItoModelResource(rl *utils.TPResourceProfile) (mdls ResourceMdls) {
 			Weight:            rl.Weight,
 			Limit:             rl.Limit,
 			AllocationMessage: rl.AllocationMessage,
+			RateWindow:        rl.RateWindow,
+			Burst:             rl.Burst,
 		}
 		if rl.ActivationInterval != nil {
 			if rl.ActivationInterval.ActivationTime != utils.EmptyString {
@@ -1161,6 +1169,8 @@ func APItoModelResource(rl *utils.TPResourceProfile) (mdls ResourceMdls) {
 			mdl.Weight = rl.Weight
 			mdl.Limit = rl.Limit
 			mdl.AllocationMessage = rl.AllocationMessage
+			mdl.RateWindow = rl.RateWindow
+			mdl.Burst = rl.Burst
 			if rl.ActivationInterval != nil {
 				if rl.ActivationInterval.ActivationTime != utils.EmptyString {
 					mdl.ActivationInterval = rl.ActivationInterval.ActivationTime
@@ -1192,12 +1202,18 @@ func APItoResource(tpRL *utils.TPResourceProfile, timezone string) (rp *Resource
 		AllocationMessage: tpRL.AllocationMessage,
 		ThresholdIDs:      make([]string, len(tpRL.ThresholdIDs)),
 		FilterIDs:         make([]string, len(tpRL.FilterIDs)),
+		Burst:             tpRL.Burst,
 	}
 	if tpRL.UsageTTL != utils.EmptyString {
 		if rp.UsageTTL, err = utils.ParseDurationWithNanosecs(tpRL.UsageTTL); err != nil {
 			return nil, err
 		}
 	}
+	if tpRL.RateWindow != utils.EmptyString {
+		if rp.RateWindow, err = utils.ParseDurationWithNanosecs(tpRL.RateWindow); err != nil {
+			return nil, err
+		}
+	}
 	for i, fltr := range tpRL.FilterIDs {
 		rp.FilterIDs[i] = fltr
 	}
@@ -1229,10 +1245,14 @@ func ResourceProfileToAPI(rp *ResourceProfile) (tpRL *utils.TPResourceProfile) {
 		Stored:             rp.Stored,
 		Weight:             rp.Weight,
 		ThresholdIDs:       make([]string, len(rp.ThresholdIDs)),
+		Burst:              rp.Burst,
 	}
 	if rp.UsageTTL != time.Duration(0) {
 		tpRL.UsageTTL = rp.UsageTTL.String()
 	}
+	if rp.RateWindow != time.Duration(0) {
+		tpRL.RateWindow = rp.RateWindow.String()
+	}
 	for i, fli := range rp.FilterIDs {
 		tpRL.FilterIDs[i] = fli
 	}
@@ -1257,7 +1277,7 @@ type StatMdls []*StatMdl
 func (tps StatMdls) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.QueueLength, utils.TTL, utils.MinItems, utils.MetricIDs, utils.MetricFilterIDs,
-		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs}
+		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs, utils.BucketInterval, utils.BucketsLimit}
 }
 
 func (models StatMdls) AsTPStats() (result []*utils.TPStatProfile) {
@@ -1270,15 +1290,17 @@ func (models StatMdls) AsTPStats() (result []*utils.TPStatProfile) {
 		st, found := mst[key.TenantID()]
 		if !found {
 			st = &utils.TPStatProfile{
-				Tenant:      model.Tenant,
-				TPid:        model.Tpid,
-				ID:          model.ID,
-				Blocker:     model.Blocker,
-				Stored:      model.Stored,
-				Weight:      model.Weight,
-				MinItems:    model.MinItems,
-				TTL:         model.TTL,
-				QueueLength: model.QueueLength,
+				Tenant:         model.Tenant,
+				TPid:           model.Tpid,
+				ID:             model.ID,
+				Blocker:        model.Blocker,
+				Stored:         model.Stored,
+				Weight:         model.Weight,
+				MinItems:       model.MinItems,
+				TTL:            model.TTL,
+				QueueLength:    model.QueueLength,
+				BucketInterval: model.BucketInterval,
+				BucketsLimit:   model.BucketsLimit,
 			}
 		}
 		if model.Blocker {
@@ -1299,6 +1321,12 @@ func (models StatMdls) AsTPStats() (result []*utils.TPStatProfile) {
 		if model.QueueLength != 0 {
 			st.QueueLength = model.QueueLength
 		}
+		if model.BucketInterval != utils.EmptyString {
+			st.BucketInterval = model.BucketInterval
+		}
+		if model.BucketsLimit != 0 {
+			st.BucketsLimit = model.BucketsLimit
+		}
 		if model.ThresholdIDs != utils.EmptyString {
 			if _, has := thresholdMap[key.TenantID()]; !has {
 				thresholdMap[key.TenantID()] = make(utils.StringSet)
@@ -1391,6 +1419,8 @@ func APItoModelStats(st *utils.TPStatProfile) (mdls StatMdls) {
 					}
 					mdl.ThresholdIDs += val
 				}
+				mdl.BucketInterval = st.BucketInterval
+				mdl.BucketsLimit = st.BucketsLimit
 			}
 			for i, val := range metric.FilterIDs {
 				if i != 0 {
@@ -1417,12 +1447,18 @@ func APItoStats(tpST *utils.TPStatProfile, timezone string) (st *StatQueueProfil
 		Blocker:      tpST.Blocker,
 		Weight:       tpST.Weight,
 		ThresholdIDs: make([]string, len(tpST.ThresholdIDs)),
+		BucketsLimit: tpST.BucketsLimit,
 	}
 	if tpST.TTL != utils.EmptyString {
 		if st.TTL, err = utils.ParseDurationWithNanosecs(tpST.TTL); err != nil {
 			return nil, err
 		}
 	}
+	if tpST.BucketInterval != utils.EmptyString {
+		if st.BucketInterval, err = utils.ParseDurationWithNanosecs(tpST.BucketInterval); err != nil {
+			return nil, err
+		}
+	}
 	for i, metric := range tpST.Metrics {
 		st.Metrics[i] = &MetricWithFilters{
 			MetricID:  metric.MetricID,
@@ -1456,6 +1492,7 @@ func StatQueueProfileToAPI(st *StatQueueProfile) (tpST *utils.TPStatProfile) {
 		Weight:             st.Weight,
 		MinItems:           st.MinItems,
 		ThresholdIDs:       make([]string, len(st.ThresholdIDs)),
+		BucketsLimit:       st.BucketsLimit,
 	}
 	for i, metric := range st.Metrics {
 		tpST.Metrics[i] = &utils.MetricWithFilters{
@@ -1472,6 +1509,9 @@ func StatQueueProfileToAPI(st *StatQueueProfile) (tpST *utils.TPStatProfile) {
 	if st.TTL != time.Duration(0) {
 		tpST.TTL = st.TTL.String()
 	}
+	if st.BucketInterval != time.Duration(0) {
+		tpST.BucketInterval = st.BucketInterval.String()
+	}
 	for i, fli := range st.FilterIDs {
 		tpST.FilterIDs[i] = fli
 	}
@@ -1496,13 +1536,16 @@ type ThresholdMdls []*ThresholdMdl
 func (tps ThresholdMdls) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.MaxHits, utils.MinHits, utils.MinSleep,
-		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async}
+		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async,
+		utils.RecoveryFilterIDs, utils.RecoveryActionIDs}
 }
 
 func (tps ThresholdMdls) AsTPThreshold() (result []*utils.TPThresholdProfile) {
 	mst := make(map[string]*utils.TPThresholdProfile)
 	filterMap := make(map[string]utils.StringSet)
 	actionMap := make(map[string]utils.StringSet)
+	rcvFilterMap := make(map[string]utils.StringSet)
+	rcvActionMap := make(map[string]utils.StringSet)
 	for _, tp := range tps {
 		tenID := (&utils.TenantID{Tenant: tp.Tenant, ID: tp.ID}).TenantID()
 		th, found := mst[tenID]
@@ -1524,6 +1567,18 @@ func (tps ThresholdMdls) AsTPThreshold() (result []*utils.TPThresholdProfile) {
 			}
 			actionMap[tenID].AddSlice(strings.Split(tp.ActionIDs, utils.InfieldSep))
 		}
+		if tp.RecoveryFilterIDs != utils.EmptyString {
+			if _, has := rcvFilterMap[tenID]; !has {
+				rcvFilterMap[tenID] = make(utils.StringSet)
+			}
+			rcvFilterMap[tenID].AddSlice(strings.Split(tp.RecoveryFilterIDs, utils.InfieldSep))
+		}
+		if tp.RecoveryActionIDs != utils.EmptyString {
+			if _, has := rcvActionMap[tenID]; !has {
+				rcvActionMap[tenID] = make(utils.StringSet)
+			}
+			rcvActionMap[tenID].AddSlice(strings.Split(tp.RecoveryActionIDs, utils.InfieldSep))
+		}
 		if tp.Weight != 0 {
 			th.Weight = tp.Weight
 		}
@@ -1552,6 +1607,8 @@ func (tps ThresholdMdls) AsTPThreshold() (result []*utils.TPThresholdProfile) {
 		result[i] = th
 		result[i].FilterIDs = filterMap[tntID].AsSlice()
 		result[i].ActionIDs = actionMap[tntID].AsSlice()
+		result[i].RecoveryFilterIDs = rcvFilterMap[tntID].AsSlice()
+		result[i].RecoveryActionIDs = rcvActionMap[tntID].AsSlice()
 		i++
 	}
 	return
@@ -1631,6 +1688,9 @@ func APItoModelTPThreshold(th *utils.TPThresholdProfile) (mdls ThresholdMdls) {
 				mdls = append(mdls, mdl)
 			}
 		}
+		// the recovery is kept on the first model, together with the other profile fields
+		mdls[0].RecoveryFilterIDs = strings.Join(th.RecoveryFilterIDs, utils.InfieldSep)
+		mdls[0].RecoveryActionIDs = strings.Join(th.RecoveryActionIDs, utils.InfieldSep)
 	}
 	return
 }
@@ -1647,6 +1707,14 @@ func APItoThresholdProfile(tpTH *utils.TPThresholdProfile, timezone string) (th
 		ActionIDs: make([]string, len(tpTH.ActionIDs)),
 		FilterIDs: make([]string, len(tpTH.FilterIDs)),
 	}
+	if len(tpTH.RecoveryFilterIDs) != 0 {
+		th.RecoveryFilterIDs = make([]string, len(tpTH.RecoveryFilterIDs))
+		copy(th.RecoveryFilterIDs, tpTH.RecoveryFilterIDs)
+	}
+	if len(tpTH.RecoveryActionIDs) != 0 {
+		th.RecoveryActionIDs = make([]string, len(tpTH.RecoveryActionIDs))
+		copy(th.RecoveryActionIDs, tpTH.RecoveryActionIDs)
+	}
 	if tpTH.MinSleep != utils.EmptyString {
 		if th.MinSleep, err = utils.ParseDurationWithNanosecs(tpTH.MinSleep); err != nil {
 			return nil, err
@@ -1689,6 +1757,14 @@ func ThresholdProfileToAPI(th *ThresholdProfile) (tpTH *utils.TPThresholdProfile
 	for i, fli := range th.ActionIDs {
 		tpTH.ActionIDs[i] = fli
 	}
+	if len(th.RecoveryFilterIDs) != 0 {
+		tpTH.RecoveryFilterIDs = make([]string, len(th.RecoveryFilterIDs))
+		copy(tpTH.RecoveryFilterIDs, th.RecoveryFilterIDs)
+	}
+	if len(th.RecoveryActionIDs) != 0 {
+		tpTH.RecoveryActionIDs = make([]string, len(th.RecoveryActionIDs))
+		copy(tpTH.RecoveryActionIDs, th.RecoveryActionIDs)
+	}
 
 	if th.ActivationInterval != nil {
 		if !th.ActivationInterval.ActivationTime.IsZero() {
@@ -1735,6 +1811,9 @@ func (tps FilterMdls) AsTPFilter() (result []*utils.TPFilterProfile) {
 			var vals []string
 			if tp.Values != utils.EmptyString {
 				vals = splitDynFltrValues(tp.Values, utils.InfieldSep)
+				if tp.Type == utils.MetaRange || tp.Type == utils.MetaNotRange {
+					vals = joinRangeLimits(vals)
+				}
 			}
 			th.Filters = append(th.Filters, &utils.TPFilter{
 				Type:    tp.Type,
@@ -1753,6 +1832,19 @@ func (tps FilterMdls) AsTPFilter() (result []*utils.TPFilterProfile) {
 	return
 }
 
+// joinRangeLimits groups back the min;max limits of the *range values split on the field separator
+func joinRangeLimits(vals []string) (rngs []string) {
+	rngs = make([]string, 0, (len(vals)+1)/2)
+	for i := 0; i < len(vals); i += 2 {
+		if i+1 == len(vals) { // unpaired limit, left for the rule compilation to complain about
+			rngs = append(rngs, vals[i])
+			break
+		}
+		rngs = append(rngs, vals[i]+utils.InfieldSep+vals[i+1])
+	}
+	return
+}
+
 func APItoModelTPFilter(th *utils.TPFilterProfile) (mdls FilterMdls) {
 	if th == nil || len(th.Filters) == 0 {
 		return
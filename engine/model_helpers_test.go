@@ -1857,6 +1857,8 @@ func TestAPItoResource(t *testing.T) {
 		Limit:              "2",
 		ThresholdIDs:       []string{"TRes1"},
 		AllocationMessage:  "asd",
+		RateWindow:         "1s",
+		Burst:              4,
 	}
 	eRL := &ResourceProfile{
 		Tenant:            "cgrates.org",
@@ -1868,6 +1870,8 @@ func TestAPItoResource(t *testing.T) {
 		ThresholdIDs:      []string{"TRes1"},
 		AllocationMessage: tpRL.AllocationMessage,
 		Limit:             2,
+		RateWindow:        time.Second,
+		Burst:             4,
 	}
 	at, _ := utils.ParseTimeDetectLayout("2014-07-29T15:00:00Z", "UTC")
 	eRL.ActivationInterval = &utils.ActivationInterval{ActivationTime: at}
@@ -1888,6 +1892,8 @@ func TestResourceProfileToAPI(t *testing.T) {
 		Limit:              "2",
 		ThresholdIDs:       []string{"TRes1"},
 		AllocationMessage:  "asd",
+		RateWindow:         "1s",
+		Burst:              4,
 	}
 	rp := &ResourceProfile{
 		Tenant: "cgrates.org",
@@ -1900,6 +1906,8 @@ func TestResourceProfileToAPI(t *testing.T) {
 		ThresholdIDs:      []string{"TRes1"},
 		AllocationMessage: "asd",
 		Limit:             2,
+		RateWindow:        time.Second,
+		Burst:             4,
 	}
 
 	if rcv := ResourceProfileToAPI(rp); !reflect.DeepEqual(expected, rcv) {
@@ -1917,6 +1925,8 @@ func TestAPItoModelResource(t *testing.T) {
 		Limit:              "2",
 		ThresholdIDs:       []string{"TRes1"},
 		AllocationMessage:  "test",
+		RateWindow:         "1s",
+		Burst:              4,
 	}
 	expModel := &ResourceMdl{
 		Tpid:               testTPID,
@@ -1927,6 +1937,8 @@ func TestAPItoModelResource(t *testing.T) {
 		Limit:              "2",
 		ThresholdIDs:       "TRes1",
 		AllocationMessage:  "test",
+		RateWindow:         "1s",
+		Burst:              4,
 	}
 	rcv := APItoModelResource(tpRL)
 	if len(rcv) != 1 {
@@ -2077,9 +2089,11 @@ func TestStatQueueProfileToAPI(t *testing.T) {
 				MetricID: "*tcc",
 			},
 		},
-		MinItems:     1,
-		ThresholdIDs: []string{"THRESH1", "THRESH2"},
-		Weight:       20.0,
+		MinItems:       1,
+		ThresholdIDs:   []string{"THRESH1", "THRESH2"},
+		Weight:         20.0,
+		BucketInterval: "1m0s",
+		BucketsLimit:   10,
 	}
 	sqPrf := &StatQueueProfile{
 		Tenant:      "cgrates.org",
@@ -2099,11 +2113,13 @@ func TestStatQueueProfileToAPI(t *testing.T) {
 				MetricID: "*tcc",
 			},
 		},
-		TTL:          time.Second,
-		ThresholdIDs: []string{"THRESH1", "THRESH2"},
-		FilterIDs:    []string{"FLTR_1"},
-		Weight:       20.0,
-		MinItems:     1,
+		TTL:            time.Second,
+		ThresholdIDs:   []string{"THRESH1", "THRESH2"},
+		FilterIDs:      []string{"FLTR_1"},
+		Weight:         20.0,
+		MinItems:       1,
+		BucketInterval: time.Minute,
+		BucketsLimit:   10,
 	}
 
 	if rcv := StatQueueProfileToAPI(sqPrf); !reflect.DeepEqual(expected, rcv) {
@@ -2131,11 +2147,13 @@ func TestAPItoModelStats(t *testing.T) {
 				MetricID: "*average#Usage",
 			},
 		},
-		Blocker:      true,
-		Stored:       true,
-		Weight:       20,
-		MinItems:     2,
-		ThresholdIDs: []string{"Th1"},
+		Blocker:        true,
+		Stored:         true,
+		Weight:         20,
+		MinItems:       2,
+		ThresholdIDs:   []string{"Th1"},
+		BucketInterval: "1m",
+		BucketsLimit:   10,
 	}
 	rcv := APItoModelStats(tpS)
 	eRcv := StatMdls{
@@ -2153,6 +2171,8 @@ func TestAPItoModelStats(t *testing.T) {
 			Blocker:            true,
 			Weight:             20.0,
 			ThresholdIDs:       "Th1",
+			BucketInterval:     "1m",
+			BucketsLimit:       10,
 		},
 		&StatMdl{
 			Tpid:      "TPS1",
@@ -2166,6 +2186,10 @@ func TestAPItoModelStats(t *testing.T) {
 	} else if !reflect.DeepEqual(eRcv, rcv) {
 		t.Errorf("Expecting: %+v, \n received: %+v", utils.ToJSON(eRcv), utils.ToJSON(rcv))
 	}
+	if tpRcv := rcv.AsTPStats(); len(tpRcv) != 1 ||
+		tpRcv[0].BucketInterval != "1m" || tpRcv[0].BucketsLimit != 10 {
+		t.Errorf("Unexpected TPStatProfile: %s", utils.ToJSON(tpRcv))
+	}
 }
 
 func TestTPThresholdsAsTPThreshold(t *testing.T) {
@@ -2268,7 +2292,7 @@ func TestAPItoModelAccountActions(t *testing.T) {
 func TestCSVHeader(t *testing.T) {
 	var tps ResourceMdls
 	eOut := []string{
-		"#Tenant", "ID", "FilterIDs", "ActivationInterval", "UsageTTL", "Limit", "AllocationMessage", "Blocker", "Stored", "Weight", "ThresholdIDs",
+		"#Tenant", "ID", "FilterIDs", "ActivationInterval", "UsageTTL", "Limit", "AllocationMessage", "Blocker", "Stored", "Weight", "ThresholdIDs", "RateWindow", "Burst",
 	}
 	if rcv := tps.CSVHeader(); !reflect.DeepEqual(eOut, rcv) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eOut), utils.ToJSON(rcv))
@@ -2292,6 +2316,9 @@ func TestAPItoModelTPThreshold(t *testing.T) {
 		Blocker:   false,
 		Weight:    20.0,
 		ActionIDs: []string{"WARN3"},
+
+		RecoveryFilterIDs: []string{"FilterID2", "FilterID3"},
+		RecoveryActionIDs: []string{"CLEAR3"},
 	}
 	models := ThresholdMdls{
 		{
@@ -2306,6 +2333,8 @@ func TestAPItoModelTPThreshold(t *testing.T) {
 			Blocker:            false,
 			Weight:             20.0,
 			ActionIDs:          "WARN3",
+			RecoveryFilterIDs:  "FilterID2;FilterID3",
+			RecoveryActionIDs:  "CLEAR3",
 		},
 	}
 	rcv := APItoModelTPThreshold(th)
@@ -2480,16 +2509,20 @@ func TestAPItoTPThreshold(t *testing.T) {
 		Blocker:            false,
 		Weight:             20.0,
 		ActionIDs:          []string{"WARN3"},
+		RecoveryFilterIDs:  []string{"FilterID3"},
+		RecoveryActionIDs:  []string{"CLEAR3"},
 	}
 
 	eTPs := &ThresholdProfile{
-		ID:        tps.ID,
-		MaxHits:   tps.MaxHits,
-		Blocker:   tps.Blocker,
-		MinHits:   tps.MinHits,
-		Weight:    tps.Weight,
-		FilterIDs: tps.FilterIDs,
-		ActionIDs: []string{"WARN3"},
+		ID:                tps.ID,
+		MaxHits:           tps.MaxHits,
+		Blocker:           tps.Blocker,
+		MinHits:           tps.MinHits,
+		Weight:            tps.Weight,
+		FilterIDs:         tps.FilterIDs,
+		ActionIDs:         []string{"WARN3"},
+		RecoveryFilterIDs: []string{"FilterID3"},
+		RecoveryActionIDs: []string{"CLEAR3"},
 	}
 	if eTPs.MinSleep, err = utils.ParseDurationWithNanosecs(tps.MinSleep); err != nil {
 		t.Errorf("Got error: %+v", err)
@@ -2514,6 +2547,8 @@ func TestThresholdProfileToAPI(t *testing.T) {
 		MinSleep:           "1s",
 		Weight:             20.0,
 		ActionIDs:          []string{"WARN3"},
+		RecoveryFilterIDs:  []string{"FilterID3"},
+		RecoveryActionIDs:  []string{"CLEAR3"},
 	}
 
 	thPrf := &ThresholdProfile{
@@ -2529,6 +2564,9 @@ func TestThresholdProfileToAPI(t *testing.T) {
 		MinSleep:  time.Second,
 		Weight:    20.0,
 		ActionIDs: []string{"WARN3"},
+
+		RecoveryFilterIDs: []string{"FilterID3"},
+		RecoveryActionIDs: []string{"CLEAR3"},
 	}
 
 	if rcv := ThresholdProfileToAPI(thPrf); !reflect.DeepEqual(expected, rcv) {
@@ -2601,6 +2639,38 @@ func TestTPFilterAsTPFilterWithDynValues(t *testing.T) {
 	}
 }
 
+func TestTPFilterAsTPFilterWithRanges(t *testing.T) {
+	tps := []*FilterMdl{
+		{
+			Tpid:    "TEST_TPID",
+			ID:      "Filter1",
+			Type:    utils.MetaRange,
+			Element: "~*req.Usage",
+			Values:  "1m;5m;;~*req.MaxUsage",
+		},
+	}
+	eTPs := []*utils.TPFilterProfile{
+		{
+			TPid: tps[0].Tpid,
+			ID:   tps[0].ID,
+			Filters: []*utils.TPFilter{
+				{
+					Type:    utils.MetaRange,
+					Element: "~*req.Usage",
+					Values:  []string{"1m;5m", ";~*req.MaxUsage"},
+				},
+			},
+		},
+	}
+	rcvTPs := FilterMdls(tps).AsTPFilter()
+	if !reflect.DeepEqual(eTPs, rcvTPs) {
+		t.Errorf("Expecting:\n%+v\nReceived:\n%+v", utils.ToJSON(eTPs), utils.ToJSON(rcvTPs))
+	}
+	if rcv := APItoModelTPFilter(rcvTPs[0]); rcv[0].Values != tps[0].Values {
+		t.Errorf("Expecting: %q, received: %q", tps[0].Values, rcv[0].Values)
+	}
+}
+
 func TestTPFilterAsTPFilter2(t *testing.T) {
 	tps := []*FilterMdl{
 		{
@@ -5598,7 +5668,8 @@ func TestThresholdMdlsCSVHeader(t *testing.T) {
 	}
 	expStruct := []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.MaxHits, utils.MinHits, utils.MinSleep,
-		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async}
+		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async,
+		utils.RecoveryFilterIDs, utils.RecoveryActionIDs}
 	result := testStruct.CSVHeader()
 	if !reflect.DeepEqual(result, expStruct) {
 		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.ToJSON(expStruct), utils.ToJSON(result))
@@ -6594,7 +6665,7 @@ func TestStatMdlsCSVHeader(t *testing.T) {
 	}}
 	expStruct := []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.QueueLength, utils.TTL, utils.MinItems, utils.MetricIDs, utils.MetricFilterIDs,
-		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs}
+		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs, utils.BucketInterval, utils.BucketsLimit}
 	result := testStruct.CSVHeader()
 	if !reflect.DeepEqual(result, expStruct) {
 		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.ToJSON(expStruct), utils.ToJSON(result))
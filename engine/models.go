@@ -253,6 +253,8 @@ type ResourceMdl struct {
 	Stored             bool    `index:"8" re:""`
 	Weight             float64 `index:"9" re:"\d+\.?\d*"`
 	ThresholdIDs       string  `index:"10" re:""`
+	RateWindow         string  `index:"11" re:""`
+	Burst              float64 `index:"12" re:""`
 	CreatedAt          time.Time
 }
 
@@ -276,6 +278,8 @@ type StatMdl struct {
 	Blocker            bool    `index:"10" re:""`
 	Weight             float64 `index:"11" re:"\d+\.?\d*"`
 	ThresholdIDs       string  `index:"12" re:""`
+	BucketInterval     string  `index:"13" re:""`
+	BucketsLimit       int     `index:"14" re:""`
 	CreatedAt          time.Time
 }
 
@@ -297,6 +301,8 @@ type ThresholdMdl struct {
 	Weight             float64 `index:"8" re:"\d+\.?\d*"`
 	ActionIDs          string  `index:"9" re:""`
 	Async              bool    `index:"10" re:""`
+	RecoveryFilterIDs  string  `index:"11" re:""`
+	RecoveryActionIDs  string  `index:"12" re:""`
 	CreatedAt          time.Time
 }
 
@@ -0,0 +1,109 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/nats-io/nats.go"
+)
+
+// NewNATSPoster creates a nats poster
+// "nats://localhost:4222" with the subject and JetStream usage in opts
+func NewNATSPoster(dialURL string, attempts int, opts map[string]interface{}) *NATSPoster {
+	pstr := &NATSPoster{
+		dialURL:  dialURL,
+		attempts: attempts,
+		subject:  utils.NATSDefaultSubject,
+	}
+	pstr.parseOpts(opts)
+	return pstr
+}
+
+// NATSPoster is a nats poster
+type NATSPoster struct {
+	dialURL    string
+	subject    string // subject where we publish
+	jetStream  bool   // publish through JetStream and wait for the stream acknowledgement
+	attempts   int
+	sync.Mutex // protect connection
+	conn       *nats.Conn
+	js         nats.JetStreamContext
+}
+
+func (pstr *NATSPoster) parseOpts(opts map[string]interface{}) {
+	if vals, has := opts[utils.NATSSubject]; has {
+		pstr.subject = utils.IfaceAsString(vals)
+	}
+	if vals, has := opts[utils.NATSJetStream]; has {
+		pstr.jetStream, _ = utils.IfaceAsBool(vals)
+	}
+}
+
+// Post is the method being called when we need to post anything in the queue
+func (pstr *NATSPoster) Post(content []byte, _ string) (err error) {
+	fib := utils.Fib()
+	for i := 0; i < pstr.attempts; i++ {
+		if err = pstr.publish(content); err == nil {
+			return
+		}
+		if i+1 < pstr.attempts {
+			time.Sleep(time.Duration(fib()) * time.Second)
+		}
+	}
+	return
+}
+
+func (pstr *NATSPoster) publish(content []byte) (err error) {
+	pstr.Lock()
+	defer pstr.Unlock()
+	if pstr.conn == nil {
+		if pstr.conn, err = nats.Connect(pstr.dialURL); err != nil {
+			return
+		}
+		if pstr.jetStream {
+			if pstr.js, err = pstr.conn.JetStream(); err != nil {
+				pstr.conn.Close()
+				pstr.conn = nil
+				return
+			}
+		}
+	}
+	if pstr.jetStream {
+		_, err = pstr.js.Publish(pstr.subject, content)
+		return
+	}
+	if err = pstr.conn.Publish(pstr.subject, content); err != nil {
+		return
+	}
+	return pstr.conn.Flush()
+}
+
+// Close closes the nats connection
+func (pstr *NATSPoster) Close() {
+	pstr.Lock()
+	if pstr.conn != nil {
+		pstr.conn.Close()
+	}
+	pstr.conn = nil
+	pstr.js = nil
+	pstr.Unlock()
+}
@@ -153,6 +153,22 @@ type RateProfileCost struct {
 	Altered         []string
 }
 
+// RateProfileCostForEvent is the cost of one event out of a batch
+// the Error is populated instead of the Cost when the event could not be rated
+type RateProfileCostForEvent struct {
+	EventID string
+	Cost    *RateProfileCost
+	Error   string
+}
+
+// RateProfileCostForProfile is the cost of an event with one of its matching RateProfiles
+// the Error is populated instead of the Cost when the RateProfile could not rate the event
+type RateProfileCostForProfile struct {
+	RateProfileID string
+	Cost          *RateProfileCost
+	Error         string
+}
+
 // CorrectCost should be called in final phase of cost calculation
 // in order to apply further correction like Min/MaxCost or rounding
 func (rPc *RateProfileCost) CorrectCost(rndDec *int, rndMtd string) {
@@ -46,8 +46,10 @@ type ResourceProfile struct {
 	AllocationMessage  string                    // message returned by the winning resource on allocation
 	Blocker            bool                      // blocker flag to stop processing on filters matched
 	Stored             bool
-	Weight             float64  // Weight to sort the resources
-	ThresholdIDs       []string // Thresholds to check after changing Limit
+	Weight             float64       // Weight to sort the resources
+	ThresholdIDs       []string      // Thresholds to check after changing Limit
+	RateWindow         time.Duration // enforce the Limit per time window instead of concurrently, 0 to disable
+	Burst              float64       // units which can be allocated at once on rate limited resources, defaults to Limit
 }
 
 // ResourceProfileWithOpts is used in replicatorV1 for dispatcher
@@ -61,6 +63,19 @@ func (rp *ResourceProfile) TenantID() string {
 	return utils.ConcatenatedKey(rp.Tenant, rp.ID)
 }
 
+// isRateLimited returns true if the Limit is enforced per RateWindow
+func (rp *ResourceProfile) isRateLimited() bool {
+	return rp.RateWindow > 0 && rp.Limit >= 0
+}
+
+// bucketCapacity returns the maximum number of units available at once
+func (rp *ResourceProfile) bucketCapacity() float64 {
+	if rp.Burst > 0 {
+		return rp.Burst
+	}
+	return rp.Limit
+}
+
 // ResourceUsage represents an usage counted
 type ResourceUsage struct {
 	Tenant     string
@@ -86,6 +101,26 @@ func (ru *ResourceUsage) Clone() (cln *ResourceUsage) {
 	return
 }
 
+// ResourceBucket is the token bucket of a rate limited resource
+type ResourceBucket struct {
+	Tokens  float64   // units available for allocation
+	Updated time.Time // last time the tokens were refilled
+}
+
+// ResourceRateLimit reports the state of a rate limited resource
+type ResourceRateLimit struct {
+	Remaining float64   // units available for allocation
+	ResetTime time.Time // time when all the units become available again
+}
+
+// ResourceAllocationReply is the reply of the authorization and allocation APIs
+// reporting also the state of the rate limited resources
+type ResourceAllocationReply struct {
+	Authorized        bool // false if there are no units available
+	AllocationMessage string
+	RateLimits        map[string]*ResourceRateLimit // indexed by ResourceID
+}
+
 // Resource represents a resource in the system
 // not thread safe, needs locking at process level
 type Resource struct {
@@ -93,6 +128,7 @@ type Resource struct {
 	ID     string
 	Usages map[string]*ResourceUsage
 	TTLIdx []string         // holds ordered list of ResourceIDs based on their TTL, empty if feature is disabled
+	Bucket *ResourceBucket  // token bucket used by the rate limited resources
 	ttl    *time.Duration   // time to leave for this resource, picked up on each Resource initialization out of config
 	tUsage *float64         // sum of all usages
 	dirty  *bool            // the usages were modified, needs save, *bool so we only save if enabled in config
@@ -163,11 +199,58 @@ func (r *Resource) TotalUsage() (tU float64) {
 // Available returns the available number of units
 // Exported method to be used by filterS
 func (r *ResourceWithConfig) Available() float64 {
+	if r.Config.isRateLimited() {
+		return r.bucketTokens(r.Config, time.Now())
+	}
 	return r.Config.Limit - r.totalUsage()
 }
 
+// bucketTokens returns the tokens available at a specific time
+// without modifying the bucket
+func (r *Resource) bucketTokens(rPrf *ResourceProfile, atTime time.Time) (tokens float64) {
+	capacity := rPrf.bucketCapacity()
+	if r.Bucket == nil {
+		return capacity
+	}
+	tokens = r.Bucket.Tokens
+	if elapsed := atTime.Sub(r.Bucket.Updated); elapsed > 0 {
+		tokens += rPrf.Limit * float64(elapsed) / float64(rPrf.RateWindow)
+	}
+	if tokens > capacity {
+		tokens = capacity
+	}
+	return
+}
+
+// refillBucket adds to the bucket the tokens gained since the last update
+func (r *Resource) refillBucket(atTime time.Time) {
+	tokens := r.bucketTokens(r.rPrf, atTime)
+	if r.Bucket == nil {
+		r.Bucket = new(ResourceBucket)
+	}
+	r.Bucket.Tokens = tokens
+	r.Bucket.Updated = atTime
+}
+
+// rateLimit returns the state of the bucket at a specific time
+func (r *Resource) rateLimit(atTime time.Time) (rl *ResourceRateLimit) {
+	rl = &ResourceRateLimit{
+		Remaining: r.bucketTokens(r.rPrf, atTime),
+		ResetTime: atTime,
+	}
+	if missing := r.rPrf.bucketCapacity() - rl.Remaining; missing > 0 && r.rPrf.Limit > 0 {
+		rl.ResetTime = atTime.Add(time.Duration(missing / r.rPrf.Limit * float64(r.rPrf.RateWindow)))
+	}
+	return
+}
+
 // recordUsage records a new usage
 func (r *Resource) recordUsage(ru *ResourceUsage) (err error) {
+	if r.rPrf != nil && r.rPrf.isRateLimited() { // only consume the tokens
+		r.refillBucket(time.Now())
+		r.Bucket.Tokens -= ru.Units
+		return
+	}
 	if _, hasID := r.Usages[ru.ID]; hasID {
 		return fmt.Errorf("duplicate resource usage with id: %s", ru.TenantID())
 	}
@@ -190,6 +273,9 @@ func (r *Resource) recordUsage(ru *ResourceUsage) (err error) {
 
 // clearUsage clears the usage for an ID
 func (r *Resource) clearUsage(ruID string) (err error) {
+	if r.rPrf != nil && r.rPrf.isRateLimited() { // the consumed tokens are given back only with time
+		return
+	}
 	ru, hasIt := r.Usages[ruID]
 	if !hasIt {
 		return fmt.Errorf("cannot find usage record with id: %s", ruID)
@@ -229,6 +315,10 @@ func (rs Resources) recordUsage(ru *ResourceUsage) (err error) {
 	}
 	if err != nil {
 		for _, r := range rs[:nonReservedIdx] {
+			if r.rPrf != nil && r.rPrf.isRateLimited() {
+				r.Bucket.Tokens += ru.Units
+				continue
+			}
 			if errClear := r.clearUsage(ru.ID); errClear != nil {
 				utils.Logger.Warning(fmt.Sprintf("<%s> cannot clear usage, err: %s", utils.ResourceS, errClear.Error()))
 			} // best effort
@@ -295,7 +385,12 @@ func (rs Resources) allocateResource(ru *ResourceUsage, dryRun bool) (alcMessage
 				err = fmt.Errorf("empty configuration for resourceID: %s", r.TenantID())
 				return
 			}
-			if r.rPrf.Limit >= r.totalUsage()+ru.Units || r.rPrf.Limit == -1 {
+			available := r.rPrf.Limit - r.totalUsage()
+			if r.rPrf.isRateLimited() {
+				r.refillBucket(time.Now())
+				available = r.Bucket.Tokens
+			}
+			if available >= ru.Units || r.rPrf.Limit == -1 {
 				if alcMessage == "" {
 					if r.rPrf.AllocationMessage != "" {
 						alcMessage = r.rPrf.AllocationMessage
@@ -318,6 +413,25 @@ func (rs Resources) allocateResource(ru *ResourceUsage, dryRun bool) (alcMessage
 	return
 }
 
+// rateLimits returns the state of the rate limited resources
+func (rs Resources) rateLimits() (rls map[string]*ResourceRateLimit) {
+	lockIDs := utils.PrefixSliceItems(rs.tenatIDs(), utils.ResourcesPrefix)
+	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		now := time.Now()
+		for _, r := range rs {
+			if r.rPrf == nil || !r.rPrf.isRateLimited() {
+				continue
+			}
+			if rls == nil {
+				rls = make(map[string]*ResourceRateLimit)
+			}
+			rls[r.ID] = r.rateLimit(now)
+		}
+		return
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, lockIDs...)
+	return
+}
+
 // NewResourceService  returns a new ResourceService
 func NewResourceService(dm *DataManager, cgrcfg *config.CGRConfig,
 	filterS *FilterS, connMgr *ConnManager) *ResourceService {
@@ -640,16 +754,8 @@ func (rS *ResourceService) V1AuthorizeResources(args utils.ArgRSv1ResourceUsage,
 	}
 	// end of RPC caching
 
-	var mtcRLs Resources
-	if mtcRLs, err = rS.matchingResourcesForEvent(tnt, args.CGREvent, args.UsageID, args.UsageTTL); err != nil {
-		return err
-	}
 	var alcMessage string
-	if alcMessage, err = mtcRLs.allocateResource(
-		&ResourceUsage{
-			Tenant: tnt,
-			ID:     args.UsageID,
-			Units:  args.Units}, true); err != nil {
+	if _, alcMessage, err = rS.allocateResources(tnt, args, true); err != nil {
 		if err == utils.ErrResourceUnavailable {
 			err = utils.ErrResourceUnauthorized
 		}
@@ -693,16 +799,25 @@ func (rS *ResourceService) V1AllocateResource(args utils.ArgRSv1ResourceUsage, r
 	}
 	// end of RPC caching
 
-	var mtcRLs Resources
+	var alcMsg string
+	if _, alcMsg, err = rS.allocateResources(tnt, args, false); err != nil {
+		return
+	}
+	*reply = alcMsg
+	return
+}
+
+// allocateResources allocates the usage on the resources matching the event,
+// only simulating the allocation on dryRun
+func (rS *ResourceService) allocateResources(tnt string, args utils.ArgRSv1ResourceUsage,
+	dryRun bool) (mtcRLs Resources, alcMsg string, err error) {
 	if mtcRLs, err = rS.matchingResourcesForEvent(tnt, args.CGREvent, args.UsageID,
 		args.UsageTTL); err != nil {
-		return err
+		return
 	}
-
-	var alcMsg string
 	if alcMsg, err = mtcRLs.allocateResource(
 		&ResourceUsage{Tenant: tnt, ID: args.UsageID,
-			Units: args.Units}, false); err != nil {
+			Units: args.Units}, dryRun); err != nil || dryRun {
 		return
 	}
 
@@ -726,10 +841,101 @@ func (rS *ResourceService) V1AllocateResource(args utils.ArgRSv1ResourceUsage, r
 			return
 		}
 	}
-	*reply = alcMsg
 	return
 }
 
+// V1AuthorizeResourcesWithLimits queries service to find if an Usage is allowed,
+// reporting also the remaining capacity of the rate limited resources
+func (rS *ResourceService) V1AuthorizeResourcesWithLimits(args utils.ArgRSv1ResourceUsage, reply *ResourceAllocationReply) (err error) {
+	if args.CGREvent == nil {
+		return utils.NewErrMandatoryIeMissing(utils.Event)
+	}
+	if missing := utils.MissingStructFields(args.CGREvent, []string{utils.ID, utils.Event}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	} else if args.UsageID == "" {
+		return utils.NewErrMandatoryIeMissing(utils.UsageID)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = rS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+
+	// RPC caching
+	if config.CgrConfig().CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
+		cacheKey := utils.ConcatenatedKey(utils.ResourceSv1AuthorizeResourcesWithLimits, utils.ConcatenatedKey(tnt, args.ID))
+		refID := guardian.Guardian.GuardIDs("",
+			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		defer guardian.Guardian.UnguardIDs(refID)
+		if itm, has := Cache.Get(utils.CacheRPCResponses, cacheKey); has {
+			cachedResp := itm.(*utils.CachedRPCResponse)
+			if cachedResp.Error == nil {
+				*reply = *cachedResp.Result.(*ResourceAllocationReply)
+			}
+			return cachedResp.Error
+		}
+		defer Cache.Set(utils.CacheRPCResponses, cacheKey,
+			&utils.CachedRPCResponse{Result: reply, Error: err},
+			nil, true, utils.NonTransactional)
+	}
+	// end of RPC caching
+
+	return rS.allocateResourcesWithLimits(tnt, args, true, reply)
+}
+
+// V1AllocateResourcesWithLimits allocates the Usage if allowed,
+// reporting also the remaining capacity of the rate limited resources
+func (rS *ResourceService) V1AllocateResourcesWithLimits(args utils.ArgRSv1ResourceUsage, reply *ResourceAllocationReply) (err error) {
+	if args.CGREvent == nil {
+		return utils.NewErrMandatoryIeMissing(utils.Event)
+	}
+	if missing := utils.MissingStructFields(args.CGREvent, []string{utils.ID, utils.Event}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	} else if args.UsageID == "" {
+		return utils.NewErrMandatoryIeMissing(utils.UsageID)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = rS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+
+	// RPC caching
+	if config.CgrConfig().CacheCfg().Partitions[utils.CacheRPCResponses].Limit != 0 {
+		cacheKey := utils.ConcatenatedKey(utils.ResourceSv1AllocateResourcesWithLimits, utils.ConcatenatedKey(tnt, args.ID))
+		refID := guardian.Guardian.GuardIDs("",
+			config.CgrConfig().GeneralCfg().LockingTimeout, cacheKey) // RPC caching needs to be atomic
+		defer guardian.Guardian.UnguardIDs(refID)
+		if itm, has := Cache.Get(utils.CacheRPCResponses, cacheKey); has {
+			cachedResp := itm.(*utils.CachedRPCResponse)
+			if cachedResp.Error == nil {
+				*reply = *cachedResp.Result.(*ResourceAllocationReply)
+			}
+			return cachedResp.Error
+		}
+		defer Cache.Set(utils.CacheRPCResponses, cacheKey,
+			&utils.CachedRPCResponse{Result: reply, Error: err},
+			nil, true, utils.NonTransactional)
+	}
+	// end of RPC caching
+
+	return rS.allocateResourcesWithLimits(tnt, args, false, reply)
+}
+
+// allocateResourcesWithLimits builds the ResourceAllocationReply out of the allocation,
+// not considering an error the lack of available units
+func (rS *ResourceService) allocateResourcesWithLimits(tnt string, args utils.ArgRSv1ResourceUsage,
+	dryRun bool, reply *ResourceAllocationReply) (err error) {
+	mtcRLs, alcMsg, err := rS.allocateResources(tnt, args, dryRun)
+	if err != nil && err != utils.ErrResourceUnavailable {
+		return
+	}
+	*reply = ResourceAllocationReply{
+		Authorized:        err == nil,
+		AllocationMessage: alcMsg,
+		RateLimits:        mtcRLs.rateLimits(),
+	}
+	return nil
+}
+
 // V1ReleaseResource is called when we need to clear an allocation
 func (rS *ResourceService) V1ReleaseResource(args utils.ArgRSv1ResourceUsage, reply *string) (err error) {
 	if args.CGREvent == nil {
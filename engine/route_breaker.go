@@ -0,0 +1,305 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// RouteOutcome is the outcome of one call through a route
+type RouteOutcome struct {
+	Time    time.Time
+	Success bool
+}
+
+// RouteBreaker is the circuit breaker state of a route, stored in DataDB so it can be shared between engines
+type RouteBreaker struct {
+	Tenant   string
+	ID       string // the RouteID
+	State    string // *closed, *open or *half_open
+	OpenedAt time.Time
+	ProbeAt  time.Time       // when the probe call was let through while half-open, zero if none
+	Outcomes []*RouteOutcome // outcomes within the window, considered only while closed
+}
+
+// RouteBreakerWithOpts is used in replicatorV1 for dispatcher
+type RouteBreakerWithOpts struct {
+	*RouteBreaker
+	Opts map[string]interface{}
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (rb *RouteBreaker) TenantID() string {
+	return utils.ConcatenatedKey(rb.Tenant, rb.ID)
+}
+
+// Clone returns a copy of the RouteBreaker
+// the breakers are shared through cache so they are cloned before being modified
+func (rb *RouteBreaker) Clone() (cln *RouteBreaker) {
+	cln = &RouteBreaker{
+		Tenant:   rb.Tenant,
+		ID:       rb.ID,
+		State:    rb.State,
+		OpenedAt: rb.OpenedAt,
+		ProbeAt:  rb.ProbeAt,
+	}
+	if rb.Outcomes != nil {
+		cln.Outcomes = make([]*RouteOutcome, len(rb.Outcomes))
+		for i, outcome := range rb.Outcomes {
+			cln.Outcomes[i] = &RouteOutcome{Time: outcome.Time, Success: outcome.Success}
+		}
+	}
+	return
+}
+
+// state returns the state of the breaker at the given time
+// an opened breaker becomes half-open after the cooldown
+func (rb *RouteBreaker) state(cfg *config.RouteSCfg, now time.Time) string {
+	if rb.State == utils.MetaOpen &&
+		!now.Before(rb.OpenedAt.Add(cfg.BreakerCooldown)) {
+		return utils.MetaHalfOpen
+	}
+	if rb.State == utils.EmptyString {
+		return utils.MetaClosed
+	}
+	return rb.State
+}
+
+// probing returns true if a probe call is already in progress while half-open
+// a probe without outcome is considered lost after the cooldown so another one can be let through
+func (rb *RouteBreaker) probing(cfg *config.RouteSCfg, now time.Time) bool {
+	return !rb.ProbeAt.IsZero() &&
+		now.Before(rb.ProbeAt.Add(cfg.BreakerCooldown))
+}
+
+// open moves the breaker in open state
+func (rb *RouteBreaker) open(now time.Time) {
+	rb.State = utils.MetaOpen
+	rb.OpenedAt = now
+	rb.ProbeAt = time.Time{}
+	rb.Outcomes = nil
+}
+
+// recordOutcome updates the breaker based on the outcome of a call
+// returns true if the breaker was modified and needs to be stored
+func (rb *RouteBreaker) recordOutcome(cfg *config.RouteSCfg, success bool, now time.Time) bool {
+	switch rb.state(cfg, now) {
+	case utils.MetaOpen: // not probing yet, the outcome is ignored
+		return false
+	case utils.MetaHalfOpen: // the probe decides the state
+		if !success {
+			rb.open(now)
+			return true
+		}
+		rb.State = utils.MetaClosed
+		rb.OpenedAt = time.Time{}
+		rb.ProbeAt = time.Time{}
+		rb.Outcomes = nil
+		return true
+	}
+	rb.State = utils.MetaClosed
+	rb.Outcomes = append(rb.Outcomes, &RouteOutcome{Time: now, Success: success})
+	var idx, failures int
+	for i, outcome := range rb.Outcomes {
+		if cfg.BreakerWindow > 0 &&
+			outcome.Time.Before(now.Add(-cfg.BreakerWindow)) {
+			idx = i + 1 // outside the window
+			continue
+		}
+		if !outcome.Success {
+			failures++
+		}
+	}
+	rb.Outcomes = rb.Outcomes[idx:]
+	if cfg.BreakerFailures > 0 && failures >= cfg.BreakerFailures {
+		rb.open(now)
+		return true
+	}
+	if calls := len(rb.Outcomes); cfg.BreakerMinASR > 0 && calls >= cfg.BreakerMinCalls &&
+		float64(calls-failures)*100/float64(calls) < cfg.BreakerMinASR {
+		rb.open(now)
+	}
+	return true
+}
+
+// routeBreakerOpen returns true if the circuit breaker of the route does not allow traffic through it
+// while half-open only one probe call is let through, the route being excluded for the others
+func (rpS *RouteService) routeBreakerOpen(tnt, routeID string) (open bool, err error) {
+	var rb *RouteBreaker
+	if rb, err = rpS.dm.GetRouteBreaker(tnt, routeID, true, true, utils.NonTransactional); err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	cfg := rpS.cgrcfg.RouteSCfg()
+	now := time.Now()
+	if state := rb.state(cfg, now); state != utils.MetaHalfOpen {
+		return state == utils.MetaOpen, nil
+	}
+	if rb.probing(cfg, now) {
+		return true, nil
+	}
+	open = true
+	err = rpS.updateRouteBreaker(tnt, routeID, func(rb *RouteBreaker) bool {
+		switch rb.state(cfg, now) {
+		case utils.MetaClosed:
+			open = false
+			return false
+		case utils.MetaOpen:
+			return false
+		}
+		if rb.probing(cfg, now) { // another call became the probe in the meantime
+			return false
+		}
+		rb.ProbeAt = now
+		open = false
+		return true
+	})
+	return
+}
+
+// updateRouteBreaker applies the change on a copy of the circuit breaker of the route, storing it if modified
+// the changes are done under lock so they are not lost between concurrent calls
+func (rpS *RouteService) updateRouteBreaker(tnt, routeID string, update func(rb *RouteBreaker) bool) (err error) {
+	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		var rb *RouteBreaker
+		if rb, err = rpS.dm.GetRouteBreaker(tnt, routeID, true, true, utils.NonTransactional); err != nil {
+			if err != utils.ErrNotFound {
+				return
+			}
+			err = nil
+			rb = &RouteBreaker{Tenant: tnt, ID: routeID, State: utils.MetaClosed}
+		} else {
+			rb = rb.Clone()
+		}
+		if !update(rb) {
+			return
+		}
+		err = rpS.dm.SetRouteBreaker(rb)
+		return
+	}, config.CgrConfig().GeneralCfg().LockingTimeout,
+		utils.RouteBreakerPrefix+utils.ConcatenatedKey(tnt, routeID))
+	return
+}
+
+// V1ProcessOutcome records the outcome of a call within the circuit breaker of the route
+// the event should contain the RouteID and the AnswerTime for the answered calls
+func (rpS *RouteService) V1ProcessOutcome(args *utils.CGREvent, reply *string) (err error) {
+	if args == nil {
+		return utils.NewErrMandatoryIeMissing(utils.CGREventString)
+	}
+	if args.Event == nil {
+		return utils.NewErrMandatoryIeMissing(utils.Event)
+	}
+	if !rpS.cgrcfg.RouteSCfg().BreakerEnabled() {
+		return utils.ErrNotImplemented
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = rpS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	var routeID string
+	if routeID, err = args.FieldAsString(utils.RouteID); err != nil {
+		if err == utils.ErrNotFound {
+			err = utils.NewErrMandatoryIeMissing(utils.RouteID)
+		}
+		return
+	}
+	var answerTime time.Time
+	if answerTime, err = args.FieldAsTime(utils.AnswerTime,
+		rpS.cgrcfg.GeneralCfg().DefaultTimezone); err != nil {
+		if err != utils.ErrNotFound {
+			return
+		}
+		err = nil
+	}
+	cfg := rpS.cgrcfg.RouteSCfg()
+	if err = rpS.updateRouteBreaker(tnt, routeID, func(rb *RouteBreaker) bool {
+		now := time.Now()
+		prevState := rb.state(cfg, now)
+		if !rb.recordOutcome(cfg, !answerTime.IsZero(), now) {
+			return false
+		}
+		if prevState != rb.State {
+			utils.Logger.Info(
+				fmt.Sprintf("<%s> circuit breaker for route: <%s> changed from %s to %s",
+					utils.RouteS, rb.TenantID(), prevState, rb.State))
+		}
+		return true
+	}); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1GetRouteBreaker returns the circuit breaker state of a route
+func (rpS *RouteService) V1GetRouteBreaker(args *utils.TenantIDWithOpts, reply *RouteBreaker) (err error) {
+	if args.TenantID == nil || args.ID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ID)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = rpS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	rb, err := rpS.dm.GetRouteBreaker(tnt, args.ID, true, true, utils.NonTransactional)
+	if err != nil {
+		return
+	}
+	*reply = *rb.Clone()
+	reply.State = rb.state(rpS.cgrcfg.RouteSCfg(), time.Now())
+	return
+}
+
+// V1GetRouteBreakerIDs returns the IDs of the routes with circuit breaker state
+func (rpS *RouteService) V1GetRouteBreakerIDs(args *utils.TenantWithOpts, reply *[]string) (err error) {
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = rpS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	prfx := utils.RouteBreakerPrefix + tnt + utils.ConcatenatedKeySep
+	keys, err := rpS.dm.DataDB().GetKeysForPrefix(prfx)
+	if err != nil {
+		return
+	}
+	rbIDs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		id := key[len(prfx):]
+		// for *internal DataDB the routes cached as not having a breaker share the same partition
+		if _, err = rpS.dm.GetRouteBreaker(tnt, id, true, false, utils.NonTransactional); err != nil {
+			if err != utils.ErrNotFound {
+				return
+			}
+			err = nil
+			continue
+		}
+		rbIDs = append(rbIDs, id)
+	}
+	if len(rbIDs) == 0 {
+		return utils.ErrNotFound
+	}
+	*reply = rbIDs
+	return
+}
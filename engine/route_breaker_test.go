@@ -0,0 +1,256 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func TestRouteBreakerRecordOutcome(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig().RouteSCfg()
+	cfg.BreakerFailures = 2
+	cfg.BreakerMinASR = 50
+	cfg.BreakerMinCalls = 4
+	cfg.BreakerWindow = time.Minute
+	cfg.BreakerCooldown = 30 * time.Second
+	now := time.Date(2021, 1, 5, 10, 0, 0, 0, time.UTC)
+	rb := &RouteBreaker{Tenant: "cgrates.org", ID: "route1"}
+
+	// failures outside the window are not considered
+	rb.recordOutcome(cfg, false, now)
+	rb.recordOutcome(cfg, false, now.Add(2*time.Minute))
+	if rb.State != utils.MetaClosed || len(rb.Outcomes) != 1 {
+		t.Fatalf("Unexpected breaker: %s", utils.ToJSON(rb))
+	}
+	now = now.Add(2 * time.Minute)
+	rb.recordOutcome(cfg, true, now)
+	rb.recordOutcome(cfg, false, now)
+	if rb.State != utils.MetaOpen || !rb.OpenedAt.Equal(now) || len(rb.Outcomes) != 0 {
+		t.Fatalf("Unexpected breaker: %s", utils.ToJSON(rb))
+	}
+	if rb.recordOutcome(cfg, true, now.Add(time.Second)) {
+		t.Error("Expected the outcome to be ignored while open")
+	}
+	now = now.Add(cfg.BreakerCooldown)
+	if state := rb.state(cfg, now); state != utils.MetaHalfOpen {
+		t.Errorf("Expected %s, received %s", utils.MetaHalfOpen, state)
+	}
+	// failed probe opens the breaker again
+	rb.recordOutcome(cfg, false, now)
+	if rb.State != utils.MetaOpen || !rb.OpenedAt.Equal(now) {
+		t.Fatalf("Unexpected breaker: %s", utils.ToJSON(rb))
+	}
+	now = now.Add(cfg.BreakerCooldown)
+	rb.recordOutcome(cfg, true, now)
+	exp := &RouteBreaker{Tenant: "cgrates.org", ID: "route1", State: utils.MetaClosed}
+	if !reflect.DeepEqual(exp, rb) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rb))
+	}
+
+	// ASR under the minimum opens the breaker once there are enough calls
+	cfg.BreakerFailures = 0
+	for _, success := range []bool{true, false, false} {
+		rb.recordOutcome(cfg, success, now)
+	}
+	if rb.State != utils.MetaClosed {
+		t.Fatalf("Unexpected breaker: %s", utils.ToJSON(rb))
+	}
+	rb.recordOutcome(cfg, false, now)
+	if rb.State != utils.MetaOpen {
+		t.Errorf("Unexpected breaker: %s", utils.ToJSON(rb))
+	}
+}
+
+func TestRouteBreakerSortedRoutesForEvent(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RouteSCfg().BreakerFailures = 1
+	data := NewInternalDB(nil, nil, true)
+	dm := NewDataManager(data, cfg.CacheCfg(), nil)
+	rpS, err := NewRouteService(dm, &FilterS{dm: dm, cfg: cfg}, cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dm.SetRouteProfile(&RouteProfile{
+		Tenant:  "cgrates.org",
+		ID:      "ROUTE_BREAKER",
+		Sorting: utils.MetaWeight,
+		Routes: []*Route{
+			{ID: "route1", Weight: 20},
+			{ID: "route2", Weight: 10},
+		},
+	}, true); err != nil {
+		t.Fatal(err)
+	}
+	var reply string
+	if err = rpS.V1ProcessOutcome(&utils.CGREvent{Tenant: "cgrates.org",
+		Event: map[string]interface{}{utils.RouteID: "route1"}}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	var rb RouteBreaker
+	if err = rpS.V1GetRouteBreaker(&utils.TenantIDWithOpts{
+		TenantID: &utils.TenantID{Tenant: "cgrates.org", ID: "route1"}}, &rb); err != nil {
+		t.Fatal(err)
+	} else if rb.State != utils.MetaOpen {
+		t.Errorf("Expected %s, received %s", utils.MetaOpen, rb.State)
+	}
+	var ids []string
+	if err = rpS.V1GetRouteBreakerIDs(&utils.TenantWithOpts{Tenant: "cgrates.org"}, &ids); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual([]string{"route1"}, ids) {
+		t.Errorf("Unexpected IDs: %+v", ids)
+	}
+	sRoutes, err := rpS.sortedRoutesForEvent("cgrates.org", &ArgsGetRoutes{
+		CGREvent: &utils.CGREvent{Tenant: "cgrates.org", ID: "ev1",
+			Event: map[string]interface{}{utils.AccountField: "1001"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sRoutes.SortedRoutes) != 1 || sRoutes.SortedRoutes[0].RouteID != "route2" {
+		t.Errorf("Expected only route2, received %s", utils.ToJSON(sRoutes))
+	}
+	// route2 is cached as not having a breaker but is not listed
+	if err = rpS.V1GetRouteBreakerIDs(&utils.TenantWithOpts{Tenant: "cgrates.org"}, &ids); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual([]string{"route1"}, ids) {
+		t.Errorf("Unexpected IDs: %+v", ids)
+	}
+}
+
+func TestRouteBreakerHalfOpenProbe(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RouteSCfg().BreakerFailures = 1
+	data := NewInternalDB(nil, nil, true)
+	dm := NewDataManager(data, cfg.CacheCfg(), nil)
+	rpS, err := NewRouteService(dm, &FilterS{dm: dm, cfg: cfg}, cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opened := &RouteBreaker{Tenant: "cgrates.org", ID: "route1", State: utils.MetaOpen,
+		OpenedAt: time.Now().Add(-cfg.RouteSCfg().BreakerCooldown - time.Second)}
+	if err = dm.SetRouteBreaker(opened); err != nil {
+		t.Fatal(err)
+	}
+	// only the first call is let through as probe
+	if open, err := rpS.routeBreakerOpen("cgrates.org", "route1"); err != nil {
+		t.Fatal(err)
+	} else if open {
+		t.Error("Expected the probe to be let through")
+	}
+	if open, err := rpS.routeBreakerOpen("cgrates.org", "route1"); err != nil {
+		t.Fatal(err)
+	} else if !open {
+		t.Error("Expected the route to be excluded while probing")
+	}
+	if !opened.ProbeAt.IsZero() {
+		t.Errorf("Expected the stored breaker to not be modified, received %s", utils.ToJSON(opened))
+	}
+	var reply string
+	if err = rpS.V1ProcessOutcome(&utils.CGREvent{Tenant: "cgrates.org",
+		Event: map[string]interface{}{
+			utils.RouteID:    "route1",
+			utils.AnswerTime: time.Now(),
+		}}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if opened.State != utils.MetaOpen {
+		t.Errorf("Expected the stored breaker to not be modified, received %s", utils.ToJSON(opened))
+	}
+	if open, err := rpS.routeBreakerOpen("cgrates.org", "route1"); err != nil {
+		t.Fatal(err)
+	} else if open {
+		t.Error("Expected the breaker to be closed after the successful probe")
+	}
+	if rb, err := dm.GetRouteBreaker("cgrates.org", "route1", true, false, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if rb.State != utils.MetaClosed || !rb.ProbeAt.IsZero() {
+		t.Errorf("Unexpected breaker: %s", utils.ToJSON(rb))
+	}
+}
+
+type routeOutcomeMock struct {
+	events []*utils.CGREvent
+}
+
+func (r *routeOutcomeMock) Call(method string, args interface{}, rply interface{}) error {
+	if method != utils.RouteSv1ProcessOutcome {
+		return utils.ErrNotImplemented
+	}
+	r.events = append(r.events, args.(*utils.CGREvent))
+	*rply.(*string) = utils.OK
+	return nil
+}
+
+func TestRouteBreakerOutcomesFromCDRsAndStats(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	rtConn := utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRoutes)
+	cfg.CdrsCfg().RouteSConns = []string{rtConn}
+	cfg.StatSCfg().RouteSConns = []string{rtConn}
+	mock := new(routeOutcomeMock)
+	rtChan := make(chan rpcclient.ClientConnector, 1)
+	rtChan <- mock
+	connMgr := NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		rtConn: rtChan,
+	})
+	cdrS := &CDRServer{cgrCfg: cfg, connMgr: connMgr}
+	sS := &StatService{cgrcfg: cfg, connMgr: connMgr}
+
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "ev1",
+		Event: map[string]interface{}{
+			utils.OriginID:   "route_outcome",
+			utils.RouteID:    "route1",
+			utils.AnswerTime: time.Date(2021, 1, 5, 10, 0, 0, 0, time.UTC),
+		},
+	}
+	if _, err := cdrS.processEvent(ev, false, false, false, false,
+		false, false, false, false, false, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := sS.processRouteOutcome(ev); err != nil {
+		t.Fatal(err)
+	}
+	// events without RouteID are not reported
+	noRoute := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "ev2",
+		Event:  map[string]interface{}{utils.OriginID: "no_route"},
+	}
+	if _, err := cdrS.processEvent(noRoute, false, false, false, false,
+		false, false, false, false, false, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := sS.processRouteOutcome(noRoute); err != nil {
+		t.Fatal(err)
+	}
+	if len(mock.events) != 2 {
+		t.Fatalf("Expected 2 outcomes, received %s", utils.ToJSON(mock.events))
+	}
+	for _, rcv := range mock.events {
+		if rcv.Event[utils.RouteID] != "route1" {
+			t.Errorf("Unexpected outcome event: %s", utils.ToJSON(rcv))
+		}
+	}
+}
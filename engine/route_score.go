@@ -0,0 +1,313 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewScoreSorter constructs ScoreSorter
+func NewScoreSorter(rS *RouteService) *ScoreSorter {
+	return &ScoreSorter{rS: rS,
+		sorting: utils.MetaScore}
+}
+
+// ScoreSorter orders routes ascendent based on a weighted score
+// computed out of the expressions defined in SortingParameters
+type ScoreSorter struct {
+	sorting string
+	rS      *RouteService
+}
+
+// SortRoutes computes the score for each route and sorts them
+func (ss *ScoreSorter) SortRoutes(prflID string, routes map[string]*Route,
+	ev *utils.CGREvent, extraOpts *optsGetRoutes) (sortedRoutes *SortedRoutes, err error) {
+	exprs := extraOpts.scoreExprs
+	if exprs == nil { // not compiled with the profile
+		if exprs, err = newScoreExprs(extraOpts.sortingParameters); err != nil {
+			return
+		}
+	}
+	sortedRoutes = &SortedRoutes{ProfileID: prflID,
+		Sorting:      ss.sorting,
+		SortedRoutes: make([]*SortedRoute, 0)}
+	for _, route := range routes {
+		if srtSpl, pass, err := ss.rS.populateSortingData(ev, route, extraOpts); err != nil {
+			return nil, err
+		} else if pass && srtSpl != nil {
+			if missing := scoreMissingFields(exprs, srtSpl.SortingData); len(missing) != 0 {
+				err = utils.NewErrMandatoryIeMissing(missing...)
+				if !extraOpts.ignoreErrors {
+					return nil, err
+				}
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> ignoring route with ID: %s, err: %s",
+						utils.RouteS, route.ID, err.Error()))
+				continue
+			}
+			sortedRoutes.SortedRoutes = append(sortedRoutes.SortedRoutes, srtSpl)
+		}
+	}
+	sortedRoutes.computeScore(exprs)
+	sortedRoutes.SortScore()
+	return
+}
+
+// computeScore populates the Score and the ScoreComponents in SortingData
+// the values used by the expressions are normalized between the routes to [0,1]
+func (sRoutes *SortedRoutes) computeScore(exprs []*scoreExpr) {
+	fields := make(utils.StringSet)
+	for _, expr := range exprs {
+		expr.node.fields(fields)
+	}
+	minVals := make(map[string]float64)
+	maxVals := make(map[string]float64)
+	for i, sRoute := range sRoutes.SortedRoutes {
+		for fld := range fields {
+			val, _ := utils.IfaceAsFloat64(sRoute.SortingData[fld])
+			if i == 0 || val < minVals[fld] {
+				minVals[fld] = val
+			}
+			if i == 0 || val > maxVals[fld] {
+				maxVals[fld] = val
+			}
+		}
+	}
+	for _, sRoute := range sRoutes.SortedRoutes {
+		normVals := make(map[string]float64)
+		for fld := range fields {
+			if maxVals[fld] == minVals[fld] {
+				normVals[fld] = 0
+				continue
+			}
+			val, _ := utils.IfaceAsFloat64(sRoute.SortingData[fld])
+			normVals[fld] = (val - minVals[fld]) / (maxVals[fld] - minVals[fld])
+		}
+		var score float64
+		components := make(map[string]float64)
+		for _, expr := range exprs {
+			components[expr.param] = expr.node.eval(normVals)
+			score += components[expr.param]
+		}
+		sRoute.SortingData[utils.Score] = score
+		sRoute.SortingData[utils.ScoreComponents] = components
+	}
+}
+
+// SortScore is part of sort interface,
+// sort ascendent based on Score with fallback on Weight
+func (sRoutes *SortedRoutes) SortScore() {
+	sort.Slice(sRoutes.SortedRoutes, func(i, j int) bool {
+		if sRoutes.SortedRoutes[i].SortingData[utils.Score].(float64) == sRoutes.SortedRoutes[j].SortingData[utils.Score].(float64) {
+			if sRoutes.SortedRoutes[i].SortingData[utils.Weight].(float64) == sRoutes.SortedRoutes[j].SortingData[utils.Weight].(float64) {
+				return utils.BoolGenerator().RandomBool()
+			}
+			return sRoutes.SortedRoutes[i].SortingData[utils.Weight].(float64) > sRoutes.SortedRoutes[j].SortingData[utils.Weight].(float64)
+		}
+		return sRoutes.SortedRoutes[i].SortingData[utils.Score].(float64) < sRoutes.SortedRoutes[j].SortingData[utils.Score].(float64)
+	})
+}
+
+// scoreMissingFields returns the fields used by expressions but not populated for the route
+func scoreMissingFields(exprs []*scoreExpr, sortingData map[string]interface{}) (missing []string) {
+	fields := make(utils.StringSet)
+	for _, expr := range exprs {
+		expr.node.fields(fields)
+	}
+	for _, fld := range fields.AsOrderedSlice() {
+		if _, has := sortingData[fld]; !has {
+			missing = append(missing, fld)
+		}
+	}
+	return
+}
+
+// scoreExpr is one of the SortingParameters compiled
+type scoreExpr struct {
+	param string
+	node  scoreNode
+}
+
+// newScoreExprs compiles the SortingParameters of a *score profile
+func newScoreExprs(params []string) (exprs []*scoreExpr, err error) {
+	if len(params) == 0 {
+		return nil, utils.NewErrMandatoryIeMissing(utils.SortingParameters)
+	}
+	exprs = make([]*scoreExpr, len(params))
+	for i, param := range params {
+		p := &scoreParser{expr: param}
+		var node scoreNode
+		if node, err = p.parseExpr(); err != nil {
+			return nil, fmt.Errorf("invalid score expression <%s>: %s", param, err.Error())
+		}
+		if p.skipSpaces(); p.pos != len(p.expr) {
+			return nil, fmt.Errorf("invalid score expression <%s>: unexpected <%c> at position %d",
+				param, p.expr[p.pos], p.pos)
+		}
+		exprs[i] = &scoreExpr{param: param, node: node}
+	}
+	return
+}
+
+// scoreNode is one node of the compiled score expression
+type scoreNode interface {
+	eval(vals map[string]float64) float64
+	fields(flds utils.StringSet)
+}
+
+type scoreNumber float64
+
+func (n scoreNumber) eval(map[string]float64) float64 { return float64(n) }
+func (scoreNumber) fields(utils.StringSet)            {}
+
+// scoreField references a value out of the route SortingData
+type scoreField string
+
+func (f scoreField) eval(vals map[string]float64) float64 { return vals[string(f)] }
+func (f scoreField) fields(flds utils.StringSet)          { flds.Add(string(f)) }
+
+type scoreOperation struct {
+	op          byte
+	left, right scoreNode
+}
+
+func (o *scoreOperation) eval(vals map[string]float64) float64 {
+	l, r := o.left.eval(vals), o.right.eval(vals)
+	switch o.op {
+	case '+':
+		return l + r
+	case '-':
+		return l - r
+	case '*':
+		return l * r
+	default: // '/'
+		if r == 0 {
+			return 0
+		}
+		return l / r
+	}
+}
+
+func (o *scoreOperation) fields(flds utils.StringSet) {
+	o.left.fields(flds)
+	o.right.fields(flds)
+}
+
+// scoreParser is a recursive descent parser for the score expressions
+// supporting numbers, SortingData fields, + - * / and parenthesis
+type scoreParser struct {
+	expr string
+	pos  int
+}
+
+func (p *scoreParser) skipSpaces() {
+	for p.pos < len(p.expr) && p.expr[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// parseExpr parses the additive operations
+func (p *scoreParser) parseExpr() (node scoreNode, err error) {
+	if node, err = p.parseTerm(); err != nil {
+		return
+	}
+	for p.skipSpaces(); p.pos < len(p.expr) &&
+		(p.expr[p.pos] == '+' || p.expr[p.pos] == '-'); p.skipSpaces() {
+		op := p.expr[p.pos]
+		p.pos++
+		var right scoreNode
+		if right, err = p.parseTerm(); err != nil {
+			return
+		}
+		node = &scoreOperation{op: op, left: node, right: right}
+	}
+	return
+}
+
+// parseTerm parses the multiplicative operations
+func (p *scoreParser) parseTerm() (node scoreNode, err error) {
+	if node, err = p.parseFactor(); err != nil {
+		return
+	}
+	for p.skipSpaces(); p.pos < len(p.expr) &&
+		(p.expr[p.pos] == '*' || p.expr[p.pos] == '/'); p.skipSpaces() {
+		op := p.expr[p.pos]
+		p.pos++
+		var right scoreNode
+		if right, err = p.parseFactor(); err != nil {
+			return
+		}
+		node = &scoreOperation{op: op, left: node, right: right}
+	}
+	return
+}
+
+// parseFactor parses numbers, fields, negations and parenthesis
+// a * in front of an operand is considered part of the field name(ie: *asr)
+func (p *scoreParser) parseFactor() (node scoreNode, err error) {
+	p.skipSpaces()
+	if p.pos == len(p.expr) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	switch c := p.expr[p.pos]; {
+	case c == '(':
+		p.pos++
+		if node, err = p.parseExpr(); err != nil {
+			return
+		}
+		if p.skipSpaces(); p.pos == len(p.expr) || p.expr[p.pos] != ')' {
+			return nil, fmt.Errorf("missing ) at position %d", p.pos)
+		}
+		p.pos++
+		return
+	case c == '-':
+		p.pos++
+		if node, err = p.parseFactor(); err != nil {
+			return
+		}
+		return &scoreOperation{op: '-', left: scoreNumber(0), right: node}, nil
+	case c >= '0' && c <= '9' || c == '.':
+		start := p.pos
+		for p.pos < len(p.expr) && (p.expr[p.pos] >= '0' && p.expr[p.pos] <= '9' || p.expr[p.pos] == '.') {
+			p.pos++
+		}
+		var val float64
+		if val, err = strconv.ParseFloat(p.expr[start:p.pos], 64); err != nil {
+			return
+		}
+		return scoreNumber(val), nil
+	default:
+		start := p.pos
+		if c == '*' {
+			p.pos++
+		}
+		for p.pos < len(p.expr) && strings.IndexByte("+-*/() ", p.expr[p.pos]) == -1 {
+			p.pos++
+		}
+		if p.pos == start || (c == '*' && p.pos == start+1) {
+			return nil, fmt.Errorf("unexpected <%c> at position %d", c, start)
+		}
+		return scoreField(p.expr[start:p.pos]), nil
+	}
+}
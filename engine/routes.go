@@ -66,6 +66,13 @@ type RouteProfileWithOpts struct {
 }
 
 func (rp *RouteProfile) compileCacheParameters() error {
+	if rp.Sorting == utils.MetaScore {
+		exprs, err := newScoreExprs(rp.SortingParameters)
+		if err != nil {
+			return err
+		}
+		rp.cache = map[string]interface{}{utils.MetaScore: exprs}
+	}
 	if rp.Sorting == utils.MetaLoad {
 		// construct the map for ratio
 		ratioMap := make(map[string]int)
@@ -461,7 +468,12 @@ func (rpS *RouteService) populateSortingData(ev *utils.CGREvent, route *Route,
 			//check if the route have the metric from sortingParameters
 			//in case that the metric don't exist
 			//we use 10000000 for *pdd and -1 for others
-			for _, metric := range extraOpts.sortingParameters {
+			//*score strategy uses expressions as sortingParameters
+			var sortingMetrics []string
+			if extraOpts.sortingStragety != utils.MetaScore {
+				sortingMetrics = extraOpts.sortingParameters
+			}
+			for _, metric := range sortingMetrics {
 				if _, hasMetric := metricSupp[metric]; !hasMetric {
 					switch metric {
 					default:
@@ -525,6 +537,9 @@ func (rpS *RouteService) sortedRoutesForEvent(tnt string, args *ArgsGetRoutes) (
 	}
 	extraOpts.sortingParameters = rPrfl.SortingParameters // populate sortingParameters in extraOpts
 	extraOpts.sortingStragety = rPrfl.Sorting             // populate sortingStrategy in extraOpts
+	if exprs, has := rPrfl.cache[utils.MetaScore]; has {
+		extraOpts.scoreExprs = exprs.([]*scoreExpr)
+	}
 
 	//construct the DP and pass it to filterS
 	nM := utils.MapStorage{utils.MetaReq: args.Event}
@@ -542,6 +557,14 @@ func (rpS *RouteService) sortedRoutesForEvent(tnt string, args *ArgsGetRoutes) (
 		} else if !pass {
 			continue
 		}
+		if rpS.cgrcfg.RouteSCfg().BreakerEnabled() {
+			var open bool
+			if open, err = rpS.routeBreakerOpen(tnt, route.ID); err != nil {
+				return nil, err
+			} else if open { // blacklisted until the cooldown passes
+				continue
+			}
+		}
 		route.lazyCheckRules = lazyCheckRules
 		if prev, has := passedRoutes[route.ID]; has && prev.Weight >= route.Weight {
 			continue
@@ -630,6 +653,7 @@ type optsGetRoutes struct {
 	maxCost           float64
 	sortingParameters []string //used for QOS strategy
 	sortingStragety   string
+	scoreExprs        []*scoreExpr // compiled SortingParameters for *score strategy
 }
 
 // V1GetRoutes returns the list of valid routes
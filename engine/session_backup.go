@@ -0,0 +1,57 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// SessionBackup is the state of an active session checkpointed into DataDB
+// so it can be restored by the same node after a restart
+type SessionBackup struct {
+	NodeID        string // the node owning the session
+	CGRID         string
+	Tenant        string
+	ResourceID    string
+	ClientConnID  string
+	EventStart    MapEvent
+	DebitInterval time.Duration
+	OptsStart     MapEvent
+	CallContextID string
+	Chargeable    bool
+	SRuns         []*SRunBackup
+}
+
+// SRunBackup is the state of one session run checkpointed into DataDB
+type SRunBackup struct {
+	Event         MapEvent
+	CD            *CallDescriptor
+	EventCost     *EventCost
+	ExtraDuration time.Duration
+	LastUsage     time.Duration
+	TotalUsage    time.Duration
+	NextAutoDebit *time.Time
+}
+
+// NodeCGRID returns the concatenated key between the NodeID and CGRID
+func (sb *SessionBackup) NodeCGRID() string {
+	return utils.ConcatenatedKey(sb.NodeID, sb.CGRID)
+}
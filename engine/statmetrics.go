@@ -20,6 +20,8 @@ package engine
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -54,9 +56,16 @@ func NewStatMetric(metricID string, minItems int, filterIDs []string) (sm StatMe
 		utils.MetaSum:      NewStatSum,
 		utils.MetaAverage:  NewStatAverage,
 		utils.MetaDistinct: NewStatDistinct,
+
+		utils.MetaPercentile:  NewStatPercentile,
+		utils.MetaStdDev:      NewStatStdDev,
+		utils.MetaMin:         NewStatMin,
+		utils.MetaMax:         NewStatMax,
+		utils.MetaHighestCost: NewStatHighestCost,
 	}
 	// split the metricID
 	// in case of *sum we have *sum#~*req.FieldName
+	// in case of *percentile we have *percentile#95:~*req.FieldName
 	metricSplit := strings.Split(metricID, utils.HashtagSep)
 	if _, has := metrics[metricSplit[0]]; !has {
 		return nil, fmt.Errorf("unsupported metric type <%s>", metricSplit[0])
@@ -1016,10 +1025,14 @@ func (ddc *StatDDC) GetFilterIDs() []string {
 func (ddc *StatDDC) GetMinItems() (minIts int) { return ddc.MinItems }
 
 func (ddc *StatDDC) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
-	for id := range ddc.Events {
-		eventIDs = append(eventIDs, id)
+	if ddc.Count < queueLen {
+		for id := range ddc.Events {
+			eventIDs = append(eventIDs, id)
+		}
+		return
 	}
-	return
+	ddc.Events, ddc.FieldValues = compressDistinctValues(ddc.Events, defaultID)
+	return []string{defaultID}
 }
 
 ////////////////////
@@ -1041,6 +1054,21 @@ func (ddc *StatDDC) GetCompressFactor(events map[string]int) map[string]int {
 	return events
 }
 
+// compressDistinctValues merges the events of the distinct metrics under defaultID
+// only the distinct field values are kept, each with the number of events having it
+func compressDistinctValues(events map[string]map[string]int64, defaultID string) (
+	cmpEvents map[string]map[string]int64, fieldValues map[string]utils.StringSet) {
+	cmpVals := make(map[string]int64)
+	fieldValues = make(map[string]utils.StringSet)
+	for _, vals := range events {
+		for fieldValue, cnt := range vals {
+			cmpVals[fieldValue] += cnt
+			fieldValues[fieldValue] = utils.NewStringSet([]string{defaultID})
+		}
+	}
+	return map[string]map[string]int64{defaultID: cmpVals}, fieldValues
+}
+
 func NewStatSum(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
 	return &StatSum{Events: make(map[string]*StatWithCompress),
 		MinItems: minItems, FieldName: extraParams, FilterIDs: filterIDs}, nil
@@ -1431,10 +1459,14 @@ func (dst *StatDistinct) GetFilterIDs() []string {
 func (dst *StatDistinct) GetMinItems() (minIts int) { return dst.MinItems }
 
 func (dst *StatDistinct) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
-	for id := range dst.Events {
-		eventIDs = append(eventIDs, id)
+	if dst.Count < queueLen {
+		for id := range dst.Events {
+			eventIDs = append(eventIDs, id)
+		}
+		return
 	}
-	return
+	dst.Events, dst.FieldValues = compressDistinctValues(dst.Events, defaultID)
+	return []string{defaultID}
 }
 
 // Compress is part of StatMetric interface
@@ -1453,3 +1485,624 @@ func (dst *StatDistinct) GetCompressFactor(events map[string]int) map[string]int
 	}
 	return events
 }
+
+// StatAggregate keeps the aggregates of the values added for the same event
+// so repeated event IDs do not lose the spread of their values
+// the aggregates of different events can be merged when compressing
+type StatAggregate struct {
+	Count int64
+	Sum   float64
+	SumSq float64 // sum of the squared values
+	Min   float64
+	Max   float64
+}
+
+// addValue adds one value to the aggregates
+func (sa *StatAggregate) addValue(val float64) {
+	if sa.Count == 0 || val < sa.Min {
+		sa.Min = val
+	}
+	if sa.Count == 0 || val > sa.Max {
+		sa.Max = val
+	}
+	sa.Count++
+	sa.Sum += val
+	sa.SumSq += val * val
+}
+
+// merge adds the aggregates of another event
+func (sa *StatAggregate) merge(oSa *StatAggregate) {
+	if sa.Count == 0 || oSa.Min < sa.Min {
+		sa.Min = oSa.Min
+	}
+	if sa.Count == 0 || oSa.Max > sa.Max {
+		sa.Max = oSa.Max
+	}
+	sa.Count += oSa.Count
+	sa.Sum += oSa.Sum
+	sa.SumSq += oSa.SumSq
+}
+
+// remValue removes one of the values, the mean one since they are no longer known
+// Min and Max are kept since they cannot be recomputed
+func (sa *StatAggregate) remValue() {
+	sa.Sum -= sa.Sum / float64(sa.Count)
+	sa.SumSq -= sa.SumSq / float64(sa.Count)
+	sa.Count--
+}
+
+// addStatAggregate parses the field value out of the event and adds it to the aggregates of the event
+func addStatAggregate(events map[string]*StatAggregate, evID, fieldName string,
+	ev utils.DataProvider) (err error) {
+	var val float64
+	if val, err = statFieldValue(fieldName, ev); err != nil {
+		return
+	}
+	if _, has := events[evID]; !has {
+		events[evID] = new(StatAggregate)
+	}
+	events[evID].addValue(val)
+	return
+}
+
+// remStatAggregate removes one value of the event out of the aggregates
+func remStatAggregate(events map[string]*StatAggregate, evID string) (err error) {
+	sa, has := events[evID]
+	if !has {
+		return utils.ErrNotFound
+	}
+	if sa.Count <= 1 {
+		delete(events, evID)
+	} else {
+		sa.remValue()
+	}
+	return
+}
+
+// compressStatAggregates merges the aggregates of all the events under defaultID once count reaches queueLen
+func compressStatAggregates(events map[string]*StatAggregate, count, queueLen int64,
+	defaultID string) (cmprsd map[string]*StatAggregate, eventIDs []string) {
+	if count < queueLen {
+		for id := range events {
+			eventIDs = append(eventIDs, id)
+		}
+		return events, eventIDs
+	}
+	sa := new(StatAggregate)
+	for _, evSa := range events {
+		sa.merge(evSa)
+	}
+	return map[string]*StatAggregate{defaultID: sa}, []string{defaultID}
+}
+
+// getCompressFactorAggregates is the GetCompressFactor for metrics using StatAggregate
+func getCompressFactorAggregates(evs map[string]*StatAggregate, events map[string]int) map[string]int {
+	for id, sa := range evs {
+		if events[id] < int(sa.Count) {
+			events[id] = int(sa.Count)
+		}
+	}
+	return events
+}
+
+// statFieldValue parses the value of the field out of the event
+func statFieldValue(fieldName string, ev utils.DataProvider) (val float64, err error) {
+	var ival interface{}
+	if ival, err = utils.DPDynamicInterface(fieldName, ev); err != nil {
+		if err == utils.ErrNotFound {
+			err = utils.ErrPrefix(err, fieldName)
+		}
+		return
+	}
+	return utils.IfaceAsFloat64(ival)
+}
+
+// statSketchAccuracy is the relative accuracy of the values returned by the *percentile metric
+const statSketchAccuracy = 0.01
+
+// statSketchOffset keeps the bins of the positive values above 0 and the negative ones below
+const statSketchOffset = 1 << 16
+
+// statSketchLogGamma is the logarithmic width of a sketch bin
+var statSketchLogGamma = math.Log((1 + statSketchAccuracy) / (1 - statSketchAccuracy))
+
+// StatBin counts the values of one sketch bin, all of them within statSketchAccuracy of each other
+type StatBin struct {
+	Count int64
+	Min   float64
+	Max   float64
+}
+
+// statSketchIndex returns the bin of the value, ordering the bins as their values
+func statSketchIndex(val float64) int {
+	switch {
+	case val > 0:
+		return int(math.Ceil(math.Log(val)/statSketchLogGamma)) + statSketchOffset
+	case val < 0:
+		return -int(math.Ceil(math.Log(-val)/statSketchLogGamma)) - statSketchOffset
+	}
+	return 0
+}
+
+// NewStatPercentile instantiates the *percentile metric
+// extraParams are in the format: <percentile>:<fieldName>, ie: 95:~*req.Usage
+func NewStatPercentile(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	params := strings.SplitN(extraParams, utils.InInFieldSep, 2)
+	if len(params) != 2 {
+		return nil, fmt.Errorf("invalid format for percentile params <%s>", extraParams)
+	}
+	prcnt, err := strconv.ParseFloat(params[0], 64)
+	if err != nil {
+		return nil, err
+	}
+	if prcnt <= 0 || prcnt > 100 {
+		return nil, fmt.Errorf("percentile <%s> out of range", params[0])
+	}
+	return &StatPercentile{Bins: make(map[int]*StatBin), Events: make(map[string]map[int]int64),
+		MinItems: minItems, Percentile: prcnt, FieldName: params[1], FilterIDs: filterIDs}, nil
+}
+
+// StatPercentile implements the percentile metric using the nearest-rank method
+// the values are counted into a logarithmic sketch so its size does not grow with the number of events
+type StatPercentile struct {
+	FilterIDs  []string
+	Count      int64
+	Bins       map[int]*StatBin         // map[binIndex]*StatBin
+	Events     map[string]map[int]int64 // map[EventTenantID]map[binIndex]count
+	MinItems   int
+	Percentile float64
+	FieldName  string
+	val        *float64 // cached percentile value
+}
+
+// getValue returns prc.val
+func (prc *StatPercentile) getValue(roundingDecimal int) float64 {
+	if prc.val == nil {
+		if prc.Count == 0 || prc.Count < int64(prc.MinItems) {
+			prc.val = utils.Float64Pointer(utils.StatsNA)
+		} else {
+			rank := int64(math.Ceil(prc.Percentile / 100 * float64(prc.Count)))
+			if rank < 1 {
+				rank = 1
+			}
+			binIdxs := make([]int, 0, len(prc.Bins))
+			for idx := range prc.Bins {
+				binIdxs = append(binIdxs, idx)
+			}
+			sort.Ints(binIdxs)
+			var val float64
+			for _, idx := range binIdxs {
+				bin := prc.Bins[idx]
+				if rank > bin.Count {
+					rank -= bin.Count
+					continue
+				}
+				// interpolate between the limits of the bin, exact if all its values are equal
+				val = bin.Min
+				if bin.Count > 1 {
+					val += (bin.Max - bin.Min) * float64(rank-1) / float64(bin.Count-1)
+				}
+				break
+			}
+			prc.val = utils.Float64Pointer(utils.Round(val,
+				roundingDecimal, utils.MetaRoundingMiddle))
+		}
+	}
+	return *prc.val
+}
+
+func (prc *StatPercentile) GetStringValue(roundingDecimal int) (valStr string) {
+	if val := prc.getValue(roundingDecimal); val == utils.StatsNA {
+		valStr = utils.NotAvailable
+	} else {
+		valStr = strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return
+}
+
+func (prc *StatPercentile) GetValue(roundingDecimal int) (v interface{}) {
+	return prc.getValue(roundingDecimal)
+}
+
+func (prc *StatPercentile) GetFloat64Value(roundingDecimal int) (v float64) {
+	return prc.getValue(roundingDecimal)
+}
+
+func (prc *StatPercentile) AddEvent(evID string, ev utils.DataProvider) (err error) {
+	var val float64
+	if val, err = statFieldValue(prc.FieldName, ev); err != nil {
+		return
+	}
+	idx := statSketchIndex(val)
+	if bin, has := prc.Bins[idx]; !has {
+		prc.Bins[idx] = &StatBin{Count: 1, Min: val, Max: val}
+	} else {
+		bin.Count++
+		bin.Min = math.Min(bin.Min, val)
+		bin.Max = math.Max(bin.Max, val)
+	}
+	if _, has := prc.Events[evID]; !has {
+		prc.Events[evID] = make(map[int]int64)
+	}
+	prc.Events[evID][idx]++
+	prc.Count++
+	prc.val = nil
+	return
+}
+
+// RemEvent removes one value of the event out of the sketch
+// the most populated bin of the event is picked once the event is compressed
+func (prc *StatPercentile) RemEvent(evID string) (err error) {
+	evBins, has := prc.Events[evID]
+	if !has {
+		return utils.ErrNotFound
+	}
+	var remIdx int
+	var remCnt int64
+	for idx, cnt := range evBins {
+		if cnt > remCnt ||
+			(cnt == remCnt && idx < remIdx) {
+			remIdx, remCnt = idx, cnt
+		}
+	}
+	if evBins[remIdx]--; evBins[remIdx] == 0 {
+		delete(evBins, remIdx)
+	}
+	if len(evBins) == 0 {
+		delete(prc.Events, evID)
+	}
+	if bin, has := prc.Bins[remIdx]; has {
+		if bin.Count--; bin.Count <= 0 {
+			delete(prc.Bins, remIdx)
+		}
+	}
+	prc.Count--
+	prc.val = nil
+	return
+}
+
+func (prc *StatPercentile) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(prc)
+}
+
+func (prc *StatPercentile) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, prc)
+}
+
+// GetFilterIDs is part of StatMetric interface
+func (prc *StatPercentile) GetFilterIDs() []string {
+	return prc.FilterIDs
+}
+
+// GetMinItems returns the minim items for the metric
+func (prc *StatPercentile) GetMinItems() (minIts int) { return prc.MinItems }
+
+// Compress is part of StatMetric interface
+// the bins of the events are merged under defaultID
+func (prc *StatPercentile) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
+	if prc.Count < queueLen {
+		for id := range prc.Events {
+			eventIDs = append(eventIDs, id)
+		}
+		return
+	}
+	evBins := make(map[int]int64)
+	for _, bins := range prc.Events {
+		for idx, cnt := range bins {
+			evBins[idx] += cnt
+		}
+	}
+	prc.Events = map[string]map[int]int64{defaultID: evBins}
+	return []string{defaultID}
+}
+
+// GetCompressFactor is part of StatMetric interface
+func (prc *StatPercentile) GetCompressFactor(events map[string]int) map[string]int {
+	for id, bins := range prc.Events {
+		var compressFactor int
+		for _, cnt := range bins {
+			compressFactor += int(cnt)
+		}
+		if events[id] < compressFactor {
+			events[id] = compressFactor
+		}
+	}
+	return events
+}
+
+func NewStatStdDev(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	return &StatStdDev{Events: make(map[string]*StatAggregate),
+		MinItems: minItems, FieldName: extraParams, FilterIDs: filterIDs}, nil
+}
+
+// StatStdDev implements the population standard deviation metric
+type StatStdDev struct {
+	FilterIDs []string
+	Count     int64
+	Events    map[string]*StatAggregate // map[EventTenantID]Aggregates
+	MinItems  int
+	FieldName string
+	val       *float64 // cached standard deviation value
+}
+
+// getValue returns std.val
+func (std *StatStdDev) getValue(roundingDecimal int) float64 {
+	if std.val == nil {
+		if std.Count == 0 || std.Count < int64(std.MinItems) {
+			std.val = utils.Float64Pointer(utils.StatsNA)
+		} else {
+			var sum, sumSq float64
+			for _, sa := range std.Events {
+				sum += sa.Sum
+				sumSq += sa.SumSq
+			}
+			mean := sum / float64(std.Count)
+			variance := sumSq/float64(std.Count) - mean*mean
+			if variance < 0 { // float rounding
+				variance = 0
+			}
+			std.val = utils.Float64Pointer(utils.Round(math.Sqrt(variance),
+				roundingDecimal, utils.MetaRoundingMiddle))
+		}
+	}
+	return *std.val
+}
+
+func (std *StatStdDev) GetStringValue(roundingDecimal int) (valStr string) {
+	if val := std.getValue(roundingDecimal); val == utils.StatsNA {
+		valStr = utils.NotAvailable
+	} else {
+		valStr = strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return
+}
+
+func (std *StatStdDev) GetValue(roundingDecimal int) (v interface{}) {
+	return std.getValue(roundingDecimal)
+}
+
+func (std *StatStdDev) GetFloat64Value(roundingDecimal int) (v float64) {
+	return std.getValue(roundingDecimal)
+}
+
+func (std *StatStdDev) AddEvent(evID string, ev utils.DataProvider) (err error) {
+	if err = addStatAggregate(std.Events, evID, std.FieldName, ev); err != nil {
+		return
+	}
+	std.Count++
+	std.val = nil
+	return
+}
+
+func (std *StatStdDev) RemEvent(evID string) (err error) {
+	if err = remStatAggregate(std.Events, evID); err != nil {
+		return
+	}
+	std.Count--
+	std.val = nil
+	return
+}
+
+func (std *StatStdDev) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(std)
+}
+
+func (std *StatStdDev) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, std)
+}
+
+// GetFilterIDs is part of StatMetric interface
+func (std *StatStdDev) GetFilterIDs() []string {
+	return std.FilterIDs
+}
+
+// GetMinItems returns the minim items for the metric
+func (std *StatStdDev) GetMinItems() (minIts int) { return std.MinItems }
+
+// Compress is part of StatMetric interface
+func (std *StatStdDev) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
+	std.Events, eventIDs = compressStatAggregates(std.Events, std.Count, queueLen, defaultID)
+	return
+}
+
+// GetCompressFactor is part of StatMetric interface
+func (std *StatStdDev) GetCompressFactor(events map[string]int) map[string]int {
+	return getCompressFactorAggregates(std.Events, events)
+}
+
+func NewStatMin(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	return &StatMin{Events: make(map[string]*StatAggregate),
+		MinItems: minItems, FieldName: extraParams, FilterIDs: filterIDs}, nil
+}
+
+// StatMin implements the minimum value metric
+type StatMin struct {
+	FilterIDs []string
+	Count     int64
+	Events    map[string]*StatAggregate // map[EventTenantID]Aggregates
+	MinItems  int
+	FieldName string
+	val       *float64 // cached min value
+}
+
+// getValue returns mn.val
+func (mn *StatMin) getValue(roundingDecimal int) float64 {
+	if mn.val == nil {
+		if mn.Count == 0 || mn.Count < int64(mn.MinItems) {
+			mn.val = utils.Float64Pointer(utils.StatsNA)
+		} else {
+			var val *float64
+			for _, sa := range mn.Events {
+				if val == nil || sa.Min < *val {
+					val = utils.Float64Pointer(sa.Min)
+				}
+			}
+			mn.val = utils.Float64Pointer(utils.Round(*val,
+				roundingDecimal, utils.MetaRoundingMiddle))
+		}
+	}
+	return *mn.val
+}
+
+func (mn *StatMin) GetStringValue(roundingDecimal int) (valStr string) {
+	if val := mn.getValue(roundingDecimal); val == utils.StatsNA {
+		valStr = utils.NotAvailable
+	} else {
+		valStr = strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return
+}
+
+func (mn *StatMin) GetValue(roundingDecimal int) (v interface{}) {
+	return mn.getValue(roundingDecimal)
+}
+
+func (mn *StatMin) GetFloat64Value(roundingDecimal int) (v float64) {
+	return mn.getValue(roundingDecimal)
+}
+
+func (mn *StatMin) AddEvent(evID string, ev utils.DataProvider) (err error) {
+	if err = addStatAggregate(mn.Events, evID, mn.FieldName, ev); err != nil {
+		return
+	}
+	mn.Count++
+	mn.val = nil
+	return
+}
+
+func (mn *StatMin) RemEvent(evID string) (err error) {
+	if err = remStatAggregate(mn.Events, evID); err != nil {
+		return
+	}
+	mn.Count--
+	mn.val = nil
+	return
+}
+
+func (mn *StatMin) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(mn)
+}
+
+func (mn *StatMin) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, mn)
+}
+
+// GetFilterIDs is part of StatMetric interface
+func (mn *StatMin) GetFilterIDs() []string {
+	return mn.FilterIDs
+}
+
+// GetMinItems returns the minim items for the metric
+func (mn *StatMin) GetMinItems() (minIts int) { return mn.MinItems }
+
+// Compress is part of StatMetric interface
+func (mn *StatMin) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
+	mn.Events, eventIDs = compressStatAggregates(mn.Events, mn.Count, queueLen, defaultID)
+	return
+}
+
+// GetCompressFactor is part of StatMetric interface
+func (mn *StatMin) GetCompressFactor(events map[string]int) map[string]int {
+	return getCompressFactorAggregates(mn.Events, events)
+}
+
+func NewStatMax(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	return &StatMax{Events: make(map[string]*StatAggregate),
+		MinItems: minItems, FieldName: extraParams, FilterIDs: filterIDs}, nil
+}
+
+// NewStatHighestCost instantiates the *highest_cost metric, a *max on the Cost field
+func NewStatHighestCost(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	return NewStatMax(minItems, utils.DynamicDataPrefix+utils.MetaReq+utils.NestingSep+utils.Cost, filterIDs)
+}
+
+// StatMax implements the maximum value metric
+type StatMax struct {
+	FilterIDs []string
+	Count     int64
+	Events    map[string]*StatAggregate // map[EventTenantID]Aggregates
+	MinItems  int
+	FieldName string
+	val       *float64 // cached max value
+}
+
+// getValue returns mx.val
+func (mx *StatMax) getValue(roundingDecimal int) float64 {
+	if mx.val == nil {
+		if mx.Count == 0 || mx.Count < int64(mx.MinItems) {
+			mx.val = utils.Float64Pointer(utils.StatsNA)
+		} else {
+			var val *float64
+			for _, sa := range mx.Events {
+				if val == nil || sa.Max > *val {
+					val = utils.Float64Pointer(sa.Max)
+				}
+			}
+			mx.val = utils.Float64Pointer(utils.Round(*val,
+				roundingDecimal, utils.MetaRoundingMiddle))
+		}
+	}
+	return *mx.val
+}
+
+func (mx *StatMax) GetStringValue(roundingDecimal int) (valStr string) {
+	if val := mx.getValue(roundingDecimal); val == utils.StatsNA {
+		valStr = utils.NotAvailable
+	} else {
+		valStr = strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return
+}
+
+func (mx *StatMax) GetValue(roundingDecimal int) (v interface{}) {
+	return mx.getValue(roundingDecimal)
+}
+
+func (mx *StatMax) GetFloat64Value(roundingDecimal int) (v float64) {
+	return mx.getValue(roundingDecimal)
+}
+
+func (mx *StatMax) AddEvent(evID string, ev utils.DataProvider) (err error) {
+	if err = addStatAggregate(mx.Events, evID, mx.FieldName, ev); err != nil {
+		return
+	}
+	mx.Count++
+	mx.val = nil
+	return
+}
+
+func (mx *StatMax) RemEvent(evID string) (err error) {
+	if err = remStatAggregate(mx.Events, evID); err != nil {
+		return
+	}
+	mx.Count--
+	mx.val = nil
+	return
+}
+
+func (mx *StatMax) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(mx)
+}
+
+func (mx *StatMax) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, mx)
+}
+
+// GetFilterIDs is part of StatMetric interface
+func (mx *StatMax) GetFilterIDs() []string {
+	return mx.FilterIDs
+}
+
+// GetMinItems returns the minim items for the metric
+func (mx *StatMax) GetMinItems() (minIts int) { return mx.MinItems }
+
+// Compress is part of StatMetric interface
+func (mx *StatMax) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
+	mx.Events, eventIDs = compressStatAggregates(mx.Events, mx.Count, queueLen, defaultID)
+	return
+}
+
+// GetCompressFactor is part of StatMetric interface
+func (mx *StatMax) GetCompressFactor(events map[string]int) map[string]int {
+	return getCompressFactorAggregates(mx.Events, events)
+}
@@ -18,6 +18,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"fmt"
 	"net"
 	"reflect"
 	"sort"
@@ -4164,3 +4165,253 @@ func TestStatMetricsStatASRAddEventErr3(t *testing.T) {
 		t.Errorf("\nExpecting <%+v>,\n Recevied <%+v>", utils.ErrAccountNotFound, err)
 	}
 }
+
+func TestStatPercentileGetFloat64Value(t *testing.T) {
+	if _, err := NewStatMetric(utils.MetaPercentile+"#95", 0, []string{}); err == nil ||
+		err.Error() != "invalid format for percentile params <95>" {
+		t.Error(err)
+	}
+	if _, err := NewStatMetric(utils.MetaPercentile+"#101:~*req.Usage", 0, []string{}); err == nil ||
+		err.Error() != "percentile <101> out of range" {
+		t.Error(err)
+	}
+	prc, err := NewStatMetric(utils.MetaPercentile+"#90:~*req.Cost", 2, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := prc.AddEvent("EVENT_1", utils.MapStorage{utils.MetaReq: map[string]interface{}{"Cost": "1"}}); err != nil {
+		t.Error(err)
+	}
+	if v := prc.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != utils.StatsNA {
+		t.Errorf("wrong percentile value: %v", v)
+	}
+	for i := 2; i <= 10; i++ {
+		if err := prc.AddEvent(fmt.Sprintf("EVENT_%d", i),
+			utils.MapStorage{utils.MetaReq: map[string]interface{}{"Cost": i}}); err != nil {
+			t.Error(err)
+		}
+	}
+	if v := prc.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != 9 {
+		t.Errorf("wrong percentile value: %v", v)
+	}
+	if err := prc.RemEvent("EVENT_1"); err != nil {
+		t.Error(err)
+	}
+	if v := prc.GetStringValue(config.CgrConfig().GeneralCfg().RoundingDecimals); v != "10" {
+		t.Errorf("wrong percentile value: %v", v)
+	}
+	if err := prc.RemEvent("EVENT_1"); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+}
+
+func TestStatStdDevGetFloat64Value(t *testing.T) {
+	std, err := NewStatMetric(utils.MetaStdDev+"#~*req.Cost", 2, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, cost := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		if err := std.AddEvent(fmt.Sprintf("EVENT_%d", i),
+			utils.MapStorage{utils.MetaReq: map[string]interface{}{"Cost": cost}}); err != nil {
+			t.Error(err)
+		}
+	}
+	if v := std.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != 2 {
+		t.Errorf("wrong stddev value: %v", v)
+	}
+	if err := std.AddEvent("EVENT_9", utils.MapStorage{utils.MetaReq: map[string]interface{}{}}); err == nil ||
+		err.Error() != "NOT_FOUND:~*req.Cost" {
+		t.Error(err)
+	}
+}
+
+func TestStatStdDevRepeatedEventIDs(t *testing.T) {
+	std, err := NewStatMetric(utils.MetaStdDev+"#~*req.Cost", 2, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the same values as in TestStatStdDevGetFloat64Value, two events per ID
+	for i, cost := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		if err := std.AddEvent(fmt.Sprintf("EVENT_%d", i%4),
+			utils.MapStorage{utils.MetaReq: map[string]interface{}{"Cost": cost}}); err != nil {
+			t.Error(err)
+		}
+	}
+	if v := std.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != 2 {
+		t.Errorf("wrong stddev value: %v", v)
+	}
+	// EVENT_0 received 2 and 5, its aggregates are reduced by their mean when one is removed
+	if err := std.RemEvent("EVENT_0"); err != nil {
+		t.Error(err)
+	}
+	if v := std.GetFloat64Value(5); v != 1.97044 {
+		t.Errorf("wrong stddev value: %v", v)
+	}
+	if rcv := std.GetCompressFactor(make(map[string]int)); !reflect.DeepEqual(rcv,
+		map[string]int{"EVENT_0": 1, "EVENT_1": 2, "EVENT_2": 2, "EVENT_3": 2}) {
+		t.Errorf("Unexpected compress factor: %+v", rcv)
+	}
+}
+
+func TestStatMinMaxGetFloat64Value(t *testing.T) {
+	min, err := NewStatMetric(utils.MetaMin+"#~*req.Usage", 0, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	max, err := NewStatMetric(utils.MetaMax+"#~*req.Usage", 0, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hCost, err := NewStatMetric(utils.MetaHighestCost, 0, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []StatMetric{min, max, hCost} {
+		if v := m.GetStringValue(config.CgrConfig().GeneralCfg().RoundingDecimals); v != utils.NotAvailable {
+			t.Errorf("wrong value: %v", v)
+		}
+	}
+	evs := map[string]map[string]interface{}{
+		"EVENT_1": {utils.Usage: 10 * time.Second, utils.Cost: 0.5},
+		"EVENT_2": {utils.Usage: time.Minute, utils.Cost: 1.2},
+		"EVENT_3": {utils.Usage: 30 * time.Second, utils.Cost: 2.1},
+	}
+	for evID, ev := range evs {
+		for _, m := range []StatMetric{min, max, hCost} {
+			if err := m.AddEvent(evID, utils.MapStorage{utils.MetaReq: ev}); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+	if v := min.GetFloat64Value(-1); v != float64(10*time.Second) {
+		t.Errorf("wrong min value: %v", v)
+	}
+	if v := max.GetFloat64Value(-1); v != float64(time.Minute) {
+		t.Errorf("wrong max value: %v", v)
+	}
+	if v := hCost.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != 2.1 {
+		t.Errorf("wrong highest cost value: %v", v)
+	}
+	for _, m := range []StatMetric{min, max, hCost} {
+		if err := m.RemEvent("EVENT_2"); err != nil {
+			t.Error(err)
+		}
+	}
+	if v := max.GetFloat64Value(-1); v != float64(30*time.Second) {
+		t.Errorf("wrong max value: %v", v)
+	}
+	if v := hCost.GetFloat64Value(config.CgrConfig().GeneralCfg().RoundingDecimals); v != 2.1 {
+		t.Errorf("wrong highest cost value: %v", v)
+	}
+	if eIDs := max.Compress(10, "EVENT_3", 2); len(eIDs) != 2 {
+		t.Errorf("Expected events not to be compressed, received: %+v", eIDs)
+	}
+	if rcv := max.GetCompressFactor(make(map[string]int)); !reflect.DeepEqual(rcv,
+		map[string]int{"EVENT_1": 1, "EVENT_3": 1}) {
+		t.Errorf("Unexpected compress factor: %+v", rcv)
+	}
+	// the limits of the same event are not averaged
+	for _, usage := range []time.Duration{time.Hour, time.Second} {
+		for _, m := range []StatMetric{min, max} {
+			if err := m.AddEvent("EVENT_3", utils.MapStorage{utils.MetaReq: map[string]interface{}{utils.Usage: usage}}); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+	if v := min.GetFloat64Value(-1); v != float64(time.Second) {
+		t.Errorf("wrong min value: %v", v)
+	}
+	if v := max.GetFloat64Value(-1); v != float64(time.Hour) {
+		t.Errorf("wrong max value: %v", v)
+	}
+	if rcv := max.GetCompressFactor(make(map[string]int)); !reflect.DeepEqual(rcv,
+		map[string]int{"EVENT_1": 1, "EVENT_3": 3}) {
+		t.Errorf("Unexpected compress factor: %+v", rcv)
+	}
+	// the limits of the event are kept when one of its values is removed
+	if err := max.RemEvent("EVENT_3"); err != nil {
+		t.Error(err)
+	}
+	if v := max.GetFloat64Value(-1); v != float64(time.Hour) {
+		t.Errorf("wrong max value: %v", v)
+	}
+	// compressing merges the aggregates of the events
+	for _, m := range []StatMetric{min, max} {
+		if eIDs := m.Compress(1, "EVENT_3", 2); !reflect.DeepEqual(eIDs, []string{"EVENT_3"}) {
+			t.Errorf("Expected events to be compressed, received: %+v", eIDs)
+		}
+	}
+	if v := min.GetFloat64Value(-1); v != float64(time.Second) {
+		t.Errorf("wrong min value: %v", v)
+	}
+	if v := max.GetFloat64Value(-1); v != float64(time.Hour) {
+		t.Errorf("wrong max value: %v", v)
+	}
+	if rcv := max.GetCompressFactor(make(map[string]int)); !reflect.DeepEqual(rcv,
+		map[string]int{"EVENT_3": 3}) {
+		t.Errorf("Unexpected compress factor: %+v", rcv)
+	}
+}
+
+func TestStatPercentileCompress(t *testing.T) {
+	prc, err := NewStatMetric(utils.MetaPercentile+"#50:~*req.Cost", 0, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 100 and 100.5 share the same bin
+	for i, cost := range []float64{1, 100, 100.5, 1000} {
+		if err := prc.AddEvent(fmt.Sprintf("EVENT_%d", i),
+			utils.MapStorage{utils.MetaReq: map[string]interface{}{"Cost": cost}}); err != nil {
+			t.Error(err)
+		}
+	}
+	if len(prc.(*StatPercentile).Bins) != 3 {
+		t.Errorf("unexpected bins: %s", utils.ToJSON(prc.(*StatPercentile).Bins))
+	}
+	if v := prc.GetFloat64Value(-1); v != 100 {
+		t.Errorf("wrong percentile value: %v", v)
+	}
+	if eIDs := prc.Compress(10, "EVENT_3", 2); len(eIDs) != 4 {
+		t.Errorf("Expected events not to be compressed, received: %+v", eIDs)
+	}
+	if eIDs := prc.Compress(4, "EVENT_3", 2); !reflect.DeepEqual(eIDs, []string{"EVENT_3"}) {
+		t.Errorf("Expected events to be compressed, received: %+v", eIDs)
+	}
+	if rcv := prc.GetCompressFactor(make(map[string]int)); !reflect.DeepEqual(rcv,
+		map[string]int{"EVENT_3": 4}) {
+		t.Errorf("Unexpected compress factor: %+v", rcv)
+	}
+	if v := prc.GetFloat64Value(-1); v != 100 {
+		t.Errorf("wrong percentile value: %v", v)
+	}
+	// the most populated bin is removed first out of the compressed events
+	if err := prc.RemEvent("EVENT_3"); err != nil {
+		t.Error(err)
+	}
+	if len(prc.(*StatPercentile).Bins) != 3 {
+		t.Errorf("unexpected bins: %s", utils.ToJSON(prc.(*StatPercentile).Bins))
+	}
+	if rcv := prc.GetCompressFactor(make(map[string]int)); !reflect.DeepEqual(rcv,
+		map[string]int{"EVENT_3": 3}) {
+		t.Errorf("Unexpected compress factor: %+v", rcv)
+	}
+	if v := prc.GetFloat64Value(-1); v != 100 {
+		t.Errorf("wrong percentile value: %v", v)
+	}
+}
+
+func TestStatPercentileMarshal(t *testing.T) {
+	prc, _ := NewStatMetric(utils.MetaPercentile+"#95:~*req.Cost", 2, []string{})
+	prc.AddEvent("EVENT_1", utils.MapStorage{utils.MetaReq: map[string]interface{}{"Cost": "20"}})
+	nPrc, _ := NewStatMetric(utils.MetaPercentile+"#95:~*req.Cost", 0, []string{})
+	expected := []byte(`{"FilterIDs":[],"Count":1,"Bins":{"65686":{"Count":1,"Min":20,"Max":20}},"Events":{"EVENT_1":{"65686":1}},"MinItems":2,"Percentile":95,"FieldName":"~*req.Cost"}`)
+	if b, err := prc.Marshal(&jMarshaler); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, b) {
+		t.Errorf("Expected: %s , received: %s", string(expected), string(b))
+	} else if err := nPrc.LoadMarshaled(&jMarshaler, b); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(prc, nPrc) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(prc), utils.ToJSON(nPrc))
+	}
+}
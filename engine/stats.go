@@ -251,12 +251,35 @@ func (sS *StatService) getStatQueue(tnt, id string) (sq *StatQueue, err error) {
 		return
 	}
 	lkID := utils.StatQueuePrefix + sq.TenantID()
-	var removed int
+	var changed bool
 	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		sq.Lock()
+		defer sq.Unlock()
+		if sq.sqPrfl == nil { // the queues processing events already have their profile attached
+			var sqPrfl *StatQueueProfile
+			if sqPrfl, err = sS.dm.GetStatQueueProfile(tnt, id, true, true, utils.NonTransactional); err != nil {
+				if err != utils.ErrNotFound {
+					return
+				}
+				err = nil
+			}
+			if sqPrfl != nil {
+				if sqPrfl.Stored && sq.dirty == nil {
+					sq.dirty = utils.BoolPointer(false)
+				}
+				sq.sqPrfl = sqPrfl
+			}
+		}
+		if sq.bucketed() { // close the expired bucket so the reads do not return stale values
+			changed, err = sq.rotateBuckets(time.Now(), sS.cgrcfg.GeneralCfg().RoundingDecimals)
+			return
+		}
+		var removed int
 		removed, err = sq.remExpired()
+		changed = removed != 0
 		return
 	}, sS.cgrcfg.GeneralCfg().LockingTimeout, lkID)
-	if err != nil || removed == 0 {
+	if err != nil || !changed {
 		return
 	}
 	sS.storeStatQueue(sq)
@@ -277,6 +300,24 @@ func (sS *StatService) storeStatQueue(sq *StatQueue) {
 	}
 }
 
+// processRouteOutcome reports the outcome of the call to the circuit breaker of the route
+// only the events having the RouteID field are sent to RouteS
+func (sS *StatService) processRouteOutcome(cgrEv *utils.CGREvent) (err error) {
+	if len(sS.cgrcfg.StatSCfg().RouteSConns) == 0 {
+		return
+	}
+	if _, has := cgrEv.Event[utils.RouteID]; !has {
+		return
+	}
+	var reply string
+	if err = sS.connMgr.Call(sS.cgrcfg.StatSCfg().RouteSConns, nil,
+		utils.RouteSv1ProcessOutcome, cgrEv, &reply); err != nil &&
+		err.Error() == utils.ErrNotImplemented.Error() {
+		err = nil // the circuit breaker is not configured on RouteS
+	}
+	return
+}
+
 // processEvent processes a new event, dispatching to matching queues
 // queues matching are also cached to speed up
 func (sS *StatService) processEvent(tnt string, args *StatsArgsProcessEvent) (statQueueIDs []string, err error) {
@@ -297,6 +338,12 @@ func (sS *StatService) processEvent(tnt string, args *StatsArgsProcessEvent) (st
 	args.Opts[utils.MetaEventType] = utils.StatUpdate
 	var stsIDs []string
 	var withErrors bool
+	if err = sS.processRouteOutcome(args.CGREvent); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<StatS> error: %s processing event %+v with RouteS.", err.Error(), args.CGREvent))
+		withErrors = true
+		err = nil
+	}
 	for _, sq := range matchSQs {
 		stsIDs = append(stsIDs, sq.ID)
 		lkID := utils.StatQueuePrefix + sq.TenantID()
@@ -475,6 +522,28 @@ func (sS *StatService) V1GetQueueFloatMetrics(args *utils.TenantID, reply *map[s
 	return
 }
 
+// V1GetQueueMetricsHistory returns the time series of each metric out of the time buckets
+func (sS *StatService) V1GetQueueMetricsHistory(args *utils.TenantID, reply *map[string][]*StatMetricPoint) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = sS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	sq, err := sS.getStatQueue(tnt, args.ID)
+	if err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return err
+	}
+	sq.RLock()
+	*reply = sq.MetricsHistory(sS.cgrcfg.GeneralCfg().RoundingDecimals)
+	sq.RUnlock()
+	return
+}
+
 // V1GetQueueIDs returns list of queueIDs registered for a tenant
 func (sS *StatService) V1GetQueueIDs(tenant string, qIDs *[]string) (err error) {
 	if tenant == utils.EmptyString {
@@ -516,6 +585,8 @@ func (sS *StatService) V1ResetStatQueue(tntID *utils.TenantID, rply *string) (er
 	sq.Lock()
 	defer sq.Unlock()
 	sq.SQItems = make([]SQItem, 0)
+	sq.SQBuckets = nil
+	sq.BucketStart = nil
 	metrics := sq.SQMetrics
 	sq.SQMetrics = make(map[string]StatMetric)
 	for id, m := range metrics {
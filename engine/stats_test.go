@@ -1021,3 +1021,122 @@ func TestStatQueuesV1ProcessEvent(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", expected, reply)
 	}
 }
+
+func TestStatQueuesV1GetQueueMetricsHistory(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	data := NewInternalDB(nil, nil, true)
+	dm := NewDataManager(data, cfg.CacheCfg(), nil)
+	fltrS := NewFilterS(cfg, nil, dm)
+	sS := NewStatService(dm, cfg, fltrS, nil)
+	sqPrf := &StatQueueProfile{
+		Tenant: "cgrates.org",
+		ID:     "SQ_BUCKETS",
+		Metrics: []*MetricWithFilters{
+			{
+				MetricID: utils.MetaTCC,
+			},
+		},
+		ThresholdIDs:   []string{utils.MetaNone},
+		BucketInterval: time.Hour,
+		BucketsLimit:   3,
+	}
+	if err := dm.SetStatQueueProfile(sqPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	sq, err := NewStatQueue(sqPrf.Tenant, sqPrf.ID, sqPrf.Metrics, sqPrf.MinItems)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetStatQueue(sq, sqPrf.Metrics, sqPrf.MinItems, nil, 0, true); err != nil {
+		t.Fatal(err)
+	}
+	var reply []string
+	for _, evID := range []string{"EV1", "EV2"} {
+		if err := sS.V1ProcessEvent(&StatsArgsProcessEvent{
+			CGREvent: &utils.CGREvent{
+				Tenant: "cgrates.org",
+				ID:     evID,
+				Event: map[string]interface{}{
+					utils.Cost: 1.5,
+				},
+			},
+		}, &reply); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if sq, err = dm.GetStatQueue("cgrates.org", "SQ_BUCKETS", true, false, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if len(sq.SQItems) != 0 {
+		t.Errorf("not expecting items in bucketed StatQueue: %+v", sq.SQItems)
+	}
+	if tcc := sq.SQMetrics[utils.MetaTCC].(*StatTCC); len(tcc.Events) != 1 || tcc.Count != 2 {
+		t.Errorf("expecting compressed metric, received: %s", utils.ToJSON(tcc))
+	}
+	var hist map[string][]*StatMetricPoint
+	if err := sS.V1GetQueueMetricsHistory(&utils.TenantID{ID: "SQ_BUCKETS"}, &hist); err != nil {
+		t.Fatal(err)
+	}
+	if len(hist[utils.MetaTCC]) != 1 || hist[utils.MetaTCC][0].Value != 3 ||
+		!hist[utils.MetaTCC][0].Time.Equal(*sq.BucketStart) {
+		t.Errorf("unexpected history: %s", utils.ToJSON(hist))
+	}
+	// simulate the end of the current bucket
+	sq.BucketStart = utils.TimePointer(sq.BucketStart.Add(-time.Hour))
+	if err := sS.V1GetQueueMetricsHistory(&utils.TenantID{ID: "SQ_BUCKETS"}, &hist); err != nil {
+		t.Fatal(err)
+	}
+	if len(hist[utils.MetaTCC]) != 2 || hist[utils.MetaTCC][0].Value != 3 ||
+		hist[utils.MetaTCC][1].Value != utils.StatsNA {
+		t.Errorf("unexpected history: %s", utils.ToJSON(hist))
+	}
+	if err := sS.V1GetQueueMetricsHistory(&utils.TenantID{}, &hist); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.ID).Error() {
+		t.Errorf("Expected %+v, received %+v", utils.NewErrMandatoryIeMissing(utils.ID), err)
+	}
+	// the metrics reads close the expired bucket as well
+	if err := sS.V1ProcessEvent(&StatsArgsProcessEvent{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "EV3",
+			Event: map[string]interface{}{
+				utils.Cost: 2,
+			},
+		},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	sq.BucketStart = utils.TimePointer(sq.BucketStart.Add(-time.Hour))
+	var metrics map[string]float64
+	if err := sS.V1GetQueueFloatMetrics(&utils.TenantID{ID: "SQ_BUCKETS"}, &metrics); err != nil {
+		t.Fatal(err)
+	} else if metrics[utils.MetaTCC] != utils.StatsNA {
+		t.Errorf("expecting the metrics of the new bucket, received: %+v", metrics)
+	}
+	if len(sq.SQBuckets) != 2 || sq.SQBuckets[1].Metrics[utils.MetaTCC] != 2 {
+		t.Errorf("unexpected buckets: %s", utils.ToJSON(sq.SQBuckets))
+	}
+	var rply string
+	if err := sS.V1ResetStatQueue(&utils.TenantID{Tenant: "cgrates.org", ID: "SQ_BUCKETS"}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	if err := sS.V1GetQueueMetricsHistory(&utils.TenantID{ID: "SQ_BUCKETS"}, &hist); err != nil {
+		t.Fatal(err)
+	}
+	if len(sq.SQBuckets) != 0 || len(hist[utils.MetaTCC]) != 1 ||
+		hist[utils.MetaTCC][0].Value != utils.StatsNA {
+		t.Errorf("unexpected history after reset: %s", utils.ToJSON(hist))
+	}
+	// the reads rotate with the profile attached to the queue, without querying it again
+	if err := dm.DataDB().RemStatQueueProfileDrv("cgrates.org", "SQ_BUCKETS"); err != nil {
+		t.Fatal(err)
+	}
+	Cache.Remove(utils.CacheStatQueueProfiles, "cgrates.org:SQ_BUCKETS", true, utils.NonTransactional)
+	sq.BucketStart = utils.TimePointer(sq.BucketStart.Add(-time.Hour))
+	if err := sS.V1GetQueueMetricsHistory(&utils.TenantID{ID: "SQ_BUCKETS"}, &hist); err != nil {
+		t.Fatal(err)
+	}
+	if !sq.BucketStart.Equal(time.Now().Truncate(time.Hour)) {
+		t.Errorf("expecting the bucket rotated, received: %s", utils.ToJSON(sq))
+	}
+}
@@ -114,6 +114,18 @@ type DataDB interface {
 	GetRouteProfileDrv(string, string) (*RouteProfile, error)
 	SetRouteProfileDrv(*RouteProfile) error
 	RemoveRouteProfileDrv(string, string) error
+	GetRouteBreakerDrv(string, string) (*RouteBreaker, error)
+	SetRouteBreakerDrv(*RouteBreaker) error
+	RemoveRouteBreakerDrv(string, string) error
+	GetInvoiceDrv(string, string) (*Invoice, error)
+	SetInvoiceDrv(*Invoice) error
+	RemoveInvoiceDrv(string, string) error
+	GetAccountChargesDrv(string, string) (*utils.AccountCharges, error)
+	SetAccountChargesDrv(*utils.AccountCharges) error
+	RemoveAccountChargesDrv(string, string) error
+	GetSessionBackupsDrv(string) ([]*SessionBackup, error)
+	SetSessionBackupDrv(*SessionBackup) error
+	RemoveSessionBackupDrv(string, string) error
 	GetAttributeProfileDrv(string, string) (*AttributeProfile, error)
 	SetAttributeProfileDrv(*AttributeProfile) error
 	RemoveAttributeProfileDrv(string, string) error
@@ -39,6 +39,7 @@ type InternalDB struct {
 	indexedFieldsMutex  sync.RWMutex   // used for reload
 	cnter               *utils.Counter // used for OrderID for cdr
 	ms                  Marshaler
+	persister           *internalDBPersister // nil if kept only in memory
 }
 
 // NewInternalDB constructs an InternalDB
@@ -53,6 +54,43 @@ func NewInternalDB(stringIndexedFields, prefixIndexedFields []string, isDataDB b
 	return
 }
 
+// NewPersistentInternalDB constructs an InternalDB persisted on disk, in the dumpPath folder
+func NewPersistentInternalDB(stringIndexedFields, prefixIndexedFields []string, isDataDB bool,
+	dumpPath string, opts map[string]interface{}) (iDB *InternalDB, err error) {
+	iDB = NewInternalDB(stringIndexedFields, prefixIndexedFields, isDataDB)
+	if iDB.persister, err = newInternalDBPersister(dumpPath, isDataDB, opts); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// setItem stores the item in Cache, persisting it if needed
+func (iDB *InternalDB) setItem(chID, itmID string, val interface{}, groupIDs []string, transID string) {
+	if iDB.persister != nil {
+		iDB.persister.set(chID, itmID, val, groupIDs, transID)
+		return
+	}
+	Cache.SetWithoutReplicate(chID, itmID, val, groupIDs, cacheCommit(transID), transID)
+}
+
+// removeItem removes the item from Cache, persisting the change if needed
+func (iDB *InternalDB) removeItem(chID, itmID, transID string) {
+	if iDB.persister != nil {
+		iDB.persister.remove(chID, itmID, transID)
+		return
+	}
+	Cache.RemoveWithoutReplicate(chID, itmID, cacheCommit(transID), transID)
+}
+
+// removeGroup removes the items of the group from Cache, persisting the change if needed
+func (iDB *InternalDB) removeGroup(chID, grpID string) {
+	if iDB.persister != nil {
+		iDB.persister.removeGroup(chID, grpID)
+		return
+	}
+	Cache.tCache.RemoveGroup(chID, grpID, true, utils.EmptyString)
+}
+
 // SetStringIndexedFields set the stringIndexedFields, used at StorDB reload (is thread safe)
 func (iDB *InternalDB) SetStringIndexedFields(stringIndexedFields []string) {
 	iDB.indexedFieldsMutex.Lock()
@@ -67,12 +105,23 @@ func (iDB *InternalDB) SetPrefixIndexedFields(prefixIndexedFields []string) {
 	iDB.indexedFieldsMutex.Unlock()
 }
 
-// Close only to implement Storage interface
-func (iDB *InternalDB) Close() {}
+// Close writes the final snapshot if persisted, otherwise only to implement Storage interface
+func (iDB *InternalDB) Close() {
+	if iDB.persister == nil {
+		return
+	}
+	if err := iDB.persister.close(); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed closing the internal database: %s",
+			iDB.persister.tag, err.Error()))
+	}
+}
 
-// Flush clears the cache
+// Flush clears the cache together with the persisted data
 func (iDB *InternalDB) Flush(string) error {
 	Cache.Clear(nil)
+	if iDB.persister != nil {
+		return iDB.persister.flush()
+	}
 	return nil
 }
 
@@ -103,8 +152,7 @@ func (iDB *InternalDB) RemoveKeysForPrefix(prefix string) (err error) {
 		return
 	}
 	for _, key := range keys {
-		Cache.RemoveWithoutReplicate(utils.CacheReverseDestinations, key,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.removeItem(utils.CacheReverseDestinations, key, utils.NonTransactional)
 	}
 	return
 }
@@ -132,16 +180,14 @@ func (iDB *InternalDB) SetVersions(vrs Versions, overwrite bool) (err error) {
 	}
 	x, ok := Cache.Get(utils.CacheVersions, utils.VersionName)
 	if !ok || x == nil {
-		Cache.SetWithoutReplicate(utils.CacheVersions, utils.VersionName, vrs, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheVersions, utils.VersionName, vrs, nil, utils.NonTransactional)
 		return
 	}
 	provVrs := x.(Versions)
 	for key, val := range vrs {
 		provVrs[key] = val
 	}
-	Cache.SetWithoutReplicate(utils.CacheVersions, utils.VersionName, provVrs, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheVersions, utils.VersionName, provVrs, nil, utils.NonTransactional)
 	return
 }
 
@@ -156,12 +202,10 @@ func (iDB *InternalDB) RemoveVersions(vrs Versions) (err error) {
 		for key := range vrs {
 			delete(internalVersions, key)
 		}
-		Cache.SetWithoutReplicate(utils.CacheVersions, utils.VersionName, internalVersions, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheVersions, utils.VersionName, internalVersions, nil, utils.NonTransactional)
 		return
 	}
-	Cache.RemoveWithoutReplicate(utils.CacheVersions, utils.VersionName,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheVersions, utils.VersionName, utils.NonTransactional)
 	return
 }
 
@@ -204,14 +248,12 @@ func (iDB *InternalDB) GetRatingPlanDrv(id string) (rp *RatingPlan, err error) {
 }
 
 func (iDB *InternalDB) SetRatingPlanDrv(rp *RatingPlan) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheRatingPlans, rp.Id, rp, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheRatingPlans, rp.Id, rp, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveRatingPlanDrv(id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheRatingPlans, id,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheRatingPlans, id, utils.NonTransactional)
 	return
 }
 
@@ -224,14 +266,12 @@ func (iDB *InternalDB) GetRatingProfileDrv(id string) (rp *RatingProfile, err er
 }
 
 func (iDB *InternalDB) SetRatingProfileDrv(rp *RatingProfile) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheRatingProfiles, rp.Id, rp, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheRatingProfiles, rp.Id, rp, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveRatingProfileDrv(id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheRatingProfiles, id,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheRatingProfiles, id, utils.NonTransactional)
 	return
 }
 
@@ -243,14 +283,12 @@ func (iDB *InternalDB) GetDestinationDrv(key, _ string) (dest *Destination, err
 }
 
 func (iDB *InternalDB) SetDestinationDrv(dest *Destination, transactionID string) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheDestinations, dest.Id, dest, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheDestinations, dest.Id, dest, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveDestinationDrv(destID string, transactionID string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheDestinations, destID,
-		cacheCommit(transactionID), transactionID)
+	iDB.removeItem(utils.CacheDestinations, destID, transactionID)
 	return
 }
 
@@ -266,11 +304,9 @@ func (iDB *InternalDB) RemoveReverseDestinationDrv(dstID, prfx, transactionID st
 	mpRevDst := utils.NewStringSet(revDst)
 	mpRevDst.Remove(dstID)
 	if mpRevDst.Size() != 0 {
-		Cache.SetWithoutReplicate(utils.CacheReverseDestinations, prfx, mpRevDst.AsSlice(), nil,
-			cacheCommit(transactionID), transactionID)
+		iDB.setItem(utils.CacheReverseDestinations, prfx, mpRevDst.AsSlice(), nil, transactionID)
 	} else {
-		Cache.RemoveWithoutReplicate(utils.CacheReverseDestinations, prfx,
-			cacheCommit(transactionID), transactionID)
+		iDB.removeItem(utils.CacheReverseDestinations, prfx, transactionID)
 	}
 	return
 }
@@ -286,8 +322,7 @@ func (iDB *InternalDB) SetReverseDestinationDrv(destID string, prefixes []string
 		mpRevDst := utils.NewStringSet(revDst)
 		mpRevDst.Add(destID)
 		// for ReverseDestination we will use Groups
-		Cache.SetWithoutReplicate(utils.CacheReverseDestinations, p, mpRevDst.AsSlice(), nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheReverseDestinations, p, mpRevDst.AsSlice(), nil, utils.NonTransactional)
 	}
 	return
 }
@@ -309,14 +344,12 @@ func (iDB *InternalDB) GetActionsDrv(id string) (acts Actions, err error) {
 }
 
 func (iDB *InternalDB) SetActionsDrv(id string, acts Actions) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheActions, id, acts, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheActions, id, acts, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveActionsDrv(id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheActions, id,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheActions, id, utils.NonTransactional)
 	return
 }
 
@@ -328,14 +361,12 @@ func (iDB *InternalDB) GetSharedGroupDrv(id string) (sh *SharedGroup, err error)
 }
 
 func (iDB *InternalDB) SetSharedGroupDrv(sh *SharedGroup) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheSharedGroups, sh.Id, sh, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheSharedGroups, sh.Id, sh, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveSharedGroupDrv(id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheSharedGroups, id,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheSharedGroups, id, utils.NonTransactional)
 	return
 }
 
@@ -347,14 +378,12 @@ func (iDB *InternalDB) GetActionTriggersDrv(id string) (at ActionTriggers, err e
 }
 
 func (iDB *InternalDB) SetActionTriggersDrv(id string, at ActionTriggers) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheActionTriggers, id, at, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheActionTriggers, id, at, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveActionTriggersDrv(id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheActionTriggers, id,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheActionTriggers, id, utils.NonTransactional)
 	return
 }
 
@@ -368,10 +397,8 @@ func (iDB *InternalDB) GetActionPlanDrv(key string, skipCache bool,
 
 func (iDB *InternalDB) SetActionPlanDrv(key string, ats *ActionPlan,
 	overwrite bool, transactionID string) (err error) {
-	cCommit := cacheCommit(transactionID)
 	if len(ats.ActionTimings) == 0 {
-		Cache.RemoveWithoutReplicate(utils.CacheActionPlans, key,
-			cCommit, transactionID)
+		iDB.removeItem(utils.CacheActionPlans, key, transactionID)
 		return
 	}
 	if !overwrite {
@@ -386,13 +413,12 @@ func (iDB *InternalDB) SetActionPlanDrv(key string, ats *ActionPlan,
 			}
 		}
 	}
-	Cache.SetWithoutReplicate(utils.CacheActionPlans, key, ats, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheActionPlans, key, ats, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveActionPlanDrv(key string, transactionID string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheActionPlans, key, cacheCommit(transactionID), transactionID)
+	iDB.removeItem(utils.CacheActionPlans, key, transactionID)
 	return
 }
 
@@ -436,15 +462,13 @@ func (iDB *InternalDB) SetAccountActionPlansDrv(acntID string, apIDs []string, o
 			}
 		}
 	}
-	Cache.SetWithoutReplicate(utils.CacheAccountActionPlans, acntID, apIDs, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheAccountActionPlans, acntID, apIDs, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemAccountActionPlansDrv(acntID string, apIDs []string) (err error) {
 	if len(apIDs) == 0 {
-		Cache.RemoveWithoutReplicate(utils.CacheAccountActionPlans, acntID,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.removeItem(utils.CacheAccountActionPlans, acntID, utils.NonTransactional)
 		return
 	}
 	var oldaPlIDs []string
@@ -460,12 +484,10 @@ func (iDB *InternalDB) RemAccountActionPlansDrv(acntID string, apIDs []string) (
 		i++
 	}
 	if len(oldaPlIDs) == 0 {
-		Cache.RemoveWithoutReplicate(utils.CacheAccountActionPlans, acntID,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.removeItem(utils.CacheAccountActionPlans, acntID, utils.NonTransactional)
 		return
 	}
-	Cache.SetWithoutReplicate(utils.CacheAccountActionPlans, acntID, oldaPlIDs, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheAccountActionPlans, acntID, oldaPlIDs, nil, utils.NonTransactional)
 	return
 }
 
@@ -510,14 +532,12 @@ func (iDB *InternalDB) SetAccountDrv(acc *Account) (err error) {
 		}
 	}
 	acc.UpdateTime = time.Now()
-	Cache.SetWithoutReplicate(utils.CacheAccounts, acc.ID, acc, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheAccounts, acc.ID, acc, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveAccountDrv(id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheAccounts, id,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheAccounts, id, utils.NonTransactional)
 	return
 }
 
@@ -529,14 +549,12 @@ func (iDB *InternalDB) GetResourceProfileDrv(tenant, id string) (rp *ResourcePro
 }
 
 func (iDB *InternalDB) SetResourceProfileDrv(rp *ResourceProfile) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheResourceProfiles, rp.TenantID(), rp, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheResourceProfiles, rp.TenantID(), rp, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveResourceProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheResourceProfiles, utils.ConcatenatedKey(tenant, id),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheResourceProfiles, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
 	return
 }
 
@@ -548,14 +566,12 @@ func (iDB *InternalDB) GetResourceDrv(tenant, id string) (r *Resource, err error
 }
 
 func (iDB *InternalDB) SetResourceDrv(r *Resource) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheResources, r.TenantID(), r, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheResources, r.TenantID(), r, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveResourceDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheResources, utils.ConcatenatedKey(tenant, id),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheResources, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
 	return
 }
 
@@ -568,14 +584,12 @@ func (iDB *InternalDB) GetTimingDrv(id string) (tmg *utils.TPTiming, err error)
 }
 
 func (iDB *InternalDB) SetTimingDrv(timing *utils.TPTiming) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheTimings, timing.ID, timing, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheTimings, timing.ID, timing, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveTimingDrv(id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheTimings, id,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheTimings, id, utils.NonTransactional)
 	return
 }
 
@@ -596,14 +610,12 @@ func (iDB *InternalDB) GetStatQueueProfileDrv(tenant string, id string) (sq *Sta
 
 }
 func (iDB *InternalDB) SetStatQueueProfileDrv(sq *StatQueueProfile) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheStatQueueProfiles, sq.TenantID(), sq, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheStatQueueProfiles, sq.TenantID(), sq, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemStatQueueProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheStatQueueProfiles, utils.ConcatenatedKey(tenant, id),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheStatQueueProfiles, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
 	return
 }
 
@@ -621,13 +633,11 @@ func (iDB *InternalDB) SetStatQueueDrv(ssq *StoredStatQueue, sq *StatQueue) (err
 			return
 		}
 	}
-	Cache.SetWithoutReplicate(utils.CacheStatQueues, utils.ConcatenatedKey(sq.Tenant, sq.ID), sq, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheStatQueues, utils.ConcatenatedKey(sq.Tenant, sq.ID), sq, nil, utils.NonTransactional)
 	return
 }
 func (iDB *InternalDB) RemStatQueueDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheStatQueues, utils.ConcatenatedKey(tenant, id),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheStatQueues, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
 	return
 }
 
@@ -640,14 +650,12 @@ func (iDB *InternalDB) GetThresholdProfileDrv(tenant, id string) (tp *ThresholdP
 }
 
 func (iDB *InternalDB) SetThresholdProfileDrv(tp *ThresholdProfile) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheThresholdProfiles, tp.TenantID(), tp, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheThresholdProfiles, tp.TenantID(), tp, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemThresholdProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheThresholdProfiles, utils.ConcatenatedKey(tenant, id),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheThresholdProfiles, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
 	return
 }
 
@@ -660,14 +668,12 @@ func (iDB *InternalDB) GetThresholdDrv(tenant, id string) (th *Threshold, err er
 }
 
 func (iDB *InternalDB) SetThresholdDrv(th *Threshold) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheThresholds, th.TenantID(), th, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheThresholds, th.TenantID(), th, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveThresholdDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheThresholds, utils.ConcatenatedKey(tenant, id),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheThresholds, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
 	return
 }
 
@@ -684,14 +690,12 @@ func (iDB *InternalDB) SetFilterDrv(fltr *Filter) (err error) {
 	if err = fltr.Compile(); err != nil {
 		return
 	}
-	Cache.SetWithoutReplicate(utils.CacheFilters, fltr.TenantID(), fltr, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheFilters, fltr.TenantID(), fltr, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveFilterDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheFilters, utils.ConcatenatedKey(tenant, id),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheFilters, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
 	return
 }
 
@@ -707,14 +711,88 @@ func (iDB *InternalDB) SetRouteProfileDrv(spp *RouteProfile) (err error) {
 	if err = spp.Compile(); err != nil {
 		return
 	}
-	Cache.SetWithoutReplicate(utils.CacheRouteProfiles, spp.TenantID(), spp, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheRouteProfiles, spp.TenantID(), spp, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveRouteProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheRouteProfiles, utils.ConcatenatedKey(tenant, id),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheRouteProfiles, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetRouteBreakerDrv(tenant, id string) (rb *RouteBreaker, err error) {
+	x, ok := Cache.Get(utils.CacheRouteBreakers, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*RouteBreaker), nil
+}
+
+func (iDB *InternalDB) SetRouteBreakerDrv(rb *RouteBreaker) (err error) {
+	iDB.setItem(utils.CacheRouteBreakers, rb.TenantID(), rb, nil, utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveRouteBreakerDrv(tenant, id string) (err error) {
+	iDB.removeItem(utils.CacheRouteBreakers, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetInvoiceDrv(tenant, id string) (inv *Invoice, err error) {
+	x, ok := Cache.Get(utils.CacheInvoices, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*Invoice), nil
+}
+
+func (iDB *InternalDB) SetInvoiceDrv(inv *Invoice) (err error) {
+	iDB.setItem(utils.CacheInvoices, inv.TenantID(), inv, nil, utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveInvoiceDrv(tenant, id string) (err error) {
+	iDB.removeItem(utils.CacheInvoices, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetAccountChargesDrv(tenant, id string) (aC *utils.AccountCharges, err error) {
+	x, ok := Cache.Get(utils.CacheAccountCharges, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*utils.AccountCharges), nil
+}
+
+func (iDB *InternalDB) SetAccountChargesDrv(aC *utils.AccountCharges) (err error) {
+	iDB.setItem(utils.CacheAccountCharges, aC.TenantID(), aC, nil, utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveAccountChargesDrv(tenant, id string) (err error) {
+	iDB.removeItem(utils.CacheAccountCharges, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetSessionBackupsDrv(nodeID string) (sbs []*SessionBackup, err error) {
+	for _, key := range Cache.GetItemIDs(utils.CacheSessionBackups, nodeID+utils.ConcatenatedKeySep) {
+		if x, ok := Cache.Get(utils.CacheSessionBackups, key); ok && x != nil {
+			sbs = append(sbs, x.(*SessionBackup))
+		}
+	}
+	if len(sbs) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+func (iDB *InternalDB) SetSessionBackupDrv(sb *SessionBackup) (err error) {
+	iDB.setItem(utils.CacheSessionBackups, sb.NodeCGRID(), sb, nil, utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveSessionBackupDrv(nodeID, cgrID string) (err error) {
+	iDB.removeItem(utils.CacheSessionBackups, utils.ConcatenatedKey(nodeID, cgrID), utils.NonTransactional)
 	return
 }
 
@@ -730,14 +808,12 @@ func (iDB *InternalDB) SetAttributeProfileDrv(attr *AttributeProfile) (err error
 	if err = attr.Compile(); err != nil {
 		return
 	}
-	Cache.SetWithoutReplicate(utils.CacheAttributeProfiles, attr.TenantID(), attr, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheAttributeProfiles, attr.TenantID(), attr, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveAttributeProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheAttributeProfiles, utils.ConcatenatedKey(tenant, id),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheAttributeProfiles, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
 	return
 }
 
@@ -750,14 +826,12 @@ func (iDB *InternalDB) GetChargerProfileDrv(tenant, id string) (ch *ChargerProfi
 }
 
 func (iDB *InternalDB) SetChargerProfileDrv(chr *ChargerProfile) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheChargerProfiles, chr.TenantID(), chr, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheChargerProfiles, chr.TenantID(), chr, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveChargerProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheChargerProfiles, utils.ConcatenatedKey(tenant, id),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheChargerProfiles, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
 	return
 }
 
@@ -770,14 +844,12 @@ func (iDB *InternalDB) GetDispatcherProfileDrv(tenant, id string) (dpp *Dispatch
 }
 
 func (iDB *InternalDB) SetDispatcherProfileDrv(dpp *DispatcherProfile) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheDispatcherProfiles, dpp.TenantID(), dpp, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheDispatcherProfiles, dpp.TenantID(), dpp, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveDispatcherProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheDispatcherProfiles, utils.ConcatenatedKey(tenant, id),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheDispatcherProfiles, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
 	return
 }
 
@@ -794,8 +866,7 @@ func (iDB *InternalDB) GetItemLoadIDsDrv(itemIDPrefix string) (loadIDs map[strin
 }
 
 func (iDB *InternalDB) SetLoadIDsDrv(loadIDs map[string]int64) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheLoadIDs, utils.LoadIDs, loadIDs, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheLoadIDs, utils.LoadIDs, loadIDs, nil, utils.NonTransactional)
 	return
 }
 
@@ -808,14 +879,12 @@ func (iDB *InternalDB) GetDispatcherHostDrv(tenant, id string) (dpp *DispatcherH
 }
 
 func (iDB *InternalDB) SetDispatcherHostDrv(dpp *DispatcherHost) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheDispatcherHosts, dpp.TenantID(), dpp, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheDispatcherHosts, dpp.TenantID(), dpp, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveDispatcherHostDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheDispatcherHosts, utils.ConcatenatedKey(tenant, id),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheDispatcherHosts, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
 	return
 }
 
@@ -831,14 +900,12 @@ func (iDB *InternalDB) SetRateProfileDrv(rpp *RateProfile) (err error) {
 	if err = rpp.Compile(); err != nil {
 		return
 	}
-	Cache.SetWithoutReplicate(utils.CacheRateProfiles, rpp.TenantID(), rpp, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheRateProfiles, rpp.TenantID(), rpp, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveRateProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheRateProfiles, utils.ConcatenatedKey(tenant, id),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheRateProfiles, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
 	return
 }
 
@@ -851,14 +918,12 @@ func (iDB *InternalDB) GetActionProfileDrv(tenant, id string) (ap *ActionProfile
 }
 
 func (iDB *InternalDB) SetActionProfileDrv(ap *ActionProfile) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheActionProfiles, ap.TenantID(), ap, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheActionProfiles, ap.TenantID(), ap, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveActionProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheActionProfiles, utils.ConcatenatedKey(tenant, id),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheActionProfiles, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
 	return
 }
 
@@ -903,11 +968,9 @@ func (iDB *InternalDB) SetIndexesDrv(idxItmType, tntCtx string,
 			if !ok || x == nil {
 				continue
 			}
-			Cache.RemoveWithoutReplicate(idxItmType, dbKey,
-				cacheCommit(utils.NonTransactional), utils.NonTransactional)
+			iDB.removeItem(idxItmType, dbKey, utils.NonTransactional)
 			key := strings.TrimSuffix(strings.TrimPrefix(dbKey, "tmp_"), utils.ConcatenatedKeySep+transactionID)
-			Cache.SetWithoutReplicate(idxItmType, key, x, []string{tntCtx},
-				cacheCommit(utils.NonTransactional), utils.NonTransactional)
+			iDB.setItem(idxItmType, key, x, []string{tntCtx}, utils.NonTransactional)
 		}
 		return
 	}
@@ -917,22 +980,20 @@ func (iDB *InternalDB) SetIndexesDrv(idxItmType, tntCtx string,
 			dbKey = "tmp_" + utils.ConcatenatedKey(dbKey, transactionID)
 		}
 		if len(indx) == 0 {
-			Cache.SetWithoutReplicate(idxItmType, dbKey, nil, []string{tntCtx},
-				cacheCommit(utils.NonTransactional), utils.NonTransactional)
+			iDB.setItem(idxItmType, dbKey, nil, []string{tntCtx}, utils.NonTransactional)
 			continue
 		}
-		Cache.SetWithoutReplicate(idxItmType, dbKey, indx, []string{tntCtx},
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(idxItmType, dbKey, indx, []string{tntCtx}, utils.NonTransactional)
 	}
 	return
 }
 
 func (iDB *InternalDB) RemoveIndexesDrv(idxItmType, tntCtx, idxKey string) (err error) {
 	if idxKey == utils.EmptyString {
-		Cache.tCache.RemoveGroup(idxItmType, tntCtx, true, utils.EmptyString)
+		iDB.removeGroup(idxItmType, tntCtx)
 		return
 	}
-	Cache.RemoveWithoutReplicate(idxItmType, utils.ConcatenatedKey(tntCtx, idxKey), cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(idxItmType, utils.ConcatenatedKey(tntCtx, idxKey), utils.NonTransactional)
 	return
 }
 
@@ -945,13 +1006,11 @@ func (iDB *InternalDB) GetAccountProfileDrv(tenant, id string) (ap *utils.Accoun
 }
 
 func (iDB *InternalDB) SetAccountProfileDrv(ap *utils.AccountProfile) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheAccountProfiles, ap.TenantID(), ap, nil,
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheAccountProfiles, ap.TenantID(), ap, nil, utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveAccountProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheAccountProfiles, utils.ConcatenatedKey(tenant, id),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheAccountProfiles, utils.ConcatenatedKey(tenant, id), utils.NonTransactional)
 	return
 }
@@ -0,0 +1,685 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+const (
+	internalDBRecordSet byte = iota
+	internalDBRecordRemove
+	internalDBRecordRemoveGroup
+
+	internalDBHeaderLen    = 8 // record length and CRC32 checksum
+	internalDBSnapshotExt  = ".snapshot"
+	internalDBWALExt       = ".wal"
+	internalDBDataFileName = "datadb"
+	internalDBStorFileName = "stordb"
+)
+
+var (
+	// internalDataDBItems are the partitions persisted for the DataDB together with the type of their items
+	internalDataDBItems = map[string]reflect.Type{
+		utils.CacheVersions:                     reflect.TypeOf(Versions{}),
+		utils.CacheDestinations:                 reflect.TypeOf(new(Destination)),
+		utils.CacheReverseDestinations:          reflect.TypeOf([]string{}),
+		utils.CacheRatingPlans:                  reflect.TypeOf(new(RatingPlan)),
+		utils.CacheRatingProfiles:               reflect.TypeOf(new(RatingProfile)),
+		utils.CacheActions:                      reflect.TypeOf(Actions{}),
+		utils.CacheActionTriggers:               reflect.TypeOf(ActionTriggers{}),
+		utils.CacheSharedGroups:                 reflect.TypeOf(new(SharedGroup)),
+		utils.CacheActionPlans:                  reflect.TypeOf(new(ActionPlan)),
+		utils.CacheAccountActionPlans:           reflect.TypeOf([]string{}),
+		utils.CacheAccounts:                     reflect.TypeOf(new(Account)),
+		utils.CacheTimings:                      reflect.TypeOf(new(utils.TPTiming)),
+		utils.CacheResourceProfiles:             reflect.TypeOf(new(ResourceProfile)),
+		utils.CacheResources:                    reflect.TypeOf(new(Resource)),
+		utils.CacheStatQueueProfiles:            reflect.TypeOf(new(StatQueueProfile)),
+		utils.CacheStatQueues:                   reflect.TypeOf(new(StoredStatQueue)),
+		utils.CacheThresholdProfiles:            reflect.TypeOf(new(ThresholdProfile)),
+		utils.CacheThresholds:                   reflect.TypeOf(new(Threshold)),
+		utils.CacheFilters:                      reflect.TypeOf(new(Filter)),
+		utils.CacheRouteProfiles:                reflect.TypeOf(new(RouteProfile)),
+		utils.CacheRouteBreakers:                reflect.TypeOf(new(RouteBreaker)),
+		utils.CacheSessionBackups:               reflect.TypeOf(new(SessionBackup)),
+		utils.CacheInvoices:                     reflect.TypeOf(new(Invoice)),
+		utils.CacheAccountCharges:               reflect.TypeOf(new(utils.AccountCharges)),
+		utils.CacheAttributeProfiles:            reflect.TypeOf(new(AttributeProfile)),
+		utils.CacheChargerProfiles:              reflect.TypeOf(new(ChargerProfile)),
+		utils.CacheDispatcherProfiles:           reflect.TypeOf(new(DispatcherProfile)),
+		utils.CacheDispatcherHosts:              reflect.TypeOf(new(DispatcherHost)),
+		utils.CacheRateProfiles:                 reflect.TypeOf(new(RateProfile)),
+		utils.CacheActionProfiles:               reflect.TypeOf(new(ActionProfile)),
+		utils.CacheAccountProfiles:              reflect.TypeOf(new(utils.AccountProfile)),
+		utils.CacheLoadIDs:                      reflect.TypeOf(map[string]int64{}),
+		utils.CacheResourceFilterIndexes:        reflect.TypeOf(utils.StringSet{}),
+		utils.CacheStatFilterIndexes:            reflect.TypeOf(utils.StringSet{}),
+		utils.CacheThresholdFilterIndexes:       reflect.TypeOf(utils.StringSet{}),
+		utils.CacheRouteFilterIndexes:           reflect.TypeOf(utils.StringSet{}),
+		utils.CacheAttributeFilterIndexes:       reflect.TypeOf(utils.StringSet{}),
+		utils.CacheChargerFilterIndexes:         reflect.TypeOf(utils.StringSet{}),
+		utils.CacheDispatcherFilterIndexes:      reflect.TypeOf(utils.StringSet{}),
+		utils.CacheRateProfilesFilterIndexes:    reflect.TypeOf(utils.StringSet{}),
+		utils.CacheRateFilterIndexes:            reflect.TypeOf(utils.StringSet{}),
+		utils.CacheActionProfilesFilterIndexes:  reflect.TypeOf(utils.StringSet{}),
+		utils.CacheAccountProfilesFilterIndexes: reflect.TypeOf(utils.StringSet{}),
+		utils.CacheReverseFilterIndexes:         reflect.TypeOf(utils.StringSet{}),
+	}
+
+	// internalStorDBItems are the partitions persisted for the StorDB together with the type of their items
+	internalStorDBItems = map[string]reflect.Type{
+		utils.CacheVersions:              reflect.TypeOf(Versions{}),
+		utils.CacheTBLTPTimings:          reflect.TypeOf(new(utils.ApierTPTiming)),
+		utils.CacheTBLTPDestinations:     reflect.TypeOf(new(utils.TPDestination)),
+		utils.CacheTBLTPRates:            reflect.TypeOf(new(utils.TPRateRALs)),
+		utils.CacheTBLTPDestinationRates: reflect.TypeOf(new(utils.TPDestinationRate)),
+		utils.CacheTBLTPRatingPlans:      reflect.TypeOf(new(utils.TPRatingPlan)),
+		utils.CacheTBLTPRatingProfiles:   reflect.TypeOf(new(utils.TPRatingProfile)),
+		utils.CacheTBLTPSharedGroups:     reflect.TypeOf(new(utils.TPSharedGroups)),
+		utils.CacheTBLTPActions:          reflect.TypeOf(new(utils.TPActions)),
+		utils.CacheTBLTPActionPlans:      reflect.TypeOf(new(utils.TPActionPlan)),
+		utils.CacheTBLTPActionTriggers:   reflect.TypeOf(new(utils.TPActionTriggers)),
+		utils.CacheTBLTPAccountActions:   reflect.TypeOf(new(utils.TPAccountActions)),
+		utils.CacheTBLTPResources:        reflect.TypeOf(new(utils.TPResourceProfile)),
+		utils.CacheTBLTPStats:            reflect.TypeOf(new(utils.TPStatProfile)),
+		utils.CacheTBLTPThresholds:       reflect.TypeOf(new(utils.TPThresholdProfile)),
+		utils.CacheTBLTPFilters:          reflect.TypeOf(new(utils.TPFilterProfile)),
+		utils.CacheTBLTPRoutes:           reflect.TypeOf(new(utils.TPRouteProfile)),
+		utils.CacheTBLTPAttributes:       reflect.TypeOf(new(utils.TPAttributeProfile)),
+		utils.CacheTBLTPChargers:         reflect.TypeOf(new(utils.TPChargerProfile)),
+		utils.CacheTBLTPDispatchers:      reflect.TypeOf(new(utils.TPDispatcherProfile)),
+		utils.CacheTBLTPDispatcherHosts:  reflect.TypeOf(new(utils.TPDispatcherHost)),
+		utils.CacheTBLTPRateProfiles:     reflect.TypeOf(new(utils.TPRateProfile)),
+		utils.CacheTBLTPActionProfiles:   reflect.TypeOf(new(utils.TPActionProfile)),
+		utils.CacheTBLTPAccountProfiles:  reflect.TypeOf(new(utils.TPAccountProfile)),
+		utils.CacheSessionCostsTBL:       reflect.TypeOf(new(SMCost)),
+		utils.CacheCDRsTBL:               reflect.TypeOf(new(CDR)),
+	}
+
+	// internalDBPersisters are the active persisters, restored again each time the Cache is replaced
+	internalDBPersisters   = make(map[*internalDBPersister]struct{})
+	internalDBPersistersMu sync.Mutex
+)
+
+// internalDBRecord is one change of the InternalDB as written in the snapshot and in the write-ahead log
+type internalDBRecord struct {
+	Op       byte
+	CacheID  string
+	ItemID   string // the group ID for internalDBRecordRemoveGroup
+	GroupIDs []string
+	Value    []byte // nil for nil items
+}
+
+// internalDBPersister keeps the items of an InternalDB on disk as a snapshot plus a write-ahead log with the changes done after it
+type internalDBPersister struct {
+	sync.Mutex
+	snpMux           sync.Mutex // one snapshot at a time, taken before the main lock
+	tag              string     // DataDB or StorDB, used in logs
+	itmTypes         map[string]reflect.Type
+	ms               Marshaler
+	snapshotPath     string
+	walPath          string
+	snapshotInterval time.Duration // 0 to write the snapshot only when closing
+	fsyncInterval    time.Duration // 0 to sync after each write, -1 to never sync
+	compactSize      int64         // write a snapshot once the log reaches this size, 0 to disable
+	wal              *os.File
+	walSize          int64
+	unsynced         bool
+	groups           map[string]map[string][]string // groupIDs of the items, needed when writing the snapshot
+	trans            map[string][]*internalDBRecord // records staged in transactions, logged on commit
+	compacting       bool
+	stopChan         chan struct{}
+}
+
+// newInternalDBPersister opens the files from dumpPath and restores their content into Cache
+func newInternalDBPersister(dumpPath string, isDataDB bool, opts map[string]interface{}) (p *internalDBPersister, err error) {
+	tag, fName, itmTypes := utils.StorDB, internalDBStorFileName, internalStorDBItems
+	if isDataDB {
+		tag, fName, itmTypes = utils.DataDB, internalDBDataFileName, internalDataDBItems
+	}
+	p = &internalDBPersister{
+		tag:          tag,
+		itmTypes:     itmTypes,
+		ms:           NewCodecMsgpackMarshaler(), // fixed encoding so the files stay readable if the db_data_encoding changes
+		snapshotPath: path.Join(dumpPath, fName+internalDBSnapshotExt),
+		walPath:      path.Join(dumpPath, fName+internalDBWALExt),
+		trans:        make(map[string][]*internalDBRecord),
+		stopChan:     make(chan struct{}),
+	}
+	if p.snapshotInterval, err = utils.IfaceAsDuration(opts[utils.InternalDBSnapshotIntervalCfg]); err != nil {
+		return
+	}
+	if p.fsyncInterval, err = utils.IfaceAsDuration(opts[utils.InternalDBFsyncIntervalCfg]); err != nil {
+		return
+	}
+	if p.compactSize, err = utils.IfaceAsTInt64(opts[utils.InternalDBCompactSizeCfg]); err != nil {
+		return
+	}
+	if err = os.MkdirAll(dumpPath, 0755); err != nil {
+		return
+	}
+	if err = p.restore(); err != nil {
+		if p.wal != nil {
+			p.wal.Close()
+		}
+		return nil, err
+	}
+	go p.loop()
+	internalDBPersistersMu.Lock()
+	internalDBPersisters[p] = struct{}{}
+	internalDBPersistersMu.Unlock()
+	return
+}
+
+// commitInternalDBTransaction logs the records staged by the persisters in the transaction
+func commitInternalDBTransaction(transID string) {
+	internalDBPersistersMu.Lock()
+	defer internalDBPersistersMu.Unlock()
+	for p := range internalDBPersisters {
+		p.commit(transID)
+	}
+}
+
+// rollbackInternalDBTransaction discards the records staged by the persisters in the transaction
+func rollbackInternalDBTransaction(transID string) {
+	internalDBPersistersMu.Lock()
+	defer internalDBPersistersMu.Unlock()
+	for p := range internalDBPersisters {
+		p.rollback(transID)
+	}
+}
+
+// restoreInternalDBPersisters loads the persisted InternalDBs into the current Cache
+func restoreInternalDBPersisters() {
+	internalDBPersistersMu.Lock()
+	defer internalDBPersistersMu.Unlock()
+	for p := range internalDBPersisters {
+		if err := p.restore(); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed restoring the internal database from <%s>: %s",
+				p.tag, p.snapshotPath, err.Error()))
+		}
+	}
+}
+
+// restore loads the snapshot and replays the write-ahead log on top of it
+// a corrupted log is truncated after the last valid record
+func (p *internalDBPersister) restore() (err error) {
+	p.snpMux.Lock()
+	defer p.snpMux.Unlock()
+	p.Lock()
+	defer p.Unlock()
+	p.groups = make(map[string]map[string][]string)
+	p.trans = make(map[string][]*internalDBRecord)
+	var snpFile *os.File
+	if snpFile, err = os.Open(p.snapshotPath); err == nil {
+		var fi os.FileInfo
+		if fi, err = snpFile.Stat(); err != nil {
+			snpFile.Close()
+			return
+		}
+		var offset int64
+		offset, err = p.readRecords(snpFile, fi.Size())
+		snpFile.Close()
+		if err != nil {
+			return
+		}
+		if fi.Size() != offset {
+			return fmt.Errorf("corrupted snapshot <%s> at offset %d", p.snapshotPath, offset)
+		}
+	} else if !os.IsNotExist(err) {
+		return
+	}
+	if p.wal == nil {
+		if p.wal, err = os.OpenFile(p.walPath, os.O_CREATE|os.O_RDWR, 0644); err != nil {
+			return
+		}
+	}
+	if _, err = p.wal.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+	var fi os.FileInfo
+	if fi, err = p.wal.Stat(); err != nil {
+		return
+	}
+	if p.walSize, err = p.readRecords(p.wal, fi.Size()); err != nil {
+		return
+	}
+	if fi.Size() != p.walSize {
+		utils.Logger.Warning(fmt.Sprintf("<%s> corrupted record in <%s> at offset %d, discarding the last %d bytes",
+			p.tag, p.walPath, p.walSize, fi.Size()-p.walSize))
+		if err = p.wal.Truncate(p.walSize); err != nil {
+			return
+		}
+	}
+	_, err = p.wal.Seek(p.walSize, io.SeekStart)
+	return
+}
+
+// readRecords applies the records from the reader until its end or the first corrupted record
+// a record longer than the size left is considered corrupted
+// returns the offset after the last valid record
+func (p *internalDBPersister) readRecords(rdr io.Reader, size int64) (offset int64, err error) {
+	bufRdr := bufio.NewReader(rdr)
+	hdr := make([]byte, internalDBHeaderLen)
+	for {
+		if _, err = io.ReadFull(bufRdr, hdr); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				err = nil
+			}
+			return
+		}
+		recLen := int64(binary.BigEndian.Uint32(hdr[:4]))
+		if recLen > size-offset-internalDBHeaderLen {
+			return
+		}
+		data := make([]byte, recLen)
+		if _, err = io.ReadFull(bufRdr, data); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				err = nil
+			}
+			return
+		}
+		if crc32.ChecksumIEEE(data) != binary.BigEndian.Uint32(hdr[4:]) {
+			return
+		}
+		rec := new(internalDBRecord)
+		if err = p.ms.Unmarshal(data, rec); err != nil {
+			return
+		}
+		if err = p.apply(rec); err != nil {
+			return
+		}
+		offset += int64(internalDBHeaderLen + len(data))
+	}
+}
+
+// apply executes the record on Cache
+func (p *internalDBPersister) apply(rec *internalDBRecord) (err error) {
+	switch rec.Op {
+	case internalDBRecordSet:
+		var val interface{}
+		if val, err = p.decodeValue(rec.CacheID, rec.Value); err != nil {
+			return
+		}
+		Cache.SetWithoutReplicate(rec.CacheID, rec.ItemID, val, rec.GroupIDs, true, utils.NonTransactional)
+		p.setGroups(rec.CacheID, rec.ItemID, rec.GroupIDs)
+	case internalDBRecordRemove:
+		Cache.RemoveWithoutReplicate(rec.CacheID, rec.ItemID, true, utils.NonTransactional)
+		p.setGroups(rec.CacheID, rec.ItemID, nil)
+	case internalDBRecordRemoveGroup:
+		for _, itmID := range Cache.tCache.GetGroupItemIDs(rec.CacheID, rec.ItemID) {
+			p.setGroups(rec.CacheID, itmID, nil)
+		}
+		Cache.tCache.RemoveGroup(rec.CacheID, rec.ItemID, true, utils.EmptyString)
+	default:
+		return fmt.Errorf("unsupported record operation: %d", rec.Op)
+	}
+	return
+}
+
+// setGroups remembers the groupIDs of the item
+func (p *internalDBPersister) setGroups(chID, itmID string, groupIDs []string) {
+	if len(groupIDs) == 0 {
+		if chGrps, has := p.groups[chID]; has {
+			delete(chGrps, itmID)
+		}
+		return
+	}
+	if _, has := p.groups[chID]; !has {
+		p.groups[chID] = make(map[string][]string)
+	}
+	p.groups[chID][itmID] = groupIDs
+}
+
+func (p *internalDBPersister) encodeValue(val interface{}) (data []byte, err error) {
+	if val == nil {
+		return
+	}
+	if sq, isSQ := val.(*StatQueue); isSQ { // the metrics are interfaces so we store them marshaled
+		if val, err = NewStoredStatQueue(sq, p.ms); err != nil {
+			return
+		}
+	}
+	return p.ms.Marshal(val)
+}
+
+func (p *internalDBPersister) decodeValue(chID string, data []byte) (val interface{}, err error) {
+	if len(data) == 0 {
+		return
+	}
+	itmType, has := p.itmTypes[chID]
+	if !has {
+		return nil, fmt.Errorf("unsupported partition: <%s>", chID)
+	}
+	itm := reflect.New(itmType)
+	if err = p.ms.Unmarshal(data, itm.Interface()); err != nil {
+		return
+	}
+	if ssq, isSSQ := itm.Elem().Interface().(*StoredStatQueue); isSSQ {
+		return ssq.AsStatQueue(p.ms)
+	}
+	return itm.Elem().Interface(), nil
+}
+
+// writeRecord frames the record with its length and checksum
+func (p *internalDBPersister) writeRecord(w io.Writer, rec *internalDBRecord) (n int, err error) {
+	var data []byte
+	if data, err = p.ms.Marshal(rec); err != nil {
+		return
+	}
+	buf := make([]byte, internalDBHeaderLen+len(data))
+	binary.BigEndian.PutUint32(buf[:4], uint32(len(data)))
+	binary.BigEndian.PutUint32(buf[4:internalDBHeaderLen], crc32.ChecksumIEEE(data))
+	copy(buf[internalDBHeaderLen:], data)
+	return w.Write(buf)
+}
+
+// log appends the record to the write-ahead log, compacting it if it grew too much
+func (p *internalDBPersister) log(rec *internalDBRecord) {
+	if p.wal == nil { // closed
+		return
+	}
+	n, err := p.writeRecord(p.wal, rec)
+	p.walSize += int64(n)
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed writing to <%s>: %s",
+			p.tag, p.walPath, err.Error()))
+		return
+	}
+	p.unsynced = true
+	if p.fsyncInterval == 0 {
+		p.sync()
+	}
+	if p.compactSize > 0 && p.walSize >= p.compactSize && !p.compacting {
+		p.compacting = true
+		go p.compact()
+	}
+}
+
+// compact writes the snapshot once the write-ahead log grew too much
+func (p *internalDBPersister) compact() {
+	if err := p.snapshot(); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed writing the snapshot <%s>: %s",
+			p.tag, p.snapshotPath, err.Error()))
+	}
+	p.Lock()
+	p.compacting = false
+	p.Unlock()
+}
+
+// sync flushes the write-ahead log to disk
+func (p *internalDBPersister) sync() {
+	if p.wal == nil || !p.unsynced {
+		return
+	}
+	if err := p.wal.Sync(); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed syncing <%s>: %s",
+			p.tag, p.walPath, err.Error()))
+		return
+	}
+	p.unsynced = false
+}
+
+// set stores the item in Cache and logs the change
+// transactional changes are logged only once the transaction is committed
+func (p *internalDBPersister) set(chID, itmID string, val interface{}, groupIDs []string, transID string) {
+	p.Lock()
+	defer p.Unlock()
+	Cache.SetWithoutReplicate(chID, itmID, val, groupIDs, cacheCommit(transID), transID)
+	data, err := p.encodeValue(val)
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed encoding item <%s> from <%s>: %s",
+			p.tag, itmID, chID, err.Error()))
+		return
+	}
+	p.stage(&internalDBRecord{Op: internalDBRecordSet, CacheID: chID, ItemID: itmID, GroupIDs: groupIDs, Value: data}, transID)
+}
+
+// remove removes the item from Cache and logs the change
+func (p *internalDBPersister) remove(chID, itmID, transID string) {
+	p.Lock()
+	defer p.Unlock()
+	Cache.RemoveWithoutReplicate(chID, itmID, cacheCommit(transID), transID)
+	p.stage(&internalDBRecord{Op: internalDBRecordRemove, CacheID: chID, ItemID: itmID}, transID)
+}
+
+// stage logs the record or keeps it until the transaction is committed
+func (p *internalDBPersister) stage(rec *internalDBRecord, transID string) {
+	if !cacheCommit(transID) {
+		p.trans[transID] = append(p.trans[transID], rec)
+		return
+	}
+	p.setGroups(rec.CacheID, rec.ItemID, rec.GroupIDs)
+	p.log(rec)
+}
+
+// commit logs the records staged in the transaction
+func (p *internalDBPersister) commit(transID string) {
+	p.Lock()
+	defer p.Unlock()
+	for _, rec := range p.trans[transID] {
+		p.setGroups(rec.CacheID, rec.ItemID, rec.GroupIDs)
+		p.log(rec)
+	}
+	delete(p.trans, transID)
+}
+
+// rollback discards the records staged in the transaction
+func (p *internalDBPersister) rollback(transID string) {
+	p.Lock()
+	delete(p.trans, transID)
+	p.Unlock()
+}
+
+// removeGroup removes the items of the group from Cache and logs the change
+func (p *internalDBPersister) removeGroup(chID, grpID string) {
+	p.Lock()
+	defer p.Unlock()
+	for _, itmID := range Cache.tCache.GetGroupItemIDs(chID, grpID) {
+		p.setGroups(chID, itmID, nil)
+	}
+	Cache.tCache.RemoveGroup(chID, grpID, true, utils.EmptyString)
+	p.log(&internalDBRecord{Op: internalDBRecordRemoveGroup, CacheID: chID, ItemID: grpID})
+}
+
+// snapshot writes the persisted partitions into a new snapshot and removes from the write-ahead log the records it contains
+// the items are copied under lock and written in a temporary file without it, renamed only once complete
+func (p *internalDBPersister) snapshot() (err error) {
+	p.snpMux.Lock()
+	defer p.snpMux.Unlock()
+	p.Lock()
+	if p.wal == nil { // closed
+		p.Unlock()
+		return
+	}
+	var recs []*internalDBRecord
+	var vals []interface{}
+	for chID := range p.itmTypes {
+		for _, itmID := range Cache.tCache.GetItemIDs(chID, utils.EmptyString) {
+			val, has := Cache.tCache.Get(chID, itmID)
+			if !has {
+				continue
+			}
+			recs = append(recs, &internalDBRecord{Op: internalDBRecordSet, CacheID: chID, ItemID: itmID,
+				GroupIDs: p.groups[chID][itmID]})
+			vals = append(vals, val)
+		}
+	}
+	walOffset := p.walSize // the records logged from now on are kept in the log
+	p.Unlock()
+
+	tmpPath := p.snapshotPath + utils.TmpSuffix
+	var f *os.File
+	if f, err = os.Create(tmpPath); err != nil {
+		return
+	}
+	defer os.Remove(tmpPath) // no-op once renamed
+	w := bufio.NewWriter(f)
+	for i, rec := range recs {
+		if rec.Value, err = p.encodeValue(vals[i]); err != nil {
+			f.Close()
+			return fmt.Errorf("failed encoding item <%s> from <%s>: %s", rec.ItemID, rec.CacheID, err.Error())
+		}
+		if _, err = p.writeRecord(w, rec); err != nil {
+			f.Close()
+			return
+		}
+	}
+	if err = w.Flush(); err != nil {
+		f.Close()
+		return
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return
+	}
+	if err = f.Close(); err != nil {
+		return
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	if err = os.Rename(tmpPath, p.snapshotPath); err != nil {
+		return
+	}
+	if p.wal == nil {
+		return
+	}
+	return p.trimWAL(walOffset)
+}
+
+// trimWAL removes from the write-ahead log the records before offset, already part of the snapshot
+func (p *internalDBPersister) trimWAL(offset int64) (err error) {
+	tail := make([]byte, p.walSize-offset)
+	if _, err = p.wal.ReadAt(tail, offset); err != nil {
+		return
+	}
+	if err = p.wal.Truncate(0); err != nil {
+		return
+	}
+	if _, err = p.wal.WriteAt(tail, 0); err != nil {
+		return
+	}
+	if _, err = p.wal.Seek(int64(len(tail)), io.SeekStart); err != nil {
+		return
+	}
+	p.walSize = int64(len(tail))
+	p.unsynced = len(tail) != 0
+	if p.fsyncInterval == 0 {
+		p.sync()
+	}
+	return
+}
+
+// loop writes the periodic snapshots and syncs the write-ahead log
+func (p *internalDBPersister) loop() {
+	var snpChan, syncChan <-chan time.Time
+	if p.snapshotInterval > 0 {
+		snpTicker := time.NewTicker(p.snapshotInterval)
+		defer snpTicker.Stop()
+		snpChan = snpTicker.C
+	}
+	if p.fsyncInterval > 0 {
+		syncTicker := time.NewTicker(p.fsyncInterval)
+		defer syncTicker.Stop()
+		syncChan = syncTicker.C
+	}
+	for {
+		select {
+		case <-p.stopChan:
+			return
+		case <-snpChan:
+			if err := p.snapshot(); err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> failed writing the snapshot <%s>: %s",
+					p.tag, p.snapshotPath, err.Error()))
+			}
+		case <-syncChan:
+			p.Lock()
+			p.sync()
+			p.Unlock()
+		}
+	}
+}
+
+// flush removes the persisted data
+func (p *internalDBPersister) flush() (err error) {
+	p.snpMux.Lock()
+	defer p.snpMux.Unlock()
+	p.Lock()
+	defer p.Unlock()
+	p.groups = make(map[string]map[string][]string)
+	p.trans = make(map[string][]*internalDBRecord)
+	if err = os.Remove(p.snapshotPath); err != nil && !os.IsNotExist(err) {
+		return
+	}
+	err = nil
+	if p.wal == nil {
+		return
+	}
+	if err = p.wal.Truncate(0); err != nil {
+		return
+	}
+	_, err = p.wal.Seek(0, io.SeekStart)
+	p.walSize = 0
+	return
+}
+
+// close writes the final snapshot and closes the write-ahead log
+func (p *internalDBPersister) close() (err error) {
+	internalDBPersistersMu.Lock()
+	delete(internalDBPersisters, p)
+	internalDBPersistersMu.Unlock()
+	p.Lock()
+	select {
+	case <-p.stopChan: // already closing
+		p.Unlock()
+		return
+	default:
+	}
+	if p.wal == nil {
+		p.Unlock()
+		return
+	}
+	close(p.stopChan)
+	p.Unlock()
+	err = p.snapshot()
+	p.Lock()
+	defer p.Unlock()
+	if p.wal == nil {
+		return
+	}
+	p.sync()
+	if errClose := p.wal.Close(); err == nil {
+		err = errClose
+	}
+	p.wal = nil
+	return
+}
@@ -0,0 +1,247 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"math"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestInternalDBPersistence(t *testing.T) {
+	dumpPath, err := ioutil.TempDir(utils.EmptyString, "internal_db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dumpPath)
+	opts := map[string]interface{}{
+		utils.InternalDBSnapshotIntervalCfg: "0",
+		utils.InternalDBFsyncIntervalCfg:    "0",
+		utils.InternalDBCompactSizeCfg:      0,
+	}
+	tmp := Cache
+	Cache = NewCacheS(config.CgrConfig(), nil, nil)
+	defer func() { Cache = tmp }()
+	iDB, err := NewPersistentInternalDB(nil, nil, true, dumpPath, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc := &Account{ID: "cgrates.org:1001", BalanceMap: map[string]Balances{
+		utils.MetaMonetary: {{ID: "TestBalance", Value: 10}}}}
+	if err = iDB.SetAccountDrv(acc); err != nil {
+		t.Fatal(err)
+	}
+	if err = iDB.SetAccountDrv(&Account{ID: "cgrates.org:1002"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = iDB.RemoveAccountDrv("cgrates.org:1002"); err != nil {
+		t.Fatal(err)
+	}
+	asr, _ := NewASR(0, utils.EmptyString, nil)
+	sq := &StatQueue{Tenant: "cgrates.org", ID: "SQ1",
+		SQMetrics: map[string]StatMetric{utils.MetaASR: asr}}
+	if err = iDB.SetStatQueueDrv(nil, sq); err != nil {
+		t.Fatal(err)
+	}
+	idxs := map[string]utils.StringSet{"*string:*req.Account:1001": {"ATTR1": {}}}
+	if err = iDB.SetIndexesDrv(utils.CacheAttributeFilterIndexes, "cgrates.org:*sessions",
+		idxs, true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+
+	checkRestored := func() {
+		t.Helper()
+		if rcv, err := iDB.GetAccountDrv("cgrates.org:1001"); err != nil {
+			t.Error(err)
+		} else if expAcc := acc.Clone(); !reflect.DeepEqual(expAcc, rcv) {
+			t.Errorf("Expected %s, received %s", utils.ToJSON(expAcc), utils.ToJSON(rcv))
+		}
+		if _, err := iDB.GetAccountDrv("cgrates.org:1002"); err != utils.ErrNotFound {
+			t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+		}
+		if rcv, err := iDB.GetStatQueueDrv("cgrates.org", "SQ1"); err != nil {
+			t.Error(err)
+		} else if _, has := rcv.SQMetrics[utils.MetaASR]; !has {
+			t.Errorf("Expected metric %s, received %s", utils.MetaASR, utils.ToJSON(rcv))
+		}
+		if rcv, err := iDB.GetIndexesDrv(utils.CacheAttributeFilterIndexes, "cgrates.org:*sessions",
+			utils.EmptyString); err != nil {
+			t.Error(err)
+		} else if !reflect.DeepEqual(idxs, rcv) {
+			t.Errorf("Expected %s, received %s", utils.ToJSON(idxs), utils.ToJSON(rcv))
+		}
+	}
+
+	// replay only the write-ahead log
+	Cache.Clear(nil)
+	if err = iDB.persister.restore(); err != nil {
+		t.Fatal(err)
+	}
+	checkRestored()
+
+	// restart from the snapshot
+	iDB.Close()
+	if fi, err := os.Stat(path.Join(dumpPath, internalDBDataFileName+internalDBWALExt)); err != nil {
+		t.Fatal(err)
+	} else if fi.Size() != 0 {
+		t.Errorf("Expected the log to be truncated after snapshot, has %d bytes", fi.Size())
+	}
+	Cache.Clear(nil)
+	if iDB, err = NewPersistentInternalDB(nil, nil, true, dumpPath, opts); err != nil {
+		t.Fatal(err)
+	}
+	checkRestored()
+
+	// a corrupted tail of the log is discarded
+	if err = iDB.SetAccountDrv(&Account{ID: "cgrates.org:1003"}); err != nil {
+		t.Fatal(err)
+	}
+	walSize := iDB.persister.walSize
+	if _, err = iDB.persister.wal.Write([]byte("corrupted")); err != nil {
+		t.Fatal(err)
+	}
+	Cache.Clear(nil)
+	if err = iDB.persister.restore(); err != nil {
+		t.Fatal(err)
+	}
+	checkRestored()
+	if _, err = iDB.GetAccountDrv("cgrates.org:1003"); err != nil {
+		t.Error(err)
+	}
+	if iDB.persister.walSize != walSize {
+		t.Errorf("Expected log size %d, received %d", walSize, iDB.persister.walSize)
+	}
+
+	// the snapshot is checked as well
+	iDB.Close()
+	snpPath := path.Join(dumpPath, internalDBDataFileName+internalDBSnapshotExt)
+	snp, err := ioutil.ReadFile(snpPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snp[len(snp)-1]++
+	if err = ioutil.WriteFile(snpPath, snp, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = NewPersistentInternalDB(nil, nil, true, dumpPath, opts); err == nil {
+		t.Error("Expected error for corrupted snapshot")
+	}
+}
+
+func TestInternalDBPersistenceTransactions(t *testing.T) {
+	dumpPath, err := ioutil.TempDir(utils.EmptyString, "internal_db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dumpPath)
+	opts := map[string]interface{}{
+		utils.InternalDBSnapshotIntervalCfg: "0",
+		utils.InternalDBFsyncIntervalCfg:    "0",
+		utils.InternalDBCompactSizeCfg:      0,
+	}
+	tmp := Cache
+	Cache = NewCacheS(config.CgrConfig(), nil, nil)
+	defer func() { Cache = tmp }()
+	iDB, err := NewPersistentInternalDB(nil, nil, true, dumpPath, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iDB.Close()
+
+	// the rolled back changes are not logged
+	transID := Cache.BeginTransaction()
+	iDB.persister.set(utils.CacheTimings, "ROLLEDBACK", &utils.TPTiming{ID: "ROLLEDBACK"}, nil, transID)
+	if iDB.persister.walSize != 0 {
+		t.Errorf("Expected nothing logged before commit, received %d bytes", iDB.persister.walSize)
+	}
+	Cache.RollbackTransaction(transID)
+
+	transID = Cache.BeginTransaction()
+	iDB.persister.set(utils.CacheTimings, "COMMITTED", &utils.TPTiming{ID: "COMMITTED"}, nil, transID)
+	Cache.CommitTransaction(transID)
+	if iDB.persister.walSize == 0 {
+		t.Error("Expected the committed changes logged")
+	}
+
+	Cache.Clear(nil)
+	if err = iDB.persister.restore(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = iDB.GetTimingDrv("COMMITTED"); err != nil {
+		t.Error(err)
+	}
+	if _, err = iDB.GetTimingDrv("ROLLEDBACK"); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+
+	// a record length bigger than the file is considered corrupted
+	walSize := iDB.persister.walSize
+	hdr := make([]byte, internalDBHeaderLen)
+	binary.BigEndian.PutUint32(hdr[:4], math.MaxUint32)
+	if _, err = iDB.persister.wal.Write(hdr); err != nil {
+		t.Fatal(err)
+	}
+	if err = iDB.persister.restore(); err != nil {
+		t.Fatal(err)
+	}
+	if iDB.persister.walSize != walSize {
+		t.Errorf("Expected log size %d, received %d", walSize, iDB.persister.walSize)
+	}
+
+	// the records logged after the snapshot started are kept in the log
+	if err = iDB.persister.snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	if iDB.persister.walSize != 0 {
+		t.Errorf("Expected the log truncated, received %d bytes", iDB.persister.walSize)
+	}
+	if err = iDB.SetAccountDrv(&Account{ID: "cgrates.org:1001"}); err != nil {
+		t.Fatal(err)
+	}
+	walOffset := iDB.persister.walSize // as if the snapshot contains the first account only
+	if err = iDB.SetAccountDrv(&Account{ID: "cgrates.org:1002"}); err != nil {
+		t.Fatal(err)
+	}
+	walSize = iDB.persister.walSize
+	iDB.persister.Lock()
+	err = iDB.persister.trimWAL(walOffset)
+	iDB.persister.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	} else if iDB.persister.walSize != walSize-walOffset {
+		t.Errorf("Expected log size %d, received %d", walSize-walOffset, iDB.persister.walSize)
+	}
+	Cache.Clear(nil)
+	if err = iDB.persister.restore(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = iDB.GetAccountDrv("cgrates.org:1002"); err != nil {
+		t.Error(err)
+	}
+	if _, err = iDB.GetAccountDrv("cgrates.org:1001"); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
@@ -654,8 +654,7 @@ func (iDB *InternalDB) RemTpData(table, tpid string, args map[string]string) (er
 	}
 	ids := Cache.GetItemIDs(utils.CacheStorDBPartitions[table], key)
 	for _, id := range ids {
-		Cache.RemoveWithoutReplicate(utils.CacheStorDBPartitions[table], id,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.removeItem(utils.CacheStorDBPartitions[table], id, utils.NonTransactional)
 	}
 	return
 }
@@ -665,8 +664,7 @@ func (iDB *InternalDB) SetTPTimings(timings []*utils.ApierTPTiming) (err error)
 		return nil
 	}
 	for _, timing := range timings {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPTimings, utils.ConcatenatedKey(timing.TPid, timing.ID), timing, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPTimings, utils.ConcatenatedKey(timing.TPid, timing.ID), timing, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -675,8 +673,7 @@ func (iDB *InternalDB) SetTPDestinations(dests []*utils.TPDestination) (err erro
 		return nil
 	}
 	for _, destination := range dests {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPDestinations, utils.ConcatenatedKey(destination.TPid, destination.ID), destination, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPDestinations, utils.ConcatenatedKey(destination.TPid, destination.ID), destination, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -686,8 +683,7 @@ func (iDB *InternalDB) SetTPRates(rates []*utils.TPRateRALs) (err error) {
 		return nil
 	}
 	for _, rate := range rates {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPRates, utils.ConcatenatedKey(rate.TPid, rate.ID), rate, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPRates, utils.ConcatenatedKey(rate.TPid, rate.ID), rate, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -697,8 +693,7 @@ func (iDB *InternalDB) SetTPDestinationRates(dRates []*utils.TPDestinationRate)
 		return nil
 	}
 	for _, dRate := range dRates {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPDestinationRates, utils.ConcatenatedKey(dRate.TPid, dRate.ID), dRate, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPDestinationRates, utils.ConcatenatedKey(dRate.TPid, dRate.ID), dRate, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -708,8 +703,7 @@ func (iDB *InternalDB) SetTPRatingPlans(ratingPlans []*utils.TPRatingPlan) (err
 		return nil
 	}
 	for _, rPlan := range ratingPlans {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPRatingPlans, utils.ConcatenatedKey(rPlan.TPid, rPlan.ID), rPlan, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPRatingPlans, utils.ConcatenatedKey(rPlan.TPid, rPlan.ID), rPlan, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -719,9 +713,8 @@ func (iDB *InternalDB) SetTPRatingProfiles(ratingProfiles []*utils.TPRatingProfi
 		return nil
 	}
 	for _, rProfile := range ratingProfiles {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPRatingProfiles, utils.ConcatenatedKey(rProfile.TPid,
-			rProfile.LoadId, rProfile.Tenant, rProfile.Category, rProfile.Subject), rProfile, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPRatingProfiles, utils.ConcatenatedKey(rProfile.TPid,
+			rProfile.LoadId, rProfile.Tenant, rProfile.Category, rProfile.Subject), rProfile, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -731,8 +724,7 @@ func (iDB *InternalDB) SetTPSharedGroups(groups []*utils.TPSharedGroups) (err er
 		return nil
 	}
 	for _, group := range groups {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPSharedGroups, utils.ConcatenatedKey(group.TPid, group.ID), group, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPSharedGroups, utils.ConcatenatedKey(group.TPid, group.ID), group, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -742,8 +734,7 @@ func (iDB *InternalDB) SetTPActions(acts []*utils.TPActions) (err error) {
 		return nil
 	}
 	for _, action := range acts {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPActions, utils.ConcatenatedKey(action.TPid, action.ID), action, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPActions, utils.ConcatenatedKey(action.TPid, action.ID), action, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -753,8 +744,7 @@ func (iDB *InternalDB) SetTPActionPlans(aPlans []*utils.TPActionPlan) (err error
 		return nil
 	}
 	for _, aPlan := range aPlans {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPActionPlans, utils.ConcatenatedKey(aPlan.TPid, aPlan.ID), aPlan, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPActionPlans, utils.ConcatenatedKey(aPlan.TPid, aPlan.ID), aPlan, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -764,8 +754,7 @@ func (iDB *InternalDB) SetTPActionTriggers(aTriggers []*utils.TPActionTriggers)
 		return nil
 	}
 	for _, aTrigger := range aTriggers {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPActionTriggers, utils.ConcatenatedKey(aTrigger.TPid, aTrigger.ID), aTrigger, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPActionTriggers, utils.ConcatenatedKey(aTrigger.TPid, aTrigger.ID), aTrigger, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -775,9 +764,8 @@ func (iDB *InternalDB) SetTPAccountActions(accActions []*utils.TPAccountActions)
 		return nil
 	}
 	for _, accAction := range accActions {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPAccountActions, utils.ConcatenatedKey(accAction.TPid,
-			accAction.LoadId, accAction.Tenant, accAction.Account), accAction, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPAccountActions, utils.ConcatenatedKey(accAction.TPid,
+			accAction.LoadId, accAction.Tenant, accAction.Account), accAction, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -787,8 +775,7 @@ func (iDB *InternalDB) SetTPResources(resources []*utils.TPResourceProfile) (err
 		return nil
 	}
 	for _, resource := range resources {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPResources, utils.ConcatenatedKey(resource.TPid, resource.Tenant, resource.ID), resource, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPResources, utils.ConcatenatedKey(resource.TPid, resource.Tenant, resource.ID), resource, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -797,8 +784,7 @@ func (iDB *InternalDB) SetTPStats(stats []*utils.TPStatProfile) (err error) {
 		return nil
 	}
 	for _, stat := range stats {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPStats, utils.ConcatenatedKey(stat.TPid, stat.Tenant, stat.ID), stat, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPStats, utils.ConcatenatedKey(stat.TPid, stat.Tenant, stat.ID), stat, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -808,8 +794,7 @@ func (iDB *InternalDB) SetTPThresholds(thresholds []*utils.TPThresholdProfile) (
 	}
 
 	for _, threshold := range thresholds {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPThresholds, utils.ConcatenatedKey(threshold.TPid, threshold.Tenant, threshold.ID), threshold, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPThresholds, utils.ConcatenatedKey(threshold.TPid, threshold.Tenant, threshold.ID), threshold, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -819,8 +804,7 @@ func (iDB *InternalDB) SetTPFilters(filters []*utils.TPFilterProfile) (err error
 	}
 
 	for _, filter := range filters {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPFilters, utils.ConcatenatedKey(filter.TPid, filter.Tenant, filter.ID), filter, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPFilters, utils.ConcatenatedKey(filter.TPid, filter.Tenant, filter.ID), filter, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -830,8 +814,7 @@ func (iDB *InternalDB) SetTPRoutes(routes []*utils.TPRouteProfile) (err error) {
 		return nil
 	}
 	for _, route := range routes {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPRoutes, utils.ConcatenatedKey(route.TPid, route.Tenant, route.ID), route, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPRoutes, utils.ConcatenatedKey(route.TPid, route.Tenant, route.ID), route, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -842,8 +825,7 @@ func (iDB *InternalDB) SetTPAttributes(attributes []*utils.TPAttributeProfile) (
 	}
 
 	for _, attribute := range attributes {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPAttributes, utils.ConcatenatedKey(attribute.TPid, attribute.Tenant, attribute.ID), attribute, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPAttributes, utils.ConcatenatedKey(attribute.TPid, attribute.Tenant, attribute.ID), attribute, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -853,8 +835,7 @@ func (iDB *InternalDB) SetTPChargers(cpps []*utils.TPChargerProfile) (err error)
 	}
 
 	for _, cpp := range cpps {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPChargers, utils.ConcatenatedKey(cpp.TPid, cpp.Tenant, cpp.ID), cpp, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPChargers, utils.ConcatenatedKey(cpp.TPid, cpp.Tenant, cpp.ID), cpp, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -864,8 +845,7 @@ func (iDB *InternalDB) SetTPDispatcherProfiles(dpps []*utils.TPDispatcherProfile
 	}
 
 	for _, dpp := range dpps {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPDispatchers, utils.ConcatenatedKey(dpp.TPid, dpp.Tenant, dpp.ID), dpp, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPDispatchers, utils.ConcatenatedKey(dpp.TPid, dpp.Tenant, dpp.ID), dpp, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -874,8 +854,7 @@ func (iDB *InternalDB) SetTPDispatcherHosts(dpps []*utils.TPDispatcherHost) (err
 		return nil
 	}
 	for _, dpp := range dpps {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPDispatcherHosts, utils.ConcatenatedKey(dpp.TPid, dpp.Tenant, dpp.ID), dpp, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPDispatcherHosts, utils.ConcatenatedKey(dpp.TPid, dpp.Tenant, dpp.ID), dpp, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -885,8 +864,7 @@ func (iDB *InternalDB) SetTPRateProfiles(tpPrfs []*utils.TPRateProfile) (err err
 		return nil
 	}
 	for _, tpPrf := range tpPrfs {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPRateProfiles, utils.ConcatenatedKey(tpPrf.TPid, tpPrf.Tenant, tpPrf.ID), tpPrf, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPRateProfiles, utils.ConcatenatedKey(tpPrf.TPid, tpPrf.Tenant, tpPrf.ID), tpPrf, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -896,8 +874,7 @@ func (iDB *InternalDB) SetTPActionProfiles(tpPrfs []*utils.TPActionProfile) (err
 		return nil
 	}
 	for _, tpPrf := range tpPrfs {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPActionProfiles, utils.ConcatenatedKey(tpPrf.TPid, tpPrf.Tenant, tpPrf.ID), tpPrf, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPActionProfiles, utils.ConcatenatedKey(tpPrf.TPid, tpPrf.Tenant, tpPrf.ID), tpPrf, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -907,8 +884,7 @@ func (iDB *InternalDB) SetTPAccountProfiles(tpPrfs []*utils.TPAccountProfile) (e
 		return nil
 	}
 	for _, tpPrf := range tpPrfs {
-		Cache.SetWithoutReplicate(utils.CacheTBLTPAccountProfiles, utils.ConcatenatedKey(tpPrf.TPid, tpPrf.Tenant, tpPrf.ID), tpPrf, nil,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.setItem(utils.CacheTBLTPAccountProfiles, utils.ConcatenatedKey(tpPrf.TPid, tpPrf.Tenant, tpPrf.ID), tpPrf, nil, utils.NonTransactional)
 	}
 	return
 }
@@ -957,15 +933,13 @@ func (iDB *InternalDB) SetCDR(cdr *CDR, allowUpdate bool) (err error) {
 	}
 	iDB.indexedFieldsMutex.RUnlock()
 
-	Cache.SetWithoutReplicate(utils.CacheCDRsTBL, cdrKey, cdr, idxs.AsSlice(),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheCDRsTBL, cdrKey, cdr, idxs.AsSlice(), utils.NonTransactional)
 
 	return
 }
 
 func (iDB *InternalDB) RemoveSMCost(smc *SMCost) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheSessionCostsTBL, utils.ConcatenatedKey(smc.CGRID, smc.RunID, smc.OriginHost, smc.OriginID),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.removeItem(utils.CacheSessionCostsTBL, utils.ConcatenatedKey(smc.CGRID, smc.RunID, smc.OriginHost, smc.OriginID), utils.NonTransactional)
 	return
 }
 
@@ -1045,8 +1019,7 @@ func (iDB *InternalDB) RemoveSMCosts(qryFltr *utils.SMCostFilter) error {
 	}
 
 	for key := range smMpIDs {
-		Cache.RemoveWithoutReplicate(utils.CacheSessionCostsTBL, key,
-			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+		iDB.removeItem(utils.CacheSessionCostsTBL, key, utils.NonTransactional)
 	}
 	return nil
 }
@@ -1489,8 +1462,7 @@ func (iDB *InternalDB) GetCDRs(filter *utils.CDRsFilter, remove bool) (cdrs []*C
 	}
 	if remove {
 		for _, cdr := range cdrs {
-			Cache.RemoveWithoutReplicate(utils.CacheCDRsTBL, utils.ConcatenatedKey(cdr.CGRID, cdr.RunID, cdr.OriginID),
-				cacheCommit(utils.NonTransactional), utils.NonTransactional)
+			iDB.removeItem(utils.CacheCDRsTBL, utils.ConcatenatedKey(cdr.CGRID, cdr.RunID, cdr.OriginID), utils.NonTransactional)
 		}
 		return nil, 0, nil
 	}
@@ -1563,7 +1535,6 @@ func (iDB *InternalDB) SetSMCost(smCost *SMCost) (err error) {
 	idxs.Add(utils.ConcatenatedKey(utils.OriginHost, smCost.OriginHost))
 	idxs.Add(utils.ConcatenatedKey(utils.OriginID, smCost.OriginID))
 	idxs.Add(utils.ConcatenatedKey(utils.CostSource, smCost.CostSource))
-	Cache.SetWithoutReplicate(utils.CacheSessionCostsTBL, utils.ConcatenatedKey(smCost.CGRID, smCost.RunID, smCost.OriginHost, smCost.OriginID), smCost, idxs.AsSlice(),
-		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.setItem(utils.CacheSessionCostsTBL, utils.ConcatenatedKey(smCost.CGRID, smCost.RunID, smCost.OriginHost, smCost.OriginID), smCost, idxs.AsSlice(), utils.NonTransactional)
 	return err
 }
@@ -71,6 +71,10 @@ const (
 	ColThs  = "thresholds"
 	ColFlt  = "filters"
 	ColRts  = "route_profiles"
+	ColRbk  = "route_breakers"
+	ColSbk  = "session_backups"
+	ColInv  = "invoices"
+	ColAch  = "account_charges"
 	ColAttr = "attribute_profiles"
 	ColCDRs = "cdrs"
 	ColCpp  = "charger_profiles"
@@ -224,6 +228,9 @@ func NewMongoStorage(host, port, db, user, pass, mrshlerStr, storageType string,
 		if empty {
 			return ms.EnsureIndexes()
 		}
+		if storageType == utils.DataDB { // make sure the expired charges are removed on existing databases as well
+			return ms.ensureTTLIndex(ColAch, "expirytime")
+		}
 		return nil
 	}); err != nil {
 		return nil, err
@@ -281,6 +288,17 @@ func (ms *MongoStorage) enusureIndex(colName string, uniq bool, keys ...string)
 	})
 }
 
+// ensureTTLIndex removes the documents once the time in key is reached
+func (ms *MongoStorage) ensureTTLIndex(colName, key string) error {
+	return ms.query(func(sctx mongo.SessionContext) error {
+		_, err := ms.getCol(colName).Indexes().CreateOne(sctx, mongo.IndexModel{
+			Keys:    bson.M{key: 1},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		})
+		return err
+	})
+}
+
 func (ms *MongoStorage) dropAllIndexesForCol(colName string) error {
 	return ms.query(func(sctx mongo.SessionContext) error {
 		col := ms.getCol(colName)
@@ -316,14 +334,23 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 		if err = ms.enusureIndex(col, true, "key"); err != nil {
 			return
 		}
-	case ColRsP, ColRes, ColSqs, ColSqp, ColTps, ColThs, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColDph, ColRpp, ColApp, ColAnp:
+	case ColRsP, ColRes, ColSqs, ColSqp, ColTps, ColThs, ColRts, ColRbk, ColInv, ColAch, ColAttr, ColFlt, ColCpp, ColDpp, ColDph, ColRpp, ColApp, ColAnp:
 		if err = ms.enusureIndex(col, true, "tenant", "id"); err != nil {
 			return
 		}
+		if col == ColAch { // let mongo remove the expired charges
+			if err = ms.ensureTTLIndex(col, "expirytime"); err != nil {
+				return
+			}
+		}
 	case ColRpf, ColShg, ColAcc:
 		if err = ms.enusureIndex(col, true, "id"); err != nil {
 			return
 		}
+	case ColSbk:
+		if err = ms.enusureIndex(col, true, "nodeid", "cgrid"); err != nil {
+			return
+		}
 		//StorDB
 	case utils.TBLTPTimings, utils.TBLTPDestinations,
 		utils.TBLTPDestinationRates, utils.TBLTPRatingPlans,
@@ -380,8 +407,8 @@ func (ms *MongoStorage) EnsureIndexes(cols ...string) (err error) {
 	if ms.storageType == utils.DataDB {
 		for _, col := range []string{ColAct, ColApl, ColAAp, ColAtr,
 			ColRpl, ColDst, ColRds, ColLht, ColIndx, ColRsP, ColRes, ColSqs, ColSqp,
-			ColTps, ColThs, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColRpp, ColApp,
-			ColRpf, ColShg, ColAcc, ColAnp} {
+			ColTps, ColThs, ColRts, ColRbk, ColAttr, ColFlt, ColCpp, ColDpp, ColRpp, ColApp,
+			ColRpf, ColShg, ColAcc, ColAnp, ColSbk, ColInv, ColAch} {
 			if err = ms.ensureIndexesForCol(col); err != nil {
 				return
 			}
@@ -632,6 +659,12 @@ func (ms *MongoStorage) GetKeysForPrefix(prefix string) (result []string, err er
 			result, err = ms.getField2(sctx, ColTps, utils.ThresholdProfilePrefix, subject, tntID)
 		case utils.RouteProfilePrefix:
 			result, err = ms.getField2(sctx, ColRts, utils.RouteProfilePrefix, subject, tntID)
+		case utils.RouteBreakerPrefix:
+			result, err = ms.getField2(sctx, ColRbk, utils.RouteBreakerPrefix, subject, tntID)
+		case utils.InvoicePrefix:
+			result, err = ms.getField2(sctx, ColInv, utils.InvoicePrefix, subject, tntID)
+		case utils.AccountChargesPrefix:
+			result, err = ms.getField2(sctx, ColAch, utils.AccountChargesPrefix, subject, tntID)
 		case utils.AttributeProfilePrefix:
 			result, err = ms.getField2(sctx, ColAttr, utils.AttributeProfilePrefix, subject, tntID)
 		case utils.ChargerProfilePrefix:
@@ -1825,6 +1858,158 @@ func (ms *MongoStorage) RemoveRouteProfileDrv(tenant, id string) (err error) {
 	})
 }
 
+func (ms *MongoStorage) GetRouteBreakerDrv(tenant, id string) (r *RouteBreaker, err error) {
+	r = new(RouteBreaker)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColRbk).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(r); err != nil {
+			r = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetRouteBreakerDrv(r *RouteBreaker) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColRbk).UpdateOne(sctx, bson.M{"tenant": r.Tenant, "id": r.ID},
+			bson.M{"$set": r},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveRouteBreakerDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColRbk).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetInvoiceDrv(tenant, id string) (inv *Invoice, err error) {
+	inv = new(Invoice)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColInv).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(inv); err != nil {
+			inv = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetInvoiceDrv(inv *Invoice) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColInv).UpdateOne(sctx, bson.M{"tenant": inv.Tenant, "id": inv.ID},
+			bson.M{"$set": inv},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveInvoiceDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColInv).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetAccountChargesDrv(tenant, id string) (aC *utils.AccountCharges, err error) {
+	aC = new(utils.AccountCharges)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColAch).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(aC); err != nil {
+			aC = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetAccountChargesDrv(aC *utils.AccountCharges) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColAch).UpdateOne(sctx, bson.M{"tenant": aC.Tenant, "id": aC.ID},
+			bson.M{"$set": aC},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveAccountChargesDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColAch).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetSessionBackupsDrv(nodeID string) (sbs []*SessionBackup, err error) {
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(ColSbk).Find(sctx, bson.M{"nodeid": nodeID})
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var sb SessionBackup
+			if err = cur.Decode(&sb); err != nil {
+				return err
+			}
+			sbs = append(sbs, &sb)
+		}
+		if err = cur.Close(sctx); err != nil {
+			return err
+		}
+		if len(sbs) == 0 {
+			return utils.ErrNotFound
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetSessionBackupDrv(sb *SessionBackup) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColSbk).UpdateOne(sctx, bson.M{"nodeid": sb.NodeID, "cgrid": sb.CGRID},
+			bson.M{"$set": sb},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveSessionBackupDrv(nodeID, cgrID string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColSbk).DeleteOne(sctx, bson.M{"nodeid": nodeID, "cgrid": cgrID})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
 func (ms *MongoStorage) GetAttributeProfileDrv(tenant, id string) (r *AttributeProfile, err error) {
 	r = new(AttributeProfile)
 	err = ms.query(func(sctx mongo.SessionContext) (err error) {
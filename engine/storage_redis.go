@@ -66,6 +66,7 @@ const (
 	redis_HGET     = "HGET"
 	redis_RENAME   = "RENAME"
 	redis_HMSET    = "HMSET"
+	redis_PX       = "PX"
 )
 
 func NewRedisStorage(address string, db int, user, pass, mrshlerStr string,
@@ -1104,6 +1105,122 @@ func (rs *RedisStorage) RemoveRouteProfileDrv(tenant, id string) (err error) {
 	return rs.Cmd(nil, redis_DEL, utils.RouteProfilePrefix+utils.ConcatenatedKey(tenant, id))
 }
 
+func (rs *RedisStorage) GetRouteBreakerDrv(tenant, id string) (r *RouteBreaker, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.RouteBreakerPrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &r)
+	return
+}
+
+func (rs *RedisStorage) SetRouteBreakerDrv(r *RouteBreaker) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(r); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.RouteBreakerPrefix+utils.ConcatenatedKey(r.Tenant, r.ID), string(result))
+}
+
+func (rs *RedisStorage) RemoveRouteBreakerDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.RouteBreakerPrefix+utils.ConcatenatedKey(tenant, id))
+}
+
+func (rs *RedisStorage) GetSessionBackupsDrv(nodeID string) (sbs []*SessionBackup, err error) {
+	var keys []string
+	if keys, err = rs.GetKeysForPrefix(utils.SessionBackupPrefix + nodeID + utils.ConcatenatedKeySep); err != nil {
+		return
+	}
+	for _, key := range keys {
+		var values []byte
+		if err = rs.Cmd(&values, redis_GET, key); err != nil {
+			return
+		} else if len(values) == 0 { // removed in the meantime
+			continue
+		}
+		var sb *SessionBackup
+		if err = rs.ms.Unmarshal(values, &sb); err != nil {
+			return
+		}
+		sbs = append(sbs, sb)
+	}
+	if len(sbs) == 0 {
+		err = utils.ErrNotFound
+	}
+	return
+}
+
+func (rs *RedisStorage) SetSessionBackupDrv(sb *SessionBackup) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(sb); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.SessionBackupPrefix+sb.NodeCGRID(), string(result))
+}
+
+func (rs *RedisStorage) RemoveSessionBackupDrv(nodeID, cgrID string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.SessionBackupPrefix+utils.ConcatenatedKey(nodeID, cgrID))
+}
+
+func (rs *RedisStorage) GetInvoiceDrv(tenant, id string) (inv *Invoice, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.InvoicePrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &inv)
+	return
+}
+
+func (rs *RedisStorage) SetInvoiceDrv(inv *Invoice) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(inv); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.InvoicePrefix+utils.ConcatenatedKey(inv.Tenant, inv.ID), string(result))
+}
+
+func (rs *RedisStorage) RemoveInvoiceDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.InvoicePrefix+utils.ConcatenatedKey(tenant, id))
+}
+
+func (rs *RedisStorage) GetAccountChargesDrv(tenant, id string) (aC *utils.AccountCharges, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.AccountChargesPrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &aC)
+	return
+}
+
+func (rs *RedisStorage) SetAccountChargesDrv(aC *utils.AccountCharges) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(aC); err != nil {
+		return
+	}
+	if aC.ExpiryTime == nil {
+		return rs.Cmd(nil, redis_SET, utils.AccountChargesPrefix+aC.TenantID(), string(result))
+	}
+	ttl := time.Until(*aC.ExpiryTime)
+	if ttl <= 0 { // already expired, nothing to keep
+		return rs.RemoveAccountChargesDrv(aC.Tenant, aC.ID)
+	}
+	return rs.Cmd(nil, redis_SET, utils.AccountChargesPrefix+aC.TenantID(), string(result),
+		redis_PX, strconv.FormatInt(ttl.Milliseconds(), 10))
+}
+
+func (rs *RedisStorage) RemoveAccountChargesDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.AccountChargesPrefix+utils.ConcatenatedKey(tenant, id))
+}
+
 func (rs *RedisStorage) GetAttributeProfileDrv(tenant, id string) (r *AttributeProfile, err error) {
 	var values []byte
 	if err = rs.Cmd(&values, redis_GET, utils.AttributeProfilePrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
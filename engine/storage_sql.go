@@ -97,6 +97,20 @@ func (sqls *SQLStorage) CreateTablesFromScript(scriptPath string) error {
 	return nil
 }
 
+// timeField returns the time field as used in conditions and ordering
+// SQLite keeps the times as text so they are converted in order to not depend on the time zone
+func (sqls *SQLStorage) timeField(field string) string {
+	if sqls.db.Dialector.Name() == utils.SQLite {
+		return fmt.Sprintf("julianday(%s)", field)
+	}
+	return field
+}
+
+// timeQry returns the condition comparing the time field with the query parameter
+func (sqls *SQLStorage) timeQry(field, op string) string {
+	return fmt.Sprintf("%s %s %s", sqls.timeField(field), op, sqls.timeField("?"))
+}
+
 func (sqls *SQLStorage) IsDBEmpty() (resp bool, err error) {
 	tbls := []string{
 		utils.TBLTPTimings, utils.TBLTPDestinations, utils.TBLTPRates,
@@ -121,10 +135,9 @@ func (sqls *SQLStorage) IsDBEmpty() (resp bool, err error) {
 func (sqls *SQLStorage) GetTpIds(colName string) ([]string, error) {
 	var rows *sql.Rows
 	var err error
-	qryStr := fmt.Sprintf(" (SELECT tpid FROM %s)", colName)
+	tbls := []string{colName}
 	if colName == "" {
-		qryStr = fmt.Sprintf(
-			"(SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s)",
+		tbls = []string{
 			utils.TBLTPTimings,
 			utils.TBLTPDestinations,
 			utils.TBLTPRates,
@@ -145,9 +158,17 @@ func (sqls *SQLStorage) GetTpIds(colName string) ([]string, error) {
 			utils.TBLTPChargers,
 			utils.TBLTPDispatchers,
 			utils.TBLTPDispatcherHosts,
-		)
+		}
+	}
+	slctFmt := "(SELECT tpid FROM %s)"
+	if sqls.db.Dialector.Name() == utils.SQLite { // SQLite does not accept parenthesized selects
+		slctFmt = "SELECT tpid FROM %s"
+	}
+	slcts := make([]string, len(tbls))
+	for i, tbl := range tbls {
+		slcts[i] = fmt.Sprintf(slctFmt, tbl)
 	}
-	rows, err = sqls.Db.Query(qryStr)
+	rows, err = sqls.Db.Query(strings.Join(slcts, " UNION "))
 	if err != nil {
 		return nil, err
 	}
@@ -864,10 +885,10 @@ func (sqls *SQLStorage) RemoveSMCosts(qryFltr *utils.SMCostFilter) error {
 		q = q.Where("costsource not in (?)", qryFltr.NotCostSources)
 	}
 	if qryFltr.CreatedAt.Begin != nil {
-		q = q.Where("created_at >= ?", qryFltr.CreatedAt.Begin)
+		q = q.Where(sqls.timeQry("created_at", ">="), qryFltr.CreatedAt.Begin)
 	}
 	if qryFltr.CreatedAt.End != nil {
-		q = q.Where("created_at < ?", qryFltr.CreatedAt.End)
+		q = q.Where(sqls.timeQry("created_at", "<"), qryFltr.CreatedAt.End)
 	}
 	if qryFltr.Usage.Min != nil {
 		if sqls.db.Dialector.Name() == utils.MySQL { // MySQL needs escaping for usage
@@ -944,7 +965,8 @@ func (sqls *SQLStorage) SetCDR(cdr *CDR, allowUpdate bool) error {
 	if saved.Error != nil {
 		tx.Rollback()
 		if !allowUpdate {
-			if strings.Contains(saved.Error.Error(), "1062") || strings.Contains(saved.Error.Error(), "duplicate key") { // returns 1062/pq when key is duplicated
+			if strings.Contains(saved.Error.Error(), "1062") || strings.Contains(saved.Error.Error(), "duplicate key") ||
+				strings.Contains(saved.Error.Error(), "UNIQUE constraint failed") { // returns 1062/pq/sqlite when key is duplicated
 				return utils.ErrExists
 			}
 			return saved.Error
@@ -1002,10 +1024,10 @@ func (sqls *SQLStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR,
 		q = q.Where("origin_host not in (?)", qryFltr.NotOriginHosts)
 	}
 	if qryFltr.AnswerTimeStart != nil && !qryFltr.AnswerTimeStart.IsZero() { // With IsZero we keep backwards compatible with APIerSv1
-		q = q.Where("answer_time >= ?", qryFltr.AnswerTimeStart)
+		q = q.Where(sqls.timeQry("answer_time", ">="), qryFltr.AnswerTimeStart)
 	}
 	if qryFltr.AnswerTimeEnd != nil && !qryFltr.AnswerTimeEnd.IsZero() {
-		q = q.Where("answer_time < ?", qryFltr.AnswerTimeEnd)
+		q = q.Where(sqls.timeQry("answer_time", "<"), qryFltr.AnswerTimeEnd)
 	}
 	if len(qryFltr.Sources) != 0 {
 		q = q.Where("source in (?)", qryFltr.Sources)
@@ -1112,22 +1134,22 @@ func (sqls *SQLStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR,
 		q = q.Where(utils.CDRsTBL+".id < ?", *qryFltr.OrderIDEnd)
 	}
 	if qryFltr.SetupTimeStart != nil {
-		q = q.Where("setup_time >= ?", qryFltr.SetupTimeStart)
+		q = q.Where(sqls.timeQry("setup_time", ">="), qryFltr.SetupTimeStart)
 	}
 	if qryFltr.SetupTimeEnd != nil {
-		q = q.Where("setup_time < ?", qryFltr.SetupTimeEnd)
+		q = q.Where(sqls.timeQry("setup_time", "<"), qryFltr.SetupTimeEnd)
 	}
 	if qryFltr.CreatedAtStart != nil && !qryFltr.CreatedAtStart.IsZero() { // With IsZero we keep backwards compatible with APIerSv1
-		q = q.Where("created_at >= ?", qryFltr.CreatedAtStart)
+		q = q.Where(sqls.timeQry("created_at", ">="), qryFltr.CreatedAtStart)
 	}
 	if qryFltr.CreatedAtEnd != nil && !qryFltr.CreatedAtEnd.IsZero() {
-		q = q.Where("created_at < ?", qryFltr.CreatedAtEnd)
+		q = q.Where(sqls.timeQry("created_at", "<"), qryFltr.CreatedAtEnd)
 	}
 	if qryFltr.UpdatedAtStart != nil && !qryFltr.UpdatedAtStart.IsZero() { // With IsZero we keep backwards compatible with APIerSv1
-		q = q.Where("updated_at >= ?", qryFltr.UpdatedAtStart)
+		q = q.Where(sqls.timeQry("updated_at", ">="), qryFltr.UpdatedAtStart)
 	}
 	if qryFltr.UpdatedAtEnd != nil && !qryFltr.UpdatedAtEnd.IsZero() {
-		q = q.Where("updated_at < ?", qryFltr.UpdatedAtEnd)
+		q = q.Where(sqls.timeQry("updated_at", "<"), qryFltr.UpdatedAtEnd)
 	}
 	if qryFltr.OrderBy != "" {
 		var orderVal string
@@ -1136,9 +1158,9 @@ func (sqls *SQLStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR,
 		case utils.OrderID:
 			orderVal = "id"
 		case utils.AnswerTime:
-			orderVal = "answer_time"
+			orderVal = sqls.timeField("answer_time")
 		case utils.SetupTime:
-			orderVal = "setup_time"
+			orderVal = sqls.timeField("setup_time")
 		case utils.Usage:
 			if sqls.db.Dialector.Name() == utils.MySQL {
 				orderVal = "`usage`"
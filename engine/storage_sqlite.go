@@ -0,0 +1,105 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// NewSQLiteStorage returns the SQLite storDB stored in the file at path
+func NewSQLiteStorage(path string, maxConn, maxIdleConn, connMaxLifetime int) (*SQLStorage, error) {
+	// foreign keys are off by default in SQLite and the busy timeout avoids failing on concurrent writes
+	connectString := fmt.Sprintf("file:%s?_foreign_keys=1&_busy_timeout=5000", path)
+	db, err := gorm.Open(sqlite.Open(connectString), &gorm.Config{AllowGlobalUpdate: true})
+	if err != nil {
+		return nil, err
+	}
+	sqliteStorage := new(SQLiteStorage)
+	if sqliteStorage.Db, err = db.DB(); err != nil {
+		return nil, err
+	}
+	if err = sqliteStorage.Db.Ping(); err != nil {
+		return nil, err
+	}
+	sqliteStorage.Db.SetMaxIdleConns(maxIdleConn)
+	sqliteStorage.Db.SetMaxOpenConns(maxConn)
+	sqliteStorage.Db.SetConnMaxLifetime(time.Duration(connMaxLifetime) * time.Second)
+	sqliteStorage.db = db
+	return &SQLStorage{
+		Db:      sqliteStorage.Db,
+		db:      sqliteStorage.db,
+		StorDB:  sqliteStorage,
+		SQLImpl: sqliteStorage,
+	}, nil
+}
+
+// SQLiteStorage is the storDB kept in a SQLite database file
+type SQLiteStorage struct {
+	SQLStorage
+}
+
+// SetVersions will set a slice of versions, updating existing
+func (self *SQLiteStorage) SetVersions(vrs Versions, overwrite bool) (err error) {
+	tx := self.db.Begin()
+	if overwrite {
+		tx.Table(utils.TBLVersions).Delete(nil)
+	}
+	for key, val := range vrs {
+		vrModel := &TBLVersion{Item: key, Version: val}
+		if !overwrite {
+			if err = tx.Model(&TBLVersion{}).Where(
+				TBLVersion{Item: vrModel.Item}).Delete(TBLVersion{Version: val}).Error; err != nil {
+				tx.Rollback()
+				return
+			}
+		}
+		if err = tx.Save(vrModel).Error; err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+	tx.Commit()
+	return
+}
+
+// the extra fields are stored as JSON text so they are matched the same way as in MySQL
+func (self *SQLiteStorage) extraFieldsExistsQry(field string) string {
+	return fmt.Sprintf(" extra_fields LIKE '%%\"%s\":%%'", field)
+}
+
+func (self *SQLiteStorage) extraFieldsValueQry(field, value string) string {
+	return fmt.Sprintf(" extra_fields LIKE '%%\"%s\":\"%s\"%%'", field, value)
+}
+
+func (self *SQLiteStorage) notExtraFieldsExistsQry(field string) string {
+	return fmt.Sprintf(" extra_fields NOT LIKE '%%\"%s\":%%'", field)
+}
+
+func (self *SQLiteStorage) notExtraFieldsValueQry(field, value string) string {
+	return fmt.Sprintf(" extra_fields NOT LIKE '%%\"%s\":\"%s\"%%'", field, value)
+}
+
+func (self *SQLiteStorage) GetStorageType() string {
+	return utils.SQLite
+}
@@ -0,0 +1,144 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestSQLiteStorage(t *testing.T) {
+	sqlDB, err := NewSQLiteStorage(path.Join(t.TempDir(), "cgrates.db"), 10, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	if err = sqlDB.Flush(path.Join("..", "data", "storage", utils.SQLite)); err != nil {
+		t.Fatal(err)
+	}
+	if typ := sqlDB.GetStorageType(); typ != utils.SQLite {
+		t.Errorf("Expecting: %q, received: %q", utils.SQLite, typ)
+	}
+
+	vrs := CurrentStorDBVersions()
+	if err = sqlDB.SetVersions(vrs, true); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := sqlDB.GetVersions(utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(vrs, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", vrs, rcv)
+	}
+	if err = sqlDB.SetVersions(Versions{utils.CDRs: 1}, false); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := sqlDB.GetVersions(utils.CDRs); err != nil {
+		t.Error(err)
+	} else if rcv[utils.CDRs] != 1 {
+		t.Errorf("Expecting version 1, received: %+v", rcv)
+	}
+
+	if err = sqlDB.SetTPTimings([]*utils.ApierTPTiming{
+		{TPid: "TP1", ID: "ALWAYS", Years: utils.MetaAny, Months: utils.MetaAny,
+			MonthDays: utils.MetaAny, WeekDays: utils.MetaAny, Time: "00:00:00"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := sqlDB.GetTpIds(utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual([]string{"TP1"}, rcv) {
+		t.Errorf("Expecting: [TP1], received: %+v", rcv)
+	}
+
+	// the times are stored with different time zones
+	cet, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cdr1 := &CDR{CGRID: "CGRID1", RunID: utils.MetaDefault, OriginHost: "127.0.0.1", Source: "test",
+		OriginID: "orig1", ToR: utils.MetaVoice, RequestType: utils.MetaPrepaid, Tenant: "cgrates.org",
+		Category: "call", Account: "1001", Subject: "1001", Destination: "1002",
+		SetupTime:   time.Date(2020, 10, 10, 10, 0, 0, 0, time.UTC),
+		AnswerTime:  time.Date(2020, 10, 10, 10, 0, 5, 0, time.UTC),
+		Usage:       time.Minute,
+		ExtraFields: map[string]string{"Service": "premium"},
+		Cost:        1.5}
+	cdr2 := &CDR{CGRID: "CGRID2", RunID: utils.MetaDefault, OriginHost: "127.0.0.1", Source: "test",
+		OriginID: "orig2", ToR: utils.MetaVoice, RequestType: utils.MetaPostpaid, Tenant: "cgrates.org",
+		Category: "call", Account: "1002", Subject: "1002", Destination: "1001",
+		SetupTime:   time.Date(2020, 10, 10, 12, 30, 0, 0, cet),
+		AnswerTime:  time.Date(2020, 10, 10, 12, 30, 5, 0, cet),
+		Usage:       2 * time.Minute,
+		ExtraFields: map[string]string{"Service": "basic"},
+		Cost:        3}
+	for _, cdr := range []*CDR{cdr1, cdr2} {
+		if err = sqlDB.SetCDR(cdr, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = sqlDB.SetCDR(cdr1, false); err != utils.ErrExists {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrExists, err)
+	}
+
+	answerStart := time.Date(2020, 10, 10, 10, 20, 0, 0, time.UTC) // 12:20 in Berlin
+	answerEnd := time.Date(2020, 10, 10, 11, 0, 0, 0, time.UTC)    // 13:00 in Berlin
+	for _, tc := range []struct {
+		fltr *utils.CDRsFilter
+		exp  []string
+	}{
+		{&utils.CDRsFilter{}, []string{"CGRID1", "CGRID2"}},
+		{&utils.CDRsFilter{AnswerTimeStart: &answerStart}, []string{"CGRID2"}},
+		{&utils.CDRsFilter{AnswerTimeEnd: &answerStart}, []string{"CGRID1"}},
+		{&utils.CDRsFilter{AnswerTimeEnd: &answerEnd}, []string{"CGRID1", "CGRID2"}},
+		{&utils.CDRsFilter{ExtraFields: map[string]string{"Service": "premium"}}, []string{"CGRID1"}},
+		{&utils.CDRsFilter{NotExtraFields: map[string]string{"Service": "premium"}}, []string{"CGRID2"}},
+		{&utils.CDRsFilter{MinUsage: "90s"}, []string{"CGRID2"}},
+		{&utils.CDRsFilter{OrderBy: utils.AnswerTime + utils.InfieldSep + "desc"}, []string{"CGRID2", "CGRID1"}},
+		{&utils.CDRsFilter{RequestTypes: []string{utils.MetaPrepaid}}, []string{"CGRID1"}},
+		{&utils.CDRsFilter{DestinationPrefixes: []string{"100"}, OrderBy: utils.Cost + utils.InfieldSep + "desc"}, []string{"CGRID2", "CGRID1"}},
+	} {
+		cdrs, _, err := sqlDB.GetCDRs(tc.fltr, false)
+		if err != nil {
+			t.Errorf("filter: %s, received error: %v", utils.ToJSON(tc.fltr), err)
+			continue
+		}
+		rcv := make([]string, len(cdrs))
+		for i, cdr := range cdrs {
+			rcv[i] = cdr.CGRID
+		}
+		if !reflect.DeepEqual(tc.exp, rcv) {
+			t.Errorf("filter: %s, expecting: %+v, received: %+v", utils.ToJSON(tc.fltr), tc.exp, rcv)
+		}
+	}
+	if _, cnt, err := sqlDB.GetCDRs(&utils.CDRsFilter{Count: true, AnswerTimeStart: &answerStart}, false); err != nil {
+		t.Error(err)
+	} else if cnt != 1 {
+		t.Errorf("Expecting 1 CDR, received: %d", cnt)
+	}
+	if _, _, err = sqlDB.GetCDRs(&utils.CDRsFilter{CGRIDs: []string{"CGRID1"}}, true); err != nil {
+		t.Error(err)
+	}
+	if _, _, err = sqlDB.GetCDRs(&utils.CDRsFilter{CGRIDs: []string{"CGRID1"}}, false); err != utils.ErrNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
@@ -71,6 +71,13 @@ func NewDataDBConn(dbType, host, port, name, user,
 		}
 		d, err = NewMongoStorage(host, port, name, user, pass, marshaler, utils.DataDB, nil, ttl)
 	case utils.INTERNAL:
+		if dumpPath := utils.IfaceAsString(opts[utils.InternalDBDumpPathCfg]); dumpPath != utils.EmptyString {
+			var iDB *InternalDB
+			if iDB, err = NewPersistentInternalDB(nil, nil, true, dumpPath, opts); err != nil {
+				return
+			}
+			return iDB, nil
+		}
 		d = NewInternalDB(nil, nil, true)
 	default:
 		err = fmt.Errorf("unsupported db_type <%s>", dbType)
@@ -114,11 +121,30 @@ func NewStorDBConn(dbType, host, port, name, user, pass, marshaler string,
 			return
 		}
 		db, err = NewMySQLStorage(host, port, name, user, pass, int(maxConn), int(maxIdleConn), int(connMaxLifetime))
+	case utils.SQLite:
+		var maxConn, maxIdleConn, connMaxLifetime int64
+		if maxConn, err = utils.IfaceAsTInt64(opts[utils.MaxOpenConnsCfg]); err != nil {
+			return
+		}
+		if maxIdleConn, err = utils.IfaceAsTInt64(opts[utils.MaxIdleConnsCfg]); err != nil {
+			return
+		}
+		if connMaxLifetime, err = utils.IfaceAsTInt64(opts[utils.ConnMaxLifetimeCfg]); err != nil {
+			return
+		}
+		db, err = NewSQLiteStorage(name, int(maxConn), int(maxIdleConn), int(connMaxLifetime))
 	case utils.INTERNAL:
+		if dumpPath := utils.IfaceAsString(opts[utils.InternalDBDumpPathCfg]); dumpPath != utils.EmptyString {
+			var iDB *InternalDB
+			if iDB, err = NewPersistentInternalDB(stringIndexedFields, prefixIndexedFields, false, dumpPath, opts); err != nil {
+				return
+			}
+			return iDB, nil
+		}
 		db = NewInternalDB(stringIndexedFields, prefixIndexedFields, false)
 	default:
-		err = fmt.Errorf("unknown db '%s' valid options are [%s, %s, %s, %s]",
-			dbType, utils.MySQL, utils.Mongo, utils.Postgres, utils.INTERNAL)
+		err = fmt.Errorf("unknown db '%s' valid options are [%s, %s, %s, %s, %s]",
+			dbType, utils.MySQL, utils.Mongo, utils.Postgres, utils.SQLite, utils.INTERNAL)
 	}
 	return
 }
@@ -55,6 +55,8 @@ type ThresholdProfile struct {
 	Weight             float64 // Weight to sort the thresholds
 	ActionIDs          []string
 	Async              bool
+	RecoveryFilterIDs  []string // filters checked after the actions were executed, resetting the threshold when passing
+	RecoveryActionIDs  []string // actions executed when the threshold recovers
 }
 
 // TenantID returns the concatenated key beteen tenant and ID
@@ -62,6 +64,11 @@ func (tp *ThresholdProfile) TenantID() string {
 	return utils.ConcatenatedKey(tp.Tenant, tp.ID)
 }
 
+// hasRecovery returns true if the threshold waits for recovery after executing the actions
+func (tp *ThresholdProfile) hasRecovery() bool {
+	return len(tp.RecoveryFilterIDs) != 0
+}
+
 // ThresholdWithOpts is used in replicatorV1 for dispatcher
 type ThresholdWithOpts struct {
 	*Threshold
@@ -70,13 +77,15 @@ type ThresholdWithOpts struct {
 
 // Threshold is the unit matched by filters
 type Threshold struct {
-	Tenant string
-	ID     string
-	Hits   int       // number of hits for this threshold
-	Snooze time.Time // prevent threshold to run too early
-
-	tPrfl *ThresholdProfile
-	dirty *bool // needs save
+	Tenant    string
+	ID        string
+	Hits      int       // number of hits for this threshold
+	Snooze    time.Time // prevent threshold to run too early
+	Triggered bool      // the actions were executed and the threshold waits for recovery
+
+	tPrfl      *ThresholdProfile
+	dirty      *bool // needs save
+	recovering bool  // the event passed the recovery filters
 }
 
 // TenantID returns the concatenated key beteen tenant and ID
@@ -87,12 +96,29 @@ func (t *Threshold) TenantID() string {
 // ProcessEvent processes an ThresholdEvent
 // concurrentActions limits the number of simultaneous action sets executed
 func (t *Threshold) ProcessEvent(args *ThresholdsArgsProcessEvent, dm *DataManager) (err error) {
-	if t.Snooze.After(time.Now()) || // snoozed, not executing actions
+	if t.Triggered || // waiting for recovery
+		t.Snooze.After(time.Now()) || // snoozed, not executing actions
 		t.Hits < t.tPrfl.MinHits || // number of hits was not met, will not execute actions
 		(t.tPrfl.MaxHits != -1 &&
 			t.Hits > t.tPrfl.MaxHits) {
 		return
 	}
+	if t.tPrfl.hasRecovery() {
+		t.Triggered = true
+	}
+	return t.executeActions(t.tPrfl.ActionIDs, args)
+}
+
+// recover resets the threshold and executes the recovery actions
+func (t *Threshold) recover(args *ThresholdsArgsProcessEvent) (err error) {
+	t.Hits = 0
+	t.Snooze = time.Time{}
+	t.Triggered = false
+	return t.executeActions(t.tPrfl.RecoveryActionIDs, args)
+}
+
+// executeActions executes the action sets on behalf of the account within the event
+func (t *Threshold) executeActions(actionIDs []string, args *ThresholdsArgsProcessEvent) (err error) {
 	var tntAcnt string
 	var acnt string
 	if utils.IfaceAsString(args.Opts[utils.MetaEventType]) == utils.AccountUpdate {
@@ -104,7 +130,7 @@ func (t *Threshold) ProcessEvent(args *ThresholdsArgsProcessEvent, dm *DataManag
 		tntAcnt = utils.ConcatenatedKey(args.Tenant, acnt)
 	}
 
-	for _, actionSetID := range t.tPrfl.ActionIDs {
+	for _, actionSetID := range actionIDs {
 		at := &ActionTiming{
 			Uuid:      utils.GenUUID(),
 			ActionsID: actionSetID,
@@ -260,10 +286,10 @@ func (tS *ThresholdService) matchingThresholdsForEvent(tnt string, args *Thresho
 			!tPrfl.ActivationInterval.IsActiveAtTime(*args.Time) { // not active
 			continue
 		}
-		if pass, err := tS.filterS.Pass(tnt, tPrfl.FilterIDs,
-			evNm); err != nil {
+		pass, err := tS.filterS.Pass(tnt, tPrfl.FilterIDs, evNm)
+		if err != nil {
 			return nil, err
-		} else if !pass {
+		} else if !pass && !tPrfl.hasRecovery() {
 			continue
 		}
 		t, err := tS.dm.GetThreshold(tPrfl.Tenant, tPrfl.ID, true, true, "")
@@ -273,10 +299,22 @@ func (tS *ThresholdService) matchingThresholdsForEvent(tnt string, args *Thresho
 			}
 			return nil, err
 		}
-		if t.dirty == nil || tPrfl.MaxHits == -1 || t.Hits < tPrfl.MaxHits {
+		var recovering bool
+		if !pass { // only the triggered thresholds can recover
+			if !t.Triggered {
+				continue
+			}
+			if recovering, err = tS.filterS.Pass(tnt, tPrfl.RecoveryFilterIDs, evNm); err != nil {
+				return nil, err
+			} else if !recovering {
+				continue
+			}
+		}
+		if t.dirty == nil || tPrfl.MaxHits == -1 || t.Hits < tPrfl.MaxHits || tPrfl.hasRecovery() {
 			t.dirty = utils.BoolPointer(false)
 		}
 		t.tPrfl = tPrfl
+		t.recovering = recovering
 		ts = append(ts, t)
 	}
 	// All good, convert from Map to Slice so we can sort
@@ -338,6 +376,23 @@ func (tS *ThresholdService) processEvent(tnt string, args *ThresholdsArgsProcess
 	var tIDs []string
 	for _, t := range matchTs {
 		tIDs = append(tIDs, t.ID)
+		if t.recovering {
+			if err = t.recover(args); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<ThresholdService> threshold: %s, recovering on event: %s, error: %s",
+						t.TenantID(), utils.ConcatenatedKey(tnt, args.CGREvent.ID), err.Error()))
+				withErrors = true
+			}
+			*t.dirty = true // mark it to be saved
+			if tS.cgrcfg.ThresholdSCfg().StoreInterval == -1 {
+				tS.StoreThreshold(t)
+			} else {
+				tS.stMux.Lock()
+				tS.storedTdIDs.Add(t.TenantID())
+				tS.stMux.Unlock()
+			}
+			continue
+		}
 		t.Hits++
 		err = t.ProcessEvent(args, tS.dm)
 		if err != nil {
@@ -347,7 +402,7 @@ func (tS *ThresholdService) processEvent(tnt string, args *ThresholdsArgsProcess
 			withErrors = true
 			continue
 		}
-		if t.dirty == nil || t.Hits == t.tPrfl.MaxHits { // one time threshold
+		if t.dirty == nil || (t.Hits == t.tPrfl.MaxHits && !t.Triggered) { // one time threshold
 			if err = tS.dm.RemoveThreshold(t.Tenant, t.ID, utils.NonTransactional); err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<ThresholdService> failed removing from database non-recurrent threshold: %s, error: %s",
@@ -1068,3 +1068,74 @@ func TestThresholdsProcessEvent2(t *testing.T) {
 		}
 	}
 }
+
+func TestThresholdsProcessEventRecovery(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.ThresholdSCfg().StoreInterval = -1
+	dm := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	thS := NewThresholdService(dm, cfg, NewFilterS(cfg, nil, dm))
+	if err := dm.SetActions("ACT_LOG", Actions{{Id: "ACT_LOG", ActionType: utils.MetaLog}},
+		utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	tPrfl := &ThresholdProfile{
+		Tenant:            "cgrates.org",
+		ID:                "TH_RECOVERY",
+		FilterIDs:         []string{"*gte:~*req.Load:10"},
+		MaxHits:           1,
+		MinHits:           1,
+		ActionIDs:         []string{"ACT_LOG"},
+		RecoveryFilterIDs: []string{"*lt:~*req.Load:5"},
+		RecoveryActionIDs: []string{"ACT_LOG"},
+	}
+	if err := dm.SetThresholdProfile(tPrfl, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetThreshold(&Threshold{Tenant: "cgrates.org", ID: "TH_RECOVERY"}, 0, false); err != nil {
+		t.Fatal(err)
+	}
+	procEv := func(load int) []string {
+		ids, err := thS.processEvent("cgrates.org", &ThresholdsArgsProcessEvent{
+			ThresholdIDs: []string{"TH_RECOVERY"},
+			CGREvent: &utils.CGREvent{
+				Tenant: "cgrates.org",
+				ID:     utils.GenUUID(),
+				Event:  map[string]interface{}{"Load": load},
+			},
+		})
+		if err != nil && err != utils.ErrNotFound {
+			t.Fatal(err)
+		}
+		return ids
+	}
+	getTh := func() *Threshold {
+		th, err := dm.GetThreshold("cgrates.org", "TH_RECOVERY", false, false, utils.NonTransactional)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return th
+	}
+	// not triggered yet, the recovery is ignored
+	if ids := procEv(1); len(ids) != 0 {
+		t.Errorf("Unexpected thresholds: %+v", ids)
+	}
+	procEv(20)
+	if th := getTh(); th.Hits != 1 || !th.Triggered {
+		t.Errorf("Unexpected threshold: %s", utils.ToJSON(th))
+	}
+	// not removed after MaxHits while waiting for recovery
+	procEv(20)
+	if th := getTh(); th.Hits != 2 || !th.Triggered {
+		t.Errorf("Unexpected threshold: %s", utils.ToJSON(th))
+	}
+	// the hysteresis band does not match any of the filters
+	if ids := procEv(7); len(ids) != 0 {
+		t.Errorf("Unexpected thresholds: %+v", ids)
+	}
+	if ids := procEv(2); !reflect.DeepEqual([]string{"TH_RECOVERY"}, ids) {
+		t.Errorf("Unexpected thresholds: %+v", ids)
+	}
+	if th := getTh(); th.Hits != 0 || th.Triggered || !th.Snooze.IsZero() {
+		t.Errorf("Unexpected threshold: %s", utils.ToJSON(th))
+	}
+}
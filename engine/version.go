@@ -127,7 +127,7 @@ func (vers Versions) Compare(curent Versions, storType string, isDataDB bool) st
 		}
 	case utils.INTERNAL:
 		message = allVers
-	case utils.Postgres, utils.MySQL:
+	case utils.Postgres, utils.MySQL, utils.SQLite:
 		message = storDBVers
 	case utils.Redis:
 		message = dataDBVers
@@ -224,7 +224,7 @@ func CurrentDBVersions(storType string, isDataDB bool) Versions {
 		return CurrentStorDBVersions()
 	case utils.INTERNAL:
 		return CurrentAllDBVersions()
-	case utils.Postgres, utils.MySQL:
+	case utils.Postgres, utils.MySQL, utils.SQLite:
 		return CurrentStorDBVersions()
 	case utils.Redis:
 		return CurrentDataDBVersions()
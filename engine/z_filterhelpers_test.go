@@ -212,3 +212,34 @@ func TestFilterMatchingItemIDsForEvent2(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", prefixFilterID, aPrflIDs)
 	}
 }
+
+func TestFilterMatchingItemIDsForEventRegex(t *testing.T) {
+	dmRegex := NewDataManager(NewInternalDB(nil, nil, true), config.CgrConfig().CacheCfg(), nil)
+	Cache.Clear(nil)
+	tnt := config.CgrConfig().GeneralCfg().DefaultTenant
+	rgxFltr, err := NewFilterRule(utils.MetaRegex, "~*req.Destination", []string{`^\+4917\d+$`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dmRegex.SetFilter(&Filter{Tenant: tnt, ID: "regexFilter",
+		Rules: []*FilterRule{rgxFltr}}, true); err != nil {
+		t.Fatal(err)
+	}
+	if err = addItemToFilterIndex(dmRegex, utils.CacheAttributeFilterIndexes,
+		tnt, utils.MetaAny, "regexProfile", []string{"regexFilter"}); err != nil {
+		t.Fatal(err)
+	}
+	tntCtx := utils.ConcatenatedKey(tnt, utils.MetaAny)
+	if aPrflIDs, err := MatchingItemIDsForEvent(utils.MapStorage{utils.MetaReq: map[string]interface{}{
+		utils.Destination: "+4917612345",
+	}}, nil, nil, nil, dmRegex, utils.CacheAttributeFilterIndexes, tntCtx, true, false); err != nil {
+		t.Error(err)
+	} else if !aPrflIDs.Has("regexProfile") {
+		t.Errorf("Expecting: %+v, received: %+v", "regexProfile", aPrflIDs)
+	}
+	if _, err := MatchingItemIDsForEvent(utils.MapStorage{utils.MetaReq: map[string]interface{}{
+		utils.Destination: "+4915112345",
+	}}, nil, nil, nil, dmRegex, utils.CacheAttributeFilterIndexes, tntCtx, true, false); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+}
@@ -2820,3 +2820,119 @@ func TestResourceCaching(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", resources[0].ttl, mres[0].ttl)
 	}
 }
+
+func TestResourceRateLimitAllocate(t *testing.T) {
+	r := &Resource{
+		Tenant: "cgrates.org",
+		ID:     "RL_RATE",
+		Usages: make(map[string]*ResourceUsage),
+		rPrf: &ResourceProfile{
+			Tenant:            "cgrates.org",
+			ID:                "RL_RATE",
+			Limit:             2,
+			RateWindow:        time.Second,
+			Burst:             3,
+			AllocationMessage: "RATE",
+		},
+	}
+	rs := Resources{r}
+	ru := &ResourceUsage{Tenant: "cgrates.org", ID: "RU_1", Units: 1}
+	// the bucket starts full so the burst can be allocated at once
+	for i := 0; i < 3; i++ {
+		if alcMsg, err := rs.allocateResource(ru, false); err != nil {
+			t.Fatal(err)
+		} else if alcMsg != "RATE" {
+			t.Errorf("Wrong allocation message: %v", alcMsg)
+		}
+	}
+	if len(r.Usages) != 0 {
+		t.Errorf("Expected no usages recorded for the rate limited resources, received: %s", utils.ToJSON(r.Usages))
+	}
+	if _, err := rs.allocateResource(ru, true); err != utils.ErrResourceUnavailable {
+		t.Errorf("Expected %v, received %v", utils.ErrResourceUnavailable, err)
+	}
+	rl := rs.rateLimits()["RL_RATE"]
+	if rl == nil || rl.Remaining >= 1 {
+		t.Fatalf("Unexpected rate limit: %s", utils.ToJSON(rl))
+	}
+	if resetIn := time.Until(rl.ResetTime); resetIn <= time.Second || resetIn > 1500*time.Millisecond {
+		t.Errorf("Expected the bucket to be refilled in 1.5s, received: %v", resetIn)
+	}
+	// half of the window passing gives back one unit
+	r.Bucket.Updated = r.Bucket.Updated.Add(-500 * time.Millisecond)
+	if _, err := rs.allocateResource(ru, true); err != nil {
+		t.Error(err)
+	}
+	if err := rs.clearUsage(ru.ID); err != nil {
+		t.Error(err)
+	}
+	// the tokens are never refilled over the burst
+	r.Bucket.Updated = r.Bucket.Updated.Add(-time.Hour)
+	if rl := rs.rateLimits()["RL_RATE"]; rl.Remaining != 3 {
+		t.Errorf("Expected 3 units remaining, received: %v", rl.Remaining)
+	}
+	if avail := (&ResourceWithConfig{Resource: r, Config: r.rPrf}).Available(); avail != 3 {
+		t.Errorf("Expected 3 units available, received: %v", avail)
+	}
+}
+
+func TestResourceV1AllocateResourcesWithLimits(t *testing.T) {
+	defaultCfg := config.NewDefaultCGRConfig()
+	defaultCfg.ResourceSCfg().StoreInterval = 1
+	defaultCfg.ResourceSCfg().StringIndexedFields = nil
+	defaultCfg.ResourceSCfg().PrefixIndexedFields = nil
+	data := NewInternalDB(nil, nil, true)
+	dmRES := NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	Cache.Clear(nil)
+	resService := NewResourceService(dmRES, defaultCfg,
+		&FilterS{dm: dmRES, cfg: defaultCfg}, nil)
+	rPrf := &ResourceProfile{
+		Tenant:     "cgrates.org",
+		ID:         "RES_RATE_1001",
+		FilterIDs:  []string{"*string:~*req.Account:1001"},
+		UsageTTL:   -1,
+		Limit:      1,
+		RateWindow: time.Hour,
+		Weight:     10,
+	}
+	if err := dmRES.SetResourceProfile(rPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := dmRES.SetResource(&Resource{Tenant: rPrf.Tenant, ID: rPrf.ID,
+		Usages: make(map[string]*ResourceUsage)}, nil, 0, true); err != nil {
+		t.Fatal(err)
+	}
+	args := utils.ArgRSv1ResourceUsage{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "RateEv",
+			Event:  map[string]interface{}{utils.AccountField: "1001"},
+		},
+		UsageID: "RU_RATE",
+		Units:   1,
+	}
+	var reply ResourceAllocationReply
+	if err := resService.V1AuthorizeResourcesWithLimits(args, &reply); err != nil {
+		t.Fatal(err)
+	} else if !reply.Authorized || reply.AllocationMessage != "RES_RATE_1001" ||
+		reply.RateLimits["RES_RATE_1001"] == nil || reply.RateLimits["RES_RATE_1001"].Remaining != 1 {
+		t.Errorf("Unexpected reply: %s", utils.ToJSON(reply))
+	}
+	if err := resService.V1AllocateResourcesWithLimits(args, &reply); err != nil {
+		t.Fatal(err)
+	} else if !reply.Authorized || reply.RateLimits["RES_RATE_1001"].Remaining >= 0.001 {
+		t.Errorf("Unexpected reply: %s", utils.ToJSON(reply))
+	}
+	var alcMsg string
+	if err := resService.V1AllocateResource(args, &alcMsg); err != utils.ErrResourceUnavailable {
+		t.Errorf("Expected %v, received %v", utils.ErrResourceUnavailable, err)
+	}
+	args.CGREvent.ID = "RateEv2" // avoid the RPC cache
+	if err := resService.V1AllocateResourcesWithLimits(args, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply.Authorized || reply.AllocationMessage != utils.EmptyString {
+		t.Errorf("Unexpected reply: %s", utils.ToJSON(reply))
+	} else if resetIn := time.Until(reply.RateLimits["RES_RATE_1001"].ResetTime); resetIn <= 59*time.Minute {
+		t.Errorf("Expected the reset in one hour, received: %v", resetIn)
+	}
+}
@@ -26,12 +26,34 @@ import (
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+var (
+	processedEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: utils.MetricsNamespace,
+		Subsystem: "ers",
+		Name:      "processed_events_total",
+		Help:      "Number of events successfully processed, per reader",
+	}, []string{utils.MetricsLabelReader})
+	failedEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: utils.MetricsNamespace,
+		Subsystem: "ers",
+		Name:      "failed_events_total",
+		Help:      "Number of events failing the processing, per reader",
+	}, []string{utils.MetricsLabelReader})
+)
+
+// MetricsCollectors returns the metrics recorded by ERs, registered by the PrometheusAgent
+func MetricsCollectors() []prometheus.Collector {
+	return []prometheus.Collector{processedEvents, failedEvents}
+}
+
 // erEvent is passed from reader to ERs
 type erEvent struct {
-	cgrEvent *utils.CGREvent
-	rdrCfg   *config.EventReaderCfg
+	cgrEvent  *utils.CGREvent
+	rdrCfg    *config.EventReaderCfg
+	processed chan error // optional, receives the processing result
 }
 
 // NewERService instantiates the ERService
@@ -87,10 +109,17 @@ func (erS *ERService) ListenAndServe(stopChan, cfgRldChan chan struct{}) (err er
 			erS.closeAllRdrs()
 			return
 		case erEv := <-erS.rdrEvents:
-			if err := erS.processEvent(erEv.cgrEvent, erEv.rdrCfg); err != nil {
+			evErr := erS.processEvent(erEv.cgrEvent, erEv.rdrCfg)
+			if evErr == nil {
+				processedEvents.WithLabelValues(erEv.rdrCfg.ID).Inc()
+			} else {
+				failedEvents.WithLabelValues(erEv.rdrCfg.ID).Inc()
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> reading event: <%s> got error: <%s>",
-						utils.ERs, utils.ToIJSON(erEv.cgrEvent), err.Error()))
+						utils.ERs, utils.ToIJSON(erEv.cgrEvent), evErr.Error()))
+			}
+			if erEv.processed != nil {
+				erEv.processed <- evErr
 			}
 		case <-cfgRldChan: // handle reload
 			cfgIDs := make(map[string]int)
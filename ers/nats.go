@@ -0,0 +1,284 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/nats-io/nats.go"
+)
+
+// NewNATSER return a new nats event reader
+func NewNATSER(cfg *config.CGRConfig, cfgIdx int,
+	rdrEvents chan *erEvent, rdrErr chan error,
+	fltrS *engine.FilterS, rdrExit chan struct{}) (er EventReader, err error) {
+	rdr := &NATSER{
+		cgrCfg:    cfg,
+		cfgIdx:    cfgIdx,
+		fltrS:     fltrS,
+		rdrEvents: rdrEvents,
+		rdrExit:   rdrExit,
+		rdrErr:    rdrErr,
+	}
+	if concReq := rdr.Config().ConcurrentReqs; concReq != -1 {
+		rdr.cap = make(chan struct{}, concReq)
+		for i := 0; i < concReq; i++ {
+			rdr.cap <- struct{}{}
+		}
+	}
+	rdr.dialURL = rdr.Config().SourcePath
+	if err = rdr.setOpts(rdr.Config().Opts); err != nil {
+		return
+	}
+	rdr.createPoster()
+	return rdr, nil
+}
+
+// NATSER implements EventReader interface for nats message
+type NATSER struct {
+	// sync.RWMutex
+	cgrCfg *config.CGRConfig
+	cfgIdx int // index of config instance within ERsCfg.Readers
+	fltrS  *engine.FilterS
+
+	dialURL      string
+	subject      string
+	queueID      string // queue group, empty for a plain subscription
+	jetStream    bool   // consume through a JetStream durable consumer
+	consumerName string // name of the JetStream durable consumer
+
+	deadLetterSubject string // subject receiving the messages failing the processing, empty to disable it
+
+	rdrEvents chan *erEvent // channel to dispatch the events created to
+	rdrExit   chan struct{}
+	rdrErr    chan error
+	cap       chan struct{}
+
+	conn *nats.Conn
+
+	poster engine.Poster
+}
+
+// Config returns the curent configuration
+func (rdr *NATSER) Config() *config.EventReaderCfg {
+	return rdr.cgrCfg.ERsCfg().Readers[rdr.cfgIdx]
+}
+
+// Serve will subscribe to the nats subject and start processing the messages
+func (rdr *NATSER) Serve() (err error) {
+	if rdr.conn, err = nats.Connect(rdr.dialURL); err != nil {
+		return
+	}
+	if rdr.Config().RunDelay != time.Duration(0) { // 0 disables the automatic read, maybe done per API
+		if !rdr.jetStream {
+			_, err = rdr.conn.QueueSubscribe(rdr.subject, rdr.queueID, rdr.handleMessage)
+		} else {
+			var js nats.JetStreamContext
+			if js, err = rdr.conn.JetStream(); err == nil {
+				_, err = js.QueueSubscribe(rdr.subject, rdr.queueID, rdr.handleMessage,
+					nats.Durable(rdr.consumerName), nats.ManualAck())
+			}
+		}
+		if err != nil {
+			rdr.conn.Close()
+			return
+		}
+	}
+	go func() {
+		<-rdr.rdrExit
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> stop monitoring nats path <%s>",
+				utils.ERs, rdr.dialURL))
+		rdr.close()
+	}()
+	return
+}
+
+// handleMessage is called by the nats library for each message received on the subject
+func (rdr *NATSER) handleMessage(msg *nats.Msg) {
+	if rdr.Config().ConcurrentReqs != -1 {
+		<-rdr.cap // do not try to read if the limit is reached
+	}
+	go func(msg *nats.Msg) {
+		if err := rdr.processMessage(msg.Data); err == utils.ErrDisconnected {
+			if rdr.jetStream { // let the server redeliver it once we are back
+				rdr.ack(msg, msg.Nak)
+			}
+			rdr.release()
+			return
+		} else if err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> processing message from subject %s error: %s",
+					utils.ERs, msg.Subject, err.Error()))
+			rdr.handleFailedMessage(msg)
+			rdr.release()
+			return
+		}
+		if rdr.jetStream {
+			rdr.ack(msg, msg.Ack)
+		}
+		if rdr.poster != nil { // post it
+			if err := rdr.poster.Post(msg.Data, utils.EmptyString); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> writing message from subject %s error: %s",
+						utils.ERs, msg.Subject, err.Error()))
+			}
+		}
+		rdr.release()
+	}(msg)
+}
+
+// handleFailedMessage sends the message failing the processing to the failed posts and to the dead letter subject
+// if none of them kept the message the server is asked to redeliver it
+func (rdr *NATSER) handleFailedMessage(msg *nats.Msg) {
+	var kept bool
+	if rdr.cgrCfg.GeneralCfg().FailedPostsDir != utils.MetaNone {
+		engine.AddFailedPost(rdr.dialURL, utils.MetaNATSjsonMap, utils.ERs,
+			msg.Data, rdr.Config().Opts)
+		kept = true
+	}
+	if rdr.deadLetter(msg) {
+		kept = true
+	}
+	if !rdr.jetStream {
+		return
+	}
+	if !kept {
+		rdr.ack(msg, msg.Nak)
+		return
+	}
+	rdr.ack(msg, msg.Term) // kept on our side so do not let the server redeliver it
+}
+
+// deadLetter publishes the message failing the processing on the dead letter subject
+// returns true if the message was published
+func (rdr *NATSER) deadLetter(msg *nats.Msg) bool {
+	if rdr.deadLetterSubject == utils.EmptyString {
+		return false
+	}
+	if err := rdr.conn.Publish(rdr.deadLetterSubject, msg.Data); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> publishing message from subject %s to dead letter subject %s error: %s",
+				utils.ERs, msg.Subject, rdr.deadLetterSubject, err.Error()))
+		return false
+	}
+	return true
+}
+
+func (rdr *NATSER) ack(msg *nats.Msg, ackFunc func(...nats.AckOpt) error) {
+	if err := ackFunc(); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> acknowledging message from subject %s error: %s",
+				utils.ERs, msg.Subject, err.Error()))
+	}
+}
+
+func (rdr *NATSER) release() {
+	if rdr.Config().ConcurrentReqs != -1 {
+		rdr.cap <- struct{}{}
+	}
+}
+
+// processMessage blocks until ERs has processed the event so the message is acknowledged only afterwards
+// returns utils.ErrDisconnected if the reader is stopped in the meantime
+func (rdr *NATSER) processMessage(msg []byte) (err error) {
+	var decodedMessage map[string]interface{}
+	if err = json.Unmarshal(msg, &decodedMessage); err != nil {
+		return
+	}
+	agReq := agents.NewAgentRequest(
+		utils.MapStorage(decodedMessage), nil,
+		nil, nil, nil, rdr.Config().Tenant,
+		rdr.cgrCfg.GeneralCfg().DefaultTenant,
+		utils.FirstNonEmpty(rdr.Config().Timezone,
+			rdr.cgrCfg.GeneralCfg().DefaultTimezone),
+		rdr.fltrS, nil, nil) // create an AgentRequest
+	var pass bool
+	if pass, err = rdr.fltrS.Pass(agReq.Tenant, rdr.Config().Filters,
+		agReq); err != nil || !pass {
+		return
+	}
+	if err = agReq.SetFields(rdr.Config().Fields); err != nil {
+		return
+	}
+	cgrEv := config.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
+	processed := make(chan error, 1)
+	select {
+	case rdr.rdrEvents <- &erEvent{
+		cgrEvent:  cgrEv,
+		rdrCfg:    rdr.Config(),
+		processed: processed,
+	}:
+	case <-rdr.rdrExit: // nobody reads the events anymore
+		return utils.ErrDisconnected
+	}
+	select {
+	case err = <-processed:
+		return
+	case <-rdr.rdrExit:
+		return utils.ErrDisconnected
+	}
+}
+
+func (rdr *NATSER) setOpts(opts map[string]interface{}) (err error) {
+	rdr.subject = utils.NATSDefaultSubject
+	if vals, has := opts[utils.NATSSubject]; has {
+		rdr.subject = utils.IfaceAsString(vals)
+	}
+	if vals, has := opts[utils.NATSQueueID]; has {
+		rdr.queueID = utils.IfaceAsString(vals)
+	}
+	if vals, has := opts[utils.NATSJetStream]; has {
+		if rdr.jetStream, err = utils.IfaceAsBool(vals); err != nil {
+			return
+		}
+	}
+	rdr.consumerName = utils.NATSDefaultConsumerName
+	if vals, has := opts[utils.NATSConsumerName]; has {
+		rdr.consumerName = utils.IfaceAsString(vals)
+	}
+	if vals, has := opts[utils.NATSDeadLetterSubject]; has {
+		rdr.deadLetterSubject = utils.IfaceAsString(vals)
+	}
+	return
+}
+
+// close drains the subscriptions, keeping the durable consumers, and closes the connection
+func (rdr *NATSER) close() (err error) {
+	if rdr.poster != nil {
+		rdr.poster.Close()
+	}
+	return rdr.conn.Drain()
+}
+
+func (rdr *NATSER) createPoster() {
+	processedOpt := getProcessOptions(rdr.Config().Opts)
+	if len(processedOpt) == 0 &&
+		len(rdr.Config().ProcessedPath) == 0 {
+		return
+	}
+	rdr.poster = engine.NewNATSPoster(utils.FirstNonEmpty(rdr.Config().ProcessedPath, rdr.Config().SourcePath),
+		rdr.cgrCfg.GeneralCfg().PosterAttempts, processedOpt)
+}
@@ -0,0 +1,372 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ers
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+func TestNATSERSetOpts(t *testing.T) {
+	rdr := new(NATSER)
+	if err := rdr.setOpts(map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+	exp := &NATSER{
+		subject:      utils.NATSDefaultSubject,
+		consumerName: utils.NATSDefaultConsumerName,
+	}
+	if !reflect.DeepEqual(exp, rdr) {
+		t.Errorf("Expected %+v, received %+v", exp, rdr)
+	}
+	rdr = new(NATSER)
+	if err := rdr.setOpts(map[string]interface{}{
+		utils.NATSSubject:           "cdrs",
+		utils.NATSQueueID:           "cgrates_group",
+		utils.NATSJetStream:         "true",
+		utils.NATSConsumerName:      "cgrates_durable",
+		utils.NATSDeadLetterSubject: "cdrs_failed",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	exp = &NATSER{
+		subject:           "cdrs",
+		queueID:           "cgrates_group",
+		jetStream:         true,
+		consumerName:      "cgrates_durable",
+		deadLetterSubject: "cdrs_failed",
+	}
+	if !reflect.DeepEqual(exp, rdr) {
+		t.Errorf("Expected %+v, received %+v", exp, rdr)
+	}
+	if err := new(NATSER).setOpts(map[string]interface{}{
+		utils.NATSJetStream: "notABool",
+	}); err == nil {
+		t.Error("Expected error for invalid natsJetStream option")
+	}
+}
+
+func runEmbeddedNATS(t *testing.T) *server.Server {
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Start()
+	if !srv.ReadyForConnections(5 * time.Second) {
+		t.Fatal("embedded nats server not ready")
+	}
+	return srv
+}
+
+// newJetStreamNATSER starts a reader consuming the cgrates_cdrs subject through the cgrates_ers durable consumer
+func newJetStreamNATSER(t *testing.T, srvURL, failedPostsDir, extraOpts string) (js nats.JetStreamContext,
+	rdrEvs chan *erEvent, pstr engine.Poster, stop func()) {
+	nc, err := nats.Connect(srvURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if js, err = nc.JetStream(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = js.AddStream(&nats.StreamConfig{
+		Name:     "CGRATES",
+		Subjects: []string{"cgrates_cdrs"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.NewCGRConfigFromJSONStringWithDefaults(fmt.Sprintf(`{
+"general": {
+	"failed_posts_dir": "%s",
+},
+"ers": {
+	"enabled": true,
+	"readers": [
+		{
+			"id": "nats",
+			"type": "*nats_json_map",
+			"run_delay": "-1",
+			"concurrent_requests": 1024,
+			"source_path": "%s",
+			"processed_path": "",
+			"opts": {
+				"natsJetStream": true,
+				"natsConsumerName": "cgrates_ers",
+				"natsQueueID": "cgrates",%s
+			},
+			"tenant": "cgrates.org",
+			"fields":[
+				{"tag": "CGRID", "type": "*composed", "value": "~*req.CGRID", "path": "*cgreq.CGRID"},
+			],
+		},
+	],
+},
+}`, failedPostsDir, srvURL, extraOpts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rdrEvs = make(chan *erEvent, 1)
+	rdrExt := make(chan struct{})
+	rdr, err := NewNATSER(cfg, 1, rdrEvs, make(chan error, 1),
+		engine.NewFilterS(cfg, nil, nil), rdrExt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = rdr.Serve(); err != nil {
+		t.Fatal(err)
+	}
+	pstr = engine.NewNATSPoster(srvURL, 1, map[string]interface{}{
+		utils.NATSJetStream: true,
+	})
+	stop = func() {
+		pstr.Close()
+		close(rdrExt)
+		nc.Close()
+	}
+	return
+}
+
+// receiveNATSEvent waits for the event with the given CGRID and replies with the processing error
+func receiveNATSEvent(t *testing.T, rdrEvs chan *erEvent, cgrID string, procErr error) {
+	select {
+	case ev := <-rdrEvs:
+		if ev.rdrCfg.ID != "nats" {
+			t.Errorf("Expected 'nats' received `%s`", ev.rdrCfg.ID)
+		}
+		if rcv := ev.cgrEvent.Event[utils.CGRID]; rcv != cgrID {
+			t.Errorf("Expected %q, received %q", cgrID, rcv)
+		}
+		ev.processed <- procErr
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout")
+	}
+}
+
+// waitNATSAcks waits for the consumer to have no pending acknowledgements
+func waitNATSAcks(t *testing.T, js nats.JetStreamContext) (info *nats.ConsumerInfo) {
+	var err error
+	for i := 0; i < 50; i++ {
+		if info, err = js.ConsumerInfo("CGRATES", "cgrates_ers"); err != nil {
+			t.Fatal(err)
+		}
+		if info.NumAckPending == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return
+}
+
+func TestNATSERProcessMessageExit(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.ERsCfg().Readers[0].Fields = nil
+	rdrEvs := make(chan *erEvent) // nobody reads the events
+	rdrExt := make(chan struct{})
+	rdr := &NATSER{
+		cgrCfg:    cfg,
+		fltrS:     engine.NewFilterS(cfg, nil, nil),
+		rdrEvents: rdrEvs,
+		rdrExit:   rdrExt,
+	}
+	close(rdrExt)
+	if err := rdr.processMessage([]byte(`{"CGRID":"1"}`)); err != utils.ErrDisconnected {
+		t.Errorf("Expected %v, received %v", utils.ErrDisconnected, err)
+	}
+	// the event was sent but the reader stopped before it was processed
+	rdrEvs = make(chan *erEvent, 1)
+	rdr.rdrEvents = rdrEvs
+	if err := rdr.processMessage([]byte(`{"CGRID":"1"}`)); err != utils.ErrDisconnected {
+		t.Errorf("Expected %v, received %v", utils.ErrDisconnected, err)
+	}
+}
+
+func TestNATSERServeJetStream(t *testing.T) {
+	srv := runEmbeddedNATS(t)
+	defer srv.Shutdown()
+	js, rdrEvs, pstr, stop := newJetStreamNATSER(t, srv.ClientURL(), utils.MetaNone, utils.EmptyString)
+	defer stop()
+
+	cgrID := utils.UUIDSha1Prefix()
+	if err := pstr.Post([]byte(fmt.Sprintf(`{"CGRID": "%s"}`, cgrID)), utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	// failing the processing without failed posts and dead letter subject should make the server redeliver the message
+	receiveNATSEvent(t, rdrEvs, cgrID, errors.New("processing failed"))
+	select {
+	case ev := <-rdrEvs:
+		if rcv := ev.cgrEvent.Event[utils.CGRID]; rcv != cgrID {
+			t.Errorf("Expected %q, received %q", cgrID, rcv)
+		}
+		if info, err := js.ConsumerInfo("CGRATES", "cgrates_ers"); err != nil {
+			t.Error(err)
+		} else if info.NumAckPending != 1 {
+			t.Errorf("Expected the message to not be acknowledged before processing, received: %+v", info.NumAckPending)
+		}
+		ev.processed <- nil
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout")
+	}
+	// the message is acknowledged after being processed
+	if info := waitNATSAcks(t, js); info.NumAckPending != 0 || info.Delivered.Stream != 1 {
+		t.Errorf("Expected the message to be acknowledged, received: %s", utils.ToJSON(info))
+	}
+}
+
+func TestNATSERServeJetStreamFailedPosts(t *testing.T) {
+	srv := runEmbeddedNATS(t)
+	defer srv.Shutdown()
+	failedPostsDir := t.TempDir()
+	defer config.SetCgrConfig(config.CgrConfig())
+	cfg := config.NewDefaultCGRConfig()
+	cfg.GeneralCfg().FailedPostsDir = failedPostsDir
+	config.SetCgrConfig(cfg) // the failed posts are written in the directory from the global config
+	engine.SetFailedPostCacheTTL(10 * time.Millisecond)
+	defer engine.SetFailedPostCacheTTL(cfg.GeneralCfg().FailedPostsTTL)
+	js, rdrEvs, pstr, stop := newJetStreamNATSER(t, srv.ClientURL(), failedPostsDir, utils.EmptyString)
+	defer stop()
+
+	cgrID := utils.UUIDSha1Prefix()
+	msg := fmt.Sprintf(`{"CGRID": "%s"}`, cgrID)
+	if err := pstr.Post([]byte(msg), utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	receiveNATSEvent(t, rdrEvs, cgrID, errors.New("processing failed"))
+	// the message kept in the failed posts is not redelivered
+	if info := waitNATSAcks(t, js); info.NumAckPending != 0 || info.NumRedelivered != 0 {
+		t.Errorf("Expected the message to not be redelivered, received: %s", utils.ToJSON(info))
+	}
+	var fls []string
+	for i := 0; i < 100 && len(fls) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+		if fInfos, err := ioutil.ReadDir(failedPostsDir); err != nil {
+			t.Fatal(err)
+		} else {
+			for _, fInfo := range fInfos {
+				fls = append(fls, fInfo.Name())
+			}
+		}
+	}
+	if len(fls) != 1 {
+		t.Fatalf("Expected one failed posts file, received: %v", fls)
+	}
+	expEv, err := engine.NewExportEventsFromFile(path.Join(failedPostsDir, fls[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expEv.Path != srv.ClientURL() || expEv.Format != utils.MetaNATSjsonMap ||
+		!reflect.DeepEqual([]interface{}{[]byte(msg)}, expEv.Events) {
+		t.Errorf("Unexpected failed posts: %s", utils.ToJSON(expEv))
+	}
+}
+
+func TestNATSERServeJetStreamDeadLetter(t *testing.T) {
+	srv := runEmbeddedNATS(t)
+	defer srv.Shutdown()
+	nc, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nc.Close()
+	dlSub, err := nc.SubscribeSync("cgrates_cdrs_failed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = nc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	js, rdrEvs, pstr, stop := newJetStreamNATSER(t, srv.ClientURL(), utils.MetaNone, `
+				"natsDeadLetterSubject": "cgrates_cdrs_failed",`)
+	defer stop()
+
+	failedID := utils.UUIDSha1Prefix()
+	if err = pstr.Post([]byte(fmt.Sprintf(`{"CGRID": "%s"}`, failedID)), utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	// the message failing the processing goes to the dead letter subject and is not redelivered
+	receiveNATSEvent(t, rdrEvs, failedID, errors.New("processing failed"))
+	if msg, err := dlSub.NextMsg(5 * time.Second); err != nil {
+		t.Fatal(err)
+	} else if exp := fmt.Sprintf(`{"CGRID": "%s"}`, failedID); string(msg.Data) != exp {
+		t.Errorf("Expected %s, received %s", exp, string(msg.Data))
+	}
+	cgrID := utils.UUIDSha1Prefix()
+	if err = pstr.Post([]byte(fmt.Sprintf(`{"CGRID": "%s"}`, cgrID)), utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	receiveNATSEvent(t, rdrEvs, cgrID, nil)
+	if info := waitNATSAcks(t, js); info.NumAckPending != 0 || info.NumRedelivered != 0 || info.Delivered.Stream != 2 {
+		t.Errorf("Expected the messages to be acknowledged, received: %s", utils.ToJSON(info))
+	}
+}
+
+func TestNATSERServeNoRunDelay(t *testing.T) {
+	srv := runEmbeddedNATS(t)
+	defer srv.Shutdown()
+	cfg, err := config.NewCGRConfigFromJSONStringWithDefaults(fmt.Sprintf(`{
+"ers": {
+	"enabled": true,
+	"readers": [
+		{
+			"id": "nats",
+			"type": "*nats_json_map",
+			"run_delay": "0",
+			"source_path": "%s",
+		},
+	],
+},
+}`, srv.ClientURL()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rdrExt := make(chan struct{})
+	rdr, err := NewNATSER(cfg, 1, make(chan *erEvent, 1), make(chan error, 1),
+		engine.NewFilterS(cfg, nil, nil), rdrExt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = rdr.Serve(); err != nil {
+		t.Fatal(err)
+	}
+	// the connection is closed once the reader stops
+	close(rdrExt)
+	conn := rdr.(*NATSER).conn
+	for i := 0; i < 50 && !conn.IsClosed(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !conn.IsClosed() {
+		t.Error("Expected the nats connection to be closed")
+	}
+}
@@ -0,0 +1,294 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// dryRunLoaderTypes are the loader types supported by the dry run
+var dryRunLoaderTypes = utils.NewStringSet([]string{utils.MetaAttributes, utils.MetaFilters,
+	utils.MetaRoutes, utils.MetaRateProfiles, utils.MetaAccountProfiles})
+
+// LoadChangeset is the list of changes a load would do in DataDB
+type LoadChangeset struct {
+	Created  []*ProfileChange
+	Modified []*ProfileChange
+	Removed  []*ProfileChange
+}
+
+// ProfileChange is the change of one profile
+type ProfileChange struct {
+	Type     string // the loader type, ie: *attributes
+	TenantID string
+	Fields   []*FieldChange `json:",omitempty"` // only for the modified profiles
+}
+
+// FieldChange is the change of one field within a modified profile
+type FieldChange struct {
+	Path string // ie: Attributes[0].Value or Rates.RT_1.Weight
+	Old  interface{}
+	New  interface{}
+}
+
+// dryRunProfile is the state of one profile during the dry run
+type dryRunProfile struct {
+	ldrType  string
+	tntID    string
+	original map[string]interface{} // as found in DataDB, nil if missing
+	current  map[string]interface{} // after the simulated changes, nil if removed
+}
+
+// newDryRunChanges returns the container for the profiles touched by the dry run
+func newDryRunChanges() map[string]*dryRunProfile {
+	return make(map[string]*dryRunProfile)
+}
+
+// asChangeset compares the profiles touched by the dry run with their original version
+func asChangeset(prfs map[string]*dryRunProfile) (cs *LoadChangeset) {
+	keys := make([]string, 0, len(prfs))
+	for key := range prfs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	cs = new(LoadChangeset)
+	for _, key := range keys {
+		prf := prfs[key]
+		switch {
+		case prf.original == nil && prf.current == nil: // created and removed within the same load
+		case prf.original == nil:
+			cs.Created = append(cs.Created, &ProfileChange{Type: prf.ldrType, TenantID: prf.tntID})
+		case prf.current == nil:
+			cs.Removed = append(cs.Removed, &ProfileChange{Type: prf.ldrType, TenantID: prf.tntID})
+		default:
+			if flds := diffFields(utils.EmptyString, prf.original, prf.current); len(flds) != 0 {
+				cs.Modified = append(cs.Modified, &ProfileChange{Type: prf.ldrType, TenantID: prf.tntID, Fields: flds})
+			}
+		}
+	}
+	return
+}
+
+// asGenericMap converts the profile to the map used to compare the fields
+func asGenericMap(prf interface{}) (mp map[string]interface{}, err error) {
+	var b []byte
+	if b, err = json.Marshal(prf); err != nil {
+		return
+	}
+	err = json.Unmarshal(b, &mp)
+	return
+}
+
+// isEmptyField considers nil, empty slices and empty maps as the same value
+func isEmptyField(val interface{}) bool {
+	switch v := val.(type) {
+	case nil:
+		return true
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// diffFields returns the fields that differ between the two values, walking the maps and the slices of the same length
+func diffFields(path string, oldVal, newVal interface{}) (flds []*FieldChange) {
+	if isEmptyField(oldVal) && isEmptyField(newVal) {
+		return
+	}
+	switch oldV := oldVal.(type) {
+	case map[string]interface{}:
+		if newV, canCast := newVal.(map[string]interface{}); canCast {
+			keys := utils.NewStringSet(nil)
+			for key := range oldV {
+				keys.Add(key)
+			}
+			for key := range newV {
+				keys.Add(key)
+			}
+			sortedKeys := keys.AsSlice()
+			sort.Strings(sortedKeys)
+			for _, key := range sortedKeys {
+				fldPath := key
+				if path != utils.EmptyString {
+					fldPath = path + utils.NestingSep + key
+				}
+				flds = append(flds, diffFields(fldPath, oldV[key], newV[key])...)
+			}
+			return
+		}
+	case []interface{}:
+		if newV, canCast := newVal.([]interface{}); canCast && len(oldV) == len(newV) {
+			for i := range oldV {
+				flds = append(flds, diffFields(fmt.Sprintf("%s[%d]", path, i), oldV[i], newV[i])...)
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(oldVal, newVal) {
+		flds = append(flds, &FieldChange{Path: path, Old: oldVal, New: newVal})
+	}
+	return
+}
+
+// withRates returns a copy of the profile having the rates replaced
+func withRates(prf map[string]interface{}, rates map[string]interface{}) (cln map[string]interface{}) {
+	cln = make(map[string]interface{}, len(prf))
+	for key, val := range prf {
+		cln[key] = val
+	}
+	cln[utils.Rates] = rates
+	return
+}
+
+// dryRunCurrent returns the current state of the profile during the dry run, reading it from DataDB first time
+func (ldr *Loader) dryRunCurrent(loaderType, tnt, id string) (prf *dryRunProfile, err error) {
+	key := utils.ConcatenatedKey(loaderType, tnt, id)
+	var has bool
+	if prf, has = ldr.dryRunPrfs[key]; has {
+		return
+	}
+	var dbPrf interface{}
+	switch loaderType {
+	case utils.MetaAttributes:
+		dbPrf, err = ldr.dm.GetAttributeProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaFilters:
+		dbPrf, err = ldr.dm.GetFilter(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaRoutes:
+		dbPrf, err = ldr.dm.GetRouteProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaRateProfiles:
+		dbPrf, err = ldr.dm.GetRateProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaAccountProfiles:
+		dbPrf, err = ldr.dm.GetAccountProfile(tnt, id, false, false, utils.NonTransactional)
+	default:
+		return nil, fmt.Errorf("unsupported loader type: <%s>", loaderType)
+	}
+	prf = &dryRunProfile{ldrType: loaderType, tntID: utils.ConcatenatedKey(tnt, id)}
+	if err != nil {
+		if err != utils.ErrNotFound {
+			return nil, err
+		}
+		err = nil
+	} else if prf.original, err = asGenericMap(dbPrf); err != nil {
+		return nil, err
+	}
+	prf.current = prf.original
+	ldr.dryRunPrfs[key] = prf
+	return
+}
+
+// dryRunStore simulates storing the profile into DataDB
+func (ldr *Loader) dryRunStore(loaderType, tnt, id string, newPrf interface{}) (err error) {
+	var prf *dryRunProfile
+	if prf, err = ldr.dryRunCurrent(loaderType, tnt, id); err != nil {
+		return
+	}
+	var newMp map[string]interface{}
+	if newMp, err = asGenericMap(newPrf); err != nil {
+		return
+	}
+	if loaderType == utils.MetaRateProfiles && prf.current != nil &&
+		ldr.flagsTpls[loaderType].GetBool(utils.MetaPartial) { // only the rates are merged into the existing profile
+		rates := make(map[string]interface{})
+		if crntRates, canCast := prf.current[utils.Rates].(map[string]interface{}); canCast {
+			for rtID, rt := range crntRates {
+				rates[rtID] = rt
+			}
+		}
+		if newRates, canCast := newMp[utils.Rates].(map[string]interface{}); canCast {
+			for rtID, rt := range newRates {
+				rates[rtID] = rt
+			}
+		}
+		newMp = withRates(prf.current, rates)
+	}
+	prf.current = newMp
+	return
+}
+
+// dryRunRemove simulates removing the profile from DataDB
+func (ldr *Loader) dryRunRemove(loaderType, tntID string, ldData []LoaderData) (err error) {
+	tntIDStruct := utils.NewTenantID(tntID)
+	var prf *dryRunProfile
+	if prf, err = ldr.dryRunCurrent(loaderType, tntIDStruct.Tenant, tntIDStruct.ID); err != nil ||
+		prf.current == nil { // nothing to remove
+		return
+	}
+	if loaderType != utils.MetaRateProfiles ||
+		!ldr.flagsTpls[loaderType].GetBool(utils.MetaPartial) {
+		prf.current = nil
+		return
+	}
+	var rateIDs []string
+	if rateIDs, err = ldData[0].GetRateIDs(); err != nil {
+		return
+	}
+	rates := make(map[string]interface{})
+	if len(rateIDs) != 0 { // no rateIDs means all rates are removed
+		crntRates, _ := prf.current[utils.Rates].(map[string]interface{})
+		for rtID, rt := range crntRates {
+			rates[rtID] = rt
+		}
+		for _, rtID := range rateIDs {
+			delete(rates, rtID)
+		}
+	}
+	prf.current = withRates(prf.current, rates)
+	return
+}
+
+// dryRunFolder processes the content in the folder without writing it into DataDB
+// returns the changes that loadOption would do for the supported loader types
+func (ldr *Loader) dryRunFolder(loadOption string, stopOnError bool) (cs *LoadChangeset, err error) {
+	if err = ldr.lockFolder(); err != nil {
+		return
+	}
+	defer ldr.unlockFolder()
+	// work on a copy so the readers and the buffers of a concurrent load are not touched
+	dryLdr := *ldr
+	dryLdr.rdrs = make(map[string]map[string]*openedCSVFile)
+	dryLdr.bufLoaderData = make(map[string][]LoaderData)
+	dryLdr.dryRunPrfs = newDryRunChanges()
+	for ldrType, rdrs := range ldr.rdrs {
+		if !dryRunLoaderTypes.Has(ldrType) {
+			continue
+		}
+		dryLdr.rdrs[ldrType] = make(map[string]*openedCSVFile)
+		for fName := range rdrs {
+			dryLdr.rdrs[ldrType][fName] = nil
+		}
+	}
+	for ldrType := range dryLdr.rdrs {
+		if err = dryLdr.processFiles(ldrType, utils.MetaNone, loadOption); err != nil {
+			if stopOnError {
+				return
+			}
+			utils.Logger.Warning(fmt.Sprintf("<%s-%s> loaderType: <%s> cannot open files, err: %s",
+				utils.LoaderS, ldr.ldrID, ldrType, err.Error()))
+			err = nil
+		}
+	}
+	return asChangeset(dryLdr.dryRunPrfs), nil
+}
@@ -0,0 +1,161 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"io/ioutil"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestDiffFields(t *testing.T) {
+	oldPrf := map[string]interface{}{
+		"ID":        "ATTR_1",
+		"FilterIDs": []interface{}{},
+		"Weight":    10.,
+		"Attributes": []interface{}{
+			map[string]interface{}{"Path": "*req.Account", "Value": "1001"},
+		},
+		"Rates": map[string]interface{}{
+			"RT_1": map[string]interface{}{"Weight": 0.},
+		},
+	}
+	newPrf := map[string]interface{}{
+		"ID":        "ATTR_1",
+		"FilterIDs": nil,
+		"Weight":    20.,
+		"Attributes": []interface{}{
+			map[string]interface{}{"Path": "*req.Account", "Value": "1002"},
+		},
+		"Rates": map[string]interface{}{
+			"RT_1": map[string]interface{}{"Weight": 0.},
+			"RT_2": map[string]interface{}{"Weight": 10.},
+		},
+	}
+	exp := []*FieldChange{
+		{Path: "Attributes[0].Value", Old: "1001", New: "1002"},
+		{Path: "Rates.RT_2", New: map[string]interface{}{"Weight": 10.}},
+		{Path: "Weight", Old: 10., New: 20.},
+	}
+	if rcv := diffFields(utils.EmptyString, oldPrf, newPrf); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if rcv := diffFields(utils.EmptyString, oldPrf, oldPrf); len(rcv) != 0 {
+		t.Errorf("Expected no changes, received %s", utils.ToJSON(rcv))
+	}
+}
+
+func TestLoaderDryRunFolder(t *testing.T) {
+	tpInDir := t.TempDir()
+	if err := ioutil.WriteFile(path.Join(tpInDir, utils.FiltersCsv), []byte(`
+cgrates.org,FLTR_1,*string,~*req.Account,1002
+cgrates.org,FLTR_2,*string,~*req.Account,1003
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), config.CgrConfig().CacheCfg(), nil)
+	fltr1 := &engine.Filter{
+		Tenant: "cgrates.org",
+		ID:     "FLTR_1",
+		Rules: []*engine.FilterRule{
+			{Type: utils.MetaString, Element: "~*req.Account", Values: []string{"1001"}},
+		},
+	}
+	if err := dm.SetFilter(fltr1, true); err != nil {
+		t.Fatal(err)
+	}
+	ldr := NewLoader(dm, &config.LoaderSCfg{
+		ID:             "TestLoaderDryRunFolder",
+		Tenant:         config.NewRSRParsersMustCompile("cgrates.org", utils.InfieldSep),
+		LockFileName:   ".cgr.lck",
+		FieldSeparator: utils.FieldsSep,
+		TpInDir:        tpInDir,
+		TpOutDir:       t.TempDir(),
+		Data: []*config.LoaderDataType{{
+			Type:     utils.MetaFilters,
+			Filename: utils.FiltersCsv,
+			Fields: []*config.FCTemplate{
+				{Tag: "Tenant", Path: "Tenant", Type: utils.MetaVariable,
+					Value: config.NewRSRParsersMustCompile("~*req.0", utils.InfieldSep), Mandatory: true},
+				{Tag: "ID", Path: "ID", Type: utils.MetaVariable,
+					Value: config.NewRSRParsersMustCompile("~*req.1", utils.InfieldSep), Mandatory: true},
+				{Tag: "Type", Path: "Type", Type: utils.MetaVariable,
+					Value: config.NewRSRParsersMustCompile("~*req.2", utils.InfieldSep)},
+				{Tag: "Element", Path: "Element", Type: utils.MetaVariable,
+					Value: config.NewRSRParsersMustCompile("~*req.3", utils.InfieldSep)},
+				{Tag: "Values", Path: "Values", Type: utils.MetaVariable,
+					Value: config.NewRSRParsersMustCompile("~*req.4", utils.InfieldSep)},
+			},
+		}},
+	}, "UTC", nil, nil, nil)
+
+	exp := &LoadChangeset{
+		Created: []*ProfileChange{{Type: utils.MetaFilters, TenantID: "cgrates.org:FLTR_2"}},
+		Modified: []*ProfileChange{{Type: utils.MetaFilters, TenantID: "cgrates.org:FLTR_1",
+			Fields: []*FieldChange{{Path: "Rules[0].Values[0]", Old: "1001", New: "1002"}}}},
+	}
+	if cs, err := ldr.ProcessFolder(utils.EmptyString, utils.MetaLoadDryRun, true); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, cs) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(cs))
+	}
+	if _, err := dm.GetFilter("cgrates.org", "FLTR_2", false, false,
+		utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if rcv, err := dm.GetFilter("cgrates.org", "FLTR_1", false, false,
+		utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if rcv.Rules[0].Values[0] != "1001" {
+		t.Errorf("Expected the filter to be unchanged, received %s", utils.ToJSON(rcv))
+	}
+	if _, err := ioutil.ReadFile(path.Join(tpInDir, utils.FiltersCsv)); err != nil {
+		t.Errorf("Expected the file to not be moved, received %v", err)
+	}
+
+	exp = &LoadChangeset{
+		Removed: []*ProfileChange{{Type: utils.MetaFilters, TenantID: "cgrates.org:FLTR_1"}},
+	}
+	ldrS := &LoaderService{ldrs: map[string]*Loader{ldr.ldrID: ldr}}
+	var rply interface{}
+	if err := ldrS.V1Remove(&ArgsProcessFolder{
+		LoaderID:    ldr.ldrID,
+		StopOnError: true,
+		LoadOption:  utils.MetaLoadDryRun,
+	}, &rply); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+	if err := ldrS.V1Load(&ArgsProcessFolder{
+		LoaderID:   ldr.ldrID,
+		LoadOption: utils.MetaRemove,
+	}, &rply); err == nil || err.Error() != "UNSUPPORTED_LOAD_OPTION: *remove" {
+		t.Errorf("Expected UNSUPPORTED_LOAD_OPTION, received %v", err)
+	}
+	if _, err := dm.GetFilter("cgrates.org", "FLTR_1", false, false,
+		utils.NonTransactional); err != nil {
+		t.Error(err)
+	}
+}
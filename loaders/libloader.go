@@ -50,7 +50,14 @@ func (ld LoaderData) GetRateIDs() ([]string, error) {
 // contained in record and processed with cfgTpl
 func (ld LoaderData) UpdateFromCSV(fileName string, record []string,
 	cfgTpl []*config.FCTemplate, tnt config.RSRParsers, filterS *engine.FilterS) (err error) {
-	csvProvider := newCsvProvider(record, fileName)
+	return ld.UpdateFromDataProvider(newCsvProvider(record, fileName),
+		cfgTpl, tnt, filterS)
+}
+
+// UpdateFromDataProvider will update LoaderData with the record out of dP,
+// processed with cfgTpl
+func (ld LoaderData) UpdateFromDataProvider(dP utils.DataProvider,
+	cfgTpl []*config.FCTemplate, tnt config.RSRParsers, filterS *engine.FilterS) (err error) {
 	tenant, err := tnt.ParseValue("")
 	if err != nil {
 		return err
@@ -59,15 +66,21 @@ func (ld LoaderData) UpdateFromCSV(fileName string, record []string,
 		// Make sure filters are matching
 		if len(cfgFld.Filters) != 0 {
 			if pass, err := filterS.Pass(tenant,
-				cfgFld.Filters, csvProvider); err != nil {
+				cfgFld.Filters, dP); err != nil {
 				return err
 			} else if !pass {
 				continue // Not passes filters, ignore this CDR
 			}
 		}
-		out, err := cfgFld.Value.ParseDataProvider(csvProvider)
+		out, err := cfgFld.Value.ParseDataProvider(dP)
 		if err != nil {
-			return err
+			if err != utils.ErrNotFound {
+				return err
+			}
+			if cfgFld.Mandatory { // the *json and *xml records can miss optional fields
+				return utils.NewErrMandatoryIeMissing(cfgFld.Path)
+			}
+			continue
 		}
 		switch cfgFld.Type {
 		case utils.MetaComposed:
@@ -122,17 +135,9 @@ func (cP *csvProvider) FieldAsInterface(fldPath []string) (data interface{}, err
 
 	switch {
 	case strings.HasPrefix(fldPath[0], utils.MetaFile+utils.FilterValStart):
-		fileName := strings.TrimPrefix(fldPath[0], utils.MetaFile+utils.FilterValStart)
-		hasSelEnd := false
-		for _, val := range fldPath[1:] {
-			if hasSelEnd = strings.HasSuffix(val, utils.FilterValEnd); hasSelEnd {
-				fileName = fileName + utils.NestingSep + val[:len(val)-1]
-				break
-			}
-			fileName = fileName + utils.NestingSep + val
-		}
-		if !hasSelEnd {
-			return nil, fmt.Errorf("filter rule <%s> needs to end in )", fldPath)
+		var fileName string
+		if fileName, _, err = fileSelector(fldPath); err != nil {
+			return
 		}
 		if cP.fileName != fileName {
 			cP.cache.Set(fldPath, nil)
@@ -173,3 +178,89 @@ func (cP *csvProvider) FieldAsString(fldPath []string) (data string, err error)
 func (cP *csvProvider) RemoteHost() net.Addr {
 	return utils.LocalAddr()
 }
+
+// fileSelector returns the file name out of the *file(fileName) selector
+// together with the path following it
+func fileSelector(fldPath []string) (fileName string, rest []string, err error) {
+	fileName = strings.TrimPrefix(fldPath[0], utils.MetaFile+utils.FilterValStart)
+	for i, val := range fldPath[1:] {
+		if strings.HasSuffix(val, utils.FilterValEnd) {
+			return fileName + utils.NestingSep + val[:len(val)-1], fldPath[i+2:], nil
+		}
+		fileName = fileName + utils.NestingSep + val
+	}
+	return utils.EmptyString, nil, fmt.Errorf("filter rule <%s> needs to end in )", fldPath)
+}
+
+// newRecordProvider constructs a DataProvider over one record of the *json and *xml files
+func newRecordProvider(req utils.DataProvider, fileName string) (dP utils.DataProvider) {
+	return &recordProvider{
+		req:      req,
+		fileName: fileName,
+		cache:    utils.MapStorage{},
+		cfg:      config.CgrConfig().GetDataProvider(),
+	}
+}
+
+// recordProvider implements utils.DataProvider so we can pass it to filters
+type recordProvider struct {
+	req      utils.DataProvider
+	fileName string
+	cache    utils.MapStorage
+	cfg      utils.DataProvider
+}
+
+// String is part of utils.DataProvider interface
+func (rP *recordProvider) String() string {
+	return rP.req.String()
+}
+
+// FieldAsInterface is part of utils.DataProvider interface
+func (rP *recordProvider) FieldAsInterface(fldPath []string) (data interface{}, err error) {
+	if data, err = rP.cache.FieldAsInterface(fldPath); err == nil ||
+		err != utils.ErrNotFound { // item found in cache
+		return
+	}
+	err = nil // cancel previous err
+	var reqPath []string
+	switch {
+	case strings.HasPrefix(fldPath[0], utils.MetaFile+utils.FilterValStart):
+		var fileName string
+		if fileName, reqPath, err = fileSelector(fldPath); err != nil {
+			return
+		}
+		if rP.fileName != fileName {
+			rP.cache.Set(fldPath, nil)
+			return
+		}
+	case fldPath[0] == utils.MetaReq:
+		reqPath = fldPath[1:]
+	case fldPath[0] == utils.MetaCfg:
+		if data, err = rP.cfg.FieldAsInterface(fldPath[1:]); err != nil {
+			return
+		}
+		rP.cache.Set(fldPath, data)
+		return
+	default:
+		return nil, fmt.Errorf("invalid prefix for : %s", fldPath)
+	}
+	if data, err = rP.req.FieldAsInterface(reqPath); err != nil {
+		return
+	}
+	rP.cache.Set(fldPath, data)
+	return
+}
+
+// FieldAsString is part of utils.DataProvider interface
+func (rP *recordProvider) FieldAsString(fldPath []string) (data string, err error) {
+	var valIface interface{}
+	if valIface, err = rP.FieldAsInterface(fldPath); err != nil {
+		return
+	}
+	return utils.IfaceAsString(valIface), nil
+}
+
+// RemoteHost is part of utils.DataProvider interface
+func (rP *recordProvider) RemoteHost() net.Addr {
+	return utils.LocalAddr()
+}
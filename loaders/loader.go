@@ -20,6 +20,7 @@ package loaders
 
 import (
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -28,6 +29,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/antchfx/xmlquery"
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
@@ -37,6 +39,23 @@ type openedCSVFile struct {
 	fileName string
 	rdr      io.ReadCloser // keep reference so we can close it when done
 	csvRdr   *csv.Reader
+	rcrds    []utils.DataProvider // records of the *json, *xml and *xlsx files, read when opening them
+}
+
+// read returns the next record in the file
+func (oFile *openedCSVFile) read() (dP utils.DataProvider, err error) {
+	if oFile.csvRdr != nil {
+		var record []string
+		if record, err = oFile.csvRdr.Read(); err != nil {
+			return
+		}
+		return newCsvProvider(record, oFile.fileName), nil
+	}
+	if len(oFile.rcrds) == 0 {
+		return nil, io.EOF
+	}
+	dP, oFile.rcrds = oFile.rcrds[0], oFile.rcrds[1:]
+	return
 }
 
 func NewLoader(dm *engine.DataManager, cfg *config.LoaderSCfg,
@@ -54,6 +73,7 @@ func NewLoader(dm *engine.DataManager, cfg *config.LoaderSCfg,
 		runDelay:      cfg.RunDelay,
 		dataTpls:      make(map[string][]*config.FCTemplate),
 		flagsTpls:     make(map[string]utils.FlagsWithParams),
+		dataCfgs:      make(map[string]*config.LoaderDataType),
 		rdrs:          make(map[string]map[string]*openedCSVFile),
 		bufLoaderData: make(map[string][]LoaderData),
 		dm:            dm,
@@ -65,6 +85,7 @@ func NewLoader(dm *engine.DataManager, cfg *config.LoaderSCfg,
 	for _, ldrData := range cfg.Data {
 		ldr.dataTpls[ldrData.Type] = ldrData.Fields
 		ldr.flagsTpls[ldrData.Type] = ldrData.Flags
+		ldr.dataCfgs[ldrData.Type] = ldrData
 		ldr.rdrs[ldrData.Type] = make(map[string]*openedCSVFile)
 		if ldrData.Filename != "" {
 			ldr.rdrs[ldrData.Type][ldrData.Filename] = nil
@@ -97,6 +118,7 @@ type Loader struct {
 	runDelay      time.Duration
 	dataTpls      map[string][]*config.FCTemplate      // map[loaderType]*config.FCTemplate
 	flagsTpls     map[string]utils.FlagsWithParams     //map[loaderType]utils.FlagsWithParams
+	dataCfgs      map[string]*config.LoaderDataType    // map[loaderType]*config.LoaderDataType with the format of the files
 	rdrs          map[string]map[string]*openedCSVFile // map[loaderType]map[fileName]*openedCSVFile for common incremental read
 	procRows      int                                  // keep here the last processed row in the file/-s
 	bufLoaderData map[string][]LoaderData              // cache of data read, indexed on tenantID
@@ -105,6 +127,7 @@ type Loader struct {
 	filterS       *engine.FilterS
 	connMgr       *engine.ConnManager
 	cacheConns    []string
+	dryRunPrfs    map[string]*dryRunProfile // profiles touched by dryRunFolder, nil outside of it
 }
 
 func (ldr *Loader) ListenAndServe(stopChan chan struct{}) (err error) {
@@ -113,7 +136,11 @@ func (ldr *Loader) ListenAndServe(stopChan chan struct{}) (err error) {
 }
 
 // ProcessFolder will process the content in the folder with locking
-func (ldr *Loader) ProcessFolder(caching, loadOption string, stopOnError bool) (err error) {
+// with the *dry_run loadOption nothing is stored, the changes of the *store being returned instead
+func (ldr *Loader) ProcessFolder(caching, loadOption string, stopOnError bool) (cs *LoadChangeset, err error) {
+	if loadOption == utils.MetaLoadDryRun {
+		return ldr.dryRunFolder(utils.MetaStore, stopOnError)
+	}
 	if err = ldr.lockFolder(); err != nil {
 		return
 	}
@@ -128,7 +155,8 @@ func (ldr *Loader) ProcessFolder(caching, loadOption string, stopOnError bool) (
 			continue
 		}
 	}
-	return ldr.moveFiles()
+	err = ldr.moveFiles()
+	return
 }
 
 // lockFolder will attempt to lock the folder by creating the lock file
@@ -154,6 +182,102 @@ func (ldr *Loader) isFolderLocked() (locked bool, err error) {
 	return
 }
 
+// openFile opens the file and reads it based on the format configured for the loaderType
+func (ldr *Loader) openFile(loaderType, fName string) (err error) {
+	var rdr *os.File
+	if rdr, err = os.Open(path.Join(ldr.tpInDir, fName)); err != nil {
+		return
+	}
+	oFile := &openedCSVFile{fileName: fName, rdr: rdr}
+	format := utils.MetaCSV
+	dataCfg := ldr.dataCfgs[loaderType]
+	if dataCfg != nil && dataCfg.Format != utils.EmptyString {
+		format = dataCfg.Format
+	}
+	switch format {
+	case utils.MetaCSV:
+		oFile.csvRdr = csv.NewReader(rdr)
+		oFile.csvRdr.Comma = rune(ldr.fieldSep[0])
+		oFile.csvRdr.Comment = '#'
+	case utils.MetaJSON:
+		oFile.rcrds, err = readJSONRecords(rdr, fName, dataCfg.RootPath)
+	case utils.MetaXml:
+		oFile.rcrds, err = readXMLRecords(rdr, fName, dataCfg.RootPath)
+	case utils.MetaXLSX:
+		oFile.rcrds, err = readXLSXRecords(rdr, fName, dataCfg.Sheet)
+	default:
+		err = fmt.Errorf("unsupported format <%s>", format)
+	}
+	if err != nil {
+		rdr.Close()
+		return fmt.Errorf("cannot read file <%s>, error: %s", fName, err.Error())
+	}
+	ldr.rdrs[loaderType][fName] = oFile
+	return
+}
+
+// readJSONRecords returns the records out of the JSON file
+// the records are found under rootPath, either as list of objects or as one object
+func readJSONRecords(rdr io.Reader, fName string, rootPath utils.HierarchyPath) (rcrds []utils.DataProvider, err error) {
+	var data interface{}
+	if err = json.NewDecoder(rdr).Decode(&data); err != nil {
+		return
+	}
+	for _, fld := range rootPath {
+		mp, canCast := data.(map[string]interface{})
+		if !canCast {
+			return nil, fmt.Errorf("cannot find root path <%s>", rootPath.AsString(utils.NestingSep, false))
+		}
+		if data = mp[fld]; data == nil {
+			return nil, fmt.Errorf("cannot find root path <%s>", rootPath.AsString(utils.NestingSep, false))
+		}
+	}
+	switch dt := data.(type) {
+	case map[string]interface{}:
+		return []utils.DataProvider{newRecordProvider(utils.MapStorage(dt), fName)}, nil
+	case []interface{}:
+		rcrds = make([]utils.DataProvider, len(dt))
+		for i, itm := range dt {
+			mp, canCast := itm.(map[string]interface{})
+			if !canCast {
+				return nil, fmt.Errorf("invalid record at index %d", i)
+			}
+			rcrds[i] = newRecordProvider(utils.MapStorage(mp), fName)
+		}
+		return
+	}
+	return nil, fmt.Errorf("invalid records under root path <%s>", rootPath.AsString(utils.NestingSep, false))
+}
+
+// readXMLRecords returns the elements found at rootPath as records
+func readXMLRecords(rdr io.Reader, fName string, rootPath utils.HierarchyPath) (rcrds []utils.DataProvider, err error) {
+	var doc *xmlquery.Node
+	if doc, err = xmlquery.Parse(rdr); err != nil {
+		return
+	}
+	for _, elmt := range xmlquery.Find(doc, rootPath.AsString("/", true)) {
+		rcrds = append(rcrds, newRecordProvider(config.NewXMLProvider(elmt, rootPath), fName))
+	}
+	return
+}
+
+// readXLSXRecords returns the rows of the sheet as records, the same as the lines of a csv file
+func readXLSXRecords(rdr *os.File, fName, sheet string) (rcrds []utils.DataProvider, err error) {
+	var fi os.FileInfo
+	if fi, err = rdr.Stat(); err != nil {
+		return
+	}
+	var rows [][]string
+	if rows, err = readXLSX(rdr, fi.Size(), sheet); err != nil {
+		return
+	}
+	rcrds = make([]utils.DataProvider, len(rows))
+	for i, row := range rows {
+		rcrds[i] = newCsvProvider(row, fName)
+	}
+	return
+}
+
 // unreferenceFile will cleanup an used file by closing and removing from referece map
 func (ldr *Loader) unreferenceFile(loaderType, fileName string) (err error) {
 	openedCSVFile := ldr.rdrs[loaderType][fileName]
@@ -182,15 +306,9 @@ func (ldr *Loader) moveFiles() (err error) {
 
 func (ldr *Loader) processFiles(loaderType, caching, loadOption string) (err error) {
 	for fName := range ldr.rdrs[loaderType] {
-		var rdr *os.File
-		if rdr, err = os.Open(path.Join(ldr.tpInDir, fName)); err != nil {
+		if err = ldr.openFile(loaderType, fName); err != nil {
 			return err
 		}
-		csvReader := csv.NewReader(rdr)
-		csvReader.Comma = rune(ldr.fieldSep[0])
-		csvReader.Comment = '#'
-		ldr.rdrs[loaderType][fName] = &openedCSVFile{
-			fileName: fName, rdr: rdr, csvRdr: csvReader}
 		defer ldr.unreferenceFile(loaderType, fName)
 	}
 	// based on load option will store or remove the content
@@ -216,9 +334,9 @@ func (ldr *Loader) processContent(loaderType, caching string) (err error) {
 		lineNr++
 		var hasErrors bool
 		lData := make(LoaderData) // one row
-		for fName, rdr := range ldr.rdrs[loaderType] {
-			var record []string
-			if record, err = rdr.csvRdr.Read(); err != nil {
+		for _, rdr := range ldr.rdrs[loaderType] {
+			var dP utils.DataProvider
+			if dP, err = rdr.read(); err != nil {
 				if err == io.EOF {
 					keepLooping = false
 					break
@@ -232,7 +350,7 @@ func (ldr *Loader) processContent(loaderType, caching string) (err error) {
 				continue
 			}
 
-			if err := lData.UpdateFromCSV(fName, record,
+			if err := lData.UpdateFromDataProvider(dP,
 				ldr.dataTpls[loaderType], ldr.tenant, ldr.filterS); err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> <%s> line: %d, error: %s",
@@ -295,6 +413,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				if err != nil {
 					return err
 				}
+				if ldr.dryRunPrfs != nil {
+					if err := ldr.dryRunStore(loaderType, apf.Tenant, apf.ID, apf); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: AttributeProfile: %s",
@@ -368,6 +492,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				if err != nil {
 					return err
 				}
+				if ldr.dryRunPrfs != nil {
+					if err := ldr.dryRunStore(loaderType, fltrPrf.Tenant, fltrPrf.ID, fltrPrf); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: Filter: %s",
@@ -477,6 +607,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				if err != nil {
 					return err
 				}
+				if ldr.dryRunPrfs != nil {
+					if err := ldr.dryRunStore(loaderType, spPrf.Tenant, spPrf.ID, spPrf); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: RouteProfile: %s",
@@ -590,6 +726,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				if err != nil {
 					return err
 				}
+				if ldr.dryRunPrfs != nil {
+					if err := ldr.dryRunStore(loaderType, rpl.Tenant, rpl.ID, rpl); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: RateProfile: %s",
@@ -659,6 +801,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				if err != nil {
 					return err
 				}
+				if ldr.dryRunPrfs != nil {
+					if err := ldr.dryRunStore(loaderType, acp.Tenant, acp.ID, acp); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: AccountProfiles: %s",
@@ -724,9 +872,9 @@ func (ldr *Loader) removeContent(loaderType, caching string) (err error) {
 		lineNr++
 		var hasErrors bool
 		lData := make(LoaderData) // one row
-		for fName, rdr := range ldr.rdrs[loaderType] {
-			var record []string
-			if record, err = rdr.csvRdr.Read(); err != nil {
+		for _, rdr := range ldr.rdrs[loaderType] {
+			var dP utils.DataProvider
+			if dP, err = rdr.read(); err != nil {
 				if err == io.EOF {
 					keepLooping = false
 					break
@@ -740,7 +888,7 @@ func (ldr *Loader) removeContent(loaderType, caching string) (err error) {
 				continue
 			}
 
-			if err := lData.UpdateFromCSV(fName, record,
+			if err := lData.UpdateFromDataProvider(dP,
 				ldr.dataTpls[loaderType], ldr.tenant, ldr.filterS); err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> <%s> line: %d, error: %s",
@@ -792,7 +940,11 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 	case utils.MetaAttributes:
 		cacheIDs = []string{utils.CacheAttributeFilterIndexes}
 		for tntID := range lds {
-			if ldr.dryRun {
+			if ldr.dryRunPrfs != nil {
+				if err := ldr.dryRunRemove(loaderType, tntID, nil); err != nil {
+					return err
+				}
+			} else if ldr.dryRun {
 				utils.Logger.Info(
 					fmt.Sprintf("<%s-%s> DRY_RUN: AttributeProfileID: %s",
 						utils.LoaderS, ldr.ldrID, tntID))
@@ -833,7 +985,11 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 		}
 	case utils.MetaFilters:
 		for tntID := range lds {
-			if ldr.dryRun {
+			if ldr.dryRunPrfs != nil {
+				if err := ldr.dryRunRemove(loaderType, tntID, nil); err != nil {
+					return err
+				}
+			} else if ldr.dryRun {
 				utils.Logger.Info(
 					fmt.Sprintf("<%s-%s> DRY_RUN: Filter: %s",
 						utils.LoaderS, ldr.ldrID, tntID))
@@ -895,7 +1051,11 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 	case utils.MetaRoutes:
 		cacheIDs = []string{utils.CacheRouteFilterIndexes}
 		for tntID := range lds {
-			if ldr.dryRun {
+			if ldr.dryRunPrfs != nil {
+				if err := ldr.dryRunRemove(loaderType, tntID, nil); err != nil {
+					return err
+				}
+			} else if ldr.dryRun {
 				utils.Logger.Info(
 					fmt.Sprintf("<%s-%s> DRY_RUN: RouteProfileID: %s",
 						utils.LoaderS, ldr.ldrID, tntID))
@@ -966,7 +1126,11 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 	case utils.MetaRateProfiles:
 		cacheIDs = []string{utils.CacheRateProfilesFilterIndexes, utils.CacheRateFilterIndexes}
 		for tntID, ldData := range lds {
-			if ldr.dryRun {
+			if ldr.dryRunPrfs != nil {
+				if err := ldr.dryRunRemove(loaderType, tntID, ldData); err != nil {
+					return err
+				}
+			} else if ldr.dryRun {
 				utils.Logger.Info(
 					fmt.Sprintf("<%s-%s> DRY_RUN: RateProfileIDs: %s",
 						utils.LoaderS, ldr.ldrID, tntID))
@@ -1014,7 +1178,11 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 	case utils.MetaAccountProfiles:
 		cacheIDs = []string{utils.CacheAccountProfiles, utils.CacheAccountProfilesFilterIndexes}
 		for tntID := range lds {
-			if ldr.dryRun {
+			if ldr.dryRunPrfs != nil {
+				if err := ldr.dryRunRemove(loaderType, tntID, nil); err != nil {
+					return err
+				}
+			} else if ldr.dryRun {
 				utils.Logger.Info(
 					fmt.Sprintf("<%s-%s> DRY_RUN: AccountProfileIDs: %s",
 						utils.LoaderS, ldr.ldrID, tntID))
@@ -1112,15 +1280,9 @@ func (ldr *Loader) processFile(_, itmID string) (err error) {
 	if ldr.rdrs[loaderType][itmID] != nil {
 		ldr.unreferenceFile(loaderType, itmID)
 	}
-	var rdr *os.File
-	if rdr, err = os.Open(path.Join(ldr.tpInDir, itmID)); err != nil {
+	if err = ldr.openFile(loaderType, itmID); err != nil {
 		return
 	}
-	csvReader := csv.NewReader(rdr)
-	csvReader.Comma = rune(ldr.fieldSep[0])
-	csvReader.Comment = '#'
-	ldr.rdrs[loaderType][itmID] = &openedCSVFile{
-		fileName: itmID, rdr: rdr, csvRdr: csvReader}
 	if !ldr.allFilesPresent(loaderType) {
 		return
 	}
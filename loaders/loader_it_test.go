@@ -474,7 +474,7 @@ cgrates.org,SET_ACTPROFILE_3
 			},
 		},
 	}
-	if err := ldr.ProcessFolder(utils.EmptyString, utils.MetaStore, true); err != nil {
+	if _, err := ldr.ProcessFolder(utils.EmptyString, utils.MetaStore, true); err != nil {
 		t.Error(err)
 	}
 	expACtPrf := &engine.ActionProfile{
@@ -507,7 +507,7 @@ cgrates.org,SET_ACTPROFILE_3
 		},
 	}
 	expected := "UNSUPPORTED_SERVICE_METHOD"
-	if err := ldr.ProcessFolder(utils.MetaReload, utils.MetaStore, true); err == nil || err.Error() != expected {
+	if _, err := ldr.ProcessFolder(utils.MetaReload, utils.MetaStore, true); err == nil || err.Error() != expected {
 		t.Error(err)
 	}
 
@@ -533,12 +533,12 @@ func testLoadFromFilesCsvActionProfileOpenError(t *testing.T) {
 		},
 	}
 	expectedErr := "open /tmp/testLoadFromFilesCsvActionProfileOpenError/ActionProfiles.csv: not a directory"
-	if err := ldr.ProcessFolder(utils.EmptyString, utils.MetaStore, true); err == nil || err.Error() != expectedErr {
+	if _, err := ldr.ProcessFolder(utils.EmptyString, utils.MetaStore, true); err == nil || err.Error() != expectedErr {
 		t.Errorf("Expected %+v, received %+v", expectedErr, err)
 	}
 
 	//if stopOnError is on true, the error is avoided,but instead will get a logger.warning message
-	if err := ldr.ProcessFolder(utils.EmptyString, utils.MetaStore, false); err != nil {
+	if _, err := ldr.ProcessFolder(utils.EmptyString, utils.MetaStore, false); err != nil {
 		t.Error(err)
 	}
 }
@@ -610,7 +610,7 @@ cgrates.org,SET_ACTPROFILE_3
 	if err := ldr.dm.SetActionProfile(expACtPrf, true); err != nil {
 		t.Error(err)
 	}
-	if err := ldr.ProcessFolder(utils.EmptyString, utils.MetaRemove, true); err != nil {
+	if _, err := ldr.ProcessFolder(utils.EmptyString, utils.MetaRemove, true); err != nil {
 		t.Error(err)
 	}
 	//nothing to get from database
@@ -636,7 +636,7 @@ cgrates.org,SET_ACTPROFILE_3
 	if err := ldr.dm.SetActionProfile(expACtPrf, true); err != nil {
 		t.Error(err)
 	}
-	if err := ldr.ProcessFolder(utils.MetaReload, utils.MetaRemove, true); err != utils.ErrNotFound {
+	if _, err := ldr.ProcessFolder(utils.MetaReload, utils.MetaRemove, true); err != utils.ErrNotFound {
 		t.Error(err)
 	}
 }
@@ -657,7 +657,7 @@ func testLoadFromFilesCsvActionProfileLockFolderError(t *testing.T) {
 		},
 	}
 	expectedErr := "open : no such file or directory"
-	if err := ldr.ProcessFolder(utils.EmptyString, utils.MetaStore, true); err == nil || err.Error() != expectedErr {
+	if _, err := ldr.ProcessFolder(utils.EmptyString, utils.MetaStore, true); err == nil || err.Error() != expectedErr {
 		t.Errorf("Expected %+v, received %+v", expectedErr, err)
 	}
 }
@@ -21,6 +21,7 @@ package loaders
 import (
 	"encoding/csv"
 	"io/ioutil"
+	"path"
 	"reflect"
 	"sort"
 	"strings"
@@ -5136,3 +5137,140 @@ func TestLoaderServiceEnabled(t *testing.T) {
 		t.Errorf("Expected false, received %+v", rcv)
 	}
 }
+
+func TestLoaderProcessFolderFormats(t *testing.T) {
+	fltrFields := func(prfx string) []*config.FCTemplate {
+		return []*config.FCTemplate{
+			{Tag: "Tenant", Path: "Tenant", Type: utils.MetaVariable,
+				Value: config.NewRSRParsersMustCompile(prfx+"0", utils.InfieldSep), Mandatory: true},
+			{Tag: "ID", Path: "ID", Type: utils.MetaVariable,
+				Value: config.NewRSRParsersMustCompile(prfx+"1", utils.InfieldSep), Mandatory: true},
+			{Tag: "Type", Path: "Type", Type: utils.MetaVariable,
+				Value: config.NewRSRParsersMustCompile(prfx+"2", utils.InfieldSep)},
+			{Tag: "Element", Path: "Element", Type: utils.MetaVariable,
+				Value: config.NewRSRParsersMustCompile(prfx+"3", utils.InfieldSep)},
+			{Tag: "Values", Path: "Values", Type: utils.MetaVariable,
+				Value: config.NewRSRParsersMustCompile(prfx+"4", utils.InfieldSep)},
+		}
+	}
+	jsonFields := []*config.FCTemplate{
+		{Tag: "Tenant", Path: "Tenant", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Tenant", utils.InfieldSep), Mandatory: true},
+		{Tag: "ID", Path: "ID", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.ID", utils.InfieldSep), Mandatory: true},
+		{Tag: "Type", Path: "Type", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Rule.Type", utils.InfieldSep)},
+		{Tag: "Element", Path: "Element", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Rule.Element", utils.InfieldSep)},
+		{Tag: "Values", Path: "Values", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Rule.Values", utils.InfieldSep)},
+	}
+	xmlFields := []*config.FCTemplate{
+		{Tag: "Tenant", Path: "Tenant", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.filters.filter.tenant", utils.InfieldSep), Mandatory: true},
+		{Tag: "ID", Path: "ID", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.filters.filter.id", utils.InfieldSep), Mandatory: true},
+		{Tag: "Type", Path: "Type", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.filters.filter.type", utils.InfieldSep)},
+		{Tag: "Element", Path: "Element", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.filters.filter.element", utils.InfieldSep)},
+		{Tag: "Values", Path: "Values", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.filters.filter.values", utils.InfieldSep)},
+	}
+	for _, tc := range []struct {
+		dataCfg *config.LoaderDataType
+		content string
+		fltrID  string
+	}{
+		{
+			dataCfg: &config.LoaderDataType{Type: utils.MetaFilters, Filename: "Filters.json",
+				Format: utils.MetaJSON, RootPath: utils.HierarchyPath{"Filters"}, Fields: jsonFields},
+			content: `{"Filters": [
+	{"Tenant": "cgrates.org", "ID": "FLTR_JSON", "Rule": {"Type": "*string", "Element": "~*req.Account", "Values": 1001}},
+	{"Tenant": "cgrates.org", "ID": "FLTR_JSON", "Rule": {"Type": "*string", "Element": "~*req.Destination"}}
+]}`,
+			fltrID: "FLTR_JSON",
+		},
+		{
+			dataCfg: &config.LoaderDataType{Type: utils.MetaFilters, Filename: "Filters.xml",
+				Format: utils.MetaXml, RootPath: utils.HierarchyPath{"filters", "filter"}, Fields: xmlFields},
+			content: `<?xml version="1.0" encoding="UTF-8"?>
+<filters>
+	<filter><tenant>cgrates.org</tenant><id>FLTR_XML</id><type>*string</type><element>~*req.Account</element><values>1001</values></filter>
+	<filter><tenant>cgrates.org</tenant><id>FLTR_XML</id><type>*string</type><element>~*req.Destination</element></filter>
+</filters>`,
+			fltrID: "FLTR_XML",
+		},
+		{
+			dataCfg: &config.LoaderDataType{Type: utils.MetaFilters, Filename: "Filters.xlsx",
+				Format: utils.MetaXLSX, Sheet: "Filters", Fields: fltrFields("~*req.")},
+			fltrID: "FLTR_XLSX",
+		},
+	} {
+		tpInDir := t.TempDir()
+		if tc.dataCfg.Format == utils.MetaXLSX {
+			writeTestXLSX(t, path.Join(tpInDir, tc.dataCfg.Filename))
+		} else if err := ioutil.WriteFile(path.Join(tpInDir, tc.dataCfg.Filename),
+			[]byte(tc.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), config.CgrConfig().CacheCfg(), nil)
+		ldr := NewLoader(dm, &config.LoaderSCfg{
+			ID:             "TestLoaderProcessFolderFormats",
+			Tenant:         config.NewRSRParsersMustCompile("cgrates.org", utils.InfieldSep),
+			LockFileName:   ".cgr.lck",
+			FieldSeparator: utils.FieldsSep,
+			TpInDir:        tpInDir,
+			Data:           []*config.LoaderDataType{tc.dataCfg},
+		}, "UTC", nil, nil, nil)
+		if _, err := ldr.ProcessFolder(utils.EmptyString, utils.MetaStore, true); err != nil {
+			t.Fatalf("format: %s, error: %v", tc.dataCfg.Format, err)
+		}
+		fltr, err := dm.GetFilter("cgrates.org", tc.fltrID, false, false, utils.NonTransactional)
+		if err != nil {
+			t.Fatalf("format: %s, error: %v", tc.dataCfg.Format, err)
+		}
+		if len(fltr.Rules) != 2 ||
+			fltr.Rules[0].Element != "~*req.Account" ||
+			!reflect.DeepEqual(fltr.Rules[0].Values, []string{"1001"}) ||
+			fltr.Rules[1].Element != "~*req.Destination" {
+			t.Errorf("format: %s, received filter: %s", tc.dataCfg.Format, utils.ToJSON(fltr))
+		}
+		if _, err := ldr.ProcessFolder(utils.EmptyString, utils.MetaRemove, true); err != nil {
+			t.Fatalf("format: %s, error: %v", tc.dataCfg.Format, err)
+		}
+		if _, err := dm.GetFilter("cgrates.org", tc.fltrID, false, false,
+			utils.NonTransactional); err != utils.ErrNotFound {
+			t.Errorf("format: %s, expected %v, received %v", tc.dataCfg.Format, utils.ErrNotFound, err)
+		}
+	}
+}
+
+func TestLoaderOpenFileErrors(t *testing.T) {
+	tpInDir := t.TempDir()
+	if err := ioutil.WriteFile(path.Join(tpInDir, "Filters.json"),
+		[]byte(`{"Filters": "cgrates.org"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ldr := &Loader{
+		tpInDir: tpInDir,
+		rdrs:    map[string]map[string]*openedCSVFile{utils.MetaFilters: {}},
+		dataCfgs: map[string]*config.LoaderDataType{
+			utils.MetaFilters: {Format: utils.MetaJSON, RootPath: utils.HierarchyPath{"Filters"}},
+		},
+	}
+	expErr := "cannot read file <Filters.json>, error: invalid records under root path <Filters>"
+	if err := ldr.openFile(utils.MetaFilters, "Filters.json"); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error %q, received %v", expErr, err)
+	}
+	ldr.dataCfgs[utils.MetaFilters] = &config.LoaderDataType{Format: utils.MetaJSON, RootPath: utils.HierarchyPath{"Profiles"}}
+	expErr = "cannot read file <Filters.json>, error: cannot find root path <Profiles>"
+	if err := ldr.openFile(utils.MetaFilters, "Filters.json"); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error %q, received %v", expErr, err)
+	}
+	ldr.dataCfgs[utils.MetaFilters] = &config.LoaderDataType{Format: "*yaml"}
+	expErr = "cannot read file <Filters.json>, error: unsupported format <*yaml>"
+	if err := ldr.openFile(utils.MetaFilters, "Filters.json"); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error %q, received %v", expErr, err)
+	}
+}
@@ -66,10 +66,12 @@ type ArgsProcessFolder struct {
 	ForceLock   bool
 	Caching     *string
 	StopOnError bool
+	LoadOption  string // *dry_run to reply with the changes instead of doing them
 }
 
+// V1Load stores the content of the folder, replying OK or the changes with the *dry_run LoadOption
 func (ldrS *LoaderService) V1Load(args *ArgsProcessFolder,
-	rply *string) (err error) {
+	rply *interface{}) (err error) {
 	ldrS.RLock()
 	defer ldrS.RUnlock()
 	if args.LoaderID == "" {
@@ -94,15 +96,29 @@ func (ldrS *LoaderService) V1Load(args *ArgsProcessFolder,
 	if args.Caching != nil {
 		caching = *args.Caching
 	}
-	if err := ldr.ProcessFolder(caching, utils.MetaStore, args.StopOnError); err != nil {
+	loadOption := utils.MetaStore
+	switch args.LoadOption {
+	case utils.EmptyString, utils.MetaStore:
+	case utils.MetaLoadDryRun:
+		loadOption = utils.MetaLoadDryRun
+	default:
+		return fmt.Errorf("UNSUPPORTED_LOAD_OPTION: %s", args.LoadOption)
+	}
+	cs, err := ldr.ProcessFolder(caching, loadOption, args.StopOnError)
+	if err != nil {
 		return utils.NewErrServerError(err)
 	}
+	if cs != nil {
+		*rply = cs
+		return
+	}
 	*rply = utils.OK
 	return
 }
 
+// V1Remove removes the content of the folder, replying OK or the changes with the *dry_run LoadOption
 func (ldrS *LoaderService) V1Remove(args *ArgsProcessFolder,
-	rply *string) (err error) {
+	rply *interface{}) (err error) {
 	ldrS.RLock()
 	defer ldrS.RUnlock()
 	if args.LoaderID == "" {
@@ -127,7 +143,19 @@ func (ldrS *LoaderService) V1Remove(args *ArgsProcessFolder,
 	if args.Caching != nil {
 		caching = *args.Caching
 	}
-	if err := ldr.ProcessFolder(caching, utils.MetaRemove, args.StopOnError); err != nil {
+	switch args.LoadOption {
+	case utils.EmptyString, utils.MetaRemove:
+	case utils.MetaLoadDryRun:
+		cs, err := ldr.dryRunFolder(utils.MetaRemove, args.StopOnError)
+		if err != nil {
+			return utils.NewErrServerError(err)
+		}
+		*rply = cs
+		return nil
+	default:
+		return fmt.Errorf("UNSUPPORTED_LOAD_OPTION: %s", args.LoadOption)
+	}
+	if _, err := ldr.ProcessFolder(caching, utils.MetaRemove, args.StopOnError); err != nil {
 		return utils.NewErrServerError(err)
 	}
 	*rply = utils.OK
@@ -117,12 +117,12 @@ cgrates.org,NewRes1
 		},
 	}
 
-	var reply string
+	var reply interface{}
 	expected := "ANOTHER_LOADER_RUNNING"
 	//cannot load when there is another loader running
 	if err := ldrs.V1Load(&ArgsProcessFolder{
 		LoaderID:  "testV1LoadResource",
-		ForceLock: false}, &reply); err == nil || reply != utils.EmptyString || err.Error() != expected {
+		ForceLock: false}, &reply); err == nil || reply != nil || err.Error() != expected {
 		t.Errorf("Expected %+v and %+v \n, received %+v and %+v", expected, utils.EmptyString, err, reply)
 	}
 
@@ -177,10 +177,10 @@ cgrates.org,NewRes1
 		Data:           nil,
 	}
 
-	var reply string
+	var reply interface{}
 	ldrs := NewLoaderService(dm, cfgLdr, "UTC", nil, nil)
 	if err := ldrs.V1Load(&ArgsProcessFolder{
-		LoaderID: utils.EmptyString}, &reply); err == nil && reply != utils.EmptyString && err.Error() != utils.EmptyString {
+		LoaderID: utils.EmptyString}, &reply); err == nil && reply != nil && err.Error() != utils.EmptyString {
 		t.Errorf("Expected %+v and %+v \n, received %+v and %+v", utils.EmptyString, utils.EmptyString, err, reply)
 	}
 
@@ -213,7 +213,7 @@ func testV1LoadUnableToDeleteFile(t *testing.T) {
 		LockFileName:   utils.ResourcesCsv,
 		Data:           nil,
 	}
-	var reply string
+	var reply interface{}
 	ldrs := NewLoaderService(dm, cfgLdr, "UTC", nil, nil)
 	expected := "SERVER_ERROR: stat /\x00/Resources.csv: invalid argument"
 	if err := ldrs.V1Load(&ArgsProcessFolder{
@@ -282,14 +282,14 @@ NOT_UINT
 		},
 	}
 
-	var reply string
+	var reply interface{}
 	expected := "SERVER_ERROR: open testV1LoadProcessFolderError/not_a_file: no such file or directory"
 	//try to load by changing the caching method
 	if err := ldrs.V1Load(&ArgsProcessFolder{
 		LoaderID:    "testV1LoadResource",
 		ForceLock:   true,
 		Caching:     utils.StringPointer("not_a_chaching_method"),
-		StopOnError: true}, &reply); err == nil || err.Error() != expected || reply != utils.EmptyString {
+		StopOnError: true}, &reply); err == nil || err.Error() != expected || reply != nil {
 		t.Errorf("Expected %+q and %+q \n, received %+q and %+q", expected, utils.EmptyString, err, reply)
 	}
 
@@ -367,12 +367,12 @@ cgrates.org,NewRes1
 		t.Error(expRes)
 	}
 
-	var reply string
+	var reply interface{}
 	expected := "ANOTHER_LOADER_RUNNING"
 	//cannot load when there is another loader running
 	if err := ldrs.V1Remove(&ArgsProcessFolder{
 		LoaderID:  "testV1RemoveResource",
-		ForceLock: false}, &reply); err == nil || reply != utils.EmptyString || err.Error() != expected {
+		ForceLock: false}, &reply); err == nil || reply != nil || err.Error() != expected {
 		t.Errorf("Expected %+v and %+v \n, received %+v and %+v", expected, utils.EmptyString, err, reply)
 	}
 
@@ -423,11 +423,11 @@ cgrates.org,NewRes1
 		Data:           nil,
 	}
 
-	var reply string
+	var reply interface{}
 	ldrs := NewLoaderService(dm, cfgLdr, "UTC", nil, nil)
 	expected := "UNKNOWN_LOADER: *default"
 	if err := ldrs.V1Remove(&ArgsProcessFolder{
-		LoaderID: utils.EmptyString}, &reply); err == nil || reply != utils.EmptyString || err.Error() != expected {
+		LoaderID: utils.EmptyString}, &reply); err == nil || reply != nil || err.Error() != expected {
 		t.Errorf("Expected %+v and %+v \n, received %+v and %+v", expected, utils.EmptyString, err, reply)
 	}
 
@@ -460,7 +460,7 @@ func testV1RemoveUnableToDeleteFile(t *testing.T) {
 		LockFileName:   utils.ResourcesCsv,
 		Data:           nil,
 	}
-	var reply string
+	var reply interface{}
 	ldrs := NewLoaderService(dm, cfgLdr, "UTC", nil, nil)
 	expected := "SERVER_ERROR: stat /\x00/Resources.csv: invalid argument"
 	if err := ldrs.V1Remove(&ArgsProcessFolder{
@@ -507,14 +507,14 @@ func testV1LoadAndRemoveProcessRemoveFolderError(t *testing.T) {
 		},
 	}
 
-	var reply string
+	var reply interface{}
 	expected := "SERVER_ERROR: remove /tmp/testV1RemoveProcessFolderError: directory not empty"
 	//try to load by changing the caching method, but there is not a lockFileName
 	if err := ldrs.V1Load(&ArgsProcessFolder{
 		LoaderID:    "testV1RemoveProcessFolderError",
 		ForceLock:   true,
 		Caching:     utils.StringPointer("not_a_chaching_method"),
-		StopOnError: true}, &reply); err == nil || err.Error() != expected || reply != utils.EmptyString {
+		StopOnError: true}, &reply); err == nil || err.Error() != expected || reply != nil {
 		t.Errorf("Expected %+q and %+q \n, received %+q and %+q", expected, utils.EmptyString, err, reply)
 	}
 
@@ -523,7 +523,7 @@ func testV1LoadAndRemoveProcessRemoveFolderError(t *testing.T) {
 		LoaderID:    "testV1RemoveProcessFolderError",
 		ForceLock:   true,
 		Caching:     utils.StringPointer("not_a_chaching_method"),
-		StopOnError: true}, &reply); err == nil || err.Error() != expected || reply != utils.EmptyString {
+		StopOnError: true}, &reply); err == nil || err.Error() != expected || reply != nil {
 		t.Errorf("Expected %+q and %+q \n, received %+q and %+q", expected, utils.EmptyString, err, reply)
 	}
 
@@ -565,14 +565,14 @@ func testV1RemoveProcessFolderError(t *testing.T) {
 		},
 	}
 
-	var reply string
+	var reply interface{}
 	expected := "SERVER_ERROR: open testV1RemoveProcessFolderError/not_a_file2: no such file or directory"
 	//try to load by changing the caching method
 	if err := ldrs.V1Remove(&ArgsProcessFolder{
 		LoaderID:    "testV1RemoveProcessFolderError",
 		ForceLock:   true,
 		Caching:     utils.StringPointer("not_a_chaching_method"),
-		StopOnError: true}, &reply); err == nil || err.Error() != expected || reply != utils.EmptyString {
+		StopOnError: true}, &reply); err == nil || err.Error() != expected || reply != nil {
 		t.Errorf("Expected %+q and %+q \n, received %+q and %+q", expected, utils.EmptyString, err, reply)
 	}
 
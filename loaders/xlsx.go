@@ -0,0 +1,320 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+const (
+	xlsxWorkbook      = "xl/workbook.xml"
+	xlsxWorkbookRels  = "xl/_rels/workbook.xml.rels"
+	xlsxSharedStrings = "xl/sharedStrings.xml"
+	xlsxStyles        = "xl/styles.xml"
+	xlsxCellShared    = "s"
+	xlsxCellInline    = "inlineStr"
+	xlsxCellBool      = "b"
+	xlsxCellNumber    = "n"
+)
+
+var (
+	// xlsxEpoch is the day 0 of the serial dates, the 1900 leap year bug of Excel included
+	xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	// xlsxEpoch1904 is the day 0 of the workbooks using the 1904 date system
+	xlsxEpoch1904 = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+type xlsxWorkbookXML struct {
+	Properties struct {
+		Date1904 bool `xml:"date1904,attr"`
+	} `xml:"workbookPr"`
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelsXML struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxText is a text that can be split in multiple runs
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var sb strings.Builder
+	for _, r := range t.Runs {
+		sb.WriteString(r.T)
+	}
+	return sb.String()
+}
+
+type xlsxSharedStringsXML struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxStylesXML struct {
+	NumFmts []struct {
+		ID   int    `xml:"numFmtId,attr"`
+		Code string `xml:"formatCode,attr"`
+	} `xml:"numFmts>numFmt"`
+	CellXfs []struct {
+		NumFmtID int `xml:"numFmtId,attr"`
+	} `xml:"cellXfs>xf"`
+}
+
+// dateStyles returns the indexes of the cell styles formatting the numbers as date or time
+func (st xlsxStylesXML) dateStyles() (idxs map[int]bool) {
+	dateFmts := make(map[int]bool)
+	for _, nf := range st.NumFmts {
+		dateFmts[nf.ID] = xlsxIsDateFormat(nf.Code)
+	}
+	idxs = make(map[int]bool)
+	for i, xf := range st.CellXfs {
+		isDate, custom := dateFmts[xf.NumFmtID]
+		if !custom { // the builtin date and time formats
+			isDate = (xf.NumFmtID >= 14 && xf.NumFmtID <= 22) ||
+				(xf.NumFmtID >= 45 && xf.NumFmtID <= 47)
+		}
+		if isDate {
+			idxs[i] = true
+		}
+	}
+	return
+}
+
+type xlsxSheetXML struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Style  int      `xml:"s,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX returns the rows out of the sheet of the xlsx file
+// the first sheet is read if sheet is empty
+// the numbers formatted as date or time are returned as RFC3339 UTC time
+// empty rows and the ones starting with # are ignored, the same as for the csv files
+func readXLSX(rdr io.ReaderAt, size int64, sheet string) (records [][]string, err error) {
+	var zRdr *zip.Reader
+	if zRdr, err = zip.NewReader(rdr, size); err != nil {
+		return
+	}
+	files := make(map[string]*zip.File)
+	for _, f := range zRdr.File {
+		files[f.Name] = f
+	}
+	var wb xlsxWorkbookXML
+	if err = decodeXLSXFile(files, xlsxWorkbook, &wb); err != nil {
+		return
+	}
+	var rID string
+	for _, sht := range wb.Sheets {
+		if sheet == utils.EmptyString || sht.Name == sheet {
+			rID = sht.RID
+			break
+		}
+	}
+	if rID == utils.EmptyString {
+		return nil, fmt.Errorf("sheet <%s> not found", sheet)
+	}
+	var rels xlsxRelsXML
+	if err = decodeXLSXFile(files, xlsxWorkbookRels, &rels); err != nil {
+		return
+	}
+	var sheetPath string
+	for _, rel := range rels.Relationships {
+		if rel.ID != rID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			sheetPath = rel.Target[1:]
+		} else {
+			sheetPath = path.Join(path.Dir(xlsxWorkbook), rel.Target)
+		}
+		break
+	}
+	var shrdStrs xlsxSharedStringsXML
+	if _, has := files[xlsxSharedStrings]; has {
+		if err = decodeXLSXFile(files, xlsxSharedStrings, &shrdStrs); err != nil {
+			return
+		}
+	}
+	var dateStyles map[int]bool
+	if _, has := files[xlsxStyles]; has {
+		var styles xlsxStylesXML
+		if err = decodeXLSXFile(files, xlsxStyles, &styles); err != nil {
+			return
+		}
+		dateStyles = styles.dateStyles()
+	}
+	epoch := xlsxEpoch
+	if wb.Properties.Date1904 {
+		epoch = xlsxEpoch1904
+	}
+	var sht xlsxSheetXML
+	if err = decodeXLSXFile(files, sheetPath, &sht); err != nil {
+		return
+	}
+	for _, row := range sht.Rows {
+		var record []string
+		for i, cell := range row.Cells {
+			idx := i
+			if cell.Ref != utils.EmptyString {
+				if idx, err = xlsxColumnIndex(cell.Ref); err != nil {
+					return
+				}
+			}
+			var val string
+			switch cell.Type {
+			case xlsxCellShared:
+				var shrdIdx int
+				if shrdIdx, err = strconv.Atoi(cell.Value); err != nil {
+					return
+				}
+				if shrdIdx >= len(shrdStrs.Items) {
+					return nil, fmt.Errorf("shared string %d not found", shrdIdx)
+				}
+				val = shrdStrs.Items[shrdIdx].String()
+			case xlsxCellInline:
+				val = cell.Inline.String()
+			case xlsxCellBool:
+				val = strconv.FormatBool(cell.Value == "1")
+			case utils.EmptyString, xlsxCellNumber:
+				val = cell.Value
+				if !dateStyles[cell.Style] || val == utils.EmptyString {
+					break
+				}
+				if val, err = xlsxDate(val, epoch); err != nil {
+					return nil, fmt.Errorf("invalid date in cell <%s>: %s", cell.Ref, err.Error())
+				}
+			default:
+				val = cell.Value
+			}
+			for len(record) <= idx { // empty cells are not written in the file
+				record = append(record, utils.EmptyString)
+			}
+			record[idx] = val
+		}
+		if len(record) == 0 ||
+			strings.HasPrefix(record[0], utils.HashtagSep) {
+			continue
+		}
+		records = append(records, record)
+	}
+	return
+}
+
+// decodeXLSXFile decodes the XML file with the given name out of the xlsx archive
+func decodeXLSXFile(files map[string]*zip.File, name string, v interface{}) (err error) {
+	f, has := files[name]
+	if !has {
+		return fmt.Errorf("missing <%s> in xlsx file", name)
+	}
+	var rdr io.ReadCloser
+	if rdr, err = f.Open(); err != nil {
+		return
+	}
+	defer rdr.Close()
+	return xml.NewDecoder(rdr).Decode(v)
+}
+
+// xlsxDate converts the serial date, the number of days since epoch, to RFC3339 time rounded to the second
+func xlsxDate(serial string, epoch time.Time) (date string, err error) {
+	var days float64
+	if days, err = strconv.ParseFloat(serial, 64); err != nil {
+		return
+	}
+	if days < 0 || math.IsInf(days, 0) || math.IsNaN(days) {
+		return utils.EmptyString, fmt.Errorf("serial date <%s> out of range", serial)
+	}
+	dayNo := math.Floor(days)
+	dayFrac := time.Duration(math.Round((days - dayNo) * float64(24*time.Hour/time.Second)))
+	return epoch.AddDate(0, 0, int(dayNo)).Add(dayFrac * time.Second).Format(time.RFC3339), nil
+}
+
+// xlsxIsDateFormat returns true if the number format code displays a date or time
+// the quoted texts, the escaped characters and the bracketed sections (colors, locales, conditions) are ignored
+func xlsxIsDateFormat(code string) bool {
+	for i := 0; i < len(code); i++ {
+		switch c := code[i]; c {
+		case '"':
+			if end := strings.IndexByte(code[i+1:], '"'); end != -1 {
+				i += end + 1
+			} else {
+				i = len(code)
+			}
+		case '[':
+			end := strings.IndexByte(code[i+1:], ']')
+			if end == -1 {
+				return false
+			}
+			if sect := strings.ToLower(code[i+1 : i+1+end]); sect != utils.EmptyString &&
+				strings.Trim(sect, "hms") == utils.EmptyString { // elapsed time as [h]:mm:ss
+				return true
+			}
+			i += end + 1
+		case '\\', '_', '*': // the next character is literal or padding
+			i++
+		default:
+			switch c | 0x20 { // lower case
+			case 'y', 'm', 'd', 'h', 's':
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// xlsxColumnIndex returns the index of the column out of the cell reference (eg: C7 returns 2)
+func xlsxColumnIndex(ref string) (idx int, err error) {
+	var i int
+	for ; i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z'; i++ {
+		idx = idx*26 + int(ref[i]-'A'+1)
+	}
+	if i == 0 {
+		return 0, fmt.Errorf("invalid cell reference <%s>", ref)
+	}
+	return idx - 1, nil
+}
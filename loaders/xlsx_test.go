@@ -0,0 +1,206 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT MetaAny WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"archive/zip"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeTestXLSX writes a xlsx file with two sheets, Info and Filters
+func writeTestXLSX(t *testing.T, fPath string) {
+	writeXLSXFiles(t, fPath, map[string]string{
+		xlsxWorkbook: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Info" sheetId="1" r:id="rId1"/><sheet name="Filters" sheetId="2" r:id="rId2"/></sheets>
+</workbook>`,
+		xlsxWorkbookRels: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet2.xml"/>
+</Relationships>`,
+		xlsxSharedStrings: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>cgrates.org</t></si><si><r><t>*str</t></r><r><t>ing</t></r></si><si><t>#Tenant</t></si>
+</sst>`,
+		"xl/worksheets/sheet1.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>Rate deck</t></is></c></row></sheetData>
+</worksheet>`,
+		"xl/worksheets/sheet2.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<sheetData>
+<row r="1"><c r="A1" t="s"><v>2</v></c><c r="B1" t="inlineStr"><is><t>ID</t></is></c></row>
+<row r="2"><c r="A2" t="s"><v>0</v></c><c r="B2" t="inlineStr"><is><t>FLTR_XLSX</t></is></c><c r="C2" t="s"><v>1</v></c><c r="D2" t="inlineStr"><is><t>~*req.Account</t></is></c><c r="E2"><v>1001</v></c></row>
+<row r="4"><c r="A4" t="s"><v>0</v></c><c r="B4" t="inlineStr"><is><t>FLTR_XLSX</t></is></c><c r="C4" t="s"><v>1</v></c><c r="D4" t="inlineStr"><is><t>~*req.Destination</t></is></c><c r="F4" t="b"><v>1</v></c></row>
+</sheetData>
+</worksheet>`,
+	})
+}
+
+// writeXLSXFiles writes the xlsx archive out of the XML files
+func writeXLSXFiles(t *testing.T, fPath string, files map[string]string) {
+	f, err := os.Create(fPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zWrt := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zWrt.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = zWrt.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadXLSX(t *testing.T) {
+	fPath := path.Join(t.TempDir(), "Filters.xlsx")
+	writeTestXLSX(t, fPath)
+	f, err := os.Open(fPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := [][]string{{"Rate deck"}}
+	if rcv, err := readXLSX(f, fi.Size(), ""); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %q, received %q", exp, rcv)
+	}
+	exp = [][]string{
+		{"cgrates.org", "FLTR_XLSX", "*string", "~*req.Account", "1001"},
+		{"cgrates.org", "FLTR_XLSX", "*string", "~*req.Destination", "", "true"},
+	}
+	if rcv, err := readXLSX(f, fi.Size(), "Filters"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %q, received %q", exp, rcv)
+	}
+	expErr := "sheet <Rates> not found"
+	if _, err := readXLSX(f, fi.Size(), "Rates"); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error %q, received %v", expErr, err)
+	}
+}
+
+func TestReadXLSXDates(t *testing.T) {
+	fPath := path.Join(t.TempDir(), "Rates.xlsx")
+	sheet := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<sheetData>
+<row r="1"><c r="A1" t="inlineStr"><is><t>RT_1</t></is></c><c r="B1" s="1"><v>44270</v></c><c r="C1" s="2"><v>44270.5</v></c><c r="D1" s="3"><v>0.25</v></c><c r="E1" s="4"><v>44270</v></c><c r="F1"><v>44270</v></c></row>
+</sheetData>
+</worksheet>`
+	files := map[string]string{
+		xlsxWorkbook: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Rates" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`,
+		xlsxWorkbookRels: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`,
+		xlsxStyles: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts count="3"><numFmt numFmtId="164" formatCode="yyyy\-mm\-dd\ hh:mm"/><numFmt numFmtId="165" formatCode="[h]:mm"/><numFmt numFmtId="166" formatCode="[White]0.00&quot;days&quot;"/></numFmts>
+<cellXfs count="5"><xf numFmtId="0"/><xf numFmtId="14"/><xf numFmtId="164"/><xf numFmtId="165"/><xf numFmtId="166"/></cellXfs>
+</styleSheet>`,
+		"xl/worksheets/sheet1.xml": sheet,
+	}
+	writeXLSXFiles(t, fPath, files)
+	readFile := func() ([][]string, error) {
+		f, err := os.Open(fPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		fi, err := f.Stat()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return readXLSX(f, fi.Size(), "")
+	}
+	exp := [][]string{{"RT_1", "2021-03-15T00:00:00Z", "2021-03-15T12:00:00Z", "1899-12-30T06:00:00Z", "44270", "44270"}}
+	if rcv, err := readFile(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %q, received %q", exp, rcv)
+	}
+	// the 1904 date system
+	files[xlsxWorkbook] = strings.Replace(files[xlsxWorkbook], "<sheets>", `<workbookPr date1904="1"/><sheets>`, 1)
+	writeXLSXFiles(t, fPath, files)
+	exp = [][]string{{"RT_1", "2025-03-16T00:00:00Z", "2025-03-16T12:00:00Z", "1904-01-01T06:00:00Z", "44270", "44270"}}
+	if rcv, err := readFile(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %q, received %q", exp, rcv)
+	}
+	files["xl/worksheets/sheet1.xml"] = strings.Replace(sheet, "<v>44270.5</v>", "<v>-1</v>", 1)
+	writeXLSXFiles(t, fPath, files)
+	expErr := "invalid date in cell <C1>: serial date <-1> out of range"
+	if _, err := readFile(); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error %q, received %v", expErr, err)
+	}
+}
+
+func TestXLSXIsDateFormat(t *testing.T) {
+	for code, exp := range map[string]bool{
+		"General":               false,
+		"0.00":                  false,
+		"#,##0;[Red]-#,##0":     false,
+		`0" min"`:               false,
+		`[White]0.00\h`:         false,
+		"dd/mm/yyyy":            true,
+		"[$-409]h:mm AM/PM":     true,
+		"[mm]:ss":               true,
+		`"Date: "yyyy`:          true,
+		`_(* #,##0_);_(* "-"_)`: false,
+	} {
+		if rcv := xlsxIsDateFormat(code); rcv != exp {
+			t.Errorf("Expected %v for %q, received %v", exp, code, rcv)
+		}
+	}
+}
+
+func TestXLSXColumnIndex(t *testing.T) {
+	for ref, exp := range map[string]int{"A1": 0, "C7": 2, "Z3": 25, "AA10": 26, "AB2": 27} {
+		if rcv, err := xlsxColumnIndex(ref); err != nil {
+			t.Error(err)
+		} else if rcv != exp {
+			t.Errorf("Expected %d for %s, received %d", exp, ref, rcv)
+		}
+	}
+	if _, err := xlsxColumnIndex("10"); err == nil {
+		t.Error("Expected error for invalid reference")
+	}
+}
@@ -76,12 +76,15 @@ func NewMigratorStorDB(db_type, host, port, name, user, pass, marshaler string,
 	case utils.Postgres:
 		d = newMigratorSQL(storDb)
 		db = d.(MigratorStorDB)
+	case utils.SQLite:
+		d = newMigratorSQL(storDb)
+		db = d.(MigratorStorDB)
 	case utils.INTERNAL:
 		d = newInternalStorDBMigrator(storDb)
 		db = d.(MigratorStorDB)
 	default:
-		err = fmt.Errorf("Unknown db '%s' valid options are [%s, %s, %s, %s]",
-			db_type, utils.MySQL, utils.Mongo, utils.Postgres, utils.INTERNAL)
+		err = fmt.Errorf("Unknown db '%s' valid options are [%s, %s, %s, %s, %s]",
+			db_type, utils.MySQL, utils.Mongo, utils.Postgres, utils.SQLite, utils.INTERNAL)
 	}
 	return d, nil
 }
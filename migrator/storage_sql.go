@@ -98,7 +98,8 @@ func (mgSQL *migratorSQL) remV1CDRs(v1Cdr *v1Cdrs) (err error) {
 
 func (mgSQL *migratorSQL) renameV1SMCosts() (err error) {
 	qry := "RENAME TABLE sm_costs TO session_costs;"
-	if mgSQL.StorDB().GetStorageType() == utils.Postgres {
+	if mgSQL.StorDB().GetStorageType() == utils.Postgres ||
+		mgSQL.StorDB().GetStorageType() == utils.SQLite {
 		qry = "ALTER TABLE sm_costs RENAME TO session_costs"
 	}
 	if _, err := mgSQL.sqlStorage.Db.Exec(qry); err != nil {
@@ -125,6 +126,22 @@ func (mgSQL *migratorSQL) createV1SMCosts() (err error) {
 	  UNIQUE (cgrid, run_id)
 	);
 		`
+	} else if mgSQL.StorDB().GetStorageType() == utils.SQLite {
+		qry = `
+	CREATE TABLE sm_costs (
+	  id INTEGER PRIMARY KEY AUTOINCREMENT,
+	  cgrid VARCHAR(40) NOT NULL,
+	  run_id  VARCHAR(64) NOT NULL,
+	  origin_host VARCHAR(64) NOT NULL,
+	  origin_id VARCHAR(128) NOT NULL,
+	  cost_source VARCHAR(64) NOT NULL,
+	  usage BIGINT NOT NULL,
+	  cost_details TEXT,
+	  created_at DATETIME,
+	  deleted_at DATETIME NULL,
+	  UNIQUE (cgrid, run_id)
+	);
+		`
 	}
 	if _, err := mgSQL.sqlStorage.Db.Exec("DROP TABLE IF EXISTS session_costs;"); err != nil {
 		return err
@@ -20,6 +20,9 @@ package rates
 
 import (
 	"fmt"
+	"runtime"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -70,6 +73,24 @@ func (rS *RateS) Call(serviceMethod string, args interface{}, reply interface{})
 
 // matchingRateProfileForEvent returns the matched RateProfile for the given event
 func (rS *RateS) matchingRateProfileForEvent(tnt string, rPfIDs []string, args *utils.ArgsCostForEvent) (rtPfl *engine.RateProfile, err error) {
+	var rpWws []*rpWithWeight
+	if rpWws, err = rS.matchingRateProfilesForEvent(tnt, rPfIDs, args, false); err != nil {
+		return
+	}
+	var rpWw *rpWithWeight
+	for _, rpW := range rpWws {
+		if rpWw == nil || rpWw.weight < rpW.weight {
+			rpWw = rpW
+		}
+	}
+	return rpWw.RateProfile, nil
+}
+
+// matchingRateProfilesForEvent returns all the RateProfiles matching the given event, together with their weights
+// with ignoreFilters the FilterIDs of the RateProfiles requested explicitly within rPfIDs are not checked
+func (rS *RateS) matchingRateProfilesForEvent(tnt string, rPfIDs []string, args *utils.ArgsCostForEvent,
+	ignoreFilters bool) (rpWws []*rpWithWeight, err error) {
+	ignoreFilters = ignoreFilters && len(rPfIDs) != 0
 	evNm := utils.MapStorage{
 		utils.MetaReq:  args.CGREvent.Event,
 		utils.MetaOpts: args.Opts,
@@ -91,7 +112,6 @@ func (rS *RateS) matchingRateProfileForEvent(tnt string, rPfIDs []string, args *
 		}
 		rPfIDs = rPfIDMp.AsSlice()
 	}
-	var rpWw *rpWithWeight
 	for _, rPfID := range rPfIDs {
 		var rPf *engine.RateProfile
 		if rPf, err = rS.dm.GetRateProfile(tnt, rPfID,
@@ -106,26 +126,25 @@ func (rS *RateS) matchingRateProfileForEvent(tnt string, rPfIDs []string, args *
 			!rPf.ActivationInterval.IsActiveAtTime(*args.CGREvent.Time) { // not active
 			continue
 		}
-		var pass bool
-		if pass, err = rS.filterS.Pass(tnt, rPf.FilterIDs, evNm); err != nil {
-			return
-		} else if !pass {
-			continue
+		if !ignoreFilters {
+			var pass bool
+			if pass, err = rS.filterS.Pass(tnt, rPf.FilterIDs, evNm); err != nil {
+				return
+			} else if !pass {
+				continue
+			}
 		}
 		var rPfWeight float64
 		if rPfWeight, err = engine.WeightFromDynamics(rPf.Weights,
 			rS.filterS, tnt, evNm); err != nil {
 			return
 		}
-		if rpWw == nil || rpWw.weight < rPfWeight {
-			rpWw = &rpWithWeight{rPf, rPfWeight}
-		}
+		rpWws = append(rpWws, &rpWithWeight{rPf, rPfWeight})
 	}
-	if rpWw == nil {
+	if len(rpWws) == 0 {
 		return nil, utils.ErrNotFound
 	}
-
-	return rpWw.RateProfile, nil
+	return
 }
 
 // rateProfileCostForEvent computes the rateProfileCost for an event based on a preselected rate profile
@@ -227,3 +246,121 @@ func (rS *RateS) V1CostForEvent(args *utils.ArgsCostForEvent, rpCost *engine.Rat
 	*rpCost = *rcvCost
 	return
 }
+
+// V1CostForEvents will be called to calculate the costs for a batch of events
+// each event is rated with its own matching RateProfile, the costs are returned in the order of the events
+// the events are rated by a bounded pool of workers, failing events have their own error populated
+func (rS *RateS) V1CostForEvents(args *utils.ArgsCostForEvents, rpCosts *[]*engine.RateProfileCostForEvent) (err error) {
+	if len(args.CGREvents) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.CGREventsString)
+	}
+	tnt := utils.FirstNonEmpty(args.Tenant, rS.cfg.GeneralCfg().DefaultTenant)
+	costs := make([]*engine.RateProfileCostForEvent, len(args.CGREvents))
+	runOnWorkers(len(args.CGREvents), func(i int) {
+		costs[i] = rS.costForBatchEvent(tnt, args, args.CGREvents[i])
+	})
+	*rpCosts = costs
+	return
+}
+
+// runOnWorkers calls process for each of the itmsNo indexes out of a pool of maximum runtime.NumCPU() goroutines
+// it returns once all the indexes were processed
+func runOnWorkers(itmsNo int, process func(i int)) {
+	idxs := make(chan int, itmsNo)
+	for i := 0; i < itmsNo; i++ {
+		idxs <- i
+	}
+	close(idxs)
+	wrkrs := runtime.NumCPU()
+	if wrkrs > itmsNo {
+		wrkrs = itmsNo
+	}
+	var wg sync.WaitGroup
+	wg.Add(wrkrs)
+	for w := 0; w < wrkrs; w++ {
+		go func() {
+			for i := range idxs {
+				process(i)
+			}
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+}
+
+// costForBatchEvent rates one event out of a V1CostForEvents batch
+// the batch Opts are used for the options not present in the event
+func (rS *RateS) costForBatchEvent(tnt string, args *utils.ArgsCostForEvents,
+	cgrEv *utils.CGREvent) (evCost *engine.RateProfileCostForEvent) {
+	evCost = new(engine.RateProfileCostForEvent)
+	if cgrEv == nil {
+		evCost.Error = utils.NewErrMandatoryIeMissing(utils.CGREventString).Error()
+		return
+	}
+	evCost.EventID = cgrEv.ID
+	ev := &utils.CGREvent{
+		Tenant: utils.FirstNonEmpty(cgrEv.Tenant, tnt),
+		ID:     cgrEv.ID,
+		Time:   cgrEv.Time,
+		Event:  cgrEv.Event,
+		Opts:   make(map[string]interface{}, len(args.Opts)+len(cgrEv.Opts)),
+	}
+	for k, v := range args.Opts {
+		ev.Opts[k] = v
+	}
+	for k, v := range cgrEv.Opts {
+		ev.Opts[k] = v
+	}
+	rpCost := new(engine.RateProfileCost)
+	if err := rS.V1CostForEvent(&utils.ArgsCostForEvent{
+		RateProfileIDs: args.RateProfileIDs,
+		CGREvent:       ev,
+	}, rpCost); err != nil {
+		evCost.Error = err.Error()
+		return
+	}
+	evCost.Cost = rpCost
+	return
+}
+
+// V1RateProfilesCostForEvent will be called to calculate the cost of an event with each of the matching RateProfiles
+// the RateProfiles are rated by a bounded pool of workers, the failing ones have their own error populated
+// the costs are returned side by side, ordered ascending by Cost with the failing RateProfiles last
+// the RateProfiles requested within RateProfileIDs are rated without checking their FilterIDs
+func (rS *RateS) V1RateProfilesCostForEvent(args *utils.ArgsCostForEvent, rpCosts *[]*engine.RateProfileCostForProfile) (err error) {
+	if args.CGREvent == nil {
+		return utils.NewErrMandatoryIeMissing(utils.CGREventString)
+	}
+	tnt := utils.FirstNonEmpty(args.Tenant, rS.cfg.GeneralCfg().DefaultTenant)
+	var rpWws []*rpWithWeight
+	if rpWws, err = rS.matchingRateProfilesForEvent(tnt, args.RateProfileIDs, args, true); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	costs := make([]*engine.RateProfileCostForProfile, len(rpWws))
+	runOnWorkers(len(rpWws), func(i int) {
+		costs[i] = &engine.RateProfileCostForProfile{RateProfileID: rpWws[i].RateProfile.ID}
+		var cErr error
+		if costs[i].Cost, cErr = rS.rateProfileCostForEvent(rpWws[i].RateProfile,
+			args, rS.cfg.RateSCfg().Verbosity); cErr != nil {
+			if cErr != utils.ErrNotFound {
+				cErr = utils.NewErrServerError(cErr)
+			}
+			costs[i].Error = cErr.Error()
+		}
+	})
+	sort.SliceStable(costs, func(i, j int) bool {
+		if (costs[i].Cost == nil) != (costs[j].Cost == nil) {
+			return costs[i].Cost != nil
+		}
+		if costs[i].Cost == nil ||
+			costs[i].Cost.Cost == costs[j].Cost.Cost {
+			return costs[i].RateProfileID < costs[j].RateProfileID
+		}
+		return costs[i].Cost.Cost < costs[j].Cost.Cost
+	})
+	*rpCosts = costs
+	return
+}
@@ -970,3 +970,215 @@ func TestRateProfileCostForEventMaximumIterations(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestV1RateProfilesCostForEvent(t *testing.T) {
+	defaultCfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	rateS := NewRateS(defaultCfg, filters, dm)
+	minDecimal, err := utils.NewDecimalFromUsage("1m")
+	if err != nil {
+		t.Error(err)
+	}
+	newRPrf := func(id string, weight float64, fee *utils.Decimal) *engine.RateProfile {
+		return &engine.RateProfile{
+			Tenant:    "cgrates.org",
+			ID:        id,
+			FilterIDs: []string{"*string:~*req.Account:1001"},
+			Weights: utils.DynamicWeights{
+				{
+					Weight: weight,
+				},
+			},
+			Rates: map[string]*engine.Rate{
+				"RATE1": {
+					ID:              "RATE1",
+					ActivationTimes: "* * * * *",
+					IntervalRates: []*engine.IntervalRate{
+						{
+							IntervalStart: 0,
+							RecurrentFee:  fee,
+							Unit:          minDecimal,
+							Increment:     minDecimal,
+						},
+					},
+				},
+			},
+		}
+	}
+	for _, rPrf := range []*engine.RateProfile{
+		newRPrf("RP_EXPENSIVE", 20, utils.NewDecimal(5, 1)),
+		newRPrf("RP_CHEAP", 10, utils.NewDecimal(2, 1)),
+	} {
+		if err := rateS.dm.SetRateProfile(rPrf, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	args := &utils.ArgsCostForEvent{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "EV1",
+			Event: map[string]interface{}{
+				utils.AccountField: "1001",
+				utils.Usage:        2 * time.Minute,
+			},
+		},
+	}
+	var rpCosts []*engine.RateProfileCostForProfile
+	if err := rateS.V1RateProfilesCostForEvent(args, &rpCosts); err != nil {
+		t.Fatal(err)
+	}
+	if len(rpCosts) != 2 {
+		t.Fatalf("Expected 2 costs, received %s", utils.ToJSON(rpCosts))
+	}
+	if rpCosts[0].RateProfileID != "RP_CHEAP" || rpCosts[0].Error != utils.EmptyString ||
+		rpCosts[0].Cost == nil || rpCosts[0].Cost.Cost != 0.4 {
+		t.Errorf("Unexpected cost: %s", utils.ToJSON(rpCosts[0]))
+	}
+	if rpCosts[1].RateProfileID != "RP_EXPENSIVE" || rpCosts[1].Error != utils.EmptyString ||
+		rpCosts[1].Cost == nil || rpCosts[1].Cost.Cost != 1 {
+		t.Errorf("Unexpected cost: %s", utils.ToJSON(rpCosts[1]))
+	}
+
+	args.RateProfileIDs = []string{"RP_CHEAP", "RP_INEXISTENT"}
+	if err := rateS.V1RateProfilesCostForEvent(args, &rpCosts); err != nil {
+		t.Fatal(err)
+	} else if len(rpCosts) != 1 || rpCosts[0].RateProfileID != "RP_CHEAP" {
+		t.Errorf("Unexpected costs: %s", utils.ToJSON(rpCosts))
+	}
+
+	// a broken RateProfile does not stop the others from being rated
+	brknPrf := newRPrf("RP_BROKEN", 30, utils.NewDecimal(1, 1))
+	brknPrf.Rates["RATE1"].IntervalRates[0].Increment = utils.NewDecimal(0, 0)
+	if err := rateS.dm.SetRateProfile(brknPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	args.RateProfileIDs = nil
+	if err := rateS.V1RateProfilesCostForEvent(args, &rpCosts); err != nil {
+		t.Fatal(err)
+	} else if len(rpCosts) != 3 {
+		t.Fatalf("Expected 3 costs, received %s", utils.ToJSON(rpCosts))
+	}
+	if rpCosts[0].RateProfileID != "RP_CHEAP" || rpCosts[0].Cost == nil ||
+		rpCosts[1].RateProfileID != "RP_EXPENSIVE" || rpCosts[1].Cost == nil {
+		t.Errorf("Unexpected costs: %s", utils.ToJSON(rpCosts))
+	}
+	if rpCosts[2].RateProfileID != "RP_BROKEN" || rpCosts[2].Cost != nil ||
+		rpCosts[2].Error != "SERVER_ERROR: zero increment to be charged within rate: <cgrates.org:RP_BROKEN:RATE1>" {
+		t.Errorf("Unexpected cost: %s", utils.ToJSON(rpCosts[2]))
+	}
+
+	args.CGREvent.Event[utils.AccountField] = "1002"
+	if err := rateS.V1RateProfilesCostForEvent(args, &rpCosts); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+	// the RateProfiles requested explicitly are rated without checking their filters
+	args.RateProfileIDs = []string{"RP_CHEAP", "RP_EXPENSIVE"}
+	if err := rateS.V1RateProfilesCostForEvent(args, &rpCosts); err != nil {
+		t.Fatal(err)
+	} else if len(rpCosts) != 2 || rpCosts[0].RateProfileID != "RP_CHEAP" ||
+		rpCosts[1].RateProfileID != "RP_EXPENSIVE" {
+		t.Errorf("Unexpected costs: %s", utils.ToJSON(rpCosts))
+	}
+}
+
+func TestV1CostForEvents(t *testing.T) {
+	defaultCfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	rateS := NewRateS(defaultCfg, filters, dm)
+	minDecimal, err := utils.NewDecimalFromUsage("1m")
+	if err != nil {
+		t.Error(err)
+	}
+	rPrf := &engine.RateProfile{
+		Tenant: "cgrates.org",
+		ID:     "RP_1",
+		Rates: map[string]*engine.Rate{
+			"RATE1": {
+				ID:              "RATE1",
+				ActivationTimes: "* * * * *",
+				IntervalRates: []*engine.IntervalRate{
+					{
+						IntervalStart: 0,
+						RecurrentFee:  utils.NewDecimal(2, 1),
+						Unit:          minDecimal,
+						Increment:     minDecimal,
+					},
+				},
+			},
+		},
+	}
+	if err := rateS.dm.SetRateProfile(rPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	var rpCosts []*engine.RateProfileCostForEvent
+	if err := rateS.V1CostForEvents(&utils.ArgsCostForEvents{}, &rpCosts); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.CGREventsString).Error() {
+		t.Errorf("Expected %+v, received %+v", utils.NewErrMandatoryIeMissing(utils.CGREventsString), err)
+	}
+	args := &utils.ArgsCostForEvents{
+		Tenant: "cgrates.org",
+		CGREvents: []*utils.CGREvent{
+			{
+				ID: "EV1",
+				Event: map[string]interface{}{
+					utils.Usage: time.Minute,
+				},
+			},
+			{
+				ID: "EV2",
+				Event: map[string]interface{}{
+					utils.Usage: 3 * time.Minute,
+				},
+			},
+			{
+				ID: "EV3",
+				Event: map[string]interface{}{
+					utils.Usage: 3 * time.Minute,
+				},
+				Opts: map[string]interface{}{
+					utils.OptsRatesUsage: 2 * time.Minute,
+				},
+			},
+			{
+				ID:    "EV4",
+				Event: map[string]interface{}{},
+			},
+		},
+		Opts: map[string]interface{}{
+			utils.OptsRatesUsage: 5 * time.Minute,
+		},
+	}
+	if err := rateS.V1CostForEvents(args, &rpCosts); err != nil {
+		t.Fatal(err)
+	}
+	if len(rpCosts) != 4 {
+		t.Fatalf("Expected 4 costs, received %s", utils.ToJSON(rpCosts))
+	}
+	// the batch Opts are used only when the event has no options of its own
+	for i, expCost := range []float64{1, 1, 0.4, 1} {
+		if rpCosts[i].EventID != args.CGREvents[i].ID || rpCosts[i].Error != utils.EmptyString ||
+			rpCosts[i].Cost.ID != "RP_1" || rpCosts[i].Cost.Cost != expCost {
+			t.Errorf("Unexpected cost: %s", utils.ToJSON(rpCosts[i]))
+		}
+	}
+	if _, has := args.CGREvents[0].Opts[utils.OptsRatesUsage]; has {
+		t.Errorf("The event options should not be altered: %s", utils.ToJSON(args.CGREvents[0]))
+	}
+
+	// the failing events do not fail the batch
+	args.Opts = nil
+	args.RateProfileIDs = []string{"RP_INEXISTENT"}
+	if err := rateS.V1CostForEvents(args, &rpCosts); err != nil {
+		t.Fatal(err)
+	}
+	for i, rpCost := range rpCosts {
+		if rpCost.EventID != args.CGREvents[i].ID || rpCost.Cost != nil ||
+			rpCost.Error != utils.ErrNotFound.Error() {
+			t.Errorf("Unexpected cost: %s", utils.ToJSON(rpCost))
+		}
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/cores"
+	"github.com/cgrates/cgrates/ees"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/ers"
+	"github.com/cgrates/cgrates/servmanager"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewPrometheusAgent returns the Prometheus Agent
+func NewPrometheusAgent(cfg *config.CGRConfig, caps *engine.Caps,
+	server *cores.Server, connMgr *engine.ConnManager,
+	srvDep map[string]*sync.WaitGroup) servmanager.Service {
+	return &PrometheusAgent{
+		cfg:     cfg,
+		caps:    caps,
+		server:  server,
+		connMgr: connMgr,
+		srvDep:  srvDep,
+	}
+}
+
+// PrometheusAgent implements Agent interface
+type PrometheusAgent struct {
+	sync.RWMutex
+	cfg    *config.CGRConfig
+	caps   *engine.Caps
+	server *cores.Server
+
+	// the http handlers can not be unregistered so keep them
+	// registered and only serve the metrics while started, on the configured path
+	started bool
+	path    string          // the path serving the metrics
+	paths   utils.StringSet // the paths with the handler registered
+	agent   *agents.PrometheusAgent
+	connMgr *engine.ConnManager
+	srvDep  map[string]*sync.WaitGroup
+}
+
+// Start should handle the sercive start
+func (pa *PrometheusAgent) Start() (err error) {
+	if pa.IsRunning() {
+		return utils.ErrServiceAlreadyRunning
+	}
+	pa.Lock()
+	defer pa.Unlock()
+	if pa.agent == nil {
+		pa.agent = agents.NewPrometheusAgent(pa.cfg, pa.caps, pa.connMgr)
+		pa.paths = make(utils.StringSet)
+	}
+	pa.setPath()
+	if err = pa.agent.RegisterCollectors(metricsCollectors()...); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> error: %s registering the metrics", utils.PrometheusAgent, err.Error()))
+		return
+	}
+	pa.started = true
+	utils.Logger.Info(fmt.Sprintf("<%s> successfully started PrometheusAgent", utils.PrometheusAgent))
+	return
+}
+
+// metricsCollectors returns the metrics recorded by the subsystems
+func metricsCollectors() (cs []prometheus.Collector) {
+	cs = append(cs, cores.MetricsCollectors()...)
+	cs = append(cs, engine.MetricsCollectors()...)
+	cs = append(cs, ers.MetricsCollectors()...)
+	return append(cs, ees.MetricsCollectors()...)
+}
+
+// setPath serves the metrics on the configured path, registering the handler only once per path
+func (pa *PrometheusAgent) setPath() {
+	pa.path = pa.cfg.PrometheusAgentCfg().Path
+	if !pa.paths.Has(pa.path) {
+		pa.server.RegisterHttpFunc(pa.path, pa.serveHTTP)
+		pa.paths.Add(pa.path)
+	}
+}
+
+func (pa *PrometheusAgent) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	pa.RLock()
+	serve := pa.started && r.URL.Path == pa.path
+	pa.RUnlock()
+	if !serve { // stopped or served on other path since the last reload
+		http.NotFound(w, r)
+		return
+	}
+	pa.agent.ServeHTTP(w, r)
+}
+
+// Reload handles the change of config
+// the connections are read from config on each scrape so only the path needs to be updated
+func (pa *PrometheusAgent) Reload() (err error) {
+	pa.Lock()
+	if pa.started {
+		pa.setPath()
+	}
+	pa.Unlock()
+	return
+}
+
+// Shutdown stops the service
+func (pa *PrometheusAgent) Shutdown() (err error) {
+	pa.Lock()
+	if pa.started {
+		pa.agent.UnregisterCollectors(metricsCollectors()...)
+	}
+	pa.started = false
+	pa.Unlock()
+	return
+}
+
+// IsRunning returns if the service is running
+func (pa *PrometheusAgent) IsRunning() bool {
+	pa.RLock()
+	defer pa.RUnlock()
+	return pa != nil && pa.started
+}
+
+// ServiceName returns the service name
+func (pa *PrometheusAgent) ServiceName() string {
+	return utils.PrometheusAgent
+}
+
+// ShouldRun returns if the service should be running
+func (pa *PrometheusAgent) ShouldRun() bool {
+	return pa.cfg.PrometheusAgentCfg().Enabled
+}
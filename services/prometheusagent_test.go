@@ -0,0 +1,99 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/cores"
+	"github.com/cgrates/cgrates/ees"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func TestPrometheusAgentCoverage(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.PrometheusAgentCfg().CachesConns = nil
+	server := cores.NewServer(nil)
+	srvDep := map[string]*sync.WaitGroup{utils.DataDB: new(sync.WaitGroup)}
+	cM := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{})
+	srv := NewPrometheusAgent(cfg, nil, server, cM, srvDep)
+	if srv.IsRunning() {
+		t.Errorf("Expected service to be down")
+	}
+	if srv.ShouldRun() {
+		t.Errorf("Expected service to not run with the default config")
+	}
+	if srv.ServiceName() != utils.PrometheusAgent {
+		t.Errorf("Expecting <%+v>, received <%+v>", utils.PrometheusAgent, srv.ServiceName())
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Start(); err != utils.ErrServiceAlreadyRunning {
+		t.Errorf("Expected %v, received %v", utils.ErrServiceAlreadyRunning, err)
+	}
+	pa := srv.(*PrometheusAgent)
+	if err := pa.agent.RegisterCollectors(ees.MetricsCollectors()...); err == nil {
+		t.Errorf("Expected the EEs metrics registered on start")
+	}
+	rec := httptest.NewRecorder()
+	pa.serveHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Unexpected status code: %d", rec.Code)
+	}
+	// the metrics move to the new path on reload
+	cfg.PrometheusAgentCfg().Path = "/prometheus"
+	if err := srv.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	for path, code := range map[string]int{"/metrics": http.StatusNotFound, "/prometheus": http.StatusOK} {
+		rec = httptest.NewRecorder()
+		pa.serveHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != code {
+			t.Errorf("Expected status code %d for %s, received: %d", code, path, rec.Code)
+		}
+	}
+	if !pa.paths.Has("/metrics") || !pa.paths.Has("/prometheus") {
+		t.Errorf("Expected the handler registered on both paths, received: %v", pa.paths)
+	}
+	// switching back does not register the handler twice
+	cfg.PrometheusAgentCfg().Path = "/metrics"
+	if err := srv.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	rec = httptest.NewRecorder()
+	pa.serveHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Unexpected status code: %d", rec.Code)
+	}
+	// starting again should not register the handler twice
+	// and needs the metrics unregistered on shutdown
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -107,7 +107,8 @@ func (db *StorDBService) Reload() (err error) {
 		}
 		mgo.SetTTL(ttl)
 	} else if db.cfg.StorDbCfg().Type == utils.Postgres ||
-		db.cfg.StorDbCfg().Type == utils.MySQL {
+		db.cfg.StorDbCfg().Type == utils.MySQL ||
+		db.cfg.StorDbCfg().Type == utils.SQLite {
 		msql, canCast := db.db.(*engine.SQLStorage)
 		if !canCast {
 			return fmt.Errorf("can't conver StorDB of type %s to SQLStorage",
@@ -225,6 +225,8 @@ func (srvMngr *ServiceManager) handleReload() {
 			go srvMngr.reloadService(utils.DiameterAgent)
 		case <-srvMngr.GetConfig().GetReloadChan(config.HttpAgentJson):
 			go srvMngr.reloadService(utils.HTTPAgent)
+		case <-srvMngr.GetConfig().GetReloadChan(config.PrometheusAgentJSN):
+			go srvMngr.reloadService(utils.PrometheusAgent)
 		case <-srvMngr.GetConfig().GetReloadChan(config.LoaderJson):
 			go srvMngr.reloadService(utils.LoaderS)
 		case <-srvMngr.GetConfig().GetReloadChan(config.AnalyzerCfgJson):
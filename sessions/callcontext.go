@@ -0,0 +1,192 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package sessions
+
+import (
+	"fmt"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// V1LinkSessionsArgs are the arguments for LinkSessions API
+type V1LinkSessionsArgs struct {
+	Tenant        string
+	CallContextID string   // the call the sessions are moved into
+	CGRIDs        []string // the sessions(legs) to be linked
+	Opts          map[string]interface{}
+}
+
+// V1RebillSessionArgs are the arguments for RebillSession API
+type V1RebillSessionArgs struct {
+	Tenant  string
+	CGRID   string
+	RunIDs  []string // the runs to be rebilled, all of them if empty
+	Account string   // the account paying for the session from now on
+	Subject string   // the rating subject, follows the Account if the run was rated on its account
+	Opts    map[string]interface{}
+}
+
+// indexCallContext adds the active session to the call context index
+// not thread safe for the Session
+func (sS *SessionS) indexCallContext(s *Session) {
+	cCtxID := s.callContextID()
+	acnts := s.payingAccounts()
+	sS.cCtxMux.Lock()
+	sS.unindexCallContextWithoutLock(s.CGRID)
+	if _, has := sS.cCtxs[cCtxID]; !has {
+		sS.cCtxs[cCtxID] = make(map[string]utils.StringSet)
+	}
+	sS.cCtxs[cCtxID][s.CGRID] = acnts
+	sS.cCtxsRIdx[s.CGRID] = cCtxID
+	sS.cCtxMux.Unlock()
+}
+
+// unindexCallContext removes the session from the call context index
+func (sS *SessionS) unindexCallContext(cgrID string) {
+	sS.cCtxMux.Lock()
+	sS.unindexCallContextWithoutLock(cgrID)
+	sS.cCtxMux.Unlock()
+}
+
+func (sS *SessionS) unindexCallContextWithoutLock(cgrID string) {
+	cCtxID, has := sS.cCtxsRIdx[cgrID]
+	if !has {
+		return
+	}
+	delete(sS.cCtxs[cCtxID], cgrID)
+	if len(sS.cCtxs[cCtxID]) == 0 {
+		delete(sS.cCtxs, cCtxID)
+	}
+	delete(sS.cCtxsRIdx, cgrID)
+}
+
+// callContextLegs returns the other active legs of the call paid by the given tenant:account
+func (sS *SessionS) callContextLegs(cCtxID, cgrID, acntKey string) (cgrIDs []string) {
+	sS.cCtxMux.RLock()
+	for legID, acnts := range sS.cCtxs[cCtxID] {
+		if legID != cgrID && acnts.Has(acntKey) {
+			cgrIDs = append(cgrIDs, legID)
+		}
+	}
+	sS.cCtxMux.RUnlock()
+	return
+}
+
+// disconnectCallContextLegs disconnects the other legs of the call paid by the given tenant:account
+// should be called without holding the lock of any session
+func (sS *SessionS) disconnectCallContextLegs(cCtxID, cgrID, acntKey string) {
+	for _, s := range sS.getSessionsFromCGRIDs(false, sS.callContextLegs(cCtxID, cgrID, acntKey)...) {
+		if s == nil { // terminated in the meantime
+			continue
+		}
+		s.Lock()
+		var err error
+		for i := 0; i < sS.cgrCfg.SessionSCfg().TerminateAttempts; i++ {
+			if err = sS.disconnectSession(s, utils.ErrInsufficientCredit.Error()); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> could not disconnect session: <%s> of call: <%s>, error: <%s>",
+					utils.SessionS, s.cgrID(), cCtxID, err.Error()))
+			if err = sS.forceSTerminate(s, 0, nil, nil); err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> failed force-terminating session: <%s>, err: <%s>",
+					utils.SessionS, s.cgrID(), err))
+			}
+		}
+		s.Unlock()
+	}
+}
+
+// BiRPCv1LinkSessions moves the active sessions as legs into the same call
+func (sS *SessionS) BiRPCv1LinkSessions(clnt rpcclient.ClientConnector,
+	args *V1LinkSessionsArgs, reply *string) (err error) {
+	if args.CallContextID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.CallContextID)
+	}
+	if len(args.CGRIDs) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.CGRIDs)
+	}
+	ss := sS.getSessionsFromCGRIDs(false, args.CGRIDs...)
+	for _, s := range ss {
+		if s == nil {
+			return utils.ErrNotFound
+		}
+	}
+	for _, s := range ss {
+		s.Lock()
+		sS.unindexSession(s.CGRID, false)
+		s.CallContextID = args.CallContextID
+		s.EventStart[utils.CallContextID] = args.CallContextID
+		for _, sr := range s.SRuns {
+			sr.Event[utils.CallContextID] = args.CallContextID
+		}
+		sS.indexSession(s, false)
+		sS.indexCallContext(s)
+		s.Unlock()
+		sS.replicateSessions(s.CGRID, false, sS.cgrCfg.SessionSCfg().ReplicationConns)
+		sS.backupSession(s.CGRID)
+	}
+	*reply = utils.OK
+	return
+}
+
+// BiRPCv1RebillSession changes the account paying for an active session
+// the debits done so far remain on the previous account
+func (sS *SessionS) BiRPCv1RebillSession(clnt rpcclient.ClientConnector,
+	args *V1RebillSessionArgs, reply *string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.CGRID, utils.AccountField}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	ss := sS.getSessions(args.CGRID, false)
+	if len(ss) == 0 {
+		return utils.ErrNotFound
+	}
+	s := ss[0]
+	runIDs := utils.NewStringSet(args.RunIDs)
+	var rebilled bool
+	s.Lock()
+	sS.unindexSession(s.CGRID, false)
+	for _, sr := range s.SRuns {
+		if runIDs.Size() != 0 && !runIDs.Has(sr.CD.RunID) {
+			continue
+		}
+		if args.Subject != utils.EmptyString {
+			sr.CD.Subject = args.Subject
+		} else if sr.CD.Subject == sr.CD.Account {
+			sr.CD.Subject = args.Account
+		}
+		sr.CD.Account = args.Account
+		sr.Event[utils.AccountField] = sr.CD.Account
+		sr.Event[utils.Subject] = sr.CD.Subject
+		rebilled = true
+	}
+	sS.indexSession(s, false)
+	sS.indexCallContext(s)
+	s.Unlock()
+	if !rebilled {
+		return utils.ErrNotFound
+	}
+	sS.replicateSessions(s.CGRID, false, sS.cgrCfg.SessionSCfg().ReplicationConns)
+	sS.backupSession(s.CGRID)
+	*reply = utils.OK
+	return
+}
@@ -64,6 +64,7 @@ type ExternalSession struct {
 	MaxCostSoFar  float64
 	DebitInterval time.Duration
 	NextAutoDebit time.Time
+	CallContextID string // the call this session is a leg of, empty if not linked
 }
 
 // Session is the main structure to describe a call
@@ -78,6 +79,7 @@ type Session struct {
 	DebitInterval time.Duration   // execute debits for *prepaid runs
 	SRuns         []*SRun         // forked based on ChargerS
 	OptsStart     engine.MapEvent
+	CallContextID string // groups the legs of the same call, defaults to CGRID
 
 	debitStop   chan struct{}
 	sTerminator *sTerminator // automatic timeout for the session
@@ -121,6 +123,7 @@ func (s *Session) Clone() (cln *Session) {
 		ClientConnID:  s.ClientConnID,
 		EventStart:    s.EventStart.Clone(),
 		DebitInterval: s.DebitInterval,
+		CallContextID: s.CallContextID,
 	}
 	if s.SRuns != nil {
 		cln.SRuns = make([]*SRun, len(s.SRuns))
@@ -156,6 +159,7 @@ func (s *Session) AsExternalSessions(tmz, nodeID string) (aSs []*ExternalSession
 			ExtraFields:   sr.Event.AsMapString(utils.MainCDRFields),
 			NodeID:        nodeID,
 			DebitInterval: s.DebitInterval,
+			CallContextID: s.CallContextID,
 		}
 		if sr.NextAutoDebit != nil {
 			aSs[i].NextAutoDebit = *sr.NextAutoDebit
@@ -193,6 +197,7 @@ func (s *Session) AsExternalSession(sr *SRun, tmz, nodeID string) (aS *ExternalS
 		ExtraFields:   sr.Event.AsMapString(utils.MainCDRFields),
 		NodeID:        nodeID,
 		DebitInterval: s.DebitInterval,
+		CallContextID: s.CallContextID,
 	}
 	if sr.NextAutoDebit != nil {
 		aS.NextAutoDebit = *sr.NextAutoDebit
@@ -207,6 +212,27 @@ func (s *Session) AsExternalSession(sr *SRun, tmz, nodeID string) (aS *ExternalS
 	return
 }
 
+// callContextID returns the ID of the call the session is a leg of
+// not thread safe
+func (s *Session) callContextID() string {
+	if s.CallContextID == utils.EmptyString {
+		return s.CGRID
+	}
+	return s.CallContextID
+}
+
+// payingAccounts returns the tenant:account keys of the runs debiting an account
+// not thread safe
+func (s *Session) payingAccounts() (acnts utils.StringSet) {
+	acnts = make(utils.StringSet)
+	for _, sr := range s.SRuns {
+		if authReqs.HasField(sr.Event.GetStringIgnoreErrors(utils.RequestType)) {
+			acnts.Add(utils.ConcatenatedKey(sr.CD.Tenant, sr.CD.Account))
+		}
+	}
+	return
+}
+
 // totalUsage returns the first session run total usage
 // not thread save
 func (s *Session) totalUsage() (tDur time.Duration) {
@@ -0,0 +1,146 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package sessions
+
+import (
+	"fmt"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// asSessionBackup returns the session as a SessionBackup owned by the node (thread safe)
+func (s *Session) asSessionBackup(nodeID string) (sb *engine.SessionBackup) {
+	s.RLock()
+	sb = &engine.SessionBackup{
+		NodeID:        nodeID,
+		CGRID:         s.CGRID,
+		Tenant:        s.Tenant,
+		ResourceID:    s.ResourceID,
+		ClientConnID:  s.ClientConnID,
+		EventStart:    s.EventStart.Clone(),
+		DebitInterval: s.DebitInterval,
+		OptsStart:     s.OptsStart.Clone(),
+		CallContextID: s.CallContextID,
+		Chargeable:    s.chargeable,
+		SRuns:         make([]*engine.SRunBackup, len(s.SRuns)),
+	}
+	for i, sr := range s.SRuns {
+		clsr := sr.Clone()
+		sb.SRuns[i] = &engine.SRunBackup{
+			Event:         clsr.Event,
+			CD:            clsr.CD,
+			EventCost:     clsr.EventCost,
+			ExtraDuration: clsr.ExtraDuration,
+			LastUsage:     clsr.LastUsage,
+			TotalUsage:    clsr.TotalUsage,
+			NextAutoDebit: clsr.NextAutoDebit,
+		}
+	}
+	s.RUnlock()
+	return
+}
+
+// newSessionFromBackup recreates the session out of its backup
+func newSessionFromBackup(sb *engine.SessionBackup) (s *Session) {
+	s = &Session{
+		CGRID:         sb.CGRID,
+		Tenant:        sb.Tenant,
+		ResourceID:    sb.ResourceID,
+		ClientConnID:  sb.ClientConnID,
+		EventStart:    sb.EventStart,
+		DebitInterval: sb.DebitInterval,
+		OptsStart:     sb.OptsStart,
+		CallContextID: sb.CallContextID,
+		chargeable:    sb.Chargeable,
+		SRuns:         make([]*SRun, len(sb.SRuns)),
+	}
+	if s.EventStart == nil {
+		s.EventStart = make(engine.MapEvent)
+	}
+	for i, sr := range sb.SRuns {
+		s.SRuns[i] = &SRun{
+			Event:         sr.Event,
+			CD:            sr.CD,
+			EventCost:     sr.EventCost,
+			ExtraDuration: sr.ExtraDuration,
+			LastUsage:     sr.LastUsage,
+			TotalUsage:    sr.TotalUsage,
+			NextAutoDebit: sr.NextAutoDebit,
+		}
+	}
+	return
+}
+
+// backupSession checkpoints the active session into DataDB
+// or removes its backup if the session is no longer active
+func (sS *SessionS) backupSession(cgrID string) {
+	if !sS.cgrCfg.SessionSCfg().BackupSessions ||
+		cgrID == utils.EmptyString {
+		return
+	}
+	nodeID := sS.cgrCfg.GeneralCfg().NodeID
+	ss := sS.getSessions(cgrID, false)
+	if len(ss) == 0 {
+		if err := sS.dm.RemoveSessionBackup(nodeID, cgrID); err != nil &&
+			err != utils.ErrNotFound {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> cannot remove the backup of session with id <%s>, err: %s",
+					utils.SessionS, cgrID, err.Error()))
+		}
+		return
+	}
+	if err := sS.dm.SetSessionBackup(ss[0].asSessionBackup(nodeID)); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot backup session with id <%s>, err: %s",
+				utils.SessionS, cgrID, err.Error()))
+	}
+}
+
+// restoreSessions restores the active sessions of this node out of their backups in DataDB
+// the restored sessions not known anymore by the clients are terminated on the next sync
+func (sS *SessionS) restoreSessions() {
+	if !sS.cgrCfg.SessionSCfg().BackupSessions {
+		return
+	}
+	sbs, err := sS.dm.GetSessionBackups(sS.cgrCfg.GeneralCfg().NodeID)
+	if err != nil {
+		if err != utils.ErrNotFound {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> cannot restore the sessions, err: %s",
+					utils.SessionS, err.Error()))
+		}
+		return
+	}
+	var restored int
+	for _, sb := range sbs {
+		s := newSessionFromBackup(sb)
+		if sS.isIndexed(s, false) {
+			continue
+		}
+		s.Lock()
+		sS.setSTerminator(s, nil)
+		sS.initSessionDebitLoops(s)
+		sS.registerSession(s, false)
+		s.Unlock()
+		restored++
+	}
+	utils.Logger.Info(
+		fmt.Sprintf("<%s> restored %d sessions from DataDB", utils.SessionS, restored))
+}
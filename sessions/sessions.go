@@ -59,6 +59,8 @@ func NewSessionS(cgrCfg *config.CGRConfig,
 		pSessions:     make(map[string]*Session),
 		pSessionsIdx:  make(map[string]map[string]map[string]utils.StringSet),
 		pSessionsRIdx: make(map[string][]*riFieldNameVal),
+		cCtxs:         make(map[string]map[string]utils.StringSet),
+		cCtxsRIdx:     make(map[string]string),
 	}
 }
 
@@ -91,11 +93,16 @@ type SessionS struct {
 	pSIMux        sync.RWMutex                                     // protects pSessionsIdx
 	pSessionsIdx  map[string]map[string]map[string]utils.StringSet // map[fieldName]map[fieldValue][cgrID]utils.StringSet[runID]sID
 	pSessionsRIdx map[string][]*riFieldNameVal                     // reverse indexes for passive sessions, used on remove
+
+	cCtxMux   sync.RWMutex                          // protects cCtxs
+	cCtxs     map[string]map[string]utils.StringSet // map[callContextID]map[cgrID]utils.StringSet[tenant:account] for the active sessions
+	cCtxsRIdx map[string]string                     // map[cgrID]callContextID, used on remove
 }
 
 // ListenAndServe starts the service and binds it to the listen loop
 func (sS *SessionS) ListenAndServe(stopChan chan struct{}) {
 	utils.Logger.Info(fmt.Sprintf("<%s> starting <%s> subsystem", utils.CoreS, utils.SessionS))
+	sS.restoreSessions()
 	if sS.cgrCfg.SessionSCfg().ChannelSyncInterval != 0 {
 		for { // Schedule sync channels to run repeately
 			select {
@@ -404,6 +411,7 @@ func (sS *SessionS) forceSTerminate(s *Session, extraUsage time.Duration, tUsage
 		}
 	}
 	sS.replicateSessions(s.CGRID, false, sS.cgrCfg.SessionSCfg().ReplicationConns)
+	sS.backupSession(s.CGRID)
 	if clntConn := sS.biJClnt(s.ClientConnID); clntConn != nil {
 		go func() {
 			var rply string
@@ -574,6 +582,8 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 			return
 		}
 		debitStop := s.debitStop // avoid concurrency with endSession
+		cCtxID := s.callContextID()
+		acntKey := utils.ConcatenatedKey(s.SRuns[sRunIdx].CD.Tenant, s.SRuns[sRunIdx].CD.Account)
 		s.SRuns[sRunIdx].NextAutoDebit = utils.TimePointer(time.Now().Add(dbtIvl))
 		if maxDebit < dbtIvl && sS.cgrCfg.SessionSCfg().MinDurLowBalance != time.Duration(0) { // warn client for low balance
 			if sS.cgrCfg.SessionSCfg().MinDurLowBalance >= dbtIvl {
@@ -585,11 +595,14 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 		}
 		s.Unlock()
 		sS.replicateSessions(s.CGRID, false, sS.cgrCfg.SessionSCfg().ReplicationConns)
+		sS.backupSession(s.CGRID)
 		if maxDebit < dbtIvl { // disconnect faster
 			select {
 			case <-debitStop: // call was disconnected already
 				return
 			case <-time.After(maxDebit):
+				// the other legs paid by the same account are out of credit as well
+				sS.disconnectCallContextLegs(cCtxID, s.CGRID, acntKey)
 				s.Lock()
 				defer s.Unlock()
 				// try to disconect the session n times before we force terminate it on our side
@@ -824,6 +837,9 @@ func (sS *SessionS) registerSession(s *Session, passive bool) {
 	sMp[s.CGRID] = s
 	sMux.Unlock()
 	sS.indexSession(s, passive)
+	if !passive {
+		sS.indexCallContext(s)
+	}
 }
 
 // isIndexed returns if the session is indexed
@@ -857,6 +873,9 @@ func (sS *SessionS) unregisterSession(cgrID string, passive bool) bool {
 	delete(sMp, cgrID)
 	sMux.Unlock()
 	sS.unindexSession(cgrID, passive)
+	if !passive {
+		sS.unindexCallContext(cgrID)
+	}
 	return true
 }
 
@@ -1158,6 +1177,7 @@ func (sS *SessionS) newSession(cgrEv *utils.CGREvent, resID, clntConnID string,
 		OptsStart:     engine.MapEvent(cgrEv.Opts).Clone(),
 		ClientConnID:  clntConnID,
 		DebitInterval: dbtItval,
+		CallContextID: evStart.GetStringIgnoreErrors(utils.CallContextID),
 	}
 	s.chargeable = s.OptsStart.GetBoolOrDefault(utils.OptsChargeable, true)
 	if !isMsg && sS.isIndexed(s, false) { // check if already exists
@@ -1329,6 +1349,8 @@ func (sS *SessionS) relocateSession(initOriginID, originID, originHost string) (
 	s.Unlock()
 	sS.registerSession(s, false)
 	sS.replicateSessions(initCGRID, false, sS.cgrCfg.SessionSCfg().ReplicationConns)
+	sS.backupSession(initCGRID)
+	sS.backupSession(newCGRID)
 	return
 }
 
@@ -1448,6 +1470,11 @@ func (sS *SessionS) authEvent(cgrEv *utils.CGREvent, forceDuration bool) (usage
 			err = utils.NewErrRALs(err)
 			return
 		}
+		// the other legs of the call paid by the same account consume it concurrently
+		if legs := sS.callContextLegs(s.callContextID(), s.CGRID,
+			utils.ConcatenatedKey(sr.CD.Tenant, sr.CD.Account)); len(legs) != 0 {
+			rplyMaxUsage /= time.Duration(len(legs) + 1)
+		}
 		if rplyMaxUsage > eventUsage {
 			rplyMaxUsage = eventUsage
 		}
@@ -1468,6 +1495,7 @@ func (sS *SessionS) initSession(cgrEv *utils.CGREvent, clntConnID,
 		sS.initSessionDebitLoops(s)
 		sS.registerSession(s, false)
 		s.Unlock()
+		sS.backupSession(s.CGRID) // after unlock since the backup reads the session
 	}
 	return
 }
@@ -1476,6 +1504,7 @@ func (sS *SessionS) initSession(cgrEv *utils.CGREvent, clntConnID,
 func (sS *SessionS) updateSession(s *Session, updtEv, opts engine.MapEvent, isMsg bool) (maxUsage map[string]time.Duration, err error) {
 	if !isMsg {
 		defer sS.replicateSessions(s.CGRID, false, sS.cgrCfg.SessionSCfg().ReplicationConns)
+		defer sS.backupSession(s.CGRID)
 		s.Lock()
 		defer s.Unlock()
 
@@ -1539,6 +1568,7 @@ func (sS *SessionS) endSession(s *Session, tUsage, lastUsage *time.Duration,
 	if !isMsg {
 		//check if we have replicate connection and close the session there
 		defer sS.replicateSessions(s.CGRID, true, sS.cgrCfg.SessionSCfg().ReplicationConns)
+		defer sS.backupSession(s.CGRID)
 		sS.unregisterSession(s.CGRID, false)
 		s.stopSTerminator()
 		s.stopDebitLoops()
@@ -4138,6 +4168,12 @@ func (sS *SessionS) Handlers() map[string]interface{} {
 		utils.SessionSv1SetPassiveSession: func(clnt *rpc2.Client, args *Session, rply *string) (err error) {
 			return sS.BiRPCv1SetPassiveSession(clnt, args, rply)
 		},
+		utils.SessionSv1LinkSessions: func(clnt *rpc2.Client, args *V1LinkSessionsArgs, rply *string) (err error) {
+			return sS.BiRPCv1LinkSessions(clnt, args, rply)
+		},
+		utils.SessionSv1RebillSession: func(clnt *rpc2.Client, args *V1RebillSessionArgs, rply *string) (err error) {
+			return sS.BiRPCv1RebillSession(clnt, args, rply)
+		},
 		utils.SessionSv1ActivateSessions: func(clnt *rpc2.Client, args *utils.SessionIDsWithArgsDispatcher, rply *string) (err error) {
 			return sS.BiRPCv1ActivateSessions(clnt, args, rply)
 		},
@@ -1627,6 +1627,131 @@ func TestSessionStransitSState(t *testing.T) {
 	}
 }
 
+func TestSessionSLinkAndRebillSessions(t *testing.T) {
+	sSCfg := config.NewDefaultCGRConfig()
+	sSCfg.SessionSCfg().SessionIndexes = utils.NewStringSet([]string{utils.AccountField})
+	sS := NewSessionS(sSCfg, nil, nil)
+	newLeg := func(cgrID, acnt string) *Session {
+		ev := engine.NewMapEvent(map[string]interface{}{
+			utils.OriginID:     cgrID,
+			utils.AccountField: acnt,
+			utils.RequestType:  utils.MetaPrepaid,
+		})
+		return &Session{
+			CGRID:      cgrID,
+			Tenant:     "cgrates.org",
+			EventStart: ev.Clone(),
+			SRuns: []*SRun{{
+				Event: ev,
+				CD: &engine.CallDescriptor{RunID: utils.MetaDefault,
+					Tenant: "cgrates.org", Account: acnt, Subject: acnt},
+			}},
+		}
+	}
+	aLeg, bLeg := newLeg("aLeg", "1001"), newLeg("bLeg", "1002")
+	sS.registerSession(aLeg, false)
+	sS.registerSession(bLeg, false)
+	if legs := sS.callContextLegs(aLeg.callContextID(), aLeg.CGRID, "cgrates.org:1001"); len(legs) != 0 {
+		t.Errorf("Unexpected legs: %+v", legs)
+	}
+
+	var reply string
+	if err := sS.BiRPCv1LinkSessions(nil, &V1LinkSessionsArgs{CallContextID: "call1",
+		CGRIDs: []string{"aLeg", "unknown"}}, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+	if err := sS.BiRPCv1LinkSessions(nil, &V1LinkSessionsArgs{CallContextID: "call1",
+		CGRIDs: []string{"aLeg", "bLeg"}}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	// the B-leg is forwarded and paid from now on by the A-leg account
+	if err := sS.BiRPCv1RebillSession(nil, &V1RebillSessionArgs{CGRID: "bLeg",
+		Account: "1001"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if bLeg.SRuns[0].CD.Account != "1001" || bLeg.SRuns[0].CD.Subject != "1001" ||
+		bLeg.SRuns[0].Event[utils.AccountField] != "1001" {
+		t.Errorf("Unexpected run: %s", utils.ToJSON(bLeg.SRuns[0]))
+	}
+	if legs := sS.callContextLegs("call1", "aLeg", "cgrates.org:1001"); !reflect.DeepEqual([]string{"bLeg"}, legs) {
+		t.Errorf("Unexpected legs: %+v", legs)
+	}
+	if cgrIDs, _ := sS.getSessionIDsMatchingIndexes(map[string][]string{
+		utils.AccountField: {"1001"}}, false); len(cgrIDs) != 2 {
+		t.Errorf("Expected both legs indexed on the new account, received: %+v", cgrIDs)
+	}
+	aSs := sS.filterSessions(&utils.SessionFilter{}, false)
+	for _, aS := range aSs {
+		if aS.CallContextID != "call1" {
+			t.Errorf("Unexpected session: %s", utils.ToJSON(aS))
+		}
+	}
+
+	sS.unregisterSession("bLeg", false)
+	if legs := sS.callContextLegs("call1", "aLeg", "cgrates.org:1001"); len(legs) != 0 {
+		t.Errorf("Unexpected legs: %+v", legs)
+	}
+}
+
+func TestSessionSBackupAndRestoreSessions(t *testing.T) {
+	sSCfg := config.NewDefaultCGRConfig()
+	sSCfg.SessionSCfg().BackupSessions = true
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), sSCfg.CacheCfg(), nil)
+	sS := NewSessionS(sSCfg, dm, nil)
+	ev := engine.NewMapEvent(map[string]interface{}{
+		utils.OriginID:     "bkp1",
+		utils.AccountField: "1001",
+		utils.RequestType:  utils.MetaPrepaid,
+	})
+	s := &Session{
+		CGRID:         "bkp1",
+		Tenant:        "cgrates.org",
+		EventStart:    ev.Clone(),
+		CallContextID: "call1",
+		chargeable:    true,
+		SRuns: []*SRun{{
+			Event: ev,
+			CD: &engine.CallDescriptor{RunID: utils.MetaDefault,
+				Tenant: "cgrates.org", Account: "1001", Subject: "1001"},
+			TotalUsage: 2 * time.Second,
+			LastUsage:  time.Second,
+		}},
+	}
+	sS.registerSession(s, false)
+	sS.backupSession(s.CGRID)
+	sbs, err := dm.GetSessionBackups(sSCfg.GeneralCfg().NodeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sbs) != 1 || sbs[0].CGRID != "bkp1" || sbs[0].CallContextID != "call1" ||
+		len(sbs[0].SRuns) != 1 || sbs[0].SRuns[0].TotalUsage != 2*time.Second {
+		t.Errorf("Unexpected backups: %s", utils.ToJSON(sbs))
+	}
+
+	// a new instance on the same node restores the session
+	rS := NewSessionS(sSCfg, dm, nil)
+	rS.restoreSessions()
+	ss := rS.getSessions("bkp1", false)
+	if len(ss) != 1 {
+		t.Fatalf("Expected the session restored, received: %s", utils.ToJSON(ss))
+	}
+	if !reflect.DeepEqual(s.SRuns, ss[0].SRuns) ||
+		!reflect.DeepEqual(s.EventStart, ss[0].EventStart) ||
+		ss[0].CallContextID != "call1" || !ss[0].chargeable {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(s), utils.ToJSON(ss[0]))
+	}
+	if legs := rS.callContextLegs("call1", "other", "cgrates.org:1001"); !reflect.DeepEqual([]string{"bkp1"}, legs) {
+		t.Errorf("Unexpected legs: %+v", legs)
+	}
+
+	// the backup is removed once the session ends
+	rS.unregisterSession("bkp1", false)
+	rS.backupSession("bkp1")
+	if _, err := dm.GetSessionBackups(sSCfg.GeneralCfg().NodeID); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+}
+
 func TestSessionSrelocateSessionS(t *testing.T) {
 	sSCfg := config.NewDefaultCGRConfig()
 	sS := NewSessionS(sSCfg, nil, nil)
@@ -1947,6 +2072,8 @@ func TestNewSessionS(t *testing.T) {
 		pSessions:     make(map[string]*Session),
 		pSessionsIdx:  make(map[string]map[string]map[string]utils.StringSet),
 		pSessionsRIdx: make(map[string][]*riFieldNameVal),
+		cCtxs:         make(map[string]map[string]utils.StringSet),
+		cCtxsRIdx:     make(map[string]string),
 	}
 	sS := NewSessionS(cgrCGF, nil, nil)
 	if !reflect.DeepEqual(sS, eOut) {
@@ -2664,6 +2791,44 @@ func TestInitSession(t *testing.T) {
 	}
 }
 
+func TestInitSessionBackup(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SessionSCfg().BackupSessions = true
+	cfg.SessionSCfg().ChargerSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaChargers)}
+	clientConect := make(chan rpcclient.ClientConnector, 1)
+	clientConect <- clMock(func(_ string, args interface{}, reply interface{}) error {
+		*reply.(*[]*engine.ChrgSProcessEventReply) = []*engine.ChrgSProcessEventReply{{
+			ChargerSProfile:    "raw",
+			AttributeSProfiles: []string{utils.MetaNone},
+			CGREvent:           args.(*utils.CGREvent),
+		}}
+		return nil
+	})
+	conMng := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaChargers): clientConect,
+	})
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	sS := NewSessionS(cfg, dm, conMng)
+	s, err := sS.initSession(&utils.CGREvent{
+		Tenant: "cgrates.org",
+		Event: map[string]interface{}{
+			utils.ToR:          utils.MetaVoice,
+			utils.OriginID:     "TestInitSessionBackup",
+			utils.RequestType:  utils.MetaPostpaid,
+			utils.AccountField: "1002",
+			utils.Destination:  "1001",
+		}}, "", "", 0, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// saved before any debit or update
+	if sbs, err := dm.GetSessionBackups(cfg.GeneralCfg().NodeID); err != nil {
+		t.Fatal(err)
+	} else if len(sbs) != 1 || sbs[0].CGRID != s.CGRID {
+		t.Errorf("Unexpected backups: %s", utils.ToJSON(sbs))
+	}
+}
+
 func TestSessionSAsBiRPC(t *testing.T) {
 	_ = rpcclient.BiRPCConector(new(SessionS))
 }
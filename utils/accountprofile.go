@@ -325,6 +325,24 @@ type ArgsAccountsForEvent struct {
 	AccountIDs []string
 }
 
+// ArgsActSetBalance is used by ActionS to alter the Units of a Balance
+type ArgsActSetBalance struct {
+	Tenant    string
+	AccountID string
+	BalanceID string
+	Type      string   // action type: *add_balance, *set_balance, *debit_balance or *reset_balance
+	Units     *Decimal // not used by *reset_balance
+	Opts      map[string]interface{}
+}
+
+// ArgsActRemoveBalance is used by ActionS to remove Balances out of an Account
+type ArgsActRemoveBalance struct {
+	Tenant     string
+	AccountID  string
+	BalanceIDs []string
+	Opts       map[string]interface{}
+}
+
 type ReplyMaxUsage struct {
 	AccountID string
 	MaxUsage  time.Duration
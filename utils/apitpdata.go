@@ -892,6 +892,8 @@ type TPResourceProfile struct {
 	Stored             bool
 	Weight             float64  // Weight to sort the ResourceLimits
 	ThresholdIDs       []string // Thresholds to check after changing Limit
+	RateWindow         string   // enforce the Limit per time window
+	Burst              float64  // units which can be allocated at once on rate limited resources
 }
 
 // TPActivationInterval represents an activation interval for an item
@@ -1008,6 +1010,8 @@ type TPStatProfile struct {
 	Weight             float64
 	MinItems           int
 	ThresholdIDs       []string
+	BucketInterval     string
+	BucketsLimit       int
 }
 
 // TPThresholdProfile is used in APIs to manage remotely offline ThresholdProfile
@@ -1024,6 +1028,8 @@ type TPThresholdProfile struct {
 	Weight             float64 // Weight to sort the thresholds
 	ActionIDs          []string
 	Async              bool
+	RecoveryFilterIDs  []string
+	RecoveryActionIDs  []string
 }
 
 // TPFilterProfile is used in APIs to manage remotely offline FilterProfile
@@ -1521,6 +1527,14 @@ func (args *ArgsCostForEvent) Usage() (usage time.Duration, err error) {
 	return
 }
 
+// ArgsCostForEvents arguments used to calculate the costs for a batch of events
+type ArgsCostForEvents struct {
+	Tenant         string
+	RateProfileIDs []string
+	CGREvents      []*CGREvent
+	Opts           map[string]interface{}
+}
+
 type TPActionProfile struct {
 	TPid               string
 	Tenant             string
@@ -104,6 +104,15 @@ func (ev *CGREvent) FieldAsFloat64(fldName string) (f float64, err error) {
 	return IfaceAsFloat64(iface)
 }
 
+// OptAsFloat64 returns an option as float64 instance
+func (ev *CGREvent) OptAsFloat64(optName string) (f float64, err error) {
+	iface, has := ev.Opts[optName]
+	if !has {
+		return f, ErrNotFound
+	}
+	return IfaceAsFloat64(iface)
+}
+
 // FieldAsInt64 returns a field as int64 instance
 func (ev *CGREvent) FieldAsInt64(fldName string) (f int64, err error) {
 	iface, has := ev.Event[fldName]
@@ -231,3 +240,11 @@ type CGREventWithEeIDs struct {
 	EeIDs []string
 	*CGREvent
 }
+
+// CGREventsWithEeIDs is a batch of events exported together with EventExporterIDs
+type CGREventsWithEeIDs struct {
+	EeIDs  []string
+	Tenant string
+	Events []*CGREvent
+	Opts   map[string]interface{}
+}
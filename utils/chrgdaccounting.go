@@ -18,4 +18,16 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 
 package utils
 
-type ChargedAccounting struct{} // placeholder for now
+// ChargedAccounting represents the units debited out of one Balance
+type ChargedAccounting struct {
+	AccountID string
+	BalanceID string
+	Units     *Decimal // units debited, in the Balance units
+}
+
+// ExtChargedAccounting is a generic ChargedAccounting used in APIs
+type ExtChargedAccounting struct {
+	AccountID string
+	BalanceID string
+	Units     *float64
+}
@@ -40,10 +40,11 @@ var (
 		MetaSQSjsonMap:    ContentJSON,
 		MetaKafkajsonMap:  ContentJSON,
 		MetaS3jsonMap:     ContentJSON,
+		MetaNATSjsonMap:   ContentJSON,
 	}
 
 	extraDBPartition = NewStringSet([]string{CacheDispatchers,
-		CacheDispatcherRoutes, CacheDispatcherLoads, CacheDiameterMessages, CacheRPCResponses, CacheClosedSessions,
+		CacheDispatcherRoutes, CacheDispatcherLoads, CacheDiameterMessages, CacheRadiusPackets, CacheRPCResponses, CacheClosedSessions,
 		CacheCDRIDs, CacheRPCConnections, CacheUCH, CacheSTIR, CacheEventCharges, MetaAPIBan,
 		CacheCapsEvents, CacheVersions})
 
@@ -56,7 +57,8 @@ var (
 		CacheAttributeFilterIndexes, CacheChargerFilterIndexes, CacheDispatcherFilterIndexes, CacheLoadIDs,
 		CacheRatingProfilesTmp, CacheRateProfiles, CacheRateProfilesFilterIndexes, CacheRateFilterIndexes,
 		CacheActionProfilesFilterIndexes, CacheAccountProfilesFilterIndexes, CacheReverseFilterIndexes,
-		CacheActionPlans, CacheAccountActionPlans, CacheAccountProfiles, CacheAccounts})
+		CacheActionPlans, CacheAccountActionPlans, CacheAccountProfiles, CacheAccounts, CacheRouteBreakers,
+		CacheSessionBackups, CacheInvoices, CacheAccountCharges})
 
 	storDBPartition = NewStringSet([]string{CacheTBLTPTimings, CacheTBLTPDestinations, CacheTBLTPRates, CacheTBLTPDestinationRates,
 		CacheTBLTPRatingPlans, CacheTBLTPRatingProfiles, CacheTBLTPSharedGroups, CacheTBLTPActions,
@@ -87,6 +89,10 @@ var (
 		CacheThresholds:                   ThresholdPrefix,
 		CacheFilters:                      FilterPrefix,
 		CacheRouteProfiles:                RouteProfilePrefix,
+		CacheRouteBreakers:                RouteBreakerPrefix,
+		CacheSessionBackups:               SessionBackupPrefix,
+		CacheInvoices:                     InvoicePrefix,
+		CacheAccountCharges:               AccountChargesPrefix,
 		CacheAttributeProfiles:            AttributeProfilePrefix,
 		CacheChargerProfiles:              ChargerProfilePrefix,
 		CacheDispatcherProfiles:           DispatcherProfilePrefix,
@@ -174,7 +180,7 @@ var (
 		TBLTPAccountProfiles:  CacheTBLTPAccountProfiles,
 	}
 	// ProtectedSFlds are the fields that sessions should not alter
-	ProtectedSFlds   = NewStringSet([]string{CGRID, OriginHost, OriginID, Usage})
+	ProtectedSFlds   = NewStringSet([]string{CGRID, OriginHost, OriginID, Usage, CallContextID})
 	ArgCacheToPrefix = map[string]string{
 		DestinationIDs:        DestinationPrefix,
 		ReverseDestinationIDs: ReverseDestinationPrefix,
@@ -266,6 +272,7 @@ const (
 	CGRateSLwr               = "cgrates"
 	Postgres                 = "postgres"
 	MySQL                    = "mysql"
+	SQLite                   = "sqlite"
 	Mongo                    = "mongo"
 	Redis                    = "redis"
 	INTERNAL                 = "internal"
@@ -308,6 +315,8 @@ const (
 	OrderID                  = "OrderID"
 	OriginID                 = "OriginID"
 	InitialOriginID          = "InitialOriginID"
+	CallContextID            = "CallContextID"
+	CGRIDs                   = "CGRIDs"
 	OriginIDPrefix           = "OriginIDPrefix"
 	Source                   = "Source"
 	OriginHost               = "OriginHost"
@@ -342,6 +351,7 @@ const (
 	MetaReqRunID            = "*req.RunID"
 	Cost                    = "Cost"
 	CostDetails             = "CostDetails"
+	ChargesID               = "ChargesID"
 	Rated                   = "rated"
 	Partial                 = "Partial"
 	PreRated                = "PreRated"
@@ -396,6 +406,10 @@ const (
 	VersionPrefix             = "ver_"
 	StatQueueProfilePrefix    = "sqp_"
 	RouteProfilePrefix        = "rpp_"
+	RouteBreakerPrefix        = "rbk_"
+	SessionBackupPrefix       = "sbk_"
+	InvoicePrefix             = "inv_"
+	AccountChargesPrefix      = "ach_"
 	RatePrefix                = "rep_"
 	AttributeProfilePrefix    = "alp_"
 	ChargerProfilePrefix      = "cpp_"
@@ -425,6 +439,7 @@ const (
 	MetaSQL                   = "*sql"
 	MetaMySQL                 = "*mysql"
 	MetaS3jsonMap             = "*s3_json_map"
+	MetaNATSjsonMap           = "*nats_json_map"
 	ConfigPath                = "/etc/cgrates/"
 	DisconnectCause           = "DisconnectCause"
 	MetaFlatstore             = "*flatstore"
@@ -470,6 +485,8 @@ const (
 	XML                      = "xml"
 	MetaGOB                  = "*gob"
 	MetaJSON                 = "*json"
+	MetaCSV                  = "*csv"
+	MetaXLSX                 = "*xlsx"
 	MetaMSGPACK              = "*msgpack"
 	MetaDateTime             = "*datetime"
 	MetaMaskedDestination    = "*masked_destination"
@@ -527,6 +544,9 @@ const (
 	XMLSuffix                = ".xml"
 	CSVSuffix                = ".csv"
 	FWVSuffix                = ".fwv"
+	ParquetSuffix            = ".parquet"
+	AvroSuffix               = ".avro"
+	PDFSuffix                = ".pdf"
 	ContentJSON              = "json"
 	ContentForm              = "form"
 	ContentText              = "text"
@@ -537,6 +557,9 @@ const (
 	MetaVirt                 = "*virt"
 	MetaElastic              = "*elastic"
 	MetaFileFWV              = "*file_fwv"
+	MetaFileParquet          = "*file_parquet"
+	MetaFileAvro             = "*file_avro"
+	MetaFilePDF              = "*file_pdf"
 	MetaFile                 = "*file"
 	Accounts                 = "Accounts"
 	AccountService           = "AccountS"
@@ -600,6 +623,7 @@ const (
 	StatID                = "StatID"
 	BalanceType           = "BalanceType"
 	BalanceID             = "BalanceID"
+	BalanceIDs            = "BalanceIDs"
 	BalanceDestinationIds = "BalanceDestinationIds"
 	BalanceWeight         = "BalanceWeight"
 	BalanceExpirationDate = "BalanceExpirationDate"
@@ -645,6 +669,25 @@ const (
 	MetaQOS                  = "*qos"
 	MetaReas                 = "*reas"
 	MetaReds                 = "*reds"
+	MetaScore                = "*score"
+	MetaClosed               = "*closed"
+	MetaOpen                 = "*open"
+	MetaHalfOpen             = "*half_open"
+	MetaInvoice              = "*invoice"
+	MetaCreditNote           = "*credit_note"
+	MetaDraft                = "*draft"
+	MetaIssued               = "*issued"
+	InvoiceID                = "InvoiceID"
+	InvoiceType              = "InvoiceType"
+	OriginalInvoiceID        = "OriginalInvoiceID"
+	PeriodStart              = "PeriodStart"
+	PeriodEnd                = "PeriodEnd"
+	IssuedAt                 = "IssuedAt"
+	LineID                   = "LineID"
+	Subtotal                 = "Subtotal"
+	DiscountAmount           = "DiscountAmount"
+	TaxAmount                = "TaxAmount"
+	Total                    = "Total"
 	Weight                   = "Weight"
 	Limit                    = "Limit"
 	UsageTTL                 = "UsageTTL"
@@ -720,6 +763,12 @@ const (
 	QueueLength              = "QueueLength"
 	TTL                      = "TTL"
 	MinItems                 = "MinItems"
+	BucketInterval           = "BucketInterval"
+	BucketsLimit             = "BucketsLimit"
+	RateWindow               = "RateWindow"
+	Burst                    = "Burst"
+	RecoveryFilterIDs        = "RecoveryFilterIDs"
+	RecoveryActionIDs        = "RecoveryActionIDs"
 	MetricIDs                = "MetricIDs"
 	MetricFilterIDs          = "MetricFilterIDs"
 	FieldName                = "FieldName"
@@ -814,6 +863,7 @@ const (
 	MetaRemove            = "*remove"
 	MetaRemoveAll         = "*removeall"
 	MetaStore             = "*store"
+	MetaLoadDryRun        = "*dry_run"
 	MetaClear             = "*clear"
 	MetaExport            = "*export"
 	MetaExportID          = "*export_id"
@@ -836,6 +886,7 @@ const (
 	MetaApier             = "*apier"
 	MetaAnalyzer          = "*analyzer"
 	CGREventString        = "CGREvent"
+	CGREventsString       = "CGREvents"
 	MetaTextPlain         = "*text_plain"
 	MetaIgnoreErrors      = "*ignore_errors"
 	MetaRelease           = "*release"
@@ -849,6 +900,8 @@ const (
 	EEs                   = "EEs"
 	Ratio                 = "Ratio"
 	Load                  = "Load"
+	Score                 = "Score"
+	ScoreComponents       = "ScoreComponents"
 	Slash                 = "/"
 	UUID                  = "UUID"
 	ActionsID             = "ActionsID"
@@ -881,6 +934,7 @@ const (
 	MetaLoaders           = "*loaders"
 	TmpSuffix             = ".tmp"
 	MetaDiamreq           = "*diamreq"
+	MetaRadDAReq          = "*radDAReq"
 	MetaCost              = "*cost"
 	MetaGroup             = "*group"
 	InternalRPCSet        = "InternalRPCSet"
@@ -950,6 +1004,9 @@ const (
 	MetaAbstract          = "*abstract"
 	MetaBalanceLimit      = "*balanceLimit"
 	MetaBalanceUnlimited  = "*balanceUnlimited"
+	MetaBalanceType       = "*balanceType"
+	MetaBalanceWeights    = "*balanceWeights"
+	MetaBalanceFilterIDs  = "*balanceFilterIDs"
 	MetaTemplateID        = "*templateID"
 	MetaCdrLog            = "*cdrLog"
 	MetaCDR               = "*cdr"
@@ -989,6 +1046,7 @@ const (
 	MetaAttributes          = "*attributes"
 	MetaActionProfiles      = "*action_profiles"
 	MetaAccountProfiles     = "*account_profiles"
+	MetaRouteBreakers       = "*route_breakers"
 	MetaLoadIDs             = "*load_ids"
 )
 
@@ -1005,6 +1063,12 @@ const (
 	MetaAverage  = "*average"
 	MetaDistinct = "*distinct"
 	MetaRAR      = "*rar"
+
+	MetaPercentile  = "*percentile"
+	MetaStdDev      = "*stddev"
+	MetaMin         = "*min"
+	MetaMax         = "*max"
+	MetaHighestCost = "*highest_cost"
 )
 
 // Services
@@ -1085,6 +1149,10 @@ const (
 	MetaCDRAccount              = "*reset_account_cdr"
 	MetaResetThreshold          = "*reset_threshold"
 	MetaResetStatQueue          = "*reset_stat_queue"
+	MetaAddBalance              = "*add_balance"
+	MetaRemBalance              = "*rem_balance"
+	MetaDebitBalance            = "*debit_balance"
+	MetaResetBalance            = "*reset_balance"
 	MetaRemoteSetAccount        = "*remote_set_account"
 	ActionID                    = "ActionID"
 	ActionType                  = "ActionType"
@@ -1165,6 +1233,10 @@ const (
 	MetaRoundRobin     = "*round_robin"
 	MetaRatio          = "*ratio"
 	MetaDefaultRatio   = "*default_ratio"
+	MetaHash           = "*hash"
+	MetaFirstHealthy   = "*first_healthy"
+	MetaHashField      = "*hash_field"
+	MetaPingInterval   = "*ping_interval"
 	ThresholdSv1       = "ThresholdSv1"
 	StatSv1            = "StatSv1"
 	ResourceSv1        = "ResourceSv1"
@@ -1199,6 +1271,8 @@ const (
 	MetaIPNet              = "*ipnet"
 	MetaAPIBan             = "*apiban"
 	MetaActivationInterval = "*ai"
+	MetaRegex              = "*regex"
+	MetaRange              = "*range"
 
 	MetaNotString             = "*notstring"
 	MetaNotPrefix             = "*notprefix"
@@ -1214,6 +1288,8 @@ const (
 	MetaNotIPNet              = "*notipnet"
 	MetaNotAPIBan             = "*notapiban"
 	MetaNotActivationInterval = "*notai"
+	MetaNotRegex              = "*notregex"
+	MetaNotRange              = "*notrange"
 
 	MetaEC = "*ec"
 )
@@ -1249,6 +1325,7 @@ const (
 	ReplicatorSv1GetActionProfile        = "ReplicatorSv1.GetActionProfile"
 	ReplicatorSv1GetDispatcherHost       = "ReplicatorSv1.GetDispatcherHost"
 	ReplicatorSv1GetAccountProfile       = "ReplicatorSv1.GetAccountProfile"
+	ReplicatorSv1GetRouteBreaker         = "ReplicatorSv1.GetRouteBreaker"
 	ReplicatorSv1GetItemLoadIDs          = "ReplicatorSv1.GetItemLoadIDs"
 	ReplicatorSv1SetThresholdProfile     = "ReplicatorSv1.SetThresholdProfile"
 	ReplicatorSv1SetThreshold            = "ReplicatorSv1.SetThreshold"
@@ -1275,6 +1352,7 @@ const (
 	ReplicatorSv1SetRateProfile          = "ReplicatorSv1.SetRateProfile"
 	ReplicatorSv1SetActionProfile        = "ReplicatorSv1.SetActionProfile"
 	ReplicatorSv1SetAccountProfile       = "ReplicatorSv1.SetAccountProfile"
+	ReplicatorSv1SetRouteBreaker         = "ReplicatorSv1.SetRouteBreaker"
 	ReplicatorSv1SetDispatcherHost       = "ReplicatorSv1.SetDispatcherHost"
 	ReplicatorSv1SetLoadIDs              = "ReplicatorSv1.SetLoadIDs"
 	ReplicatorSv1RemoveThreshold         = "ReplicatorSv1.RemoveThreshold"
@@ -1302,6 +1380,7 @@ const (
 	ReplicatorSv1RemoveActionProfile     = "ReplicatorSv1.RemoveActionProfile"
 	ReplicatorSv1RemoveDispatcherHost    = "ReplicatorSv1.RemoveDispatcherHost"
 	ReplicatorSv1RemoveAccountProfile    = "ReplicatorSv1.RemoveAccountProfile"
+	ReplicatorSv1RemoveRouteBreaker      = "ReplicatorSv1.RemoveRouteBreaker"
 	ReplicatorSv1GetIndexes              = "ReplicatorSv1.GetIndexes"
 	ReplicatorSv1SetIndexes              = "ReplicatorSv1.SetIndexes"
 	ReplicatorSv1RemoveIndexes           = "ReplicatorSv1.RemoveIndexes"
@@ -1559,9 +1638,11 @@ const (
 )
 
 const (
-	RateSv1             = "RateSv1"
-	RateSv1CostForEvent = "RateSv1.CostForEvent"
-	RateSv1Ping         = "RateSv1.Ping"
+	RateSv1                         = "RateSv1"
+	RateSv1CostForEvent             = "RateSv1.CostForEvent"
+	RateSv1CostForEvents            = "RateSv1.CostForEvents"
+	RateSv1RateProfilesCostForEvent = "RateSv1.RateProfilesCostForEvent"
+	RateSv1Ping                     = "RateSv1.Ping"
 )
 
 const (
@@ -1570,6 +1651,11 @@ const (
 	AccountSv1AccountProfilesForEvent = "AccountSv1.AccountProfilesForEvent"
 	AccountSv1MaxUsage                = "AccountSv1.MaxUsage"
 	AccountSv1DebitUsage              = "AccountSv1.DebitUsage"
+	AccountSv1MaxCost                 = "AccountSv1.MaxCost"
+	AccountSv1DebitCost               = "AccountSv1.DebitCost"
+	AccountSv1RefundCharges           = "AccountSv1.RefundCharges"
+	AccountSv1ActionSetBalance        = "AccountSv1.ActionSetBalance"
+	AccountSv1ActionRemoveBalance     = "AccountSv1.ActionRemoveBalance"
 )
 
 const (
@@ -1585,6 +1671,9 @@ const (
 	RouteSv1GetRoutes                = "RouteSv1.GetRoutes"
 	RouteSv1GetRouteProfilesForEvent = "RouteSv1.GetRouteProfilesForEvent"
 	RouteSv1Ping                     = "RouteSv1.Ping"
+	RouteSv1ProcessOutcome           = "RouteSv1.ProcessOutcome"
+	RouteSv1GetRouteBreaker          = "RouteSv1.GetRouteBreaker"
+	RouteSv1GetRouteBreakerIDs       = "RouteSv1.GetRouteBreakerIDs"
 	APIerSv1GetRouteProfile          = "APIerSv1.GetRouteProfile"
 	APIerSv1GetRouteProfileIDs       = "APIerSv1.GetRouteProfileIDs"
 	APIerSv1RemoveRouteProfile       = "APIerSv1.RemoveRouteProfile"
@@ -1635,6 +1724,7 @@ const (
 	StatSv1GetQueueIDs             = "StatSv1.GetQueueIDs"
 	StatSv1GetQueueStringMetrics   = "StatSv1.GetQueueStringMetrics"
 	StatSv1GetQueueFloatMetrics    = "StatSv1.GetQueueFloatMetrics"
+	StatSv1GetQueueMetricsHistory  = "StatSv1.GetQueueMetricsHistory"
 	StatSv1Ping                    = "StatSv1.Ping"
 	StatSv1GetStatQueuesForEvent   = "StatSv1.GetStatQueuesForEvent"
 	StatSv1GetStatQueue            = "StatSv1.GetStatQueue"
@@ -1648,17 +1738,19 @@ const (
 
 // ResourceS APIs
 const (
-	ResourceSv1AuthorizeResources    = "ResourceSv1.AuthorizeResources"
-	ResourceSv1GetResourcesForEvent  = "ResourceSv1.GetResourcesForEvent"
-	ResourceSv1AllocateResources     = "ResourceSv1.AllocateResources"
-	ResourceSv1ReleaseResources      = "ResourceSv1.ReleaseResources"
-	ResourceSv1Ping                  = "ResourceSv1.Ping"
-	ResourceSv1GetResourceWithConfig = "ResourceSv1.GetResourceWithConfig"
-	ResourceSv1GetResource           = "ResourceSv1.GetResource"
-	APIerSv1SetResourceProfile       = "APIerSv1.SetResourceProfile"
-	APIerSv1RemoveResourceProfile    = "APIerSv1.RemoveResourceProfile"
-	APIerSv1GetResourceProfile       = "APIerSv1.GetResourceProfile"
-	APIerSv1GetResourceProfileIDs    = "APIerSv1.GetResourceProfileIDs"
+	ResourceSv1AuthorizeResources           = "ResourceSv1.AuthorizeResources"
+	ResourceSv1GetResourcesForEvent         = "ResourceSv1.GetResourcesForEvent"
+	ResourceSv1AllocateResources            = "ResourceSv1.AllocateResources"
+	ResourceSv1ReleaseResources             = "ResourceSv1.ReleaseResources"
+	ResourceSv1Ping                         = "ResourceSv1.Ping"
+	ResourceSv1GetResourceWithConfig        = "ResourceSv1.GetResourceWithConfig"
+	ResourceSv1GetResource                  = "ResourceSv1.GetResource"
+	ResourceSv1AuthorizeResourcesWithLimits = "ResourceSv1.AuthorizeResourcesWithLimits"
+	ResourceSv1AllocateResourcesWithLimits  = "ResourceSv1.AllocateResourcesWithLimits"
+	APIerSv1SetResourceProfile              = "APIerSv1.SetResourceProfile"
+	APIerSv1RemoveResourceProfile           = "APIerSv1.RemoveResourceProfile"
+	APIerSv1GetResourceProfile              = "APIerSv1.GetResourceProfile"
+	APIerSv1GetResourceProfileIDs           = "APIerSv1.GetResourceProfileIDs"
 )
 
 // SessionS APIs
@@ -1693,6 +1785,8 @@ const (
 	SessionSv1WarnDisconnect             = "SessionSv1.WarnDisconnect"
 	SessionSv1STIRAuthenticate           = "SessionSv1.STIRAuthenticate"
 	SessionSv1STIRIdentity               = "SessionSv1.STIRIdentity"
+	SessionSv1LinkSessions               = "SessionSv1.LinkSessions"
+	SessionSv1RebillSession              = "SessionSv1.RebillSession"
 	SessionSv1Sleep                      = "SessionSv1.Sleep"
 )
 
@@ -1739,9 +1833,12 @@ const (
 
 // AnalyzerS APIs
 const (
-	AnalyzerSv1            = "AnalyzerSv1"
-	AnalyzerSv1Ping        = "AnalyzerSv1.Ping"
-	AnalyzerSv1StringQuery = "AnalyzerSv1.StringQuery"
+	AnalyzerSv1             = "AnalyzerSv1"
+	AnalyzerSv1Ping         = "AnalyzerSv1.Ping"
+	AnalyzerSv1StringQuery  = "AnalyzerSv1.StringQuery"
+	AnalyzerSv1Query        = "AnalyzerSv1.Query"
+	AnalyzerSv1QueryMetrics = "AnalyzerSv1.QueryMetrics"
+	AnalyzerSv1Export       = "AnalyzerSv1.Export"
 )
 
 // LoaderS APIs
@@ -1792,6 +1889,13 @@ const (
 	CDRsV1StoreSessionCost   = "CDRsV1.StoreSessionCost"
 	CDRsV1ProcessEvent       = "CDRsV1.ProcessEvent"
 	CDRsV1Ping               = "CDRsV1.Ping"
+	CDRsV1GenerateInvoice    = "CDRsV1.GenerateInvoice"
+	CDRsV1IssueInvoice       = "CDRsV1.IssueInvoice"
+	CDRsV1CreateCreditNote   = "CDRsV1.CreateCreditNote"
+	CDRsV1GetInvoice         = "CDRsV1.GetInvoice"
+	CDRsV1GetInvoiceIDs      = "CDRsV1.GetInvoiceIDs"
+	CDRsV1RemoveInvoice      = "CDRsV1.RemoveInvoice"
+	CDRsV1ExportInvoice      = "CDRsV1.ExportInvoice"
 	CDRsV2                   = "CDRsV2"
 	CDRsV2StoreSessionCost   = "CDRsV2.StoreSessionCost"
 	CDRsV2ProcessEvent       = "CDRsV2.ProcessEvent"
@@ -1808,9 +1912,10 @@ const (
 
 // EEs
 const (
-	EeSv1             = "EeSv1"
-	EeSv1Ping         = "EeSv1.Ping"
-	EeSv1ProcessEvent = "EeSv1.ProcessEvent"
+	EeSv1              = "EeSv1"
+	EeSv1Ping          = "EeSv1.Ping"
+	EeSv1ProcessEvent  = "EeSv1.ProcessEvent"
+	EeSv1ProcessEvents = "EeSv1.ProcessEvents"
 )
 
 // ActionProfile APIs
@@ -1918,6 +2023,9 @@ const (
 	CacheThresholds                   = "*thresholds"
 	CacheFilters                      = "*filters"
 	CacheRouteProfiles                = "*route_profiles"
+	CacheRouteBreakers                = "*route_breakers"
+	CacheSessionBackups               = "*session_backups"
+	CacheInvoices                     = "*invoices"
 	CacheAttributeProfiles            = "*attribute_profiles"
 	CacheChargerProfiles              = "*charger_profiles"
 	CacheDispatcherProfiles           = "*dispatcher_profiles"
@@ -1936,6 +2044,7 @@ const (
 	CacheChargerFilterIndexes         = "*charger_filter_indexes"
 	CacheDispatcherFilterIndexes      = "*dispatcher_filter_indexes"
 	CacheDiameterMessages             = "*diameter_messages"
+	CacheRadiusPackets                = "*radius_packets"
 	CacheRPCResponses                 = "*rpc_responses"
 	CacheClosedSessions               = "*closed_sessions"
 	CacheRateProfilesFilterIndexes    = "*rate_profile_filter_indexes"
@@ -1951,6 +2060,7 @@ const (
 	CacheUCH                          = "*uch"
 	CacheSTIR                         = "*stir"
 	CacheEventCharges                 = "*event_charges"
+	CacheAccountCharges               = "*account_charges"
 	CacheReverseFilterIndexes         = "*reverse_filter_indexes"
 	CacheAccounts                     = "*accounts"
 	CacheVersions                     = "*versions"
@@ -2010,6 +2120,7 @@ const (
 	AsteriskAgent   = "AsteriskAgent"
 	HTTPAgent       = "HTTPAgent"
 	SIPAgent        = "SIPAgent"
+	PrometheusAgent = "PrometheusAgent"
 )
 
 // Google_API
@@ -2092,6 +2203,11 @@ const (
 	RedisClientCertificate     = "redis_client_certificate"
 	RedisClientKey             = "redis_client_key"
 	RedisCACertificate         = "redis_ca_certificate"
+
+	InternalDBDumpPathCfg         = "internal_db_dump_path"
+	InternalDBSnapshotIntervalCfg = "internal_db_snapshot_interval"
+	InternalDBFsyncIntervalCfg    = "internal_db_fsync_interval"
+	InternalDBCompactSizeCfg      = "internal_db_compact_size"
 )
 
 // ItemOpt
@@ -2157,6 +2273,7 @@ const (
 // FilterSCfg
 const (
 	StatSConnsCfg     = "stats_conns"
+	AccountSConnsCfg  = "accounts_conns"
 	ResourceSConnsCfg = "resources_conns"
 	ApierSConnsCfg    = "apiers_conns"
 )
@@ -2205,6 +2322,7 @@ const (
 	RemoteConnsCfg         = "remote_conns"
 	DebitIntervalCfg       = "debit_interval"
 	StoreSCostsCfg         = "store_session_costs"
+	BackupSessionsCfg      = "backup_sessions"
 	SessionTTLCfg          = "session_ttl"
 	SessionTTLMaxDelayCfg  = "session_ttl_max_delay"
 	SessionTTLLastUsedCfg  = "session_ttl_last_used"
@@ -2282,6 +2400,9 @@ const (
 	ListenAcctCfg         = "listen_acct"
 	ClientSecretsCfg      = "client_secrets"
 	ClientDictionariesCfg = "client_dictionaries"
+	ClientDaAddressesCfg  = "client_da_addresses"
+	DMRTemplateCfg        = "dmr_template"
+	CoATemplateCfg        = "coa_template"
 
 	// AttributeSCfg
 	IndexedSelectsCfg = "indexed_selects"
@@ -2314,11 +2435,19 @@ const (
 	DataCfg         = "data"
 
 	DefaultRatioCfg           = "default_ratio"
+	BreakerFailuresCfg        = "breaker_failures"
+	BreakerMinASRCfg          = "breaker_min_asr"
+	BreakerMinCallsCfg        = "breaker_min_calls"
+	BreakerWindowCfg          = "breaker_window"
+	BreakerCooldownCfg        = "breaker_cooldown"
 	ReadersCfg                = "readers"
 	ExportersCfg              = "exporters"
 	PoolSize                  = "poolSize"
 	Conns                     = "conns"
 	FilenameCfg               = "file_name"
+	FormatCfg                 = "format"
+	SheetCfg                  = "sheet"
+	RootPathCfg               = "root_path"
 	RequestPayloadCfg         = "request_payload"
 	ReplyPayloadCfg           = "reply_payload"
 	TransportCfg              = "transport"
@@ -2345,8 +2474,10 @@ const (
 	ShutdownTimeoutCfg   = "shutdown_timeout"
 
 	// AccountSCfg
-	MaxIterations = "max_iterations"
-	MaxUsage      = "max_usage"
+	MaxIterations   = "max_iterations"
+	MaxUsage        = "max_usage"
+	StoreChargesCfg = "store_charges"
+	ChargesTTLCfg   = "charges_ttl"
 )
 
 // FC Template
@@ -2411,6 +2542,27 @@ const (
 	GapiTokenCfg       = "gapi_token"
 )
 
+// PrometheusAgentCfg
+const (
+	CacheIDsCfg     = "cache_ids"
+	StatQueueIDsCfg = "stat_queue_ids"
+)
+
+// Prometheus metrics
+const (
+	MetricsNamespace     = "cgrates"
+	MetricsLabelMethod   = "method"
+	MetricsLabelStatus   = "status"
+	MetricsLabelPart     = "partition"
+	MetricsLabelReader   = "reader"
+	MetricsLabelExporter = "exporter"
+	MetricsLabelTenant   = "tenant"
+	MetricsLabelQueue    = "queue"
+	MetricsLabelMetric   = "metric"
+	MetricsStatusSuccess = "success"
+	MetricsStatusError   = "error"
+)
+
 // MigratorCgrCfg
 const (
 	OutDataDBTypeCfg       = "out_datadb_type"
@@ -2506,7 +2658,8 @@ var CGROptionsSet = NewStringSet([]string{OptsRatesStartTime, OptsRatesUsage, Op
 	OptsDebitInterval, OptsStirATest, OptsStirPayloadMaxDuration, OptsStirIdentity,
 	OptsStirOriginatorTn, OptsStirOriginatorURI, OptsStirDestinationTn, OptsStirDestinationURI,
 	OptsStirPublicKeyPath, OptsStirPrivateKeyPath, OptsAPIKey, OptsRouteID, OptsContext,
-	OptsAttributesProcessRuns, OptsRoutesLimit, OptsRoutesOffset, OptsChargeable})
+	OptsAttributesProcessRuns, OptsRoutesLimit, OptsRoutesOffset, OptsChargeable,
+	OptsAccountsStoreCharges})
 
 // EventExporter metrics
 const (
@@ -2539,6 +2692,7 @@ const (
 	OptsSessionsTTLUsage     = "*sessionsTTLUsage"
 	OptsDebitInterval        = "*sessionsDebitInterval"
 	OptsChargeable           = "*sessionsChargeable"
+	OptsAccountsStoreCharges = "*accountsStoreCharges"
 	// STIR
 	OptsStirATest              = "*stirATest"
 	OptsStirPayloadMaxDuration = "*stirPayloadMaxDuration"
@@ -2666,6 +2820,18 @@ const (
 	KafkaDefaultGroupID = "cgrates"
 	KafkaDefaultMaxWait = time.Millisecond
 
+	NATSSubject             = "natsSubject"
+	NATSQueueID             = "natsQueueID"
+	NATSJetStream           = "natsJetStream"
+	NATSConsumerName        = "natsConsumerName"
+	NATSDeadLetterSubject   = "natsDeadLetterSubject"
+	NATSDefaultSubject      = "cgrates_cdrs"
+	NATSDefaultConsumerName = "cgrates"
+
+	// for columnar file exporters:
+	FileRollSize     = "fileRollSize"
+	FileRollInterval = "fileRollInterval"
+
 	SQLDBName         = "dbName"
 	SQLTableName      = "tableName"
 	SQLSSLMode        = "sslmode"
@@ -2684,11 +2850,15 @@ const (
 
 	RequestStartTime = "RequestStartTime"
 	RequestDuration  = "RequestDuration"
+	RequestMethod    = "RequestMethod"
+	RequestSource    = "RequestSource"
 	RequestParams    = "RequestParams"
 	Reply            = "Reply"
 	ReplyError       = "ReplyError"
 	AnzDBDir         = "db"
 	Opts             = "Opts"
+	MetaJSONLines    = "*json_lines"
+	MetaCGRTester    = "*cgr_tester"
 )
 
 //CMD constants
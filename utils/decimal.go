@@ -20,6 +20,7 @@ package utils
 
 import (
 	"bytes"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -82,6 +83,18 @@ func NewDecimalFromUsage(u string) (d *Decimal, err error) {
 
 }
 
+// NewDecimalFromString is a constructor for Decimal out of a number or an usage represented as string
+func NewDecimalFromString(s string) (d *Decimal, err error) {
+	if d, err = NewDecimalFromUsage(s); err == nil {
+		return
+	}
+	z, ok := new(decimal.Big).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("can't convert <%+v> to decimal", s)
+	}
+	return &Decimal{z}, nil
+}
+
 // NewDecimal is a constructor for Decimal, following the one of decimal.Big
 func NewDecimal(value int64, scale int) *Decimal {
 	return &Decimal{decimal.New(value, scale)}
@@ -20,6 +20,7 @@ package utils
 
 import (
 	"errors"
+	"time"
 )
 
 // NewEventChargers instantiates the EventChargers in a central place
@@ -30,28 +31,39 @@ func NewEventCharges() (ec *EventCharges) {
 
 // EventCharges records the charges applied to an Event
 type EventCharges struct {
+	ID         string // identifies the charges, used for refunds
 	Usage      *Decimal
 	Cost       *Decimal
 	Charges    []*ChargedInterval
 	Account    *AccountProfile
-	Accounting *ChargedAccounting
+	Accounting []*ChargedAccounting
 	Rating     *ChargedRating
 }
 
 // Merge will merge the event charges into existing
 func (ec *EventCharges) Merge(eCs ...*EventCharges) {
 	for _, nEc := range eCs {
-		if ec.Usage == nil {
-			ec.Usage = nEc.Usage
-			continue
+		if nEc.Usage != nil {
+			if ec.Usage == nil {
+				ec.Usage = nEc.Usage
+			} else {
+				ec.Usage = &Decimal{SumBig(ec.Usage.Big, nEc.Usage.Big)}
+			}
 		}
-		ec.Usage = &Decimal{SumBig(ec.Usage.Big, nEc.Usage.Big)}
+		if nEc.Cost != nil {
+			if ec.Cost == nil {
+				ec.Cost = nEc.Cost
+			} else {
+				ec.Cost = &Decimal{SumBig(ec.Cost.Big, nEc.Cost.Big)}
+			}
+		}
+		ec.Accounting = append(ec.Accounting, nEc.Accounting...)
 	}
 }
 
 // AsExtEventCharges converts EventCharges to ExtEventCharges
 func (ec *EventCharges) AsExtEventCharges() (eEc *ExtEventCharges, err error) {
-	eEc = new(ExtEventCharges)
+	eEc = &ExtEventCharges{ID: ec.ID}
 	if ec.Usage != nil {
 		if flt, ok := ec.Usage.Big.Float64(); !ok {
 			return nil, errors.New("cannot convert decimal Usage to float64")
@@ -66,12 +78,59 @@ func (ec *EventCharges) AsExtEventCharges() (eEc *ExtEventCharges, err error) {
 			eEc.Cost = &flt
 		}
 	}
+	if ec.Accounting != nil {
+		eEc.Accounting = make([]*ExtChargedAccounting, len(ec.Accounting))
+		for i, cA := range ec.Accounting {
+			eEc.Accounting[i] = &ExtChargedAccounting{
+				AccountID: cA.AccountID,
+				BalanceID: cA.BalanceID,
+			}
+			if cA.Units != nil {
+				if flt, ok := cA.Units.Big.Float64(); !ok {
+					return nil, errors.New("cannot convert decimal Units to float64")
+				} else {
+					eEc.Accounting[i].Units = &flt
+				}
+			}
+		}
+	}
 	// add here code for the rest of the fields
 	return
 }
 
 // ExtEventCharges is a generic EventCharges used in APIs
 type ExtEventCharges struct {
-	Usage *float64
-	Cost  *float64
+	ID         string
+	Usage      *float64
+	Cost       *float64
+	Accounting []*ExtChargedAccounting
+}
+
+// AccountCharges records the units debited out of the Balances for one EventCharges
+// stored in DataDB on debit and kept as refunded until expired so they can be refunded only once
+type AccountCharges struct {
+	Tenant     string
+	ID         string // the ID of the EventCharges
+	Accounting []*ChargedAccounting
+	Balances   map[string]*Balance // config of the charged Balances, indexed on AccountID:BalanceID
+	Refunded   bool                // the charges were put back on the Balances
+	ExpiryTime *time.Time          // the charges cannot be refunded after this time, nil for never
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (aC *AccountCharges) TenantID() string {
+	return ConcatenatedKey(aC.Tenant, aC.ID)
+}
+
+// IsExpired checks if the charges can no longer be refunded
+func (aC *AccountCharges) IsExpired(at time.Time) bool {
+	return aC.ExpiryTime != nil && !aC.ExpiryTime.After(at)
+}
+
+// ArgsRefundCharges is used by AccountSv1.RefundCharges
+type ArgsRefundCharges struct {
+	Tenant       string
+	ChargesID    string           // ID of the charges stored on debit
+	EventCharges *ExtEventCharges // the charges returned by the debit, alternative to ChargesID
+	Opts         map[string]interface{}
 }